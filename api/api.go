@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	cfg "crypto-braza-tokens-api/configs"
 	r "crypto-braza-tokens-api/repositories"
 	bs "crypto-braza-tokens-api/services/blockchain"
@@ -10,6 +11,7 @@ import (
 	ts "crypto-braza-tokens-api/services/token"
 	txs "crypto-braza-tokens-api/services/transaction"
 	ws "crypto-braza-tokens-api/services/wallet"
+	xs "crypto-braza-tokens-api/services/xrpl"
 	"fmt"
 	"os"
 
@@ -30,8 +32,15 @@ func Start() {
 		WalletService:      ws.NewWalletService(repo),
 		OperationService:   ops.NewOperationService(repo),
 		TransactionService: txs.NewTransactionService(repo),
+		XrplService:        xs.NewXrplService(repo),
 	}
 
+	// starts indexing the wallets transactions in background
+	resources.TransactionService.StartIndexer(context.Background())
+
+	// starts snapshotting the holders of the issued tokens in background
+	resources.TokenService.StartHolderRegistry(context.Background())
+
 	// creates a new fiber instance
 	app := fiber.New()
 
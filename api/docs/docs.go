@@ -15,6 +15,272 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/amm/deposit": {
+            "post": {
+                "description": "deposit both assets at the pool ratio, a single asset, or an amount for an exact amount of LP tokens from the domain market maker wallet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AMM"
+                ],
+                "summary": "Deposit into an AMM pool",
+                "operationId": "post-amm-deposit",
+                "parameters": [
+                    {
+                        "description": "AMM deposit object",
+                        "name": "amm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AmmDepositRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/amm/pools": {
+            "get": {
+                "description": "retrieve the state of the AMM pool of a token against XRP from the validated ledger, with the LP tokens held by the domain market maker wallet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AMM"
+                ],
+                "summary": "Get the AMM pool of a token against XRP",
+                "operationId": "get-amm-pool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blockchain ID",
+                        "name": "blockchain_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operation domain",
+                        "name": "domain",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "token_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/operation.AmmPool"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create the AMM pool of a token against XRP from the domain market maker wallet, funded with both amounts which set the initial price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AMM"
+                ],
+                "summary": "Create the AMM pool of a token against XRP",
+                "operationId": "post-amm-pool",
+                "parameters": [
+                    {
+                        "description": "AMM pool object",
+                        "name": "amm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AmmCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/amm/vote": {
+            "post": {
+                "description": "vote the trading fee of the AMM pool with the LP tokens of the domain market maker wallet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AMM"
+                ],
+                "summary": "Vote the trading fee of an AMM pool",
+                "operationId": "post-amm-vote",
+                "parameters": [
+                    {
+                        "description": "AMM vote object",
+                        "name": "amm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AmmVoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/amm/withdraw": {
+            "post": {
+                "description": "withdraw both assets, a single asset, an exact amount of LP tokens or all the liquidity of the domain market maker wallet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AMM"
+                ],
+                "summary": "Withdraw from an AMM pool",
+                "operationId": "post-amm-withdraw",
+                "parameters": [
+                    {
+                        "description": "AMM withdraw object",
+                        "name": "amm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AmmWithdrawRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/blockchains": {
             "get": {
                 "description": "retrieve the list of supported blockchains",
@@ -277,27 +543,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/fireblocks-accounts": {
+        "/api/v1/checks": {
             "get": {
-                "description": "retrieve the list of fireblocks accounts",
+                "description": "retrieve the checks sent as payouts by the payment wallets, with their ledger object IDs and whether they were cashed, expired or cancelled",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "FireblocksAccounts"
+                    "Checks"
+                ],
+                "summary": "Get the checks sent as payouts",
+                "operationId": "get-checks",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PENDING",
+                            "OPEN",
+                            "CASHED",
+                            "EXPIRED",
+                            "CANCELLED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Check status",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get the fireblocks accounts list",
-                "operationId": "get-fireblocks-accounts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/fireblocks.FireblocksAccount"
+                                "$ref": "#/definitions/repositories.Check"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -305,9 +593,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/checks/cancel": {
             "post": {
-                "description": "create a new fireblocks account",
+                "description": "cancel an expired check sent as payout, from the payment wallet that sent it, by its ledger object ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -315,18 +605,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FireblocksAccounts"
+                    "Checks"
                 ],
-                "summary": "Create a new fireblocks account",
-                "operationId": "post-fireblocks-accounts",
+                "summary": "Cancel an expired check",
+                "operationId": "post-check-cancel",
                 "parameters": [
                     {
-                        "description": "Fireblocks Account",
-                        "name": "request",
+                        "description": "Check to cancel",
+                        "name": "check",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.SaveFireblocksAccountRequest"
+                            "$ref": "#/definitions/types.CancelCheckRequest"
                         }
                     }
                 ],
@@ -334,7 +624,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Result"
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -343,8 +633,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -358,61 +648,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/fireblocks-accounts/vault/{vault_id}": {
+        "/api/v1/dex/offers": {
             "get": {
-                "description": "retrieve a fireblocks account by vault id",
+                "description": "retrieve the open offers of the domain market maker wallet from the ledger, telling which ones were placed by the service",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "FireblocksAccounts"
+                    "DEX"
                 ],
-                "summary": "Get a fireblocks account",
-                "operationId": "get-fireblocks-accounts-by-vault-id",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/fireblocks.FireblocksAccount"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
+                "summary": "Get the open offers on the DEX",
+                "operationId": "get-dex-offers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blockchain ID",
+                        "name": "blockchain_id",
+                        "in": "query",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Operation domain",
+                        "name": "domain",
+                        "in": "query",
+                        "required": true
                     }
-                }
-            }
-        },
-        "/api/v1/fireblocks-accounts/{id}": {
-            "get": {
-                "description": "retrieve a fireblocks account by id",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "FireblocksAccounts"
                 ],
-                "summary": "Get a fireblocks account",
-                "operationId": "get-fireblocks-accounts-by-id",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/fireblocks.FireblocksAccount"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/operation.MarketOffer"
+                            }
                         }
                     },
                     "400": {
@@ -421,12 +691,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -435,30 +699,35 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "delete a fireblocks account",
+            "post": {
+                "description": "place an offer of a token against XRP from the domain market maker wallet, SELL giving the token amount for XRP at the price and BUY giving XRP for it",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "FireblocksAccounts"
+                    "DEX"
                 ],
-                "summary": "Delete a fireblocks account",
-                "operationId": "delete-fireblocks-accounts",
+                "summary": "Place a new offer on the DEX",
+                "operationId": "post-dex-offer",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Fireblocks Account ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Offer object",
+                        "name": "offer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.PlaceOfferRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Result"
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -467,8 +736,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -482,78 +751,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/operations": {
-            "get": {
-                "description": "retrieve the list of operations",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Operations"
-                ],
-                "summary": "Get the operations list",
-                "operationId": "get-operations",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter parameter",
-                        "name": "filter_param",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter value",
-                        "name": "filter_value",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort field",
-                        "name": "sort_field",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort order",
-                        "name": "sort_order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/repositories.PaginatedOperations"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    }
-                }
-            },
+        "/api/v1/dex/offers/cancel": {
             "post": {
-                "description": "create a new operation",
+                "description": "cancel an open offer placed by the service from the domain market maker wallet, by the sequence of its OfferCreate transaction",
                 "consumes": [
                     "application/json"
                 ],
@@ -561,18 +761,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Operations"
+                    "DEX"
                 ],
-                "summary": "Create a new operation",
-                "operationId": "post-operation",
+                "summary": "Cancel an offer on the DEX",
+                "operationId": "post-dex-offer-cancel",
                 "parameters": [
                     {
-                        "description": "Operation object",
-                        "name": "operation",
+                        "description": "Offer to cancel",
+                        "name": "offer",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.OperationRequest"
+                            "$ref": "#/definitions/types.CancelOfferRequest"
                         }
                     }
                 ],
@@ -589,8 +789,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -604,24 +804,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/operations-domains": {
+        "/api/v1/fireblocks-accounts": {
             "get": {
-                "description": "retrieve the list of operations domains",
+                "description": "retrieve the list of fireblocks accounts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsDomains"
+                    "FireblocksAccounts"
                 ],
-                "summary": "Get the operations domains list",
-                "operationId": "get-operations-domains",
+                "summary": "Get the fireblocks accounts list",
+                "operationId": "get-fireblocks-accounts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/operation.OperationDomain"
+                                "$ref": "#/definitions/fireblocks.FireblocksAccount"
                             }
                         }
                     },
@@ -634,7 +834,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "create a new operation domain",
+                "description": "create a new fireblocks account",
                 "consumes": [
                     "application/json"
                 ],
@@ -642,18 +842,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsDomains"
+                    "FireblocksAccounts"
                 ],
-                "summary": "Create a new operation domain",
-                "operationId": "post-operation-domain",
+                "summary": "Create a new fireblocks account",
+                "operationId": "post-fireblocks-accounts",
                 "parameters": [
                     {
-                        "description": "Operation Domain",
-                        "name": "body",
+                        "description": "Fireblocks Account",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.SaveOperationDomainRequest"
+                            "$ref": "#/definitions/types.SaveFireblocksAccountRequest"
                         }
                     }
                 ],
@@ -670,6 +870,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -677,48 +883,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "update a operation domain",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/fireblocks-accounts/audit": {
+            "get": {
+                "description": "derive the XRPL address of each fireblocks account public key and report any drift from the linked wallet address",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsDomains"
-                ],
-                "summary": "Update a operation domain",
-                "operationId": "patch-operation-domain",
-                "parameters": [
-                    {
-                        "description": "Operation Domain",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.EditOperationDomainRequest"
-                        }
-                    }
+                    "FireblocksAccounts"
                 ],
+                "summary": "Audit the fireblocks accounts addresses",
+                "operationId": "get-fireblocks-accounts-audit",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Result"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
+                            "$ref": "#/definitions/fireblocks.AddressAudit"
                         }
                     },
                     "500": {
@@ -730,22 +912,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/operations-domains/list": {
+        "/api/v1/fireblocks-accounts/vault/{vault_id}": {
             "get": {
-                "description": "retrieve the list of operations domains names",
+                "description": "retrieve a fireblocks account by vault id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsDomains"
+                    "FireblocksAccounts"
                 ],
-                "summary": "Get the operations domains names list",
-                "operationId": "get-operations-domains-names",
+                "summary": "Get a fireblocks account",
+                "operationId": "get-fireblocks-accounts-by-vault-id",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/fireblocks.FireblocksAccount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
                     "500": {
@@ -757,22 +951,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/operations-domains/{id}": {
+        "/api/v1/fireblocks-accounts/{id}": {
             "get": {
-                "description": "retrieve a operation domain by id",
+                "description": "retrieve a fireblocks account by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsDomains"
+                    "FireblocksAccounts"
                 ],
-                "summary": "Get a operation domain",
-                "operationId": "get-operation-domain-by-id",
+                "summary": "Get a fireblocks account",
+                "operationId": "get-fireblocks-accounts-by-id",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/operation.OperationDomain"
+                            "$ref": "#/definitions/fireblocks.FireblocksAccount"
                         }
                     },
                     "400": {
@@ -796,19 +990,19 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "delete a operation domain",
+                "description": "delete a fireblocks account",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsDomains"
+                    "FireblocksAccounts"
                 ],
-                "summary": "Delete a operation domain",
-                "operationId": "delete-operation-domain",
+                "summary": "Delete a fireblocks account",
+                "operationId": "delete-fireblocks-accounts",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Operation Domain ID",
+                        "description": "Fireblocks Account ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -842,25 +1036,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/operations-types": {
+        "/api/v1/journal/accounts/statement": {
             "get": {
-                "description": "retrieve the list of operations types",
+                "description": "retrieve the lines posted to the account in the currency between the dates with the running balance. The current balance of a wallet or of the tokens issued can be reconciled with the ledger",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsTypes"
+                    "Journal"
+                ],
+                "summary": "Get the statement of a journal account",
+                "operationId": "get-journal-account-statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account",
+                        "name": "account",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency",
+                        "name": "currency",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Compare the closing balance with the ledger",
+                        "name": "reconcile",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get the operations types list",
-                "operationId": "get-operations-types",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/operation.OperationType"
-                            }
+                            "$ref": "#/definitions/transaction.AccountStatement"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
                     "500": {
@@ -870,36 +1101,53 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "create a new operation type",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/journal/entries": {
+            "get": {
+                "description": "retrieve the entries posted between the dates with a line of the account in the currency, in the order they were posted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsTypes"
+                    "Journal"
                 ],
-                "summary": "Create a new operation type",
-                "operationId": "post-operation-type",
+                "summary": "Get the journal entries",
+                "operationId": "get-journal-entries",
                 "parameters": [
                     {
-                        "description": "Operation Type",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.SaveOperationTypeRequest"
-                        }
+                        "type": "string",
+                        "description": "Account",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Result"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repositories.JournalEntry"
+                            }
                         }
                     },
                     "400": {
@@ -916,8 +1164,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "update a operation type",
+            "post": {
+                "description": "post an entry made by an operator, such as the opening balances of the wallets or an adjustment. The entry is rejected when its debits and credits are not balanced for every currency",
                 "consumes": [
                     "application/json"
                 ],
@@ -925,18 +1173,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsTypes"
+                    "Journal"
                 ],
-                "summary": "Update a operation type",
-                "operationId": "patch-operation-type",
+                "summary": "Post a manual journal entry",
+                "operationId": "post-journal-entry",
                 "parameters": [
                     {
-                        "description": "Operation Type",
-                        "name": "request",
+                        "description": "Journal entry to post",
+                        "name": "entry",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.SaveOperationTypeRequest"
+                            "$ref": "#/definitions/types.PostJournalEntryRequest"
                         }
                     }
                 ],
@@ -944,7 +1192,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Result"
+                            "$ref": "#/definitions/repositories.JournalEntry"
                         }
                     },
                     "400": {
@@ -953,12 +1201,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -968,49 +1210,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/operations-types/list": {
+        "/api/v1/journal/trial-balance": {
             "get": {
-                "description": "retrieve the list of operations types names",
+                "description": "retrieve the balance of every account with the entries posted before the date and whether the debits and credits of each currency are balanced. The current balances of our wallets and of the tokens issued can be reconciled with the ledger",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsTypes"
+                    "Journal"
                 ],
-                "summary": "Get the operations types names list",
-                "operationId": "get-operations-types-names",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/fiber.Map"
-                        }
+                "summary": "Get the trial balance of the journal",
+                "operationId": "get-journal-trial-balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date of the balances (RFC 3339)",
+                        "name": "as_of",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
+                    {
+                        "type": "boolean",
+                        "description": "Compare the current balances with the ledger",
+                        "name": "reconcile",
+                        "in": "query"
                     }
-                }
-            }
-        },
-        "/api/v1/operations-types/{id}": {
-            "get": {
-                "description": "retrieve a operation type by id",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "OperationsTypes"
                 ],
-                "summary": "Get a operation type",
-                "operationId": "get-operation-type-by-id",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/operation.OperationType"
+                            "$ref": "#/definitions/transaction.TrialBalance"
                         }
                     },
                     "400": {
@@ -1019,12 +1248,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1032,31 +1255,62 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "delete a operation type",
+            }
+        },
+        "/api/v1/operations": {
+            "get": {
+                "description": "retrieve the list of operations",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperationsTypes"
+                    "Operations"
                 ],
-                "summary": "Delete a operation type",
-                "operationId": "delete-operation-type",
+                "summary": "Get the operations list",
+                "operationId": "get-operations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Operation Type ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter parameter",
+                        "name": "filter_param",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter value",
+                        "name": "filter_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort_field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Result"
+                            "$ref": "#/definitions/repositories.PaginatedOperations"
                         }
                     },
                     "400": {
@@ -1065,12 +1319,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1078,33 +1326,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/operations/{id}": {
-            "get": {
-                "description": "retrieve an operation by id",
+            },
+            "post": {
+                "description": "create a new MINT or BURN operation. A MINT must be backed by confirmed BRL or USD deposits whose sum covers the amount, each bank transaction backing a single operation",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Operations"
                 ],
-                "summary": "Get an operation",
-                "operationId": "get-operation-by-id",
+                "summary": "Create a new operation",
+                "operationId": "post-operation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Operation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Operation object",
+                        "name": "operation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.OperationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/operation.OperationWithLogs"
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -1113,8 +1364,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -1128,24 +1379,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tokens": {
+        "/api/v1/operations-domains": {
             "get": {
-                "description": "retrieve the list of supported tokens",
+                "description": "retrieve the list of operations domains",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "OperationsDomains"
                 ],
-                "summary": "Get the tokens list",
-                "operationId": "get-tokens",
+                "summary": "Get the operations domains list",
+                "operationId": "get-operations-domains",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/tokens.Token"
+                                "$ref": "#/definitions/operation.OperationDomain"
                             }
                         }
                     },
@@ -1158,7 +1409,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "create a new token",
+                "description": "create a new operation domain",
                 "consumes": [
                     "application/json"
                 ],
@@ -1166,18 +1417,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "OperationsDomains"
                 ],
-                "summary": "Create a new token",
-                "operationId": "post-token",
+                "summary": "Create a new operation domain",
+                "operationId": "post-operation-domain",
                 "parameters": [
                     {
-                        "description": "Token object that needs to be added",
-                        "name": "token",
+                        "description": "Operation Domain",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.SaveTokenRequest"
+                            "$ref": "#/definitions/types.SaveOperationDomainRequest"
                         }
                     }
                 ],
@@ -1194,12 +1445,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1209,7 +1454,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "update a token",
+                "description": "update a operation domain",
                 "consumes": [
                     "application/json"
                 ],
@@ -1217,18 +1462,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "OperationsDomains"
                 ],
-                "summary": "Update a token",
-                "operationId": "patch-token",
+                "summary": "Update a operation domain",
+                "operationId": "patch-operation-domain",
                 "parameters": [
                     {
-                        "description": "Token",
+                        "description": "Operation Domain",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.EditTokenRequest"
+                            "$ref": "#/definitions/types.EditOperationDomainRequest"
                         }
                     }
                 ],
@@ -1245,6 +1490,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1254,22 +1505,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tokens/{id}": {
+        "/api/v1/operations-domains/list": {
             "get": {
-                "description": "retrieve a supported token by id",
+                "description": "retrieve the list of operations domains names",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "OperationsDomains"
                 ],
-                "summary": "Get a token",
-                "operationId": "get-token-by-id",
+                "summary": "Get the operations domains names list",
+                "operationId": "get-operations-domains-names",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tokens.Token"
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/operations-domains/{id}": {
+            "get": {
+                "description": "retrieve a operation domain by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperationsDomains"
+                ],
+                "summary": "Get a operation domain",
+                "operationId": "get-operation-domain-by-id",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/operation.OperationDomain"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
                     "404": {
@@ -1287,19 +1571,19 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "delete a token",
+                "description": "delete a operation domain",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "OperationsDomains"
                 ],
-                "summary": "Delete a token",
-                "operationId": "delete-token",
+                "summary": "Delete a operation domain",
+                "operationId": "delete-operation-domain",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Token ID",
+                        "description": "Operation Domain ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1312,6 +1596,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.Result"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1327,24 +1617,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transactions-types": {
+        "/api/v1/operations-types": {
             "get": {
-                "description": "retrieve the list of transactions types",
+                "description": "retrieve the list of operations types",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TransactionsTypes"
+                    "OperationsTypes"
                 ],
-                "summary": "Get the transactions types list",
-                "operationId": "get-transactions-types",
+                "summary": "Get the operations types list",
+                "operationId": "get-operations-types",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/transaction.TransactionType"
+                                "$ref": "#/definitions/operation.OperationType"
                             }
                         }
                     },
@@ -1357,7 +1647,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "create a new transaction type",
+                "description": "create a new operation type",
                 "consumes": [
                     "application/json"
                 ],
@@ -1365,18 +1655,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TransactionsTypes"
+                    "OperationsTypes"
                 ],
-                "summary": "Create a new transaction type",
-                "operationId": "post-transaction-type",
+                "summary": "Create a new operation type",
+                "operationId": "post-operation-type",
                 "parameters": [
                     {
-                        "description": "transaction Type",
+                        "description": "Operation Type",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.SaveTransactionTypeRequest"
+                            "$ref": "#/definitions/types.SaveOperationTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update a operation type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperationsTypes"
+                ],
+                "summary": "Update a operation type",
+                "operationId": "patch-operation-type",
+                "parameters": [
+                    {
+                        "description": "Operation Type",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SaveOperationTypeRequest"
                         }
                     }
                 ],
@@ -1393,6 +1728,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1402,17 +1743,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transactions-types/list": {
+        "/api/v1/operations-types/list": {
             "get": {
-                "description": "retrieve the list of transactions types names",
+                "description": "retrieve the list of operations types names",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TransactionsTypes"
+                    "OperationsTypes"
                 ],
-                "summary": "Get the transactions types names list",
-                "operationId": "get-transactions-types-names",
+                "summary": "Get the operations types names list",
+                "operationId": "get-operations-types-names",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1429,31 +1770,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transactions-types/{id}": {
+        "/api/v1/operations-types/{id}": {
             "get": {
-                "description": "retrieve a transaction type by id",
+                "description": "retrieve a operation type by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TransactionsTypes"
-                ],
-                "summary": "Get a transaction type",
-                "operationId": "get-transaction-type-by-id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Transaction Type ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "OperationsTypes"
                 ],
+                "summary": "Get a operation type",
+                "operationId": "get-operation-type-by-id",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transaction.TransactionType"
+                            "$ref": "#/definitions/operation.OperationType"
                         }
                     },
                     "400": {
@@ -1477,19 +1809,19 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "delete a transaction type",
+                "description": "delete a operation type",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TransactionsTypes"
+                    "OperationsTypes"
                 ],
-                "summary": "Delete a transaction type",
-                "operationId": "delete-transaction-type",
+                "summary": "Delete a operation type",
+                "operationId": "delete-operation-type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "transaction Type ID",
+                        "description": "Operation Type ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1521,9 +1853,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "update a transaction type",
+            }
+        },
+        "/api/v1/operations/cross-currency": {
+            "post": {
+                "description": "deliver a token amount to an external address spending another token or XRP of the domain payment wallet through the DEX, up to the send max of an accepted quote",
                 "consumes": [
                     "application/json"
                 ],
@@ -1531,25 +1865,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TransactionsTypes"
+                    "Operations"
                 ],
-                "summary": "Update a transaction type",
-                "operationId": "patch-transaction-type",
+                "summary": "Create a new cross currency payout operation",
+                "operationId": "post-cross-currency-payout-operation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Transaction Type ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "transaction Type",
-                        "name": "request",
+                        "description": "Cross currency payout operation object",
+                        "name": "operation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.SaveTransactionTypeRequest"
+                            "$ref": "#/definitions/types.CrossCurrencyPayoutRequest"
                         }
                     }
                 ],
@@ -1557,7 +1884,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Result"
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -1566,8 +1893,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -1581,25 +1908,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/wallets": {
-            "get": {
-                "description": "retrieve the list of wallets",
+        "/api/v1/operations/cross-currency/quote": {
+            "post": {
+                "description": "find the cheapest path to deliver a token amount to an external address spending another token or XRP of the domain payment wallet, with the suggested send max",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Operations"
+                ],
+                "summary": "Quote a cross currency payout",
+                "operationId": "post-cross-currency-quote",
+                "parameters": [
+                    {
+                        "description": "Cross currency quote object",
+                        "name": "quote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CrossCurrencyQuoteRequest"
+                        }
+                    }
                 ],
-                "summary": "Get the wallets list",
-                "operationId": "get-wallets",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/wallet.Wallet"
-                            }
+                            "$ref": "#/definitions/operation.CrossCurrencyQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
                     "500": {
@@ -1609,22 +1953,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/operations/funding": {
             "post": {
-                "description": "create a new wallet",
+                "description": "transfer native XRP between registered wallets to fund their reserves and fees",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Operations"
+                ],
+                "summary": "Create a new XRP funding operation",
+                "operationId": "post-funding-operation",
+                "parameters": [
+                    {
+                        "description": "Funding operation object",
+                        "name": "operation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.FundingOperationRequest"
+                        }
+                    }
                 ],
-                "summary": "Create a new wallet",
-                "operationId": "post-wallet",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wallet.Wallet"
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -1633,8 +1993,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -1646,9 +2006,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "update a wallet",
+            }
+        },
+        "/api/v1/operations/payout": {
+            "post": {
+                "description": "transfer tokens from the domain payment wallet to an external address, with an optional destination tag, or send them as a check the destination cashes once its trust line is ready",
                 "consumes": [
                     "application/json"
                 ],
@@ -1656,18 +2018,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Operations"
                 ],
-                "summary": "Update a wallet",
-                "operationId": "patch-wallet",
+                "summary": "Create a new token payout operation",
+                "operationId": "post-payout-operation",
                 "parameters": [
                     {
-                        "description": "Wallet",
-                        "name": "request",
+                        "description": "Payout operation object",
+                        "name": "operation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.EditWalletRequest"
+                            "$ref": "#/definitions/types.PayoutOperationRequest"
                         }
                     }
                 ],
@@ -1675,7 +2037,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wallet.Wallet"
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -1684,8 +2046,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -1699,22 +2061,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/wallets/address/{address}/blockchain_id/{blockchain_id}": {
+        "/api/v1/operations/{id}": {
             "get": {
-                "description": "retrieve a wallet by address and blockchain",
+                "description": "retrieve an operation by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Operations"
+                ],
+                "summary": "Get an operation",
+                "operationId": "get-operation-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get a wallet",
-                "operationId": "get-wallet-by-address-and-blockchain",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wallet.Wallet"
+                            "$ref": "#/definitions/operation.OperationWithLogs"
                         }
                     },
                     "400": {
@@ -1738,22 +2109,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/wallets/balances": {
+        "/api/v1/payment-channels": {
             "get": {
-                "description": "retrieve the list of wallets balances",
+                "description": "retrieve the payment channels opened by the service with the XRP funded, delivered and still claimable on the ledger",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Payment Channels"
                 ],
-                "summary": "Get the wallets balances list",
-                "operationId": "get-wallets-balances",
+                "summary": "Get the payment channels",
+                "operationId": "get-payment-channels",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wallet.TokenBalance"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/operation.PaymentChannelState"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "open a payment channel of XRP from one of the wallets to a partner address, its claims being signed by the fireblocks key of the wallet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment Channels"
+                ],
+                "summary": "Open a new payment channel",
+                "operationId": "post-payment-channel",
+                "parameters": [
+                    {
+                        "description": "Channel object",
+                        "name": "channel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreatePaymentChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -1762,6 +2175,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1771,24 +2190,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/wallets/blockchain/{blockchain_id}/domain/{domain}": {
+        "/api/v1/payment-channels/claims": {
             "get": {
-                "description": "retrieve the list of wallets by blockchain and domain",
+                "description": "retrieve the claims signed for a payment channel opened by the service, with their signatures",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Payment Channels"
+                ],
+                "summary": "Get the claims of a payment channel",
+                "operationId": "get-payment-channel-claims",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment channel ID",
+                        "name": "channel_id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get the wallets list",
-                "operationId": "get-wallets-by-blockchain-and-domain",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/wallet.Wallet"
+                                "$ref": "#/definitions/repositories.ChannelClaim"
                             }
                         }
                     },
@@ -1798,8 +2226,53 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "sign off-ledger through fireblocks RAW the claim of the cumulative XRP a partner can redeem from a payment channel opened by the service, the signature being listed once signed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment Channels"
+                ],
+                "summary": "Sign a payment channel claim",
+                "operationId": "post-payment-channel-claim",
+                "parameters": [
+                    {
+                        "description": "Claim object",
+                        "name": "claim",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SignChannelClaimRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -1813,22 +2286,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/wallets/blockchain/{blockchain_id}/type/{wallet_type}/domain/{domain}": {
-            "get": {
-                "description": "retrieve a wallet by blockchain, wallet type and domain",
+        "/api/v1/payment-channels/claims/submit": {
+            "post": {
+                "description": "redeem, from the destination wallet of a partner payment channel, a claim signed by the partner, verifying it before submitting a PaymentChannelClaim",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Payment Channels"
+                ],
+                "summary": "Redeem a payment channel claim",
+                "operationId": "post-payment-channel-claim-submit",
+                "parameters": [
+                    {
+                        "description": "Claim object",
+                        "name": "claim",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SubmitChannelClaimRequest"
+                        }
+                    }
                 ],
-                "summary": "Get a wallet",
-                "operationId": "get-wallet-by-blockchain-wallet-type-and-domain",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wallet.Wallet"
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -1837,8 +2324,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -1852,25 +2339,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/wallets/{id}": {
-            "get": {
-                "description": "retrieve a wallet by id",
+        "/api/v1/payment-channels/claims/verify": {
+            "post": {
+                "description": "verify a claim of a payment channel against the channel on the ledger, its signature having to be of the channel key and its amount covered by the XRP funded on the channel",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Payment Channels"
+                ],
+                "summary": "Verify a payment channel claim",
+                "operationId": "post-payment-channel-claim-verify",
+                "parameters": [
+                    {
+                        "description": "Claim object",
+                        "name": "claim",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.VerifyChannelClaimRequest"
+                        }
+                    }
                 ],
-                "summary": "Get a wallet",
-                "operationId": "get-wallet-by-id",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/wallet.Wallet"
-                            }
+                            "$ref": "#/definitions/operation.ClaimVerification"
                         }
                     },
                     "400": {
@@ -1879,12 +2377,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorMessage"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1892,31 +2384,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "delete a wallet",
+            }
+        },
+        "/api/v1/payment-channels/fund": {
+            "post": {
+                "description": "add XRP to a payment channel opened by the service, optionally setting when it expires",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallets"
+                    "Payment Channels"
                 ],
-                "summary": "Delete a wallet",
-                "operationId": "delete-wallet",
+                "summary": "Fund a payment channel",
+                "operationId": "post-payment-channel-fund",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Wallet ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Channel object",
+                        "name": "channel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.FundPaymentChannelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wallet.Wallet"
+                            "$ref": "#/definitions/types.OperationResponse"
                         }
                     },
                     "400": {
@@ -1925,8 +2424,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "423": {
+                        "description": "Locked",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorMessage"
                         }
@@ -1939,28 +2438,3302 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "blockchains.Blockchain": {
-            "type": "object",
-            "properties": {
-                "abbr": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "main_token": {
-                    "type": "string"
-                },
-                "name": {
+        },
+        "/api/v1/public/reserves/attestations": {
+            "get": {
+                "description": "public history of the signed reserves attestations of an issued token, or of all of them, from the newest to the oldest. Each document is verified by hashing its exact JSON with SHA-256 and checking the detached signature of the digest against the issuer public key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get the published reserves attestations",
+                "operationId": "get-public-reserve-attestations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "token_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/operation.PublicReserveAttestation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/reserves/attestations/{id}": {
+            "get": {
+                "description": "public signed reserves attestation with its detached signature",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get a published reserves attestation",
+                "operationId": "get-public-reserve-attestation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Attestation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/operation.PublicReserveAttestation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/mints": {
+            "get": {
+                "description": "retrieve the mints created on the range with the fiat deposits backing them, one row per deposit, with the dates in the timezone. The report is streamed as JSON or as CSV",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get the mints audit report",
+                "operationId": "get-mints-report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (2006-01-02 in the timezone or RFC 3339)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, inclusive (2006-01-02 in the timezone or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone, defaults to UTC",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Export format, defaults to json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/operation.MintsReportRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/operations": {
+            "get": {
+                "description": "retrieve the count of the operations created on the range by status, the amount of the completed ones when grouped by token, their failed steps and how long they took to be processed, grouped by type, token, domain or operator and by day, week or month in the timezone. The report is streamed as JSON or as CSV",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get the operations report",
+                "operationId": "get-operations-report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (2006-01-02 in the timezone or RFC 3339)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, inclusive (2006-01-02 in the timezone or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields among type, token, domain and operator, defaults to type,token",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Period of the rows",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone, defaults to UTC",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Export format, defaults to json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/operation.OperationsReportRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/supply": {
+            "get": {
+                "description": "retrieve the amount of tokens minted and burned by the completed operations created on the range, grouped by token, and by domain or operator, and by day, week or month in the timezone. The report is streamed as JSON or as CSV",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get the supply report",
+                "operationId": "get-supply-report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (2006-01-02 in the timezone or RFC 3339)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, inclusive (2006-01-02 in the timezone or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields among token, domain and operator, always including token",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Period of the rows",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone, defaults to UTC",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Export format, defaults to json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/operation.SupplyReportRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reserves": {
+            "get": {
+                "description": "retrieve the circulating supply of an issued token on the last validated ledger and the fiat reserves backing it, as they would be attested now",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reserves"
+                ],
+                "summary": "Get the current reserves of a token",
+                "operationId": "get-reserves",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "token_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/operation.ReserveAttestationDocument"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reserves/attestations": {
+            "get": {
+                "description": "retrieve the reserves attestations of an issued token, or of all of them, with their signing status, from the newest to the oldest",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reserves"
+                ],
+                "summary": "Get the reserves attestations",
+                "operationId": "get-reserve-attestations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "token_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PUBLISHED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Attestation status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repositories.ReserveAttestation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "attest the fiat reserves backing the circulating supply of an issued token, the SHA-256 digest of the attestation being signed on fireblocks by the issuer key before it is published",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reserves"
+                ],
+                "summary": "Publish a reserves attestation",
+                "operationId": "post-reserve-attestation",
+                "parameters": [
+                    {
+                        "description": "Token to attest",
+                        "name": "attestation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.PublishAttestationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reserves/balances": {
+            "get": {
+                "description": "retrieve the fiat reserve balances recorded for an issued token, or for all of them, from the newest to the oldest",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reserves"
+                ],
+                "summary": "Get the fiat reserve balances",
+                "operationId": "get-reserve-balances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "token_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repositories.ReserveBalance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "record the off-chain fiat balance backing an issued token held on a custodian account on a date, with the references of its evidences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reserves"
+                ],
+                "summary": "Record a fiat reserve balance",
+                "operationId": "post-reserve-balance",
+                "parameters": [
+                    {
+                        "description": "Reserve balance to record",
+                        "name": "balance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RecordReserveBalanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repositories.ReserveBalance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tokens": {
+            "get": {
+                "description": "retrieve the list of supported tokens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Get the tokens list",
+                "operationId": "get-tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tokens.Token"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a new token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Create a new token",
+                "operationId": "post-token",
+                "parameters": [
+                    {
+                        "description": "Token object that needs to be added",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SaveTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update a token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Update a token",
+                "operationId": "patch-token",
+                "parameters": [
+                    {
+                        "description": "Token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.EditTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tokens/{id}": {
+            "get": {
+                "description": "retrieve a supported token by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Get a token",
+                "operationId": "get-token-by-id",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tokens.Token"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Delete a token",
+                "operationId": "delete-token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tokens/{id}/holders": {
+            "get": {
+                "description": "retrieve the holders count, the concentration metrics (top 10 share, HHI and Gini) and the top holders of an issued token on its last holders snapshot, or on the given snapshot",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Get the holders distribution of a token",
+                "operationId": "get-token-holders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Holders snapshot ID",
+                        "name": "snapshot_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Amount of top holders",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tokens.HolderDistribution"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tokens/{id}/holders/snapshots": {
+            "get": {
+                "description": "retrieve the holders snapshots of an issued token taken between the dates, with the holders count and concentration metrics of each of them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Get the holders snapshots of a token",
+                "operationId": "get-token-holder-snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repositories.HolderSnapshot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tokens/{id}/holders/{account}/history": {
+            "get": {
+                "description": "retrieve the balance of a holder on each holders snapshot of an issued token taken between the dates, with how much it changed since the previous snapshot",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Get the balance history of a token holder",
+                "operationId": "get-token-holder-history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Holder address",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tokens.HolderHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions-types": {
+            "get": {
+                "description": "retrieve the list of transactions types",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TransactionsTypes"
+                ],
+                "summary": "Get the transactions types list",
+                "operationId": "get-transactions-types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/transaction.TransactionType"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a new transaction type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TransactionsTypes"
+                ],
+                "summary": "Create a new transaction type",
+                "operationId": "post-transaction-type",
+                "parameters": [
+                    {
+                        "description": "transaction Type",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SaveTransactionTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions-types/list": {
+            "get": {
+                "description": "retrieve the list of transactions types names",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TransactionsTypes"
+                ],
+                "summary": "Get the transactions types names list",
+                "operationId": "get-transactions-types-names",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions-types/{id}": {
+            "get": {
+                "description": "retrieve a transaction type by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TransactionsTypes"
+                ],
+                "summary": "Get a transaction type",
+                "operationId": "get-transaction-type-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transaction.TransactionType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a transaction type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TransactionsTypes"
+                ],
+                "summary": "Delete a transaction type",
+                "operationId": "delete-transaction-type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "transaction Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update a transaction type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TransactionsTypes"
+                ],
+                "summary": "Update a transaction type",
+                "operationId": "patch-transaction-type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "transaction Type",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SaveTransactionTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets": {
+            "get": {
+                "description": "retrieve the list of wallets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Get the wallets list",
+                "operationId": "get-wallets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/wallet.Wallet"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a new wallet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Create a new wallet",
+                "operationId": "post-wallet",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wallet.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update a wallet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Update a wallet",
+                "operationId": "patch-wallet",
+                "parameters": [
+                    {
+                        "description": "Wallet",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.EditWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wallet.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/address/{address}/blockchain_id/{blockchain_id}": {
+            "get": {
+                "description": "retrieve a wallet by address and blockchain",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Get a wallet",
+                "operationId": "get-wallet-by-address-and-blockchain",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wallet.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/balances": {
+            "get": {
+                "description": "retrieve the list of wallets balances",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Get the wallets balances list",
+                "operationId": "get-wallets-balances",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wallet.TokenBalance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/blockchain/{blockchain_id}/domain/{domain}": {
+            "get": {
+                "description": "retrieve the list of wallets by blockchain and domain",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Get the wallets list",
+                "operationId": "get-wallets-by-blockchain-and-domain",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/wallet.Wallet"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/blockchain/{blockchain_id}/type/{wallet_type}/domain/{domain}": {
+            "get": {
+                "description": "retrieve a wallet by blockchain, wallet type and domain",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Get a wallet",
+                "operationId": "get-wallet-by-blockchain-wallet-type-and-domain",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wallet.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/{id}": {
+            "get": {
+                "description": "retrieve a wallet by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Get a wallet",
+                "operationId": "get-wallet-by-id",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/wallet.Wallet"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a wallet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Delete a wallet",
+                "operationId": "delete-wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wallet.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/xrpl/decode": {
+            "post": {
+                "description": "decode a tx blob, or the blob submitted by an operation, into JSON with its signing hash and transaction ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "XRPL"
+                ],
+                "summary": "Decode a XRPL transaction blob",
+                "operationId": "post-xrpl-decode",
+                "parameters": [
+                    {
+                        "description": "Tx blob or operation ID to decode",
+                        "name": "decode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.DecodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ripple.XrpDecodedTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorMessage"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "blockchains.Blockchain": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "main_token": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "fiber.Map": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "fireblocks.AddressAudit": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fireblocks.AddressVerification"
+                    }
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "drift_count": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "fireblocks.AddressVerification": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "string"
+                },
+                "derived_address": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fireblocks_account_id": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "vault_id": {
+                    "type": "string"
+                },
+                "wallet_address": {
+                    "type": "string"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "fireblocks.Blockchain": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "main_token": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "fireblocks.FireblocksAccount": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "asset_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "public_key_fallback": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vault_id": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "$ref": "#/definitions/fireblocks.Wallet"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "fireblocks.Wallet": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "blockchain": {
+                    "$ref": "#/definitions/fireblocks.Blockchain"
+                },
+                "blockchain_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation.AmmPool": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM"
+                },
+                "lp_token_balance": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "lp_token_currency": {
+                    "type": "string",
+                    "example": "03930D02208264E2E40EC1B0C09E4DB96EE197B1"
+                },
+                "lp_token_supply": {
+                    "type": "string",
+                    "example": "71150.53584131501"
+                },
+                "token_amount": {
+                    "$ref": "#/definitions/repositories.OfferAmount"
+                },
+                "trading_fee": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "vote_slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ripple.XrpAmmVoteSlot"
+                    }
+                },
+                "wallet": {
+                    "type": "string",
+                    "example": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+                },
+                "xrp_amount": {
+                    "$ref": "#/definitions/repositories.OfferAmount"
+                }
+            }
+        },
+        "operation.AttestationSignature": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "example": "SECP256K1"
+                },
+                "digest": {
+                    "type": "string",
+                    "example": "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824"
+                },
+                "digest_algorithm": {
+                    "type": "string",
+                    "example": "SHA-256"
+                },
+                "public_key": {
+                    "type": "string",
+                    "example": "031EBB60A3036A67B6AA2055D5BD79A5ADFEC608057407F1EE3720E01489D59E82"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "3045022100..."
+                }
+            }
+        },
+        "operation.AttestedHolding": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "rwSLnXVuEPNkMw4sR7p1EsMTivsEo1LbsT"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "500000"
+                },
+                "wallet": {
+                    "type": "string",
+                    "example": "Estoque Braza On"
+                }
+            }
+        },
+        "operation.AttestedReserve": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "0001-12345-6"
+                },
+                "amount": {
+                    "type": "string",
+                    "example": "1000500"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "custodian": {
+                    "type": "string",
+                    "example": "Banco do Brasil"
+                },
+                "evidences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "operation.ClaimVerification": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+                },
+                "amount": {
+                    "type": "string",
+                    "example": "12.5"
+                },
+                "channel_amount": {
+                    "type": "string",
+                    "example": "250"
+                },
+                "channel_balance": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "channel_id": {
+                    "type": "string",
+                    "example": "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366"
+                },
+                "claimable": {
+                    "type": "string",
+                    "example": "2.5"
+                },
+                "destination": {
+                    "type": "string",
+                    "example": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"
+                },
+                "public_key": {
+                    "type": "string",
+                    "example": "0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "claim amount exceeds the XRP funded on the channel"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "operation.CrossCurrencyQuote": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"
+                },
+                "destination_amount": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "destination_currency": {
+                    "type": "string",
+                    "example": "USDB"
+                },
+                "paths_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "5.1235"
+                },
+                "send_max": {
+                    "type": "string",
+                    "example": "517.4735"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+                },
+                "source_amount": {
+                    "type": "string",
+                    "example": "512.35"
+                },
+                "source_currency": {
+                    "type": "string",
+                    "example": "BBRL"
+                }
+            }
+        },
+        "operation.MarketOffer": {
+            "type": "object",
+            "properties": {
+                "expiration": {
+                    "type": "string"
+                },
+                "flags": {
+                    "type": "integer",
+                    "example": 524288
+                },
+                "managed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "offer_id": {
+                    "type": "string",
+                    "example": "6731c302c2c5a4d1f1d4aac9"
+                },
+                "operation_id": {
+                    "type": "string",
+                    "example": "66f79f17ba6b56108cb3e81d"
+                },
+                "quality": {
+                    "type": "string",
+                    "example": "0.19"
+                },
+                "sequence": {
+                    "type": "integer",
+                    "example": 4218
+                },
+                "side": {
+                    "type": "string",
+                    "example": "SELL"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "taker_gets": {
+                    "$ref": "#/definitions/repositories.OfferAmount"
+                },
+                "taker_pays": {
+                    "$ref": "#/definitions/repositories.OfferAmount"
+                }
+            }
+        },
+        "operation.MintsReportRow": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "backing_amount": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "backing_currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "bank_transaction_id": {
+                    "type": "string",
+                    "example": "E18236120202411151830s0123456789"
+                },
+                "blockchain_status": {
+                    "type": "string",
+                    "example": "COMPLETED"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-11-15T15:45:10-0300"
+                },
+                "domain": {
+                    "type": "string",
+                    "example": "BRAZA-ON"
+                },
+                "operation_id": {
+                    "type": "string",
+                    "example": "6737b2d50404579f10316ae1"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "received_at": {
+                    "type": "string",
+                    "example": "2024-11-15T15:30:00-0300"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "BBRL"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation.OperationDomain": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation.OperationType": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation.OperationWithLogs": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "backing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.BackingRef"
+                    }
+                },
+                "balance_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.BalanceChange"
+                    }
+                },
+                "blockchain_status": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deliver_min": {
+                    "type": "string"
+                },
+                "delivered_amount": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "destination_tag": {
+                    "type": "integer"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "fireblocks_id": {
+                    "type": "string"
+                },
+                "fireblocks_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.OperationLog"
+                    }
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "origin": {
+                    "type": "string"
+                },
+                "send_max": {
+                    "type": "string"
+                },
+                "source_currency": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                },
+                "transaction_link": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation.OperationsReportRow": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "sum of the completed operations, when grouped by token",
+                    "type": "string",
+                    "example": "80000"
+                },
+                "avg_duration_seconds": {
+                    "description": "of the completed operations",
+                    "type": "number",
+                    "example": 7.5
+                },
+                "completed": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "domain": {
+                    "type": "string",
+                    "example": "BRAZA-ON"
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "failed_steps": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2024-11-15"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "BBRL"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "MINT"
+                }
+            }
+        },
+        "operation.PaymentChannelState": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "amount": {
+                    "description": "XRP funded on creation",
+                    "type": "string"
+                },
+                "blockchain": {
+                    "type": "string"
+                },
+                "cancel_after": {
+                    "type": "string"
+                },
+                "channel_id": {
+                    "type": "string"
+                },
+                "claimable": {
+                    "type": "string",
+                    "example": "237.5"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "destination_tag": {
+                    "type": "integer"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "expiration": {
+                    "type": "string"
+                },
+                "fund_operation_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ledger_amount": {
+                    "type": "string",
+                    "example": "250"
+                },
+                "ledger_balance": {
+                    "type": "string",
+                    "example": "12.5"
+                },
+                "operation_id": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "settle_delay": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation.PublicReserveAttestation": {
+            "type": "object",
+            "properties": {
+                "attested_at": {
+                    "type": "string"
+                },
+                "document": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6737b2d50404579f10316ae0"
+                },
+                "signature": {
+                    "$ref": "#/definitions/operation.AttestationSignature"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "BBRL"
+                }
+            }
+        },
+        "operation.ReserveAttestationDocument": {
+            "type": "object",
+            "properties": {
+                "attested_at": {
+                    "type": "string"
+                },
+                "circulating_supply": {
+                    "type": "string",
+                    "example": "1000000"
+                },
+                "collateralization_ratio": {
+                    "type": "string",
+                    "example": "1.0005"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "4242524C00000000000000000000000000000000"
+                },
+                "issued_supply": {
+                    "type": "string",
+                    "example": "1500000"
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"
+                },
+                "ledger_hash": {
+                    "type": "string",
+                    "example": "7D9B8F37A2A37DCBEA08F4A1E94A53A4F5C4E3B0BDF0E8A2C1F32E9A0E3A1B8C"
+                },
+                "ledger_index": {
+                    "type": "integer",
+                    "example": 90000123
+                },
+                "reserve_currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "reserves": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operation.AttestedReserve"
+                    }
+                },
+                "token": {
+                    "type": "string",
+                    "example": "BBRL"
+                },
+                "total_reserves": {
+                    "type": "string",
+                    "example": "1000500"
+                },
+                "treasury": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operation.AttestedHolding"
+                    }
+                },
+                "treasury_holdings": {
+                    "type": "string",
+                    "example": "500000"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "operation.SupplyReportRow": {
+            "type": "object",
+            "properties": {
+                "burn_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "burned": {
+                    "type": "string",
+                    "example": "25000"
+                },
+                "domain": {
+                    "type": "string",
+                    "example": "BRAZA-ON"
+                },
+                "mint_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "minted": {
+                    "type": "string",
+                    "example": "150000"
+                },
+                "net": {
+                    "type": "string",
+                    "example": "125000"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2024-11"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "BBRL"
+                }
+            }
+        },
+        "repositories.BackingRef": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "bank_transaction_id": {
+                    "type": "string",
+                    "example": "E18236120202411151830s0123456789"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "received_at": {
+                    "type": "string",
+                    "example": "2024-11-15T18:30:00Z"
+                }
+            }
+        },
+        "repositories.BalanceChange": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.ChannelClaim": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "channel_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "drops": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operation_id": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.Check": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "blockchain": {
+                    "type": "string"
+                },
+                "cancel_operation_id": {
+                    "type": "string"
+                },
+                "cashed_amount": {
+                    "type": "string"
+                },
+                "check_id": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "description": "hash of the CheckCash or CheckCancel",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "destination_tag": {
+                    "type": "integer"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "expiration": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operation_id": {
+                    "type": "string"
+                },
+                "send_max": {
+                    "$ref": "#/definitions/repositories.OfferAmount"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.HolderSnapshot": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "gini": {
+                    "type": "number"
+                },
+                "hhi": {
+                    "type": "number"
+                },
+                "holders_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "ledger_index": {
+                    "type": "integer"
+                },
+                "token_id": {
+                    "type": "string"
+                },
+                "top_10_share": {
+                    "type": "number"
+                },
+                "total_balance": {
+                    "type": "string"
+                },
+                "trust_lines_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repositories.JournalEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.JournalLine"
+                    }
+                },
+                "operation_id": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.JournalLine": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "ASSET:BRAZA-ON:SUPPLY"
+                },
+                "credit": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BBRL"
+                },
+                "debit": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"
+                }
+            }
+        },
+        "repositories.OfferAmount": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.Operation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "backing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.BackingRef"
+                    }
+                },
+                "balance_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.BalanceChange"
+                    }
+                },
+                "blockchain_status": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deliver_min": {
+                    "type": "string"
+                },
+                "delivered_amount": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "destination_tag": {
+                    "type": "integer"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "fireblocks_id": {
+                    "type": "string"
+                },
+                "fireblocks_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "origin": {
+                    "type": "string"
+                },
+                "send_max": {
+                    "type": "string"
+                },
+                "source_currency": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                },
+                "transaction_link": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.OperationLog": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "error": {},
+                "fireblocks_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operation_id": {
+                    "type": "string"
+                },
+                "payload": {},
+                "response": {}
+            }
+        },
+        "repositories.PaginatedOperations": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repositories.Operation"
+                    }
+                },
+                "next_page": {
+                    "type": "integer"
+                },
+                "previous_page": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repositories.ReserveAttestation": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string"
+                },
+                "algorithm": {
+                    "type": "string"
+                },
+                "attested_at": {
+                    "type": "string"
+                },
+                "circulating_supply": {
+                    "type": "string"
+                },
+                "collateralization_ratio": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "digest": {
+                    "type": "string"
+                },
+                "document": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ledger_index": {
+                    "type": "integer"
+                },
+                "operation_id": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "token_id": {
+                    "type": "string"
+                },
+                "total_reserves": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "repositories.ReserveBalance": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string"
+                },
+                "account": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "custodian": {
+                    "type": "string"
+                },
+                "evidences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "token_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ripple.XrpAmmVoteSlot": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "trading_fee": {
+                    "type": "integer"
+                },
+                "vote_weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ripple.XrpDecodedTransaction": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "currencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "is_signed": {
+                    "type": "boolean"
+                },
+                "memos": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "signing_hash": {
+                    "type": "string"
+                },
+                "transaction": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "tx_blob": {
+                    "type": "string"
+                }
+            }
+        },
+        "tokens.Blockchain": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "main_token": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "tokens.HolderDistribution": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "gini": {
+                    "type": "number"
+                },
+                "hhi": {
+                    "type": "number"
+                },
+                "holders_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "ledger_index": {
+                    "type": "integer"
+                },
+                "token_id": {
+                    "type": "string"
+                },
+                "top_10_share": {
+                    "type": "number"
+                },
+                "top_holders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokens.TopHolder"
+                    }
+                },
+                "total_balance": {
+                    "type": "string"
+                },
+                "trust_lines_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tokens.HolderHistory": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokens.HolderHistoryPoint"
+                    }
+                },
+                "token_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "tokens.HolderHistoryPoint": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string"
+                },
+                "change": {
+                    "type": "string"
+                },
+                "has_trust_line": {
+                    "type": "boolean"
+                },
+                "ledger_index": {
+                    "type": "integer"
+                },
+                "snapshot_id": {
+                    "type": "string"
+                },
+                "taken_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "tokens.Token": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string"
+                },
+                "blockchain": {
+                    "$ref": "#/definitions/tokens.Blockchain"
+                },
+                "blockchain_id": {
+                    "type": "string"
+                },
+                "contract": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "precision": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "tokens.TopHolder": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "authorized": {
+                    "type": "boolean"
+                },
+                "balance": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "frozen": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "string"
+                },
+                "no_ripple": {
+                    "type": "boolean"
+                },
+                "share": {
+                    "type": "number"
+                },
+                "snapshot_id": {
+                    "type": "string"
+                },
+                "token_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "transaction.AccountStatement": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "ASSET:BRAZA-ON:SUPPLY"
+                },
+                "closing_balance": {
+                    "type": "string",
+                    "example": "900"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BBRL"
+                },
+                "difference": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/transaction.StatementLine"
+                    }
+                },
+                "matches": {
+                    "type": "boolean"
+                },
+                "on_chain_balance": {
+                    "type": "string",
+                    "example": "900"
+                },
+                "opening_balance": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "transaction.StatementLine": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string",
+                    "example": "900"
+                },
+                "credit": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "debit": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "string"
+                },
+                "operation_id": {
+                    "type": "string"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "OFF-RAMP"
+                }
+            }
+        },
+        "transaction.TransactionType": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
                 },
                 "updated_at": {
@@ -1968,410 +5741,897 @@ const docTemplate = `{
                 }
             }
         },
-        "fiber.Map": {
+        "transaction.TrialBalance": {
             "type": "object",
-            "additionalProperties": true
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/transaction.TrialBalanceAccount"
+                    }
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "balanced": {
+                    "type": "boolean"
+                },
+                "reconciled": {
+                    "description": "whether the balances were compared with the ledger",
+                    "type": "boolean"
+                },
+                "totals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/transaction.TrialBalanceTotal"
+                    }
+                }
+            }
+        },
+        "transaction.TrialBalanceAccount": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "ASSET:BRAZA-ON:SUPPLY"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "credits": {
+                    "type": "string",
+                    "example": "500"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BBRL"
+                },
+                "debits": {
+                    "type": "string",
+                    "example": "1500"
+                },
+                "difference": {
+                    "description": "balance minus the on-chain balance",
+                    "type": "string",
+                    "example": "0"
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"
+                },
+                "matches": {
+                    "type": "boolean"
+                },
+                "on_chain_balance": {
+                    "type": "string",
+                    "example": "1000"
+                }
+            }
+        },
+        "transaction.TrialBalanceTotal": {
+            "type": "object",
+            "properties": {
+                "balanced": {
+                    "type": "boolean"
+                },
+                "credits": {
+                    "type": "string",
+                    "example": "1500"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BBRL"
+                },
+                "debits": {
+                    "type": "string",
+                    "example": "1500"
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"
+                }
+            }
+        },
+        "types.AmmCreateRequest": {
+            "type": "object",
+            "required": [
+                "blockchain_id",
+                "domain",
+                "operator",
+                "token_amount",
+                "token_id",
+                "xrp_amount"
+            ],
+            "properties": {
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
+                },
+                "domain": {
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "token_amount": {
+                    "type": "string",
+                    "example": "50000"
+                },
+                "token_id": {
+                    "type": "string",
+                    "example": "66f74ad8ba6b56108cb3e80b"
+                },
+                "trading_fee": {
+                    "description": "in units of 1/100,000, up to 1%",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 500
+                },
+                "xrp_amount": {
+                    "type": "string",
+                    "example": "9500"
+                }
+            }
+        },
+        "types.AmmDepositRequest": {
+            "type": "object",
+            "required": [
+                "blockchain_id",
+                "domain",
+                "operator",
+                "token_id"
+            ],
+            "properties": {
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
+                },
+                "domain": {
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
+                },
+                "lp_token_amount": {
+                    "description": "exact LP tokens to receive",
+                    "type": "string",
+                    "example": "500"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "token_amount": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "token_id": {
+                    "type": "string",
+                    "example": "66f74ad8ba6b56108cb3e80b"
+                },
+                "xrp_amount": {
+                    "type": "string",
+                    "example": "190"
+                }
+            }
+        },
+        "types.AmmVoteRequest": {
+            "type": "object",
+            "required": [
+                "blockchain_id",
+                "domain",
+                "operator",
+                "token_id"
+            ],
+            "properties": {
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
+                },
+                "domain": {
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "token_id": {
+                    "type": "string",
+                    "example": "66f74ad8ba6b56108cb3e80b"
+                },
+                "trading_fee": {
+                    "description": "in units of 1/100,000, up to 1%",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 500
+                }
+            }
+        },
+        "types.AmmWithdrawRequest": {
+            "type": "object",
+            "required": [
+                "blockchain_id",
+                "domain",
+                "operator",
+                "token_id"
+            ],
+            "properties": {
+                "all": {
+                    "description": "returns every LP token of the wallet",
+                    "type": "boolean",
+                    "example": false
+                },
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
+                },
+                "domain": {
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
+                },
+                "lp_token_amount": {
+                    "description": "exact LP tokens to return",
+                    "type": "string",
+                    "example": "500"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "token_amount": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "token_id": {
+                    "type": "string",
+                    "example": "66f74ad8ba6b56108cb3e80b"
+                },
+                "xrp_amount": {
+                    "type": "string",
+                    "example": "190"
+                }
+            }
+        },
+        "types.BackingRefRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "bank_transaction_id",
+                "currency",
+                "received_at"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "bank_transaction_id": {
+                    "type": "string",
+                    "example": "E18236120202411151830s0123456789"
+                },
+                "currency": {
+                    "type": "string",
+                    "enum": [
+                        "BRL",
+                        "USD"
+                    ],
+                    "example": "BRL"
+                },
+                "received_at": {
+                    "type": "string",
+                    "example": "2024-11-15T18:30:00Z"
+                }
+            }
+        },
+        "types.CancelCheckRequest": {
+            "type": "object",
+            "required": [
+                "check_id",
+                "operator"
+            ],
+            "properties": {
+                "check_id": {
+                    "description": "ledger object ID of the check",
+                    "type": "string",
+                    "example": "49647F0D748DC3FE26BDACBC57F251AADEFFF391403EC9BF87C97F67E9977FB0"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "types.CancelOfferRequest": {
+            "type": "object",
+            "required": [
+                "blockchain_id",
+                "domain",
+                "operator",
+                "sequence"
+            ],
+            "properties": {
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
+                },
+                "domain": {
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "sequence": {
+                    "description": "sequence of the OfferCreate transaction",
+                    "type": "integer",
+                    "example": 4218
+                }
+            }
         },
-        "fireblocks.Blockchain": {
+        "types.CreatePaymentChannelRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "blockchain_id",
+                "destination",
+                "operator",
+                "settle_delay",
+                "wallet_id"
+            ],
             "properties": {
-                "abbr": {
-                    "type": "string"
+                "amount": {
+                    "description": "XRP",
+                    "type": "string",
+                    "example": "250"
                 },
-                "created_at": {
-                    "type": "string"
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "id": {
-                    "type": "string"
+                "cancel_after": {
+                    "description": "seconds, never closes when empty",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2592000
                 },
-                "is_active": {
-                    "type": "boolean"
+                "destination": {
+                    "description": "classic address or X-address",
+                    "type": "string",
+                    "example": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"
                 },
-                "main_token": {
-                    "type": "string"
+                "destination_tag": {
+                    "type": "integer",
+                    "example": 12345
                 },
-                "name": {
-                    "type": "string"
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "updated_at": {
-                    "type": "string"
+                "settle_delay": {
+                    "description": "seconds",
+                    "type": "integer",
+                    "example": 86400
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "66f79a58ba6b56108cb3e80d"
                 }
             }
         },
-        "fireblocks.FireblocksAccount": {
+        "types.CrossCurrencyPayoutRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "blockchain_id",
+                "destination",
+                "domain",
+                "operator",
+                "send_max",
+                "token_id"
+            ],
             "properties": {
-                "alias": {
-                    "type": "string"
-                },
-                "asset_id": {
-                    "type": "string"
+                "amount": {
+                    "type": "string",
+                    "example": "100"
                 },
-                "created_at": {
-                    "type": "string"
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "id": {
-                    "type": "string"
+                "deliver_min": {
+                    "description": "required on partial payouts",
+                    "type": "string",
+                    "example": "95"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "destination": {
+                    "description": "classic address or X-address",
+                    "type": "string",
+                    "example": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
                 },
-                "name": {
-                    "type": "string"
+                "destination_tag": {
+                    "type": "integer",
+                    "example": 12345
                 },
-                "public_key": {
-                    "type": "string"
+                "domain": {
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
                 },
-                "public_key_fallback": {
-                    "type": "string"
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "updated_at": {
-                    "type": "string"
+                "partial": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "vault_id": {
-                    "type": "string"
+                "send_max": {
+                    "description": "the accepted send max of the quote",
+                    "type": "string",
+                    "example": "517.4735"
                 },
-                "wallet": {
-                    "$ref": "#/definitions/fireblocks.Wallet"
+                "source_token_id": {
+                    "description": "XRP when empty",
+                    "type": "string",
+                    "example": "66f74acbba6b56108cb3e80a"
                 },
-                "wallet_id": {
-                    "type": "string"
+                "token_id": {
+                    "type": "string",
+                    "example": "66f74ad8ba6b56108cb3e80b"
                 }
             }
         },
-        "fireblocks.Wallet": {
+        "types.CrossCurrencyQuoteRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "blockchain_id",
+                "destination",
+                "domain",
+                "token_id"
+            ],
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "blockchain": {
-                    "$ref": "#/definitions/fireblocks.Blockchain"
+                "amount": {
+                    "type": "string",
+                    "example": "100"
                 },
                 "blockchain_id": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "destination": {
+                    "description": "classic address or X-address",
+                    "type": "string",
+                    "example": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
                 },
-                "name": {
-                    "type": "string"
+                "domain": {
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
                 },
-                "type": {
-                    "type": "string"
+                "source_token_id": {
+                    "description": "XRP when empty",
+                    "type": "string",
+                    "example": "66f74acbba6b56108cb3e80a"
                 },
-                "updated_at": {
-                    "type": "string"
+                "token_id": {
+                    "type": "string",
+                    "example": "66f74ad8ba6b56108cb3e80b"
                 }
             }
         },
-        "operation.OperationDomain": {
+        "types.DecodeRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
+                "operation_id": {
+                    "type": "string",
+                    "example": "6709778cf22e601d8921bd1a"
                 },
-                "updated_at": {
-                    "type": "string"
+                "tx_blob": {
+                    "type": "string",
+                    "example": "1200002280000000240000000761D4871AFD498D000000000000000000000000000042525A410000000000000000000000000000000068400000000000000C"
                 }
             }
         },
-        "operation.OperationType": {
+        "types.EditBlockchainRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "abbr": {
+                    "type": "string",
+                    "minLength": 2,
+                    "example": "BTC"
                 },
                 "is_active": {
-                    "type": "boolean"
+                    "type": "boolean",
+                    "example": true
                 },
-                "name": {
-                    "type": "string"
+                "main_token": {
+                    "type": "string",
+                    "minLength": 2,
+                    "example": "BTC"
                 },
-                "updated_at": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "BLOCKHAIN-NAME"
                 }
             }
         },
-        "operation.OperationWithLogs": {
+        "types.EditFireblocksAccountRequest": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "string"
+                "acc_flags": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "blockchain_status": {
-                    "type": "string"
+                "alias": {
+                    "type": "string",
+                    "example": "Alias"
                 },
-                "created_at": {
-                    "type": "string"
+                "asset_id": {
+                    "type": "string",
+                    "example": "XRP_TEST"
                 },
                 "domain": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "Domain"
                 },
-                "fireblocks_id": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "fireblocks_status": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "Name"
                 },
-                "id": {
-                    "type": "string"
+                "public_key": {
+                    "type": "string",
+                    "example": "123abc"
                 },
-                "logs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/repositories.OperationLog"
-                    }
+                "vault_id": {
+                    "type": "string",
+                    "example": "17"
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "example": "18"
+                }
+            }
+        },
+        "types.EditOperationDomainRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "DOMAIN-NAME"
+                }
+            }
+        },
+        "types.EditTokenRequest": {
+            "type": "object",
+            "properties": {
+                "abbr": {
+                    "type": "string",
+                    "minLength": 2,
+                    "example": "BTC"
+                },
+                "blockchain": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "operator": {
-                    "type": "string"
+                "contract": {
+                    "type": "string",
+                    "example": "0x1234567890"
                 },
-                "transaction_hash": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "transaction_link": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "TOKEN-NAME"
                 },
-                "type": {
-                    "type": "string"
+                "precision": {
+                    "type": "integer",
+                    "example": 18
                 },
-                "updated_at": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "example": "NATIVE"
                 }
             }
         },
-        "repositories.Operation": {
+        "types.EditWalletRequest": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "string"
-                },
-                "blockchain_status": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "domain": {
-                    "type": "string"
+                "address": {
+                    "type": "string",
+                    "example": "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"
                 },
-                "fireblocks_id": {
-                    "type": "string"
+                "algorithm": {
+                    "type": "string",
+                    "enum": [
+                        "SECP256K1",
+                        "ED25519"
+                    ],
+                    "example": "SECP256K1"
                 },
-                "fireblocks_status": {
-                    "type": "string"
+                "blockchain": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "id": {
-                    "type": "string"
+                "destination_tag": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "operator": {
-                    "type": "string"
+                "domain": {
+                    "type": "string",
+                    "example": "DOMAIN-NAME"
                 },
-                "transaction_hash": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "transaction_link": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "WALLET-NAME"
                 },
                 "type": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "NATIVE"
                 }
             }
         },
-        "repositories.OperationLog": {
+        "types.ErrorMessage": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "error": {},
-                "fireblocks_id": {
-                    "type": "string"
-                },
-                "id": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "types.FundPaymentChannelRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "channel_id",
+                "operator"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "XRP",
+                    "type": "string",
+                    "example": "100"
                 },
-                "name": {
-                    "type": "string"
+                "channel_id": {
+                    "type": "string",
+                    "example": "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366"
                 },
-                "operation_id": {
-                    "type": "string"
+                "expires_in": {
+                    "description": "seconds, keeps the expiration when empty",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 604800
                 },
-                "payload": {},
-                "response": {}
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
             }
         },
-        "repositories.PaginatedOperations": {
+        "types.FundingOperationRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "blockchain_id",
+                "operator",
+                "wallet_from_id",
+                "wallet_to_id"
+            ],
             "properties": {
-                "current_page": {
-                    "type": "integer"
-                },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/repositories.Operation"
-                    }
+                "amount": {
+                    "type": "string",
+                    "example": "25.5"
                 },
-                "next_page": {
-                    "type": "integer"
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "previous_page": {
-                    "type": "integer"
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "total_count": {
-                    "type": "integer"
+                "wallet_from_id": {
+                    "type": "string",
+                    "example": "66f79a58ba6b56108cb3e80d"
                 },
-                "total_pages": {
-                    "type": "integer"
+                "wallet_to_id": {
+                    "type": "string",
+                    "example": "66f79a90ba6b56108cb3e811"
                 }
             }
         },
-        "tokens.Blockchain": {
+        "types.JournalLineRequest": {
             "type": "object",
+            "required": [
+                "account",
+                "currency"
+            ],
             "properties": {
-                "abbr": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "account": {
+                    "type": "string",
+                    "example": "ASSET:BRAZA-ON:SUPPLY"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "credit": {
+                    "type": "string",
+                    "example": "0"
                 },
-                "main_token": {
-                    "type": "string"
+                "currency": {
+                    "type": "string",
+                    "example": "BBRL"
                 },
-                "name": {
-                    "type": "string"
+                "debit": {
+                    "type": "string",
+                    "example": "1000"
                 },
-                "updated_at": {
-                    "type": "string"
+                "issuer": {
+                    "description": "empty for XRP",
+                    "type": "string",
+                    "example": "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"
                 }
             }
         },
-        "tokens.Token": {
+        "types.OperationRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "backing",
+                "blockchain_id",
+                "domain",
+                "operator",
+                "token_id",
+                "type"
+            ],
             "properties": {
-                "abbr": {
-                    "type": "string"
+                "amount": {
+                    "type": "string",
+                    "example": "2.75"
                 },
-                "blockchain": {
-                    "$ref": "#/definitions/tokens.Blockchain"
+                "backing": {
+                    "description": "confirmed fiat deposits backing a MINT, whose sum must cover the amount minted",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BackingRefRequest"
+                    }
                 },
                 "blockchain_id": {
-                    "type": "string"
-                },
-                "contract": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "domain": {
+                    "type": "string",
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
                 },
-                "name": {
-                    "type": "string"
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "precision": {
-                    "type": "integer"
+                "token_id": {
+                    "type": "string",
+                    "example": "66f74acbba6b56108cb3e80a"
                 },
                 "type": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "MINT",
+                        "BURN"
+                    ],
+                    "example": "MINT,BURN"
                 }
             }
         },
-        "transaction.TransactionType": {
+        "types.OperationResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "operation was accepted to be processed on blockchain"
                 },
-                "updated_at": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "types.EditBlockchainRequest": {
+        "types.PayoutOperationRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "blockchain_id",
+                "destination",
+                "domain",
+                "operator",
+                "token_id"
+            ],
             "properties": {
-                "abbr": {
+                "amount": {
                     "type": "string",
-                    "minLength": 2,
-                    "example": "BTC"
+                    "example": "2.75"
                 },
-                "is_active": {
+                "as_check": {
+                    "description": "sends the payout as a check the destination cashes",
                     "type": "boolean",
-                    "example": true
+                    "example": false
                 },
-                "main_token": {
+                "blockchain_id": {
                     "type": "string",
-                    "minLength": 2,
-                    "example": "BTC"
+                    "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "BLOCKHAIN-NAME"
-                }
-            }
-        },
-        "types.EditFireblocksAccountRequest": {
-            "type": "object",
-            "properties": {
-                "acc_flags": {
+                "check_expires_in": {
+                    "description": "seconds until the check expires, never when zero",
                     "type": "integer",
-                    "example": 1
+                    "minimum": 0,
+                    "example": 604800
                 },
-                "alias": {
+                "destination": {
+                    "description": "classic address or X-address",
                     "type": "string",
-                    "example": "Alias"
+                    "example": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
                 },
-                "asset_id": {
-                    "type": "string",
-                    "example": "XRP_TEST"
+                "destination_tag": {
+                    "type": "integer",
+                    "example": 12345
                 },
                 "domain": {
                     "type": "string",
@@ -2380,141 +6640,187 @@ const docTemplate = `{
                         "BRAZA-ON",
                         "BRAZA-DESK"
                     ],
-                    "example": "Domain"
-                },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Name"
-                },
-                "public_key": {
-                    "type": "string",
-                    "example": "123abc"
+                    "example": "GET-BRAZA"
                 },
-                "vault_id": {
+                "external_id": {
+                    "description": "the originating transaction request",
                     "type": "string",
-                    "example": "17"
+                    "example": "a1b2c3d4"
                 },
-                "wallet_id": {
+                "operator": {
                     "type": "string",
-                    "example": "18"
-                }
-            }
-        },
-        "types.EditOperationDomainRequest": {
-            "type": "object",
-            "properties": {
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "name": {
+                "token_id": {
                     "type": "string",
-                    "example": "DOMAIN-NAME"
+                    "example": "66f74acbba6b56108cb3e80a"
                 }
             }
         },
-        "types.EditTokenRequest": {
+        "types.PlaceOfferRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "blockchain_id",
+                "domain",
+                "operator",
+                "price",
+                "side",
+                "token_id"
+            ],
             "properties": {
-                "abbr": {
+                "amount": {
+                    "description": "token amount",
                     "type": "string",
-                    "minLength": 2,
-                    "example": "BTC"
+                    "example": "1000"
                 },
-                "blockchain": {
+                "blockchain_id": {
                     "type": "string",
                     "example": "66f6fe7eccc6398d39e981f9"
                 },
-                "contract": {
+                "domain": {
                     "type": "string",
-                    "example": "0x1234567890"
+                    "enum": [
+                        "GET-BRAZA",
+                        "BRAZA-ON",
+                        "BRAZA-DESK"
+                    ],
+                    "example": "GET-BRAZA"
                 },
-                "is_active": {
+                "expires_in": {
+                    "description": "seconds, never expires when empty",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 86400
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "passive": {
+                    "description": "does not cross offers at the same price",
                     "type": "boolean",
-                    "example": true
+                    "example": false
                 },
-                "name": {
+                "price": {
+                    "description": "XRP per token",
                     "type": "string",
-                    "example": "TOKEN-NAME"
+                    "example": "0.19"
                 },
-                "precision": {
-                    "type": "integer",
-                    "example": 18
+                "side": {
+                    "type": "string",
+                    "enum": [
+                        "SELL",
+                        "BUY"
+                    ],
+                    "example": "SELL"
                 },
-                "type": {
+                "token_id": {
                     "type": "string",
-                    "example": "NATIVE"
+                    "example": "66f74ad8ba6b56108cb3e80b"
                 }
             }
         },
-        "types.EditWalletRequest": {
+        "types.PostJournalEntryRequest": {
             "type": "object",
+            "required": [
+                "description",
+                "lines",
+                "operator",
+                "type"
+            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "example": "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"
-                },
-                "blockchain": {
+                "description": {
                     "type": "string",
-                    "example": "66f6fe7eccc6398d39e981f9"
+                    "example": "Opening balance of the BRAZA-ON supply wallet"
                 },
                 "domain": {
                     "type": "string",
-                    "example": "DOMAIN-NAME"
+                    "example": "BRAZA-ON"
                 },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
+                "lines": {
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/types.JournalLineRequest"
+                    }
                 },
-                "name": {
+                "operator": {
                     "type": "string",
-                    "example": "WALLET-NAME"
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "posted_at": {
+                    "description": "defaults to now",
+                    "type": "string",
+                    "example": "2024-11-15T18:00:00Z"
                 },
                 "type": {
                     "type": "string",
-                    "example": "NATIVE"
+                    "example": "OPENING"
                 }
             }
         },
-        "types.ErrorMessage": {
+        "types.PublishAttestationRequest": {
             "type": "object",
+            "required": [
+                "operator",
+                "token_id"
+            ],
             "properties": {
-                "message": {
-                    "type": "string"
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "token_id": {
+                    "type": "string",
+                    "example": "66fc48562dd62529e6e879b7"
                 }
             }
         },
-        "types.OperationRequest": {
+        "types.RecordReserveBalanceRequest": {
             "type": "object",
             "required": [
+                "account",
                 "amount",
-                "blockchain_id",
-                "domain",
+                "as_of",
+                "currency",
+                "custodian",
+                "evidences",
                 "operator",
-                "token_id",
-                "type"
+                "token_id"
             ],
             "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "0001-12345-6"
+                },
                 "amount": {
+                    "description": "zero when the account was emptied",
                     "type": "string",
-                    "example": "2.75"
+                    "example": "1000500.00"
                 },
-                "blockchain_id": {
+                "as_of": {
                     "type": "string",
-                    "example": "66f6fe7eccc6398d39e981f9"
+                    "example": "2024-11-15T18:00:00Z"
                 },
-                "domain": {
+                "currency": {
                     "type": "string",
-                    "enum": [
-                        "GET-BRAZA",
-                        "BRAZA-ON",
-                        "BRAZA-DESK"
-                    ],
-                    "example": "GET-BRAZA"
+                    "example": "BRL"
+                },
+                "custodian": {
+                    "type": "string",
+                    "example": "Banco do Brasil"
+                },
+                "evidences": {
+                    "description": "references of the evidences of the balance",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "statements/2024-11-15-bb-0001-12345-6.pdf"
+                    ]
                 },
                 "operator": {
                     "type": "string",
@@ -2522,28 +6828,7 @@ const docTemplate = `{
                 },
                 "token_id": {
                     "type": "string",
-                    "example": "66f74acbba6b56108cb3e80a"
-                },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "MINT",
-                        "BURN"
-                    ],
-                    "example": "MINT,BURN"
-                }
-            }
-        },
-        "types.OperationResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "operation was accepted to be processed on blockchain"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                    "example": "66fc48562dd62529e6e879b7"
                 }
             }
         },
@@ -2720,6 +7005,100 @@ const docTemplate = `{
                 }
             }
         },
+        "types.SignChannelClaimRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "channel_id",
+                "operator"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "cumulative XRP the destination can claim",
+                    "type": "string",
+                    "example": "12.5"
+                },
+                "channel_id": {
+                    "type": "string",
+                    "example": "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "types.SubmitChannelClaimRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "blockchain_id",
+                "channel_id",
+                "operator",
+                "signature"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "cumulative XRP of the claim",
+                    "type": "string",
+                    "example": "12.5"
+                },
+                "blockchain_id": {
+                    "type": "string",
+                    "example": "66f6fe7eccc6398d39e981f9"
+                },
+                "channel_id": {
+                    "type": "string",
+                    "example": "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366"
+                },
+                "close": {
+                    "description": "asks the channel to close after the claim",
+                    "type": "boolean",
+                    "example": false
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "public_key": {
+                    "description": "the channel key when empty",
+                    "type": "string",
+                    "example": "0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "30440220718D264EF05CAED7C781FF6DE298DCAC68D002562C9BF3A07C1E721B420C0DAB02203A5A4779EF4D2CCC7BC3EF886676D803A9981B928D3B8ACA483B80ECA3CD7B9B"
+                }
+            }
+        },
+        "types.VerifyChannelClaimRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "channel_id",
+                "signature"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "cumulative XRP of the claim",
+                    "type": "string",
+                    "example": "12.5"
+                },
+                "channel_id": {
+                    "type": "string",
+                    "example": "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366"
+                },
+                "public_key": {
+                    "description": "the channel key when empty",
+                    "type": "string",
+                    "example": "0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "30440220718D264EF05CAED7C781FF6DE298DCAC68D002562C9BF3A07C1E721B420C0DAB02203A5A4779EF4D2CCC7BC3EF886676D803A9981B928D3B8ACA483B80ECA3CD7B9B"
+                }
+            }
+        },
         "wallet.Blockchain": {
             "type": "object",
             "properties": {
@@ -2769,6 +7148,9 @@ const docTemplate = `{
                 "address": {
                     "type": "string"
                 },
+                "algorithm": {
+                    "type": "string"
+                },
                 "blockchain": {
                     "$ref": "#/definitions/wallet.Blockchain"
                 },
@@ -2778,6 +7160,9 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "destination_tag": {
+                    "type": "integer"
+                },
                 "domain": {
                     "type": "string"
                 },
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"crypto-braza-tokens-api/api/handlers/types"
+	cfg "crypto-braza-tokens-api/configs"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AmmHandler struct {
+	Resources *cfg.Resources
+}
+
+// GetPool retrieve the AMM pool of a token against XRP
+// @Summary Get the AMM pool of a token against XRP
+// @Description retrieve the state of the AMM pool of a token against XRP from the validated ledger, with the LP tokens held by the domain market maker wallet
+// @Tags AMM
+// @ID get-amm-pool
+// @Produce json
+// @Param blockchain_id query string true "Blockchain ID"
+// @Param domain query string true "Operation domain"
+// @Param token_id query string true "Token ID"
+// @Success 200 {object} operation.AmmPool
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/amm/pools [get]
+func (a AmmHandler) GetPool(ctx *fiber.Ctx) error {
+	request := types.AmmPoolRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "amm", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	pool, err := a.Resources.OperationService.GetAmmPool(ctx.UserContext(), request.Domain, request.BlockchainId, request.TokenId)
+	if err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(pool)
+}
+
+// PostPool create the AMM pool of a token against XRP
+// @Summary Create the AMM pool of a token against XRP
+// @Description create the AMM pool of a token against XRP from the domain market maker wallet, funded with both amounts which set the initial price
+// @Tags AMM
+// @ID post-amm-pool
+// @Accept json
+// @Produce json
+// @Param amm body types.AmmCreateRequest true "AMM pool object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/amm/pools [post]
+func (a AmmHandler) PostPool(ctx *fiber.Ctx) error {
+	request := types.AmmCreateRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "amm", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	if err := a.Resources.OperationService.ValidateParams(ctx.UserContext(), "AMM_CREATE", request.Domain, request.TokenId, request.BlockchainId); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := a.Resources.OperationService.CreateAmmPool(ctx.UserContext(), request.Domain, request.BlockchainId, request.TokenId, request.TokenAmount, request.XrpAmount, request.TradingFee, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// PostDeposit deposit into an AMM pool
+// @Summary Deposit into an AMM pool
+// @Description deposit both assets at the pool ratio, a single asset, or an amount for an exact amount of LP tokens from the domain market maker wallet
+// @Tags AMM
+// @ID post-amm-deposit
+// @Accept json
+// @Produce json
+// @Param amm body types.AmmDepositRequest true "AMM deposit object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/amm/deposit [post]
+func (a AmmHandler) PostDeposit(ctx *fiber.Ctx) error {
+	request := types.AmmDepositRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "amm", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	if err := a.Resources.OperationService.ValidateParams(ctx.UserContext(), "AMM_DEPOSIT", request.Domain, request.TokenId, request.BlockchainId); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := a.Resources.OperationService.DepositAmm(ctx.UserContext(), request.Domain, request.BlockchainId, request.TokenId, request.TokenAmount, request.XrpAmount, request.LPTokenAmount, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// PostWithdraw withdraw from an AMM pool
+// @Summary Withdraw from an AMM pool
+// @Description withdraw both assets, a single asset, an exact amount of LP tokens or all the liquidity of the domain market maker wallet
+// @Tags AMM
+// @ID post-amm-withdraw
+// @Accept json
+// @Produce json
+// @Param amm body types.AmmWithdrawRequest true "AMM withdraw object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/amm/withdraw [post]
+func (a AmmHandler) PostWithdraw(ctx *fiber.Ctx) error {
+	request := types.AmmWithdrawRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "amm", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	if err := a.Resources.OperationService.ValidateParams(ctx.UserContext(), "AMM_WITHDRAW", request.Domain, request.TokenId, request.BlockchainId); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := a.Resources.OperationService.WithdrawAmm(ctx.UserContext(), request.Domain, request.BlockchainId, request.TokenId, request.TokenAmount, request.XrpAmount, request.LPTokenAmount, request.All, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// PostVote vote the trading fee of an AMM pool
+// @Summary Vote the trading fee of an AMM pool
+// @Description vote the trading fee of the AMM pool with the LP tokens of the domain market maker wallet
+// @Tags AMM
+// @ID post-amm-vote
+// @Accept json
+// @Produce json
+// @Param amm body types.AmmVoteRequest true "AMM vote object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/amm/vote [post]
+func (a AmmHandler) PostVote(ctx *fiber.Ctx) error {
+	request := types.AmmVoteRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "amm", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	if err := a.Resources.OperationService.ValidateParams(ctx.UserContext(), "AMM_VOTE", request.Domain, request.TokenId, request.BlockchainId); err != nil {
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := a.Resources.OperationService.VoteAmm(ctx.UserContext(), request.Domain, request.BlockchainId, request.TokenId, request.TradingFee, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "amm", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
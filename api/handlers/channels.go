@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"crypto-braza-tokens-api/api/handlers/types"
+	cfg "crypto-braza-tokens-api/configs"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChannelsHandler struct {
+	Resources *cfg.Resources
+}
+
+// PostChannel open a new payment channel
+// @Summary Open a new payment channel
+// @Description open a payment channel of XRP from one of the wallets to a partner address, its claims being signed by the fireblocks key of the wallet
+// @Tags Payment Channels
+// @ID post-payment-channel
+// @Accept json
+// @Produce json
+// @Param channel body types.CreatePaymentChannelRequest true "Channel object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/payment-channels [post]
+func (c ChannelsHandler) PostChannel(ctx *fiber.Ctx) error {
+	request := types.CreatePaymentChannelRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "channel", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "channel", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := c.Resources.OperationService.CreatePaymentChannel(ctx.UserContext(), request.BlockchainId, request.WalletId, request.Destination, request.DestinationTag, request.Amount, request.SettleDelay, request.CancelAfter, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "channel", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// GetChannels retrieve the payment channels
+// @Summary Get the payment channels
+// @Description retrieve the payment channels opened by the service with the XRP funded, delivered and still claimable on the ledger
+// @Tags Payment Channels
+// @ID get-payment-channels
+// @Produce json
+// @Success 200 {array} operation.PaymentChannelState
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/payment-channels [get]
+func (c ChannelsHandler) GetChannels(ctx *fiber.Ctx) error {
+	channels, err := c.Resources.OperationService.ListPaymentChannels(ctx.UserContext())
+	if err != nil {
+		return InternalErrorWrapper(ctx, "channels", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(channels)
+}
+
+// PostFundChannel fund a payment channel
+// @Summary Fund a payment channel
+// @Description add XRP to a payment channel opened by the service, optionally setting when it expires
+// @Tags Payment Channels
+// @ID post-payment-channel-fund
+// @Accept json
+// @Produce json
+// @Param channel body types.FundPaymentChannelRequest true "Channel object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/payment-channels/fund [post]
+func (c ChannelsHandler) PostFundChannel(ctx *fiber.Ctx) error {
+	request := types.FundPaymentChannelRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "channel", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "channel", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := c.Resources.OperationService.FundPaymentChannel(ctx.UserContext(), request.ChannelId, request.Amount, request.ExpiresIn, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "channel", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// PostSignClaim sign a payment channel claim
+// @Summary Sign a payment channel claim
+// @Description sign off-ledger through fireblocks RAW the claim of the cumulative XRP a partner can redeem from a payment channel opened by the service, the signature being listed once signed
+// @Tags Payment Channels
+// @ID post-payment-channel-claim
+// @Accept json
+// @Produce json
+// @Param claim body types.SignChannelClaimRequest true "Claim object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/payment-channels/claims [post]
+func (c ChannelsHandler) PostSignClaim(ctx *fiber.Ctx) error {
+	request := types.SignChannelClaimRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "claim", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "claim", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := c.Resources.OperationService.SignChannelClaim(ctx.UserContext(), request.ChannelId, request.Amount, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "claim", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// GetClaims retrieve the claims of a payment channel
+// @Summary Get the claims of a payment channel
+// @Description retrieve the claims signed for a payment channel opened by the service, with their signatures
+// @Tags Payment Channels
+// @ID get-payment-channel-claims
+// @Produce json
+// @Param channel_id query string true "Payment channel ID"
+// @Success 200 {array} repositories.ChannelClaim
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/payment-channels/claims [get]
+func (c ChannelsHandler) GetClaims(ctx *fiber.Ctx) error {
+	request := types.ListChannelClaimsRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "claims", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "claims", err)
+	}
+
+	claims, err := c.Resources.OperationService.ListChannelClaims(ctx.UserContext(), request.ChannelId)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "claims", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(claims)
+}
+
+// PostVerifyClaim verify a payment channel claim
+// @Summary Verify a payment channel claim
+// @Description verify a claim of a payment channel against the channel on the ledger, its signature having to be of the channel key and its amount covered by the XRP funded on the channel
+// @Tags Payment Channels
+// @ID post-payment-channel-claim-verify
+// @Accept json
+// @Produce json
+// @Param claim body types.VerifyChannelClaimRequest true "Claim object"
+// @Success 200 {object} operation.ClaimVerification
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/payment-channels/claims/verify [post]
+func (c ChannelsHandler) PostVerifyClaim(ctx *fiber.Ctx) error {
+	request := types.VerifyChannelClaimRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "claim", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "claim", err)
+	}
+
+	verification, err := c.Resources.OperationService.VerifyChannelClaim(ctx.UserContext(), request.ChannelId, request.Amount, request.PublicKey, request.Signature)
+	if err != nil {
+		return BadRequestWrapper(ctx, "claim", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(verification)
+}
+
+// PostSubmitClaim redeem a payment channel claim
+// @Summary Redeem a payment channel claim
+// @Description redeem, from the destination wallet of a partner payment channel, a claim signed by the partner, verifying it before submitting a PaymentChannelClaim
+// @Tags Payment Channels
+// @ID post-payment-channel-claim-submit
+// @Accept json
+// @Produce json
+// @Param claim body types.SubmitChannelClaimRequest true "Claim object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/payment-channels/claims/submit [post]
+func (c ChannelsHandler) PostSubmitClaim(ctx *fiber.Ctx) error {
+	request := types.SubmitChannelClaimRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "claim", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "claim", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := c.Resources.OperationService.SubmitChannelClaim(ctx.UserContext(), request.BlockchainId, request.ChannelId, request.Amount, request.PublicKey, request.Signature, request.Close, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "claim", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"crypto-braza-tokens-api/api/handlers/types"
+	cfg "crypto-braza-tokens-api/configs"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChecksHandler struct {
+	Resources *cfg.Resources
+}
+
+// GetChecks retrieve the checks sent as payouts
+// @Summary Get the checks sent as payouts
+// @Description retrieve the checks sent as payouts by the payment wallets, with their ledger object IDs and whether they were cashed, expired or cancelled
+// @Tags Checks
+// @ID get-checks
+// @Produce json
+// @Param status query string false "Check status" Enums(PENDING, OPEN, CASHED, EXPIRED, CANCELLED, FAILED)
+// @Success 200 {array} repositories.Check
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/checks [get]
+func (c ChecksHandler) GetChecks(ctx *fiber.Ctx) error {
+	request := types.ListChecksRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "checks", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "checks", err)
+	}
+
+	checks, err := c.Resources.OperationService.ListChecks(ctx.UserContext(), request.Status)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "checks", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(checks)
+}
+
+// PostCancelCheck cancel an expired check
+// @Summary Cancel an expired check
+// @Description cancel an expired check sent as payout, from the payment wallet that sent it, by its ledger object ID
+// @Tags Checks
+// @ID post-check-cancel
+// @Accept json
+// @Produce json
+// @Param check body types.CancelCheckRequest true "Check to cancel"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/checks/cancel [post]
+func (c ChecksHandler) PostCancelCheck(ctx *fiber.Ctx) error {
+	request := types.CancelCheckRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "check", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "check", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := c.Resources.OperationService.CancelCheck(ctx.UserContext(), request.CheckId, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "check", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"crypto-braza-tokens-api/api/handlers/types"
+	cfg "crypto-braza-tokens-api/configs"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DexHandler struct {
+	Resources *cfg.Resources
+}
+
+// PostOffer place a new offer on the DEX
+// @Summary Place a new offer on the DEX
+// @Description place an offer of a token against XRP from the domain market maker wallet, SELL giving the token amount for XRP at the price and BUY giving XRP for it
+// @Tags DEX
+// @ID post-dex-offer
+// @Accept json
+// @Produce json
+// @Param offer body types.PlaceOfferRequest true "Offer object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/dex/offers [post]
+func (d DexHandler) PostOffer(ctx *fiber.Ctx) error {
+	request := types.PlaceOfferRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "offer", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "offer", err)
+	}
+
+	if err := d.Resources.OperationService.ValidateParams(ctx.UserContext(), "OFFER_CREATE", request.Domain, request.TokenId, request.BlockchainId); err != nil {
+		return BadRequestWrapper(ctx, "offer", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := d.Resources.OperationService.PlaceOffer(ctx.UserContext(), request.Domain, request.BlockchainId, request.TokenId, request.Side, request.Amount, request.Price, request.Passive, request.ExpiresIn, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "offer", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// GetOffers retrieve the open offers on the DEX
+// @Summary Get the open offers on the DEX
+// @Description retrieve the open offers of the domain market maker wallet from the ledger, telling which ones were placed by the service
+// @Tags DEX
+// @ID get-dex-offers
+// @Produce json
+// @Param blockchain_id query string true "Blockchain ID"
+// @Param domain query string true "Operation domain"
+// @Success 200 {array} operation.MarketOffer
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/dex/offers [get]
+func (d DexHandler) GetOffers(ctx *fiber.Ctx) error {
+	request := types.ListOffersRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "offers", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "offers", err)
+	}
+
+	offers, err := d.Resources.OperationService.ListOffers(ctx.UserContext(), request.Domain, request.BlockchainId)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "offers", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(offers)
+}
+
+// PostCancelOffer cancel an offer on the DEX
+// @Summary Cancel an offer on the DEX
+// @Description cancel an open offer placed by the service from the domain market maker wallet, by the sequence of its OfferCreate transaction
+// @Tags DEX
+// @ID post-dex-offer-cancel
+// @Accept json
+// @Produce json
+// @Param offer body types.CancelOfferRequest true "Offer to cancel"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/dex/offers/cancel [post]
+func (d DexHandler) PostCancelOffer(ctx *fiber.Ctx) error {
+	request := types.CancelOfferRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "offer", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "offer", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := d.Resources.OperationService.CancelOffer(ctx.UserContext(), request.Domain, request.BlockchainId, request.Sequence, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "offer", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
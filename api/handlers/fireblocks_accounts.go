@@ -29,6 +29,24 @@ func (f FireblocksAccountsHandler) GetFireblocksAccounts(ctx *fiber.Ctx) error {
 	return ObjectResultWrapper(ctx, result)
 }
 
+// GetFireblocksAccountsAudit audit the fireblocks accounts addresses
+// @Summary Audit the fireblocks accounts addresses
+// @Description derive the XRPL address of each fireblocks account public key and report any drift from the linked wallet address
+// @Tags FireblocksAccounts
+// @ID get-fireblocks-accounts-audit
+// @Produce json
+// @Success 200 {object} fireblocks.AddressAudit
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/fireblocks-accounts/audit [get]
+func (f FireblocksAccountsHandler) GetFireblocksAccountsAudit(ctx *fiber.Ctx) error {
+	result, err := f.Resources.FireblocksService.AuditFireblocksAccounts(ctx.UserContext())
+	if err != nil {
+		return InternalErrorWrapper(ctx, "fireblocks accounts", err)
+	}
+
+	return ObjectResultWrapper(ctx, result)
+}
+
 // GetFireblocksAccounts retrieve a fireblocks account by id
 // @Summary Get a fireblocks account
 // @Description retrieve a fireblocks account by id
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"crypto-braza-tokens-api/api/handlers/types"
+	cfg "crypto-braza-tokens-api/configs"
+	r "crypto-braza-tokens-api/repositories"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JournalHandler struct {
+	Resources *cfg.Resources
+}
+
+// PostJournalEntry post a manual journal entry
+// @Summary Post a manual journal entry
+// @Description post an entry made by an operator, such as the opening balances of the wallets or an adjustment. The entry is rejected when its debits and credits are not balanced for every currency
+// @Tags Journal
+// @ID post-journal-entry
+// @Accept json
+// @Produce json
+// @Param entry body types.PostJournalEntryRequest true "Journal entry to post"
+// @Success 200 {object} repositories.JournalEntry
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/journal/entries [post]
+func (h JournalHandler) PostJournalEntry(ctx *fiber.Ctx) error {
+	request := types.PostJournalEntryRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return BadRequestWrapper(ctx, "journal entry", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "journal entry", err)
+	}
+
+	lines := make([]*r.JournalLine, 0, len(request.Lines))
+	for _, line := range request.Lines {
+		lines = append(lines, &r.JournalLine{
+			Account:  line.Account,
+			Currency: line.Currency,
+			Issuer:   line.Issuer,
+			Debit:    line.Debit,
+			Credit:   line.Credit,
+		})
+	}
+
+	entry, err := h.Resources.TransactionService.PostManualJournalEntry(ctx.UserContext(), request.Type, request.Description, request.Domain, request.PostedAt, lines, request.Operator)
+	if err != nil {
+		return BadRequestWrapper(ctx, "journal entry", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(entry)
+}
+
+// GetJournalEntries retrieve the journal entries
+// @Summary Get the journal entries
+// @Description retrieve the entries posted between the dates with a line of the account in the currency, in the order they were posted
+// @Tags Journal
+// @ID get-journal-entries
+// @Produce json
+// @Param account query string false "Account"
+// @Param currency query string false "Currency"
+// @Param from query string false "Start date (RFC 3339)"
+// @Param to query string false "End date (RFC 3339)"
+// @Success 200 {array} repositories.JournalEntry
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/journal/entries [get]
+func (h JournalHandler) GetJournalEntries(ctx *fiber.Ctx) error {
+	request := types.JournalEntriesRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "journal entries", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "journal entries", err)
+	}
+
+	from, to, err := request.Range()
+	if err != nil {
+		return BadRequestWrapper(ctx, "journal entries", err)
+	}
+
+	entries, err := h.Resources.TransactionService.ListJournalEntries(ctx.UserContext(), request.Account, request.Currency, from, to)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "journal entries", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(entries)
+}
+
+// GetTrialBalance retrieve the trial balance of the journal
+// @Summary Get the trial balance of the journal
+// @Description retrieve the balance of every account with the entries posted before the date and whether the debits and credits of each currency are balanced. The current balances of our wallets and of the tokens issued can be reconciled with the ledger
+// @Tags Journal
+// @ID get-journal-trial-balance
+// @Produce json
+// @Param as_of query string false "Date of the balances (RFC 3339)"
+// @Param reconcile query bool false "Compare the current balances with the ledger"
+// @Success 200 {object} transaction.TrialBalance
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/journal/trial-balance [get]
+func (h JournalHandler) GetTrialBalance(ctx *fiber.Ctx) error {
+	request := types.TrialBalanceRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "trial balance", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "trial balance", err)
+	}
+
+	asOf, err := request.Date()
+	if err != nil {
+		return BadRequestWrapper(ctx, "trial balance", err)
+	}
+
+	trialBalance, err := h.Resources.TransactionService.GetTrialBalance(ctx.UserContext(), asOf, request.Reconcile)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "trial balance", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(trialBalance)
+}
+
+// GetAccountStatement retrieve the statement of a journal account
+// @Summary Get the statement of a journal account
+// @Description retrieve the lines posted to the account in the currency between the dates with the running balance. The current balance of a wallet or of the tokens issued can be reconciled with the ledger
+// @Tags Journal
+// @ID get-journal-account-statement
+// @Produce json
+// @Param account query string true "Account"
+// @Param currency query string true "Currency"
+// @Param from query string false "Start date (RFC 3339)"
+// @Param to query string false "End date (RFC 3339)"
+// @Param reconcile query bool false "Compare the closing balance with the ledger"
+// @Success 200 {object} transaction.AccountStatement
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/journal/accounts/statement [get]
+func (h JournalHandler) GetAccountStatement(ctx *fiber.Ctx) error {
+	request := types.AccountStatementRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "account statement", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "account statement", err)
+	}
+
+	from, to, err := request.Range()
+	if err != nil {
+		return BadRequestWrapper(ctx, "account statement", err)
+	}
+
+	statement, err := h.Resources.TransactionService.GetAccountStatement(ctx.UserContext(), request.Account, request.Currency, from, to, request.Reconcile)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "account statement", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(statement)
+}
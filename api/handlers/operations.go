@@ -16,6 +16,27 @@ var (
 	isOperationRunning bool
 )
 
+// startOperation takes the operation lock, only one operation is executed at a time. It returns false when another
+// operation is running. The callback is handed to the service to release the lock once the operation worker is done,
+// release is called instead when the operation failed before its worker was started. Both release the lock once.
+func startOperation() (callback func(), release func(), ok bool) {
+	operationMutex.Lock()
+	defer operationMutex.Unlock()
+
+	if isOperationRunning {
+		return nil, nil, false
+	}
+	isOperationRunning = true
+
+	release = sync.OnceFunc(func() {
+		operationMutex.Lock()
+		isOperationRunning = false
+		operationMutex.Unlock()
+	})
+
+	return release, release, true
+}
+
 type OperationsHandler struct {
 	Resources *cfg.Resources
 }
@@ -89,7 +110,7 @@ func (o OperationsHandler) GetOperationById(ctx *fiber.Ctx) error {
 
 // PostOperation create a new operation
 // @Summary Create a new operation
-// @Description create a new operation
+// @Description create a new MINT or BURN operation. A MINT must be backed by confirmed BRL or USD deposits whose sum covers the amount, each bank transaction backing a single operation
 // @Tags Operations
 // @ID post-operation
 // @Accept json
@@ -115,33 +136,19 @@ func (o OperationsHandler) PostOperation(ctx *fiber.Ctx) error {
 		return BadRequestWrapper(ctx, "blockchain", err)
 	}
 
-	// Lock the mutex after all validation checks
-	operationMutex.Lock()
-	defer operationMutex.Unlock()
-
-	// Check if an operation is already running
-	if isOperationRunning {
-		return ctx.Status(fiber.StatusLocked).JSON(fiber.Map{"error": "Another operation is currently being executed. Please try again later."})
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
 	}
 
-	// Set the flag to indicate that an operation is running
-	isOperationRunning = true
-
 	// Create a channel to receive the result of the operation
 	resultChan := make(chan types.ExecuteOperationResult)
 
-	// Define the callback function
-	callback := func() {
-		// Reset the flag and unlock the mutex when the operation is done
-		operationMutex.Lock()
-		isOperationRunning = false
-		operationMutex.Unlock()
-	}
-
 	// Execute the operation in a separate goroutine
 	go func() {
 		// Execute the operation and send the result to the channel
-		operationId, err := o.Resources.OperationService.ExecuteOperation(ctx.UserContext(), request.Type, request.Domain, request.TokenId, request.BlockchainId, request.Amount, request.Operator, callback)
+		operationId, err := o.Resources.OperationService.ExecuteOperation(ctx.UserContext(), request.Type, request.Domain, request.TokenId, request.BlockchainId, request.Amount, backingRefs(request.Backing), request.Operator, callback)
 		resultChan <- types.ExecuteOperationResult{OperationId: operationId, Error: err}
 		close(resultChan)
 	}()
@@ -149,8 +156,199 @@ func (o OperationsHandler) PostOperation(ctx *fiber.Ctx) error {
 	// Wait for the result of the operation
 	executeOpResult := <-resultChan
 	if executeOpResult.Error != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
 		return BadRequestWrapper(ctx, "operation", executeOpResult.Error)
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", executeOpResult.OperationId)})
 }
+
+// PostFundingOperation create a new XRP funding operation
+// @Summary Create a new XRP funding operation
+// @Description transfer native XRP between registered wallets to fund their reserves and fees
+// @Tags Operations
+// @ID post-funding-operation
+// @Accept json
+// @Produce json
+// @Param operation body types.FundingOperationRequest true "Funding operation object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/operations/funding [post]
+func (o OperationsHandler) PostFundingOperation(ctx *fiber.Ctx) error {
+	request := types.FundingOperationRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "operation", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "operation", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := o.Resources.OperationService.ExecuteFundingOperation(ctx.UserContext(), request.BlockchainId, request.WalletFromId, request.WalletToId, request.Amount, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "operation", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// PostPayoutOperation create a new token payout operation
+// @Summary Create a new token payout operation
+// @Description transfer tokens from the domain payment wallet to an external address, with an optional destination tag, or send them as a check the destination cashes once its trust line is ready
+// @Tags Operations
+// @ID post-payout-operation
+// @Accept json
+// @Produce json
+// @Param operation body types.PayoutOperationRequest true "Payout operation object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/operations/payout [post]
+func (o OperationsHandler) PostPayoutOperation(ctx *fiber.Ctx) error {
+	request := types.PayoutOperationRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "operation", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "operation", err)
+	}
+
+	opType := "PAYOUT"
+	if request.AsCheck {
+		opType = "CHECK_CREATE"
+	}
+
+	if err := o.Resources.OperationService.ValidateParams(ctx.UserContext(), opType, request.Domain, request.TokenId, request.BlockchainId); err != nil {
+		return BadRequestWrapper(ctx, "operation", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	var operationId string
+	var err error
+	if request.AsCheck {
+		// the destination cashes the check once its trust line is ready
+		operationId, err = o.Resources.OperationService.ExecuteCheckPayoutOperation(ctx.UserContext(), request.Domain, request.TokenId, request.BlockchainId, request.Destination, request.DestinationTag, request.Amount, request.CheckExpiresIn, request.ExternalId, request.Operator, callback)
+	} else {
+		operationId, err = o.Resources.OperationService.ExecutePayoutOperation(ctx.UserContext(), request.Domain, request.TokenId, request.BlockchainId, request.Destination, request.DestinationTag, request.Amount, request.Operator, callback)
+	}
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "operation", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// PostCrossCurrencyQuote quote a cross currency payout
+// @Summary Quote a cross currency payout
+// @Description find the cheapest path to deliver a token amount to an external address spending another token or XRP of the domain payment wallet, with the suggested send max
+// @Tags Operations
+// @ID post-cross-currency-quote
+// @Accept json
+// @Produce json
+// @Param quote body types.CrossCurrencyQuoteRequest true "Cross currency quote object"
+// @Success 200 {object} operation.CrossCurrencyQuote
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/operations/cross-currency/quote [post]
+func (o OperationsHandler) PostCrossCurrencyQuote(ctx *fiber.Ctx) error {
+	request := types.CrossCurrencyQuoteRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "quote", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "quote", err)
+	}
+
+	if err := o.Resources.OperationService.ValidateParams(ctx.UserContext(), "PAYOUT", request.Domain, request.TokenId, request.BlockchainId); err != nil {
+		return BadRequestWrapper(ctx, "quote", err)
+	}
+
+	quote, err := o.Resources.OperationService.QuoteCrossCurrencyPayout(ctx.UserContext(), request.Domain, request.BlockchainId, request.SourceTokenId, request.TokenId, request.Destination, request.Amount)
+	if err != nil {
+		return BadRequestWrapper(ctx, "quote", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(quote)
+}
+
+// PostCrossCurrencyPayoutOperation create a new cross currency payout operation
+// @Summary Create a new cross currency payout operation
+// @Description deliver a token amount to an external address spending another token or XRP of the domain payment wallet through the DEX, up to the send max of an accepted quote
+// @Tags Operations
+// @ID post-cross-currency-payout-operation
+// @Accept json
+// @Produce json
+// @Param operation body types.CrossCurrencyPayoutRequest true "Cross currency payout operation object"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/operations/cross-currency [post]
+func (o OperationsHandler) PostCrossCurrencyPayoutOperation(ctx *fiber.Ctx) error {
+	request := types.CrossCurrencyPayoutRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "operation", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "operation", err)
+	}
+
+	if err := o.Resources.OperationService.ValidateParams(ctx.UserContext(), "PAYOUT", request.Domain, request.TokenId, request.BlockchainId); err != nil {
+		return BadRequestWrapper(ctx, "operation", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := o.Resources.OperationService.ExecuteCrossCurrencyPayout(ctx.UserContext(), request.Domain, request.BlockchainId, request.SourceTokenId, request.TokenId, request.Destination, request.DestinationTag, request.Amount, request.SendMax, request.Partial, request.DeliverMin, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "operation", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// backingRefs converts the fiat deposits of the request to the ones recorded on the operation
+func backingRefs(backing []*types.BackingRefRequest) []*r.BackingRef {
+	refs := make([]*r.BackingRef, 0, len(backing))
+	for _, ref := range backing {
+		refs = append(refs, &r.BackingRef{
+			BankTransactionID: ref.BankTransactionID,
+			Amount:            ref.Amount,
+			Currency:          ref.Currency,
+			ReceivedAt:        ref.ReceivedAt,
+		})
+	}
+	return refs
+}
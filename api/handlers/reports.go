@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"crypto-braza-tokens-api/api/handlers/types"
+	cfg "crypto-braza-tokens-api/configs"
+	"crypto-braza-tokens-api/services/operation"
+	l "crypto-braza-tokens-api/utils/logger"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type ReportsHandler struct {
+	Resources *cfg.Resources
+}
+
+// GetSupplyReport retrieve the supply report
+// @Summary Get the supply report
+// @Description retrieve the amount of tokens minted and burned by the completed operations created on the range, grouped by token, and by domain or operator, and by day, week or month in the timezone. The report is streamed as JSON or as CSV
+// @Tags Reports
+// @ID get-supply-report
+// @Produce json
+// @Produce text/csv
+// @Param from query string true "Start date (2006-01-02 in the timezone or RFC 3339)"
+// @Param to query string false "End date, inclusive (2006-01-02 in the timezone or RFC 3339)"
+// @Param group_by query string false "Comma separated fields among token, domain and operator, always including token"
+// @Param period query string false "Period of the rows" Enums(day, week, month)
+// @Param timezone query string false "IANA timezone, defaults to UTC"
+// @Param format query string false "Export format, defaults to json" Enums(json, csv)
+// @Success 200 {array} operation.SupplyReportRow
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/reports/supply [get]
+func (h ReportsHandler) GetSupplyReport(ctx *fiber.Ctx) error {
+	request := types.ReportRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "supply report", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "supply report", err)
+	}
+
+	from, to, err := reportRange(request)
+	if err != nil {
+		return BadRequestWrapper(ctx, "supply report", err)
+	}
+
+	report, err := h.Resources.OperationService.OpenSupplyReport(ctx.UserContext(), from, to, request.Groups(), request.Period, request.Timezone)
+	if err != nil {
+		return BadRequestWrapper(ctx, "supply report", err)
+	}
+
+	return streamReport(ctx, "supply", request, report)
+}
+
+// GetOperationsReport retrieve the operations report
+// @Summary Get the operations report
+// @Description retrieve the count of the operations created on the range by status, the amount of the completed ones when grouped by token, their failed steps and how long they took to be processed, grouped by type, token, domain or operator and by day, week or month in the timezone. The report is streamed as JSON or as CSV
+// @Tags Reports
+// @ID get-operations-report
+// @Produce json
+// @Produce text/csv
+// @Param from query string true "Start date (2006-01-02 in the timezone or RFC 3339)"
+// @Param to query string false "End date, inclusive (2006-01-02 in the timezone or RFC 3339)"
+// @Param group_by query string false "Comma separated fields among type, token, domain and operator, defaults to type,token"
+// @Param period query string false "Period of the rows" Enums(day, week, month)
+// @Param timezone query string false "IANA timezone, defaults to UTC"
+// @Param format query string false "Export format, defaults to json" Enums(json, csv)
+// @Success 200 {array} operation.OperationsReportRow
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/reports/operations [get]
+func (h ReportsHandler) GetOperationsReport(ctx *fiber.Ctx) error {
+	request := types.ReportRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "operations report", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "operations report", err)
+	}
+
+	from, to, err := reportRange(request)
+	if err != nil {
+		return BadRequestWrapper(ctx, "operations report", err)
+	}
+
+	report, err := h.Resources.OperationService.OpenOperationsReport(ctx.UserContext(), from, to, request.Groups(), request.Period, request.Timezone)
+	if err != nil {
+		return BadRequestWrapper(ctx, "operations report", err)
+	}
+
+	return streamReport(ctx, "operations", request, report)
+}
+
+// GetMintsReport retrieve the mints audit report
+// @Summary Get the mints audit report
+// @Description retrieve the mints created on the range with the fiat deposits backing them, one row per deposit, with the dates in the timezone. The report is streamed as JSON or as CSV
+// @Tags Reports
+// @ID get-mints-report
+// @Produce json
+// @Produce text/csv
+// @Param from query string true "Start date (2006-01-02 in the timezone or RFC 3339)"
+// @Param to query string false "End date, inclusive (2006-01-02 in the timezone or RFC 3339)"
+// @Param timezone query string false "IANA timezone, defaults to UTC"
+// @Param format query string false "Export format, defaults to json" Enums(json, csv)
+// @Success 200 {array} operation.MintsReportRow
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/reports/mints [get]
+func (h ReportsHandler) GetMintsReport(ctx *fiber.Ctx) error {
+	request := types.ReportRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "mints report", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "mints report", err)
+	}
+
+	from, to, err := reportRange(request)
+	if err != nil {
+		return BadRequestWrapper(ctx, "mints report", err)
+	}
+
+	report, err := h.Resources.OperationService.OpenMintsReport(ctx.UserContext(), from, to, request.Groups(), request.Period, request.Timezone)
+	if err != nil {
+		return BadRequestWrapper(ctx, "mints report", err)
+	}
+
+	return streamReport(ctx, "mints", request, report)
+}
+
+func reportRange(request types.ReportRequest) (time.Time, time.Time, error) {
+	location, err := request.Location()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return request.Range(location)
+}
+
+// streamReport writes the rows of the report while they are read from the database. The status is sent before the
+// rows, so an error while streaming can only be logged and ends the body early.
+func streamReport(ctx *fiber.Ctx, name string, request types.ReportRequest, report *operation.Report) error {
+	// the request context is released once the handler returns, before the body is streamed
+	streamCtx := context.Background()
+
+	if request.IsCSV() {
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"%s-report.csv\"", name))
+	} else {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	}
+
+	ctx.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		write := report.WriteJSON
+		if request.IsCSV() {
+			write = report.WriteCSV
+		}
+
+		if err := write(streamCtx, w); err != nil {
+			l.Logger.Error("reports handler: failed to stream report", zap.String("report", name), zap.Error(err))
+		}
+
+		if err := w.Flush(); err != nil {
+			l.Logger.Error("reports handler: failed to flush report", zap.String("report", name), zap.Error(err))
+		}
+	})
+
+	return nil
+}
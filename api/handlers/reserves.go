@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"crypto-braza-tokens-api/api/handlers/types"
+	cfg "crypto-braza-tokens-api/configs"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReservesHandler struct {
+	Resources *cfg.Resources
+}
+
+// PostReserveBalance record a fiat reserve balance
+// @Summary Record a fiat reserve balance
+// @Description record the off-chain fiat balance backing an issued token held on a custodian account on a date, with the references of its evidences
+// @Tags Reserves
+// @ID post-reserve-balance
+// @Accept json
+// @Produce json
+// @Param balance body types.RecordReserveBalanceRequest true "Reserve balance to record"
+// @Success 200 {object} repositories.ReserveBalance
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/reserves/balances [post]
+func (h ReservesHandler) PostReserveBalance(ctx *fiber.Ctx) error {
+	request := types.RecordReserveBalanceRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return BadRequestWrapper(ctx, "reserve balance", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "reserve balance", err)
+	}
+
+	balance, err := h.Resources.OperationService.RecordReserveBalance(ctx.UserContext(), request.TokenId, request.Custodian, request.Account, request.Currency, request.Amount, request.AsOf, request.Evidences, request.Operator)
+	if err != nil {
+		return BadRequestWrapper(ctx, "reserve balance", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(balance)
+}
+
+// GetReserveBalances retrieve the fiat reserve balances
+// @Summary Get the fiat reserve balances
+// @Description retrieve the fiat reserve balances recorded for an issued token, or for all of them, from the newest to the oldest
+// @Tags Reserves
+// @ID get-reserve-balances
+// @Produce json
+// @Param token_id query string false "Token ID"
+// @Success 200 {array} repositories.ReserveBalance
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/reserves/balances [get]
+func (h ReservesHandler) GetReserveBalances(ctx *fiber.Ctx) error {
+	request := types.ListReserveBalancesRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "reserve balances", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "reserve balances", err)
+	}
+
+	balances, err := h.Resources.OperationService.ListReserveBalances(ctx.UserContext(), request.TokenId)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "reserve balances", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(balances)
+}
+
+// GetReserves retrieve the current reserves of a token
+// @Summary Get the current reserves of a token
+// @Description retrieve the circulating supply of an issued token on the last validated ledger and the fiat reserves backing it, as they would be attested now
+// @Tags Reserves
+// @ID get-reserves
+// @Produce json
+// @Param token_id query string true "Token ID"
+// @Success 200 {object} operation.ReserveAttestationDocument
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/reserves [get]
+func (h ReservesHandler) GetReserves(ctx *fiber.Ctx) error {
+	request := types.ReservesRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "reserves", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "reserves", err)
+	}
+
+	reserves, err := h.Resources.OperationService.GetReserves(ctx.UserContext(), request.TokenId)
+	if err != nil {
+		return BadRequestWrapper(ctx, "reserves", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(reserves)
+}
+
+// PostAttestation publish a reserves attestation
+// @Summary Publish a reserves attestation
+// @Description attest the fiat reserves backing the circulating supply of an issued token, the SHA-256 digest of the attestation being signed on fireblocks by the issuer key before it is published
+// @Tags Reserves
+// @ID post-reserve-attestation
+// @Accept json
+// @Produce json
+// @Param attestation body types.PublishAttestationRequest true "Token to attest"
+// @Success 200 {object} types.OperationResponse
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 423 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/reserves/attestations [post]
+func (h ReservesHandler) PostAttestation(ctx *fiber.Ctx) error {
+	request := types.PublishAttestationRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "attestation", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "attestation", err)
+	}
+
+	// only one operation is executed at a time, until its worker is done
+	callback, release, ok := startOperation()
+	if !ok {
+		return OperationLockedWrapper(ctx)
+	}
+
+	operationId, err := h.Resources.OperationService.PublishReserveAttestation(ctx.UserContext(), request.TokenId, request.Operator, callback)
+	if err != nil {
+		// the worker was not started, so the operation lock is released here
+		release()
+		return BadRequestWrapper(ctx, "attestation", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(&types.OperationResponse{Success: true, Message: fmt.Sprintf("operation %s was accepted to be processed on blockchain", operationId)})
+}
+
+// GetAttestations retrieve the reserves attestations
+// @Summary Get the reserves attestations
+// @Description retrieve the reserves attestations of an issued token, or of all of them, with their signing status, from the newest to the oldest
+// @Tags Reserves
+// @ID get-reserve-attestations
+// @Produce json
+// @Param token_id query string false "Token ID"
+// @Param status query string false "Attestation status" Enums(PENDING, PUBLISHED, FAILED)
+// @Success 200 {array} repositories.ReserveAttestation
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/reserves/attestations [get]
+func (h ReservesHandler) GetAttestations(ctx *fiber.Ctx) error {
+	request := types.ListAttestationsRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "attestations", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "attestations", err)
+	}
+
+	attestations, err := h.Resources.OperationService.ListReserveAttestations(ctx.UserContext(), request.TokenId, request.Status)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "attestations", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(attestations)
+}
+
+// GetPublicAttestations retrieve the published reserves attestations
+// @Summary Get the published reserves attestations
+// @Description public history of the signed reserves attestations of an issued token, or of all of them, from the newest to the oldest. Each document is verified by hashing its exact JSON with SHA-256 and checking the detached signature of the digest against the issuer public key.
+// @Tags Public
+// @ID get-public-reserve-attestations
+// @Produce json
+// @Param token_id query string false "Token ID"
+// @Success 200 {array} operation.PublicReserveAttestation
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/public/reserves/attestations [get]
+func (h ReservesHandler) GetPublicAttestations(ctx *fiber.Ctx) error {
+	request := types.ListPublicAttestationsRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "attestations", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "attestations", err)
+	}
+
+	attestations, err := h.Resources.OperationService.ListPublicReserveAttestations(ctx.UserContext(), request.TokenId)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "attestations", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(attestations)
+}
+
+// GetPublicAttestation retrieve a published reserves attestation
+// @Summary Get a published reserves attestation
+// @Description public signed reserves attestation with its detached signature
+// @Tags Public
+// @ID get-public-reserve-attestation
+// @Produce json
+// @Param id path string true "Attestation ID"
+// @Success 200 {object} operation.PublicReserveAttestation
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 404 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/public/reserves/attestations/{id} [get]
+func (h ReservesHandler) GetPublicAttestation(ctx *fiber.Ctx) error {
+	if err := ValidatePathParam(ctx, "id"); err != nil {
+		return BadRequestWrapper(ctx, "attestation", err)
+	}
+
+	attestation, err := h.Resources.OperationService.GetPublicReserveAttestation(ctx.UserContext(), ctx.Params("id"))
+	if err != nil {
+		return BadRequestWrapper(ctx, "attestation", err)
+	}
+
+	if attestation == nil {
+		return NotFoundWrapper(ctx)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(attestation)
+}
@@ -38,6 +38,10 @@ func ConflictWrapper(ctx *fiber.Ctx, resource string) error {
 	return ctx.Status(http.StatusConflict).JSON(types.ErrorMessage{Message: msg})
 }
 
+func OperationLockedWrapper(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusLocked).JSON(fiber.Map{"error": "Another operation is currently being executed. Please try again later."})
+}
+
 func InternalErrorWrapper(ctx *fiber.Ctx, resource string, err error) error {
 	msg := fmt.Sprintf("handler: error saving %s", resource)
 
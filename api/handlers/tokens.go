@@ -139,3 +139,129 @@ func (t TokensHandler) PatchToken(ctx *fiber.Ctx) error {
 
 	return MessageResultWrapper(ctx, result.Hex())
 }
+
+// GetTokenHolders retrieve the distribution of a token among its holders
+// @Summary Get the holders distribution of a token
+// @Description retrieve the holders count, the concentration metrics (top 10 share, HHI and Gini) and the top holders of an issued token on its last holders snapshot, or on the given snapshot
+// @Tags Tokens
+// @ID get-token-holders
+// @Produce json
+// @Param id path string true "Token ID"
+// @Param snapshot_id query string false "Holders snapshot ID"
+// @Param top query int false "Amount of top holders" default(10)
+// @Success 200 {object} tokens.HolderDistribution
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 404 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/tokens/{id}/holders [get]
+func (t TokensHandler) GetTokenHolders(ctx *fiber.Ctx) error {
+	if err := ValidatePathParam(ctx, "id"); err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	request := types.TokenHoldersRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	result, err := t.Resources.TokenService.GetHolderDistribution(ctx.UserContext(), ctx.Params("id"), request.SnapshotId, request.Top)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "token holders", err)
+	}
+
+	if result == nil {
+		return NotFoundWrapper(ctx)
+	}
+
+	return ObjectResultWrapper(ctx, result)
+}
+
+// GetTokenHolderSnapshots retrieve the holders snapshots of a token
+// @Summary Get the holders snapshots of a token
+// @Description retrieve the holders snapshots of an issued token taken between the dates, with the holders count and concentration metrics of each of them
+// @Tags Tokens
+// @ID get-token-holder-snapshots
+// @Produce json
+// @Param id path string true "Token ID"
+// @Param from query string false "Start of the range (RFC 3339)"
+// @Param to query string false "End of the range (RFC 3339)"
+// @Success 200 {array} repositories.HolderSnapshot
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/tokens/{id}/holders/snapshots [get]
+func (t TokensHandler) GetTokenHolderSnapshots(ctx *fiber.Ctx) error {
+	if err := ValidatePathParam(ctx, "id"); err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	request := types.TokenHoldersRangeRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	from, to, err := request.Range()
+	if err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	result, err := t.Resources.TokenService.GetHolderSnapshots(ctx.UserContext(), ctx.Params("id"), from, to)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "token holders", err)
+	}
+
+	return ObjectResultWrapper(ctx, result)
+}
+
+// GetTokenHolderHistory retrieve the balance history of a holder of a token
+// @Summary Get the balance history of a token holder
+// @Description retrieve the balance of a holder on each holders snapshot of an issued token taken between the dates, with how much it changed since the previous snapshot
+// @Tags Tokens
+// @ID get-token-holder-history
+// @Produce json
+// @Param id path string true "Token ID"
+// @Param account path string true "Holder address"
+// @Param from query string false "Start of the range (RFC 3339)"
+// @Param to query string false "End of the range (RFC 3339)"
+// @Success 200 {object} tokens.HolderHistory
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/tokens/{id}/holders/{account}/history [get]
+func (t TokensHandler) GetTokenHolderHistory(ctx *fiber.Ctx) error {
+	for _, param := range []string{"id", "account"} {
+		if err := ValidatePathParam(ctx, param); err != nil {
+			return BadRequestWrapper(ctx, "token holders", err)
+		}
+	}
+
+	request := types.TokenHoldersRangeRequest{}
+
+	if err := request.FromQuery(ctx); err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	from, to, err := request.Range()
+	if err != nil {
+		return BadRequestWrapper(ctx, "token holders", err)
+	}
+
+	result, err := t.Resources.TokenService.GetHolderHistory(ctx.UserContext(), ctx.Params("id"), ctx.Params("account"), from, to)
+	if err != nil {
+		return InternalErrorWrapper(ctx, "token holders", err)
+	}
+
+	return ObjectResultWrapper(ctx, result)
+}
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"crypto-braza-tokens-api/utils/validations"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AmmPoolRequest struct {
+	BlockchainId string `query:"blockchain_id" validate:"required"`
+	Domain       string `query:"domain" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	TokenId      string `query:"token_id" validate:"required"`
+}
+
+// IsValid validates the AmmPoolRequest fields
+func (a *AmmPoolRequest) IsValid() error {
+	return validations.Validate(a)
+}
+
+// FromQuery parses the request query into the AmmPoolRequest struct
+func (a *AmmPoolRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(a)
+}
+
+type AmmCreateRequest struct {
+	BlockchainId string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Domain       string `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	TokenId      string `json:"token_id" example:"66f74ad8ba6b56108cb3e80b" validate:"required"`
+	TokenAmount  string `json:"token_amount" example:"50000" validate:"required"`
+	XrpAmount    string `json:"xrp_amount" example:"9500" validate:"required"`
+	TradingFee   int    `json:"trading_fee" example:"500" validate:"gte=0,lte=1000"` // in units of 1/100,000, up to 1%
+	Operator     string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the AmmCreateRequest fields
+func (a *AmmCreateRequest) IsValid() error {
+	if err := validatePositiveAmount("token amount", a.TokenAmount); err != nil {
+		return err
+	}
+
+	if err := validatePositiveAmount("xrp amount", a.XrpAmount); err != nil {
+		return err
+	}
+
+	return validations.Validate(a)
+}
+
+// FromBody parses the request body into the AmmCreateRequest struct
+func (a *AmmCreateRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(a)
+}
+
+type AmmDepositRequest struct {
+	BlockchainId  string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Domain        string `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	TokenId       string `json:"token_id" example:"66f74ad8ba6b56108cb3e80b" validate:"required"`
+	TokenAmount   string `json:"token_amount,omitempty" example:"1000"`
+	XrpAmount     string `json:"xrp_amount,omitempty" example:"190"`
+	LPTokenAmount string `json:"lp_token_amount,omitempty" example:"500"` // exact LP tokens to receive
+	Operator      string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the AmmDepositRequest fields
+func (a *AmmDepositRequest) IsValid() error {
+	if err := validateAmmAmounts(a.TokenAmount, a.XrpAmount, a.LPTokenAmount); err != nil {
+		return err
+	}
+
+	return validations.Validate(a)
+}
+
+// FromBody parses the request body into the AmmDepositRequest struct
+func (a *AmmDepositRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(a)
+}
+
+type AmmWithdrawRequest struct {
+	BlockchainId  string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Domain        string `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	TokenId       string `json:"token_id" example:"66f74ad8ba6b56108cb3e80b" validate:"required"`
+	TokenAmount   string `json:"token_amount,omitempty" example:"1000"`
+	XrpAmount     string `json:"xrp_amount,omitempty" example:"190"`
+	LPTokenAmount string `json:"lp_token_amount,omitempty" example:"500"` // exact LP tokens to return
+	All           bool   `json:"all" example:"false"`                     // returns every LP token of the wallet
+	Operator      string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the AmmWithdrawRequest fields
+func (a *AmmWithdrawRequest) IsValid() error {
+	if a.All {
+		if a.TokenAmount != "" || a.XrpAmount != "" || a.LPTokenAmount != "" {
+			return fmt.Errorf("no amount is accepted when withdrawing all the liquidity")
+		}
+		return validations.Validate(a)
+	}
+
+	if err := validateAmmAmounts(a.TokenAmount, a.XrpAmount, a.LPTokenAmount); err != nil {
+		return err
+	}
+
+	return validations.Validate(a)
+}
+
+// FromBody parses the request body into the AmmWithdrawRequest struct
+func (a *AmmWithdrawRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(a)
+}
+
+type AmmVoteRequest struct {
+	BlockchainId string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Domain       string `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	TokenId      string `json:"token_id" example:"66f74ad8ba6b56108cb3e80b" validate:"required"`
+	TradingFee   int    `json:"trading_fee" example:"500" validate:"gte=0,lte=1000"` // in units of 1/100,000, up to 1%
+	Operator     string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the AmmVoteRequest fields
+func (a *AmmVoteRequest) IsValid() error {
+	return validations.Validate(a)
+}
+
+// FromBody parses the request body into the AmmVoteRequest struct
+func (a *AmmVoteRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(a)
+}
+
+// validateAmmAmounts checks that at least one amount is given and that the given ones are positive
+func validateAmmAmounts(tokenAmount, xrpAmount, lpTokenAmount string) error {
+	given := 0
+
+	for _, amount := range []struct{ field, value string }{
+		{"token amount", tokenAmount},
+		{"xrp amount", xrpAmount},
+		{"lp token amount", lpTokenAmount},
+	} {
+		if amount.value == "" {
+			continue
+		}
+
+		if err := validatePositiveAmount(amount.field, amount.value); err != nil {
+			return err
+		}
+		given++
+	}
+
+	if given == 0 {
+		return fmt.Errorf("at least one of token_amount, xrp_amount or lp_token_amount must be provided")
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"crypto-braza-tokens-api/utils/validations"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CreatePaymentChannelRequest struct {
+	BlockchainId   string  `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	WalletId       string  `json:"wallet_id" example:"66f79a58ba6b56108cb3e80d" validate:"required"`
+	Destination    string  `json:"destination" example:"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh" validate:"required"` // classic address or X-address
+	DestinationTag *uint32 `json:"destination_tag,omitempty" example:"12345"`
+	Amount         string  `json:"amount" example:"250" validate:"required"`                  // XRP
+	SettleDelay    int     `json:"settle_delay" example:"86400" validate:"required,gt=0"`     // seconds
+	CancelAfter    int     `json:"cancel_after,omitempty" example:"2592000" validate:"gte=0"` // seconds, never closes when empty
+	Operator       string  `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the CreatePaymentChannelRequest fields
+func (c *CreatePaymentChannelRequest) IsValid() error {
+	if err := validatePositiveAmount("amount", c.Amount); err != nil {
+		return err
+	}
+
+	return validations.Validate(c)
+}
+
+// FromBody parses the request body into the CreatePaymentChannelRequest struct
+func (c *CreatePaymentChannelRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(c)
+}
+
+type FundPaymentChannelRequest struct {
+	ChannelId string `json:"channel_id" example:"E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366" validate:"required,len=64"`
+	Amount    string `json:"amount" example:"100" validate:"required"`               // XRP
+	ExpiresIn int    `json:"expires_in,omitempty" example:"604800" validate:"gte=0"` // seconds, keeps the expiration when empty
+	Operator  string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the FundPaymentChannelRequest fields
+func (f *FundPaymentChannelRequest) IsValid() error {
+	if err := validatePositiveAmount("amount", f.Amount); err != nil {
+		return err
+	}
+
+	return validations.Validate(f)
+}
+
+// FromBody parses the request body into the FundPaymentChannelRequest struct
+func (f *FundPaymentChannelRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(f)
+}
+
+type SignChannelClaimRequest struct {
+	ChannelId string `json:"channel_id" example:"E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366" validate:"required,len=64"`
+	Amount    string `json:"amount" example:"12.5" validate:"required"` // cumulative XRP the destination can claim
+	Operator  string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the SignChannelClaimRequest fields
+func (s *SignChannelClaimRequest) IsValid() error {
+	if err := validatePositiveAmount("amount", s.Amount); err != nil {
+		return err
+	}
+
+	return validations.Validate(s)
+}
+
+// FromBody parses the request body into the SignChannelClaimRequest struct
+func (s *SignChannelClaimRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(s)
+}
+
+type ListChannelClaimsRequest struct {
+	ChannelId string `query:"channel_id" validate:"required,len=64"`
+}
+
+// IsValid validates the ListChannelClaimsRequest fields
+func (l *ListChannelClaimsRequest) IsValid() error {
+	return validations.Validate(l)
+}
+
+// FromQuery parses the request query into the ListChannelClaimsRequest struct
+func (l *ListChannelClaimsRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(l)
+}
+
+type VerifyChannelClaimRequest struct {
+	ChannelId string `json:"channel_id" example:"E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366" validate:"required,len=64"`
+	Amount    string `json:"amount" example:"12.5" validate:"required"`                                                         // cumulative XRP of the claim
+	PublicKey string `json:"public_key,omitempty" example:"0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020"` // the channel key when empty
+	Signature string `json:"signature" example:"30440220718D264EF05CAED7C781FF6DE298DCAC68D002562C9BF3A07C1E721B420C0DAB02203A5A4779EF4D2CCC7BC3EF886676D803A9981B928D3B8ACA483B80ECA3CD7B9B" validate:"required,hexadecimal"`
+}
+
+// IsValid validates the VerifyChannelClaimRequest fields
+func (v *VerifyChannelClaimRequest) IsValid() error {
+	if err := validatePositiveAmount("amount", v.Amount); err != nil {
+		return err
+	}
+
+	return validations.Validate(v)
+}
+
+// FromBody parses the request body into the VerifyChannelClaimRequest struct
+func (v *VerifyChannelClaimRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(v)
+}
+
+type SubmitChannelClaimRequest struct {
+	BlockchainId string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	ChannelId    string `json:"channel_id" example:"E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366" validate:"required,len=64"`
+	Amount       string `json:"amount" example:"12.5" validate:"required"`                                                         // cumulative XRP of the claim
+	PublicKey    string `json:"public_key,omitempty" example:"0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020"` // the channel key when empty
+	Signature    string `json:"signature" example:"30440220718D264EF05CAED7C781FF6DE298DCAC68D002562C9BF3A07C1E721B420C0DAB02203A5A4779EF4D2CCC7BC3EF886676D803A9981B928D3B8ACA483B80ECA3CD7B9B" validate:"required,hexadecimal"`
+	Close        bool   `json:"close" example:"false"` // asks the channel to close after the claim
+	Operator     string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the SubmitChannelClaimRequest fields
+func (s *SubmitChannelClaimRequest) IsValid() error {
+	if err := validatePositiveAmount("amount", s.Amount); err != nil {
+		return err
+	}
+
+	return validations.Validate(s)
+}
+
+// FromBody parses the request body into the SubmitChannelClaimRequest struct
+func (s *SubmitChannelClaimRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(s)
+}
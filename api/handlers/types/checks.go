@@ -0,0 +1,36 @@
+package types
+
+import (
+	"crypto-braza-tokens-api/utils/validations"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ListChecksRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=PENDING OPEN CASHED EXPIRED CANCELLED FAILED"`
+}
+
+// IsValid validates the ListChecksRequest fields
+func (l *ListChecksRequest) IsValid() error {
+	return validations.Validate(l)
+}
+
+// FromQuery parses the request query into the ListChecksRequest struct
+func (l *ListChecksRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(l)
+}
+
+type CancelCheckRequest struct {
+	CheckId  string `json:"check_id" example:"49647F0D748DC3FE26BDACBC57F251AADEFFF391403EC9BF87C97F67E9977FB0" validate:"required,len=64"` // ledger object ID of the check
+	Operator string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the CancelCheckRequest fields
+func (c *CancelCheckRequest) IsValid() error {
+	return validations.Validate(c)
+}
+
+// FromBody parses the request body into the CancelCheckRequest struct
+func (c *CancelCheckRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(c)
+}
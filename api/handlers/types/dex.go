@@ -0,0 +1,69 @@
+package types
+
+import (
+	"crypto-braza-tokens-api/utils/validations"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PlaceOfferRequest struct {
+	BlockchainId string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Domain       string `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	TokenId      string `json:"token_id" example:"66f74ad8ba6b56108cb3e80b" validate:"required"`
+	Side         string `json:"side" example:"SELL" validate:"required,oneof=SELL BUY"`
+	Amount       string `json:"amount" example:"1000" validate:"required"`             // token amount
+	Price        string `json:"price" example:"0.19" validate:"required"`              // XRP per token
+	Passive      bool   `json:"passive" example:"false"`                               // does not cross offers at the same price
+	ExpiresIn    int    `json:"expires_in,omitempty" example:"86400" validate:"gte=0"` // seconds, never expires when empty
+	Operator     string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the PlaceOfferRequest fields
+func (p *PlaceOfferRequest) IsValid() error {
+	if err := validatePositiveAmount("amount", p.Amount); err != nil {
+		return err
+	}
+
+	if err := validatePositiveAmount("price", p.Price); err != nil {
+		return err
+	}
+
+	return validations.Validate(p)
+}
+
+// FromBody parses the request body into the PlaceOfferRequest struct
+func (p *PlaceOfferRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(p)
+}
+
+type CancelOfferRequest struct {
+	BlockchainId string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Domain       string `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	Sequence     int    `json:"sequence" example:"4218" validate:"required,gt=0"` // sequence of the OfferCreate transaction
+	Operator     string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the CancelOfferRequest fields
+func (c *CancelOfferRequest) IsValid() error {
+	return validations.Validate(c)
+}
+
+// FromBody parses the request body into the CancelOfferRequest struct
+func (c *CancelOfferRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(c)
+}
+
+type ListOffersRequest struct {
+	BlockchainId string `query:"blockchain_id" validate:"required"`
+	Domain       string `query:"domain" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+}
+
+// IsValid validates the ListOffersRequest fields
+func (l *ListOffersRequest) IsValid() error {
+	return validations.Validate(l)
+}
+
+// FromQuery parses the request query into the ListOffersRequest struct
+func (l *ListOffersRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(l)
+}
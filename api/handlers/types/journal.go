@@ -0,0 +1,131 @@
+package types
+
+import (
+	"crypto-braza-tokens-api/utils/validations"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PostJournalEntryRequest struct {
+	Type        string                `json:"type" example:"OPENING" validate:"required"`
+	Description string                `json:"description" example:"Opening balance of the BRAZA-ON supply wallet" validate:"required"`
+	Domain      string                `json:"domain" example:"BRAZA-ON"`
+	PostedAt    time.Time             `json:"posted_at" example:"2024-11-15T18:00:00Z"` // defaults to now
+	Lines       []*JournalLineRequest `json:"lines" validate:"required,min=2,dive,required"`
+	Operator    string                `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// JournalLineRequest is either a debit or a credit of the account, the other amount being empty or zero
+type JournalLineRequest struct {
+	Account  string `json:"account" example:"ASSET:BRAZA-ON:SUPPLY" validate:"required"`
+	Currency string `json:"currency" example:"BBRL" validate:"required"`
+	Issuer   string `json:"issuer" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"` // empty for XRP
+	Debit    string `json:"debit" example:"1000" validate:"omitempty,numeric"`
+	Credit   string `json:"credit" example:"0" validate:"omitempty,numeric"`
+}
+
+// IsValid validates the PostJournalEntryRequest fields
+func (p *PostJournalEntryRequest) IsValid() error {
+	return validations.Validate(p)
+}
+
+// FromBody parses the request body into the PostJournalEntryRequest struct
+func (p *PostJournalEntryRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(p)
+}
+
+type JournalEntriesRequest struct {
+	Account  string `query:"account"`
+	Currency string `query:"currency"`
+	From     string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC 3339, defaults to the first entry
+	To       string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`   // RFC 3339, defaults to now
+}
+
+// IsValid validates the JournalEntriesRequest fields
+func (j *JournalEntriesRequest) IsValid() error {
+	return validations.Validate(j)
+}
+
+// FromQuery parses the request query into the JournalEntriesRequest struct
+func (j *JournalEntriesRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(j)
+}
+
+// Range returns the dates of the range, from the beginning when no start is given and up to now when no end is given
+func (j *JournalEntriesRequest) Range() (time.Time, time.Time, error) {
+	return journalRange(j.From, j.To)
+}
+
+type TrialBalanceRequest struct {
+	AsOf      string `query:"as_of" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC 3339, defaults to now
+	Reconcile bool   `query:"reconcile" validate:"excluded_with=AsOf"`                       // only the current balances can be reconciled
+}
+
+// IsValid validates the TrialBalanceRequest fields
+func (t *TrialBalanceRequest) IsValid() error {
+	return validations.Validate(t)
+}
+
+// FromQuery parses the request query into the TrialBalanceRequest struct
+func (t *TrialBalanceRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(t)
+}
+
+// Date returns the date of the trial balance, now when it is not given
+func (t *TrialBalanceRequest) Date() (time.Time, error) {
+	if t.AsOf == "" {
+		return time.Now(), nil
+	}
+	return time.Parse(time.RFC3339, t.AsOf)
+}
+
+type AccountStatementRequest struct {
+	Account   string `query:"account" validate:"required"`
+	Currency  string `query:"currency" validate:"required"`
+	From      string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC 3339, defaults to the first entry
+	To        string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`   // RFC 3339, defaults to now
+	Reconcile bool   `query:"reconcile" validate:"excluded_with=To"`                        // only the current balance can be reconciled
+}
+
+// IsValid validates the AccountStatementRequest fields
+func (a *AccountStatementRequest) IsValid() error {
+	return validations.Validate(a)
+}
+
+// FromQuery parses the request query into the AccountStatementRequest struct
+func (a *AccountStatementRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(a)
+}
+
+// Range returns the dates of the range, from the beginning when no start is given and up to now when no end is given
+func (a *AccountStatementRequest) Range() (time.Time, time.Time, error) {
+	return journalRange(a.From, a.To)
+}
+
+func journalRange(start, end string) (time.Time, time.Time, error) {
+	from, to := time.Time{}, time.Now()
+
+	if start != "" {
+		parsed, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return from, to, err
+		}
+		from = parsed
+	}
+
+	if end != "" {
+		parsed, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return from, to, err
+		}
+		to = parsed
+	}
+
+	if to.Before(from) {
+		return from, to, fmt.Errorf("from %s is after to %s", start, end)
+	}
+
+	return from, to, nil
+}
@@ -4,6 +4,7 @@ import (
 	"crypto-braza-tokens-api/utils/validations"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -20,6 +21,8 @@ type OperationRequest struct {
 	Amount       string `json:"amount" example:"2.75" validate:"required"`
 	Domain       string `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
 	Operator     string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+	// confirmed fiat deposits backing a MINT, whose sum must cover the amount minted
+	Backing []*BackingRefRequest `json:"backing" validate:"required_if=Type MINT,excluded_unless=Type MINT,dive,required"`
 }
 
 // IsValid validates the OperationRequest fields
@@ -33,10 +36,154 @@ func (o *OperationRequest) IsValid() error {
 		return fmt.Errorf("the amount must be at least 1")
 	}
 
+	if o.Type == "MINT" && len(o.Backing) == 0 {
+		return fmt.Errorf("a MINT must be backed by at least one fiat deposit")
+	}
+
 	return validations.Validate(o)
 }
 
+type BackingRefRequest struct {
+	BankTransactionID string    `json:"bank_transaction_id" example:"E18236120202411151830s0123456789" validate:"required"`
+	Amount            string    `json:"amount" example:"1000.00" validate:"required,numeric"`
+	Currency          string    `json:"currency" example:"BRL" validate:"required,oneof=BRL USD"`
+	ReceivedAt        time.Time `json:"received_at" example:"2024-11-15T18:30:00Z" validate:"required"`
+}
+
 // FromBody parses the request body into the OperationRequest struct
 func (o *OperationRequest) FromBody(ctx *fiber.Ctx) error {
 	return ctx.BodyParser(o)
 }
+
+type FundingOperationRequest struct {
+	BlockchainId string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	WalletFromId string `json:"wallet_from_id" example:"66f79a58ba6b56108cb3e80d" validate:"required"`
+	WalletToId   string `json:"wallet_to_id" example:"66f79a90ba6b56108cb3e811" validate:"required,nefield=WalletFromId"`
+	Amount       string `json:"amount" example:"25.5" validate:"required"`
+	Operator     string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the FundingOperationRequest fields
+func (f *FundingOperationRequest) IsValid() error {
+	amountFloat, err := strconv.ParseFloat(f.Amount, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %v", err)
+	}
+
+	if amountFloat <= 0 {
+		return fmt.Errorf("the amount must be greater than 0")
+	}
+
+	return validations.Validate(f)
+}
+
+// FromBody parses the request body into the FundingOperationRequest struct
+func (f *FundingOperationRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(f)
+}
+
+type PayoutOperationRequest struct {
+	BlockchainId   string  `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	TokenId        string  `json:"token_id" example:"66f74acbba6b56108cb3e80a" validate:"required"`
+	Domain         string  `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	Destination    string  `json:"destination" example:"rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe" validate:"required"` // classic address or X-address
+	DestinationTag *uint32 `json:"destination_tag,omitempty" example:"12345"`
+	Amount         string  `json:"amount" example:"2.75" validate:"required"`
+	AsCheck        bool    `json:"as_check" example:"false"`                                     // sends the payout as a check the destination cashes
+	CheckExpiresIn int     `json:"check_expires_in,omitempty" example:"604800" validate:"gte=0"` // seconds until the check expires, never when zero
+	ExternalId     string  `json:"external_id,omitempty" example:"a1b2c3d4"`                     // the originating transaction request
+	Operator       string  `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the PayoutOperationRequest fields
+func (p *PayoutOperationRequest) IsValid() error {
+	amountFloat, err := strconv.ParseFloat(p.Amount, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %v", err)
+	}
+
+	if amountFloat <= 0 {
+		return fmt.Errorf("the amount must be greater than 0")
+	}
+
+	return validations.Validate(p)
+}
+
+// FromBody parses the request body into the PayoutOperationRequest struct
+func (p *PayoutOperationRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(p)
+}
+
+type CrossCurrencyQuoteRequest struct {
+	BlockchainId  string `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Domain        string `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	SourceTokenId string `json:"source_token_id,omitempty" example:"66f74acbba6b56108cb3e80a"` // XRP when empty
+	TokenId       string `json:"token_id" example:"66f74ad8ba6b56108cb3e80b" validate:"required"`
+	Destination   string `json:"destination" example:"rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe" validate:"required"` // classic address or X-address
+	Amount        string `json:"amount" example:"100" validate:"required"`
+}
+
+// IsValid validates the CrossCurrencyQuoteRequest fields
+func (c *CrossCurrencyQuoteRequest) IsValid() error {
+	if err := validatePositiveAmount("amount", c.Amount); err != nil {
+		return err
+	}
+
+	return validations.Validate(c)
+}
+
+// FromBody parses the request body into the CrossCurrencyQuoteRequest struct
+func (c *CrossCurrencyQuoteRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(c)
+}
+
+type CrossCurrencyPayoutRequest struct {
+	BlockchainId   string  `json:"blockchain_id" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Domain         string  `json:"domain" example:"GET-BRAZA" validate:"required,oneof=GET-BRAZA BRAZA-ON BRAZA-DESK"`
+	SourceTokenId  string  `json:"source_token_id,omitempty" example:"66f74acbba6b56108cb3e80a"` // XRP when empty
+	TokenId        string  `json:"token_id" example:"66f74ad8ba6b56108cb3e80b" validate:"required"`
+	Destination    string  `json:"destination" example:"rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe" validate:"required"` // classic address or X-address
+	DestinationTag *uint32 `json:"destination_tag,omitempty" example:"12345"`
+	Amount         string  `json:"amount" example:"100" validate:"required"`
+	SendMax        string  `json:"send_max" example:"517.4735" validate:"required"` // the accepted send max of the quote
+	Partial        bool    `json:"partial" example:"false"`
+	DeliverMin     string  `json:"deliver_min,omitempty" example:"95"` // required on partial payouts
+	Operator       string  `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the CrossCurrencyPayoutRequest fields
+func (c *CrossCurrencyPayoutRequest) IsValid() error {
+	if err := validatePositiveAmount("amount", c.Amount); err != nil {
+		return err
+	}
+
+	if err := validatePositiveAmount("send max", c.SendMax); err != nil {
+		return err
+	}
+
+	if c.Partial {
+		if err := validatePositiveAmount("deliver min", c.DeliverMin); err != nil {
+			return err
+		}
+	}
+
+	return validations.Validate(c)
+}
+
+// FromBody parses the request body into the CrossCurrencyPayoutRequest struct
+func (c *CrossCurrencyPayoutRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(c)
+}
+
+func validatePositiveAmount(field, amount string) error {
+	amountFloat, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", field, err)
+	}
+
+	if amountFloat <= 0 {
+		return fmt.Errorf("the %s must be greater than 0", field)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"crypto-braza-tokens-api/utils/validations"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	REPORT_FORMAT_JSON = "json"
+	REPORT_FORMAT_CSV  = "csv"
+
+	// layout of the dates of a report range without a time, taken in the timezone of the report
+	REPORT_DATE_LAYOUT = "2006-01-02"
+)
+
+type ReportRequest struct {
+	From     string `query:"from" validate:"required"`                         // date (2006-01-02) or RFC 3339
+	To       string `query:"to"`                                               // date (2006-01-02), inclusive, or RFC 3339, defaults to now
+	GroupBy  string `query:"group_by"`                                         // comma separated fields, such as token,domain
+	Period   string `query:"period" validate:"omitempty,oneof=day week month"` // groups the rows by the creation period
+	Timezone string `query:"timezone" validate:"omitempty,timezone"`           // IANA timezone of the dates and periods, defaults to UTC
+	Format   string `query:"format" validate:"omitempty,oneof=json csv"`       // defaults to json
+}
+
+// IsValid validates the ReportRequest fields
+func (r *ReportRequest) IsValid() error {
+	return validations.Validate(r)
+}
+
+// FromQuery parses the request query into the ReportRequest struct
+func (r *ReportRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(r)
+}
+
+// Location returns the timezone of the report, UTC when it is not given
+func (r *ReportRequest) Location() (*time.Location, error) {
+	if r.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(r.Timezone)
+}
+
+// Range returns the dates of the range in the timezone, the end being exclusive: a date without a time ends the range
+// at the end of that day, and the range ends now when no end is given
+func (r *ReportRequest) Range(location *time.Location) (time.Time, time.Time, error) {
+	from, _, err := parseReportDate(r.From, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from %s: %w", r.From, err)
+	}
+
+	to := time.Now()
+	if r.To != "" {
+		date, isDay, err := parseReportDate(r.To, location)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to %s: %w", r.To, err)
+		}
+		to = date
+		if isDay {
+			to = date.AddDate(0, 0, 1)
+		}
+	}
+
+	if !to.After(from) {
+		return from, to, fmt.Errorf("from %s is not before to %s", r.From, r.To)
+	}
+
+	return from, to, nil
+}
+
+// Groups returns the fields the rows are grouped by
+func (r *ReportRequest) Groups() []string {
+	groups := []string{}
+	for _, field := range strings.Split(r.GroupBy, ",") {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			groups = append(groups, field)
+		}
+	}
+	return groups
+}
+
+// IsCSV tells whether the report is exported as CSV instead of JSON
+func (r *ReportRequest) IsCSV() bool {
+	return strings.EqualFold(r.Format, REPORT_FORMAT_CSV)
+}
+
+// parseReportDate parses a date without a time at the start of the day in the timezone, or a RFC 3339 date and time
+func parseReportDate(value string, location *time.Location) (time.Time, bool, error) {
+	if date, err := time.ParseInLocation(REPORT_DATE_LAYOUT, value, location); err == nil {
+		return date, true, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	return date, false, err
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"crypto-braza-tokens-api/utils/validations"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RecordReserveBalanceRequest struct {
+	TokenId   string    `json:"token_id" example:"66fc48562dd62529e6e879b7" validate:"required,len=24"`
+	Custodian string    `json:"custodian" example:"Banco do Brasil" validate:"required"`
+	Account   string    `json:"account" example:"0001-12345-6" validate:"required"`
+	Currency  string    `json:"currency" example:"BRL" validate:"required,len=3,alpha"`
+	Amount    string    `json:"amount" example:"1000500.00" validate:"required,numeric"` // zero when the account was emptied
+	AsOf      time.Time `json:"as_of" example:"2024-11-15T18:00:00Z" validate:"required"`
+	Evidences []string  `json:"evidences" example:"statements/2024-11-15-bb-0001-12345-6.pdf" validate:"required,min=1,dive,required"` // references of the evidences of the balance
+	Operator  string    `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the RecordReserveBalanceRequest fields
+func (r *RecordReserveBalanceRequest) IsValid() error {
+	return validations.Validate(r)
+}
+
+// FromBody parses the request body into the RecordReserveBalanceRequest struct
+func (r *RecordReserveBalanceRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(r)
+}
+
+type ListReserveBalancesRequest struct {
+	TokenId string `query:"token_id" validate:"omitempty,len=24"`
+}
+
+// IsValid validates the ListReserveBalancesRequest fields
+func (r *ListReserveBalancesRequest) IsValid() error {
+	return validations.Validate(r)
+}
+
+// FromQuery parses the request query into the ListReserveBalancesRequest struct
+func (r *ListReserveBalancesRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(r)
+}
+
+type ReservesRequest struct {
+	TokenId string `query:"token_id" validate:"required,len=24"`
+}
+
+// IsValid validates the ReservesRequest fields
+func (r *ReservesRequest) IsValid() error {
+	return validations.Validate(r)
+}
+
+// FromQuery parses the request query into the ReservesRequest struct
+func (r *ReservesRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(r)
+}
+
+type PublishAttestationRequest struct {
+	TokenId  string `json:"token_id" example:"66fc48562dd62529e6e879b7" validate:"required,len=24"`
+	Operator string `json:"operator" example:"123e4567-e89b-12d3-a456-426614174000" validate:"required"`
+}
+
+// IsValid validates the PublishAttestationRequest fields
+func (p *PublishAttestationRequest) IsValid() error {
+	return validations.Validate(p)
+}
+
+// FromBody parses the request body into the PublishAttestationRequest struct
+func (p *PublishAttestationRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(p)
+}
+
+type ListAttestationsRequest struct {
+	TokenId string `query:"token_id" validate:"omitempty,len=24"`
+	Status  string `query:"status" validate:"omitempty,oneof=PENDING PUBLISHED FAILED"`
+}
+
+// IsValid validates the ListAttestationsRequest fields
+func (l *ListAttestationsRequest) IsValid() error {
+	return validations.Validate(l)
+}
+
+// FromQuery parses the request query into the ListAttestationsRequest struct
+func (l *ListAttestationsRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(l)
+}
+
+type ListPublicAttestationsRequest struct {
+	TokenId string `query:"token_id" validate:"omitempty,len=24"`
+}
+
+// IsValid validates the ListPublicAttestationsRequest fields
+func (l *ListPublicAttestationsRequest) IsValid() error {
+	return validations.Validate(l)
+}
+
+// FromQuery parses the request query into the ListPublicAttestationsRequest struct
+func (l *ListPublicAttestationsRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(l)
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"crypto-braza-tokens-api/utils/validations"
+	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -41,3 +43,72 @@ func (t *EditTokenRequest) IsValid() error {
 func (t *EditTokenRequest) FromBody(ctx *fiber.Ctx) error {
 	return ctx.BodyParser(t)
 }
+
+// HOLDERS_DEFAULT_TOP is the amount of top holders listed when no amount is requested
+const HOLDERS_DEFAULT_TOP = 10
+
+type TokenHoldersRequest struct {
+	SnapshotId string `query:"snapshot_id" validate:"omitempty,len=24"`
+	Top        int    `query:"top" validate:"omitempty,min=1,max=1000"`
+}
+
+// IsValid validates the TokenHoldersRequest fields
+func (t *TokenHoldersRequest) IsValid() error {
+	return validations.Validate(t)
+}
+
+// FromQuery parses the request query into the TokenHoldersRequest struct, listing the default amount of top holders
+// when no amount is given
+func (t *TokenHoldersRequest) FromQuery(ctx *fiber.Ctx) error {
+	if err := ctx.QueryParser(t); err != nil {
+		return err
+	}
+
+	if t.Top == 0 {
+		t.Top = HOLDERS_DEFAULT_TOP
+	}
+
+	return nil
+}
+
+type TokenHoldersRangeRequest struct {
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC 3339, defaults to the first snapshot
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`   // RFC 3339, defaults to now
+}
+
+// IsValid validates the TokenHoldersRangeRequest fields
+func (t *TokenHoldersRangeRequest) IsValid() error {
+	return validations.Validate(t)
+}
+
+// FromQuery parses the request query into the TokenHoldersRangeRequest struct
+func (t *TokenHoldersRangeRequest) FromQuery(ctx *fiber.Ctx) error {
+	return ctx.QueryParser(t)
+}
+
+// Range returns the dates of the range, from the beginning when no start is given and up to now when no end is given
+func (t *TokenHoldersRangeRequest) Range() (time.Time, time.Time, error) {
+	from, to := time.Time{}, time.Now()
+
+	if t.From != "" {
+		parsed, err := time.Parse(time.RFC3339, t.From)
+		if err != nil {
+			return from, to, err
+		}
+		from = parsed
+	}
+
+	if t.To != "" {
+		parsed, err := time.Parse(time.RFC3339, t.To)
+		if err != nil {
+			return from, to, err
+		}
+		to = parsed
+	}
+
+	if to.Before(from) {
+		return from, to, fmt.Errorf("from %s is after to %s", t.From, t.To)
+	}
+
+	return from, to, nil
+}
@@ -7,12 +7,14 @@ import (
 )
 
 type SaveWalletRequest struct {
-	Name       string `json:"name" example:"WALLET-NAME" validate:"required"`
-	Address    string `json:"address" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd" validate:"required"`
-	Blockchain string `json:"blockchain" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
-	Type       string `json:"type" example:"NATIVE" validate:"required"`
-	Domain     string `json:"domain" example:"DOMAIN-NAME" validate:"required"`
-	IsActive   bool   `json:"is_active" example:"true"`
+	Name           string  `json:"name" example:"WALLET-NAME" validate:"required"`
+	Address        string  `json:"address" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd" validate:"required"`
+	Blockchain     string  `json:"blockchain" example:"66f6fe7eccc6398d39e981f9" validate:"required"`
+	Type           string  `json:"type" example:"NATIVE" validate:"required"`
+	Domain         string  `json:"domain" example:"DOMAIN-NAME" validate:"required"`
+	DestinationTag *uint32 `json:"destination_tag,omitempty" example:"1"`
+	Algorithm      string  `json:"algorithm,omitempty" example:"SECP256K1" validate:"omitempty,oneof=SECP256K1 ED25519"`
+	IsActive       bool    `json:"is_active" example:"true"`
 }
 
 func (t *SaveWalletRequest) IsValid() error {
@@ -24,12 +26,14 @@ func (t *SaveWalletRequest) FromBody(ctx *fiber.Ctx) error {
 }
 
 type EditWalletRequest struct {
-	Name       string `json:"name" example:"WALLET-NAME"`
-	Address    string `json:"address" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"`
-	Blockchain string `json:"blockchain" example:"66f6fe7eccc6398d39e981f9"`
-	Type       string `json:"type" example:"NATIVE"`
-	Domain     string `json:"domain" example:"DOMAIN-NAME"`
-	IsActive   bool   `json:"is_active" example:"true"`
+	Name           string  `json:"name" example:"WALLET-NAME"`
+	Address        string  `json:"address" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"`
+	Blockchain     string  `json:"blockchain" example:"66f6fe7eccc6398d39e981f9"`
+	Type           string  `json:"type" example:"NATIVE"`
+	Domain         string  `json:"domain" example:"DOMAIN-NAME"`
+	DestinationTag *uint32 `json:"destination_tag,omitempty" example:"1"`
+	Algorithm      string  `json:"algorithm,omitempty" example:"SECP256K1" validate:"omitempty,oneof=SECP256K1 ED25519"`
+	IsActive       bool    `json:"is_active" example:"true"`
 }
 
 func (t *EditWalletRequest) IsValid() error {
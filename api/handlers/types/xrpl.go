@@ -0,0 +1,27 @@
+package types
+
+import (
+	"crypto-braza-tokens-api/utils/validations"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DecodeRequest struct {
+	TxBlob      string `json:"tx_blob,omitempty" example:"1200002280000000240000000761D4871AFD498D000000000000000000000000000042525A410000000000000000000000000000000068400000000000000C"`
+	OperationId string `json:"operation_id,omitempty" example:"6709778cf22e601d8921bd1a"`
+}
+
+// IsValid validates the DecodeRequest fields
+func (d *DecodeRequest) IsValid() error {
+	if (d.TxBlob == "") == (d.OperationId == "") {
+		return fmt.Errorf("exactly one of tx_blob or operation_id must be provided")
+	}
+
+	return validations.Validate(d)
+}
+
+// FromBody parses the request body into the DecodeRequest struct
+func (d *DecodeRequest) FromBody(ctx *fiber.Ctx) error {
+	return ctx.BodyParser(d)
+}
@@ -172,7 +172,7 @@ func (w WalletsHandler) PostWallet(ctx *fiber.Ctx) error {
 		return BadRequestWrapper(ctx, "wallet", err)
 	}
 
-	result, err := w.Resources.WalletService.SaveWallet(ctx.UserContext(), request.Blockchain, request.Name, request.Address, request.Type, request.Domain, request.IsActive)
+	result, err := w.Resources.WalletService.SaveWallet(ctx.UserContext(), request.Blockchain, request.Name, request.Address, request.Type, request.Domain, request.DestinationTag, request.Algorithm, request.IsActive)
 	if err != nil {
 		return InternalErrorWrapper(ctx, "wallet", err)
 	}
@@ -230,7 +230,7 @@ func (w WalletsHandler) PatchWallet(ctx *fiber.Ctx) error {
 		return BadRequestWrapper(ctx, "wallet", err)
 	}
 
-	result, err := w.Resources.WalletService.EditWallet(ctx.UserContext(), request.Blockchain, request.Name, request.Address, request.Type, request.Domain, request.IsActive)
+	result, err := w.Resources.WalletService.EditWallet(ctx.UserContext(), request.Blockchain, request.Name, request.Address, request.Type, request.Domain, request.DestinationTag, request.Algorithm, request.IsActive)
 	if err != nil {
 		return InternalErrorWrapper(ctx, "wallet", err)
 	}
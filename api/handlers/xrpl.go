@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	types "crypto-braza-tokens-api/api/handlers/types"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	cfg "crypto-braza-tokens-api/configs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type XrplHandler struct {
+	Resources *cfg.Resources
+}
+
+// PostDecode decode a XRPL transaction blob
+// @Summary Decode a XRPL transaction blob
+// @Description decode a tx blob, or the blob submitted by an operation, into JSON with its signing hash and transaction ID
+// @Tags XRPL
+// @ID post-xrpl-decode
+// @Accept json
+// @Produce json
+// @Param decode body types.DecodeRequest true "Tx blob or operation ID to decode"
+// @Success 200 {object} xrpn.XrpDecodedTransaction
+// @Failure 400 {object} types.ErrorMessage
+// @Failure 500 {object} types.ErrorMessage
+// @Router /api/v1/xrpl/decode [post]
+func (x XrplHandler) PostDecode(ctx *fiber.Ctx) error {
+	request := types.DecodeRequest{}
+
+	if err := request.FromBody(ctx); err != nil {
+		return InternalErrorWrapper(ctx, "decode", err)
+	}
+
+	if err := request.IsValid(); err != nil {
+		return BadRequestWrapper(ctx, "decode", err)
+	}
+
+	var result *xrpn.XrpDecodedTransaction
+	var err error
+
+	if request.OperationId != "" {
+		result, err = x.Resources.XrplService.DecodeOperationTransaction(ctx.UserContext(), request.OperationId)
+	} else {
+		result, err = x.Resources.XrplService.DecodeTransactionBlob(request.TxBlob)
+	}
+
+	if err != nil {
+		return BadRequestWrapper(ctx, "decode", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(result)
+}
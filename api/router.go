@@ -52,6 +52,9 @@ func v1Endpoints(api fiber.Router, resources *cfg.Resources) {
 	// Tokens
 	v1.Get("/tokens", h.TokensHandler{Resources: resources}.GetTokens)
 	v1.Get("/tokens/:id", h.TokensHandler{Resources: resources}.GetTokenByID)
+	v1.Get("/tokens/:id/holders", h.TokensHandler{Resources: resources}.GetTokenHolders)
+	v1.Get("/tokens/:id/holders/snapshots", h.TokensHandler{Resources: resources}.GetTokenHolderSnapshots)
+	v1.Get("/tokens/:id/holders/:account/history", h.TokensHandler{Resources: resources}.GetTokenHolderHistory)
 	v1.Post("/tokens", h.TokensHandler{Resources: resources}.PostToken)
 	v1.Delete("/tokens/:id", h.TokensHandler{Resources: resources}.DeleteToken)
 	v1.Patch("/tokens", h.TokensHandler{Resources: resources}.PatchToken)
@@ -69,6 +72,7 @@ func v1Endpoints(api fiber.Router, resources *cfg.Resources) {
 
 	// Fireblocks Accounts
 	v1.Get("/fireblocks-accounts", h.FireblocksAccountsHandler{Resources: resources}.GetFireblocksAccounts)
+	v1.Get("/fireblocks-accounts/audit", h.FireblocksAccountsHandler{Resources: resources}.GetFireblocksAccountsAudit)
 	v1.Get("/fireblocks-accounts/:id", h.FireblocksAccountsHandler{Resources: resources}.GetFireblocksAccountById)
 	v1.Get("/fireblocks-accounts/vault/:vault_id", h.FireblocksAccountsHandler{Resources: resources}.GetFireblocksAccountByVaultId)
 	v1.Post("/fireblocks-accounts", h.FireblocksAccountsHandler{Resources: resources}.PostFireblocksAccounts)
@@ -79,6 +83,60 @@ func v1Endpoints(api fiber.Router, resources *cfg.Resources) {
 	v1.Get("/operations", h.OperationsHandler{Resources: resources}.GetOperations)
 	v1.Get("/operations/:id", h.OperationsHandler{Resources: resources}.GetOperationById)
 	v1.Post("/operations", h.OperationsHandler{Resources: resources}.PostOperation)
+	v1.Post("/operations/funding", h.OperationsHandler{Resources: resources}.PostFundingOperation)
+	v1.Post("/operations/payout", h.OperationsHandler{Resources: resources}.PostPayoutOperation)
+	v1.Post("/operations/cross-currency/quote", h.OperationsHandler{Resources: resources}.PostCrossCurrencyQuote)
+	v1.Post("/operations/cross-currency", h.OperationsHandler{Resources: resources}.PostCrossCurrencyPayoutOperation)
+
+	// DEX
+	v1.Post("/dex/offers", h.DexHandler{Resources: resources}.PostOffer)
+	v1.Get("/dex/offers", h.DexHandler{Resources: resources}.GetOffers)
+	v1.Post("/dex/offers/cancel", h.DexHandler{Resources: resources}.PostCancelOffer)
+
+	// AMM
+	v1.Get("/amm/pools", h.AmmHandler{Resources: resources}.GetPool)
+	v1.Post("/amm/pools", h.AmmHandler{Resources: resources}.PostPool)
+	v1.Post("/amm/deposit", h.AmmHandler{Resources: resources}.PostDeposit)
+	v1.Post("/amm/withdraw", h.AmmHandler{Resources: resources}.PostWithdraw)
+	v1.Post("/amm/vote", h.AmmHandler{Resources: resources}.PostVote)
+
+	// Checks
+	v1.Get("/checks", h.ChecksHandler{Resources: resources}.GetChecks)
+	v1.Post("/checks/cancel", h.ChecksHandler{Resources: resources}.PostCancelCheck)
+
+	// Payment Channels
+	v1.Post("/payment-channels", h.ChannelsHandler{Resources: resources}.PostChannel)
+	v1.Get("/payment-channels", h.ChannelsHandler{Resources: resources}.GetChannels)
+	v1.Post("/payment-channels/fund", h.ChannelsHandler{Resources: resources}.PostFundChannel)
+	v1.Post("/payment-channels/claims", h.ChannelsHandler{Resources: resources}.PostSignClaim)
+	v1.Get("/payment-channels/claims", h.ChannelsHandler{Resources: resources}.GetClaims)
+	v1.Post("/payment-channels/claims/verify", h.ChannelsHandler{Resources: resources}.PostVerifyClaim)
+	v1.Post("/payment-channels/claims/submit", h.ChannelsHandler{Resources: resources}.PostSubmitClaim)
+
+	// Reserves
+	v1.Get("/reserves", h.ReservesHandler{Resources: resources}.GetReserves)
+	v1.Post("/reserves/balances", h.ReservesHandler{Resources: resources}.PostReserveBalance)
+	v1.Get("/reserves/balances", h.ReservesHandler{Resources: resources}.GetReserveBalances)
+	v1.Post("/reserves/attestations", h.ReservesHandler{Resources: resources}.PostAttestation)
+	v1.Get("/reserves/attestations", h.ReservesHandler{Resources: resources}.GetAttestations)
+
+	// Journal
+	v1.Post("/journal/entries", h.JournalHandler{Resources: resources}.PostJournalEntry)
+	v1.Get("/journal/entries", h.JournalHandler{Resources: resources}.GetJournalEntries)
+	v1.Get("/journal/trial-balance", h.JournalHandler{Resources: resources}.GetTrialBalance)
+	v1.Get("/journal/accounts/statement", h.JournalHandler{Resources: resources}.GetAccountStatement)
+
+	// Reports
+	v1.Get("/reports/supply", h.ReportsHandler{Resources: resources}.GetSupplyReport)
+	v1.Get("/reports/operations", h.ReportsHandler{Resources: resources}.GetOperationsReport)
+	v1.Get("/reports/mints", h.ReportsHandler{Resources: resources}.GetMintsReport)
+
+	// Public
+	v1.Get("/public/reserves/attestations", h.ReservesHandler{Resources: resources}.GetPublicAttestations)
+	v1.Get("/public/reserves/attestations/:id", h.ReservesHandler{Resources: resources}.GetPublicAttestation)
+
+	// XRPL
+	v1.Post("/xrpl/decode", h.XrplHandler{Resources: resources}.PostDecode)
 
 	// Operation Types
 	v1.Get("/operations-types/list", h.OperationsHandler{Resources: resources}.GetOperationTypesNames)
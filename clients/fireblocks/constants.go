@@ -5,4 +5,8 @@ const (
 	OPERATION_TRANSFER   = "TRANSFER"
 	TARGET_VAULT_ACCOUNT = "VAULT_ACCOUNT"
 	INVALID_ASSET_CODE   = "code:1503"
+
+	// RAW signing algorithms
+	ALGORITHM_ECDSA_SECP256K1 = "MPC_ECDSA_SECP256K1"
+	ALGORITHM_EDDSA_ED25519   = "MPC_EDDSA_ED25519"
 )
@@ -177,7 +177,7 @@ func (f *FireblocksClient) GetTransactionByID(ctx context.Context, transactionID
 	return result, nil
 }
 
-func (f *FireblocksClient) BuildRawTransactionRequest(ctx context.Context, vaultAccountID, assetID, note, rawMessageContent string) *RawTransactionRequest {
+func (f *FireblocksClient) BuildRawTransactionRequest(ctx context.Context, vaultAccountID, assetID, note, algorithm, rawMessageContent string) *RawTransactionRequest {
 	payload := &RawTransactionRequest{
 		Operation: OPERATION_RAW,
 		AssetID:   assetID,
@@ -188,6 +188,7 @@ func (f *FireblocksClient) BuildRawTransactionRequest(ctx context.Context, vault
 		},
 		ExtraParameters: &RawTxExtraParameters{
 			RawMessageData: &RawTxMessageData{
+				Algorithm: algorithm,
 				Messages: []*RawTxContent{
 					{
 						Content: rawMessageContent,
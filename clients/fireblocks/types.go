@@ -61,7 +61,8 @@ type RawTxExtraParameters struct {
 }
 
 type RawTxMessageData struct {
-	Messages []*RawTxContent `json:"messages"`
+	Algorithm string          `json:"algorithm,omitempty"`
+	Messages  []*RawTxContent `json:"messages"`
 }
 
 type RawTxContent struct {
@@ -0,0 +1,73 @@
+package ripple
+
+import (
+	"context"
+	"fmt"
+
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+// ACCOUNT_LINES_PAGE_SIZE is the amount of trust lines read on each account_lines page
+const ACCOUNT_LINES_PAGE_SIZE = 400
+
+// BuildAccountLinesPageRequest builds an account_lines request of a page of the trust lines of the account. The first
+// page is read on the last validated ledger and the following ones on the ledger of the first page, as the marker is
+// only valid on the ledger it was returned for.
+func (r *RippleNodeClient) BuildAccountLinesPageRequest(account string, ledgerIndex int, marker any) *XrpJsonRpcRequest {
+	params := map[string]any{
+		"account":      account,
+		"ledger_index": "validated",
+		"limit":        ACCOUNT_LINES_PAGE_SIZE,
+	}
+
+	if ledgerIndex > 0 {
+		params["ledger_index"] = ledgerIndex
+	}
+
+	if marker != nil {
+		params["marker"] = marker
+	}
+
+	return &XrpJsonRpcRequest{
+		Method: "account_lines",
+		Params: []any{params},
+	}
+}
+
+// GetAllAccountLines retrieves every trust line of the account, reading all the account_lines pages on the same
+// validated ledger, and returns them with the index of that ledger
+func (r *RippleNodeClient) GetAllAccountLines(ctx context.Context, account string) ([]Line, int, error) {
+	lines := []Line{}
+
+	ledgerIndex := 0
+	var marker any
+	for {
+		request := r.BuildAccountLinesPageRequest(account, ledgerIndex, marker)
+		result := &XrpAccountLinesPageResponse{}
+
+		err := r.call(ctx, request, &result)
+		if err != nil {
+			l.Logger.Error("ripple client: failed to retreive account lines", zap.String("account", account), zap.Error(err))
+			return nil, 0, fmt.Errorf("failed to retreive account lines of account %s with error: %v", account, err)
+		}
+
+		if result.Result == nil {
+			return nil, 0, fmt.Errorf("account_lines response without result for account %s", account)
+		}
+
+		if result.Result.Error != "" {
+			l.Logger.Error("ripple client: account_lines request failed", zap.String("account", account), zap.String("error", result.Result.Error))
+			return nil, 0, fmt.Errorf("failed to retreive account lines of account %s with error: %s", account, result.Result.Error)
+		}
+
+		lines = append(lines, result.Result.Lines...)
+		ledgerIndex = result.Result.LedgerIndex
+
+		if result.Result.Marker == nil {
+			return lines, ledgerIndex, nil
+		}
+		marker = result.Result.Marker
+	}
+}
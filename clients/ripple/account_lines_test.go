@@ -0,0 +1,69 @@
+package ripple
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAccountLinesPageRequest(t *testing.T) {
+	client := &RippleNodeClient{}
+
+	first := client.BuildAccountLinesPageRequest(metaIssuer, 0, nil)
+	params := first.Params[0].(map[string]any)
+	require.Equal(t, "account_lines", first.Method)
+	require.Equal(t, "validated", params["ledger_index"])
+	require.NotContains(t, params, "marker")
+
+	next := client.BuildAccountLinesPageRequest(metaIssuer, 90000123, "marker-1")
+	params = next.Params[0].(map[string]any)
+	require.Equal(t, 90000123, params["ledger_index"])
+	require.Equal(t, "marker-1", params["marker"])
+}
+
+func TestGetAllAccountLinesPages(t *testing.T) {
+	page := 0
+	node := newFakeNode(t, map[string]func() (int, any){
+		"account_lines": func() (int, any) {
+			page++
+			if page == 1 {
+				return result(map[string]any{
+					"account":      metaIssuer,
+					"ledger_index": 90000123,
+					"lines": []any{
+						map[string]any{"account": metaHolder, "balance": "-150.5", "currency": "BRZ", "limit": "0", "limit_peer": "1000000", "no_ripple": true},
+					},
+					"marker": "F0B9A528CE25FE77C51C38040A7FEC016C2C841E74C1418D5A0F2F2A4E5E59A8,0",
+				})()
+			}
+			return result(map[string]any{
+				"account":      metaIssuer,
+				"ledger_index": 90000123,
+				"lines": []any{
+					map[string]any{"account": "rPEPPER7kfTD9w2To4CQk6UCfuHM9c6GDY", "balance": "-20", "currency": "BRZ", "limit": "0", "limit_peer": "500", "freeze": true, "peer_authorized": true},
+				},
+			})()
+		},
+	})
+
+	lines, ledgerIndex, err := newTestNodeClient(node).GetAllAccountLines(context.Background(), metaIssuer)
+	require.NoError(t, err)
+	require.Equal(t, 2, node.count("account_lines"))
+	require.Equal(t, 90000123, ledgerIndex)
+	require.Len(t, lines, 2)
+
+	require.Equal(t, "-150.5", lines[0].Balance)
+	require.True(t, lines[0].NoRipple)
+	require.True(t, lines[1].Freeze)
+	require.True(t, lines[1].PeerAuthorized)
+}
+
+func TestGetAllAccountLinesError(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"account_lines": result(map[string]any{"error": "actNotFound", "status": "error"}),
+	})
+
+	_, _, err := newTestNodeClient(node).GetAllAccountLines(context.Background(), metaIssuer)
+	require.ErrorContains(t, err, "actNotFound")
+}
@@ -0,0 +1,104 @@
+package ripple
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+// BuildAccountTxRequest builds an account_tx request returning the binary transactions of the account from the oldest
+// to the newest, starting on ledgerIndexMin (-1 for the earliest available ledger) or on the marker of a previous page.
+func (r *RippleNodeClient) BuildAccountTxRequest(account string, ledgerIndexMin int, marker *XrpAccountTxMarker, limit int) *XrpJsonRpcRequest {
+	params := map[string]any{
+		"account":          account,
+		"ledger_index_min": ledgerIndexMin,
+		"ledger_index_max": -1,
+		"binary":           true,
+		"forward":          true,
+		"limit":            limit,
+	}
+
+	if marker != nil {
+		params["marker"] = marker
+	}
+
+	return &XrpJsonRpcRequest{
+		Method: "account_tx",
+		Params: []any{params},
+	}
+}
+
+// GetAccountTransactions retrieves a page of transactions of the account and decodes them along with their metadata.
+// A marker is returned while there are more transactions to be read up to the last validated ledger.
+func (r *RippleNodeClient) GetAccountTransactions(ctx context.Context, account string, ledgerIndexMin int, marker *XrpAccountTxMarker, limit int) (*XrpAccountTransactions, error) {
+	request := r.BuildAccountTxRequest(account, ledgerIndexMin, marker, limit)
+	result := &XrpAccountTxResponse{}
+
+	err := r.call(ctx, request, &result)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to retreive account transactions", zap.String("account", account), zap.Error(err))
+		return nil, fmt.Errorf("failed to retreive transactions of account %s with error: %v", account, err)
+	}
+
+	if result.Result == nil {
+		return nil, fmt.Errorf("account_tx response without result for account %s", account)
+	}
+
+	if result.Result.Error != "" {
+		l.Logger.Error("ripple client: account_tx request failed", zap.String("account", account), zap.String("error", result.Result.Error))
+		return nil, fmt.Errorf("failed to retreive transactions of account %s with error: %s", account, result.Result.Error)
+	}
+
+	transactions := &XrpAccountTransactions{
+		Account:        result.Result.Account,
+		LedgerIndexMin: result.Result.LedgerIndexMin,
+		LedgerIndexMax: result.Result.LedgerIndexMax,
+		Marker:         result.Result.Marker,
+		Transactions:   make([]*XrpTransaction, 0, len(result.Result.Transactions)),
+	}
+
+	for _, entry := range result.Result.Transactions {
+		tx, err := decodeAccountTxEntry(entry)
+		if err != nil {
+			l.Logger.Error("ripple client: failed to decode account transaction", zap.String("account", account), zap.Error(err))
+			return nil, err
+		}
+		transactions.Transactions = append(transactions.Transactions, tx)
+	}
+
+	return transactions, nil
+}
+
+// decodeAccountTxEntry decodes a binary account_tx entry. The api v1 does not return the hash of binary transactions,
+// so it is computed from the blob when missing.
+func decodeAccountTxEntry(entry *XrpAccountTxEntry) (*XrpTransaction, error) {
+	// api v1 returns the binary metadata as meta, api v2 as meta_blob
+	metaBlob := entry.MetaBlob
+	if metaBlob == "" {
+		metaBlob, _ = entry.Meta.(string)
+	}
+
+	tx, err := DecodeBinaryTransaction(entry.TxBlob, metaBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Hash = entry.Hash
+	if tx.Hash == "" {
+		txBlob := strings.ToUpper(strings.TrimSpace(entry.TxBlob))
+		tx.Hash, err = Sha512Half(HASH_SIZE, ConcactPrefixWithTxBlob(PREFIX_SIGNED, txBlob))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx.LedgerIndex = entry.LedgerIndex
+	tx.Validated = entry.Validated
+	tx.Date = entry.Date
+
+	return tx, nil
+}
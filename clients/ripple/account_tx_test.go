@@ -0,0 +1,61 @@
+package ripple
+
+import (
+	"testing"
+
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAccountTxEntry(t *testing.T) {
+	HASH_SIZE = 64
+	PREFIX_SIGNED = "54584E00"
+
+	txBlob, err := binarycodec.Encode(map[string]any{
+		"TransactionType": "Payment",
+		"Account":         metaIssuer,
+		"Destination":     metaHolder,
+		"Amount":          map[string]any{"currency": ParseStringToHex("BRZA"), "issuer": metaIssuer, "value": "10"},
+		"Fee":             "12",
+		"Flags":           0,
+		"Sequence":        5,
+		"SigningPubKey":   "02A8A44DB3D4C73EEEE11DFE54D2029103B776AA8A8D293A91D645977C9DF5F544",
+		"TxnSignature":    "3045022100D184EB4AE5956FF600E7536EE459345C7BBCF097A84CC61A93B9AF7197EDB98702201CEA8009B7BEEBAA2AACC0359B41C427C1C5B550A4CA4B80CF2174AF2D6D5DCE",
+	})
+	require.NoError(t, err)
+
+	metaBlob, err := binarycodec.Encode(buildIssuedPaymentMeta())
+	require.NoError(t, err)
+
+	decoded, err := DecodeTransactionBlob(txBlob)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		entry *XrpAccountTxEntry
+	}{
+		{
+			name:  "api v1 without hash",
+			entry: &XrpAccountTxEntry{TxBlob: txBlob, Meta: metaBlob, LedgerIndex: 90, Validated: true},
+		},
+		{
+			name:  "api v2 with meta blob and hash",
+			entry: &XrpAccountTxEntry{Hash: decoded.TransactionId, TxBlob: txBlob, MetaBlob: metaBlob, LedgerIndex: 90, Validated: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := decodeAccountTxEntry(tc.entry)
+			require.NoError(t, err)
+
+			require.Equal(t, decoded.TransactionId, tx.Hash)
+			require.Equal(t, 90, tx.LedgerIndex)
+			require.True(t, tx.Validated)
+			require.Equal(t, "tesSUCCESS", tx.Metadata.TransactionResult)
+			require.Equal(t, "10", tx.DeliveredAmount)
+			require.Len(t, tx.BalanceChanges, 3)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package ripple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+// LP tokens of AMM pools have a non standard currency code starting with the 0x03 byte
+const LP_TOKEN_CURRENCY_PREFIX = "03"
+
+var ErrAmmNotFound = errors.New("amm pool not found")
+
+// IsLPTokenCurrency tells if the currency code is the one of the LP tokens of an AMM pool
+func IsLPTokenCurrency(currency string) bool {
+	return hexCurrencyRegex.MatchString(currency) && strings.HasPrefix(currency, LP_TOKEN_CURRENCY_PREFIX)
+}
+
+// BuildAmmInfoRequest builds an amm_info request of the pool of the assets on the validated ledger. When the account
+// is given, the LP tokens of the response are the ones it holds.
+func (r *RippleNodeClient) BuildAmmInfoRequest(asset, asset2 XrpAsset, account string) *XrpJsonRpcRequest {
+	params := map[string]any{
+		"asset":        asset.payload(),
+		"asset2":       asset2.payload(),
+		"ledger_index": "validated",
+	}
+
+	if account != "" {
+		params["account"] = account
+	}
+
+	return &XrpJsonRpcRequest{
+		Method: "amm_info",
+		Params: []any{params},
+	}
+}
+
+// BuildAmmInfoByAccountRequest builds an amm_info request of the pool of the AMM account, the issuer of its LP tokens
+func (r *RippleNodeClient) BuildAmmInfoByAccountRequest(ammAccount string) *XrpJsonRpcRequest {
+	return &XrpJsonRpcRequest{
+		Method: "amm_info",
+		Params: []any{
+			map[string]any{
+				"amm_account":  ammAccount,
+				"ledger_index": "validated",
+			},
+		},
+	}
+}
+
+// GetAmmInfo retrieves the state of the pool of the assets, returning ErrAmmNotFound when there is no such pool
+func (r *RippleNodeClient) GetAmmInfo(ctx context.Context, asset, asset2 XrpAsset, account string) (*XrpAmm, error) {
+	return r.ammInfo(ctx, r.BuildAmmInfoRequest(asset, asset2, account))
+}
+
+// GetAmmInfoByAccount retrieves the state of the pool of the AMM account
+func (r *RippleNodeClient) GetAmmInfoByAccount(ctx context.Context, ammAccount string) (*XrpAmm, error) {
+	return r.ammInfo(ctx, r.BuildAmmInfoByAccountRequest(ammAccount))
+}
+
+func (r *RippleNodeClient) ammInfo(ctx context.Context, request *XrpJsonRpcRequest) (*XrpAmm, error) {
+	result := &XrpAmmInfoResponse{}
+
+	err := r.call(ctx, request, &result)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to retreive amm info", zap.Error(err))
+		return nil, fmt.Errorf("failed to retreive amm info with error: %v", err)
+	}
+
+	if result.Result == nil {
+		return nil, fmt.Errorf("amm_info response without result")
+	}
+
+	// rippled answers actNotFound when the assets have no pool
+	if result.Result.Error == "actNotFound" {
+		return nil, ErrAmmNotFound
+	}
+
+	if result.Result.Error != "" || result.Result.Amm == nil {
+		l.Logger.Error("ripple client: amm_info request failed", zap.Any("response", result.Result))
+		return nil, fmt.Errorf("failed to retreive amm info with error: %s %s", result.Result.Error, result.Result.ErrorMessage)
+	}
+
+	return result.Result.Amm, nil
+}
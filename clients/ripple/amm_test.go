@@ -0,0 +1,66 @@
+package ripple
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const ammAccount = "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM"
+
+func TestGetAmmInfo(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"amm_info": result(map[string]any{
+			"amm": map[string]any{
+				"account":     ammAccount,
+				"amount":      map[string]any{"currency": "BRZ", "issuer": metaIssuer, "value": "50000"},
+				"amount2":     "9500000000",
+				"lp_token":    map[string]any{"currency": "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", "issuer": ammAccount, "value": "689202.1"},
+				"trading_fee": 500,
+				"vote_slots":  []any{map[string]any{"account": metaHolder, "trading_fee": 500, "vote_weight": 100000}},
+			},
+			"validated": true,
+		}),
+	})
+
+	amm, err := newTestNodeClient(node).GetAmmInfo(context.Background(), XrpAsset{Currency: "BRZ", Issuer: metaIssuer}, XrpAsset{}, "")
+	require.NoError(t, err)
+	require.Equal(t, ammAccount, amm.Account)
+	require.Equal(t, 500, amm.TradingFee)
+	require.Len(t, amm.VoteSlots, 1)
+
+	lpToken, err := ParseXrpAmount(amm.LPToken)
+	require.NoError(t, err)
+	require.True(t, IsLPTokenCurrency(lpToken.Currency))
+	require.Equal(t, "689202.1", lpToken.Value)
+}
+
+func TestGetAmmInfoNotFound(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"amm_info": result(map[string]any{"error": "actNotFound", "status": "error"}),
+	})
+
+	_, err := newTestNodeClient(node).GetAmmInfoByAccount(context.Background(), ammAccount)
+	require.ErrorIs(t, err, ErrAmmNotFound)
+}
+
+func TestBuildAmmInfoRequest(t *testing.T) {
+	client := newTestNodeClient()
+
+	request := client.BuildAmmInfoRequest(XrpAsset{Currency: "BRZ", Issuer: metaIssuer}, XrpAsset{}, metaHolder)
+	params := request.Params[0].(map[string]any)
+	require.Equal(t, "amm_info", request.Method)
+	require.Equal(t, map[string]any{"currency": "BRZ", "issuer": metaIssuer}, params["asset"])
+	require.Equal(t, map[string]any{"currency": "XRP"}, params["asset2"])
+	require.Equal(t, metaHolder, params["account"])
+
+	request = client.BuildAmmInfoRequest(XrpAsset{Currency: "BRZ", Issuer: metaIssuer}, XrpAsset{}, "")
+	require.NotContains(t, request.Params[0].(map[string]any), "account")
+}
+
+func TestIsLPTokenCurrency(t *testing.T) {
+	require.True(t, IsLPTokenCurrency("039C99CD9AB0B70B32ECDA51EAAE471625608EA2"))
+	require.False(t, IsLPTokenCurrency(ParseStringToHex("BRZA")))
+	require.False(t, IsLPTokenCurrency("USD"))
+}
@@ -0,0 +1,1372 @@
+package ripple
+
+import (
+	addresscodec "crypto-braza-tokens-api/clients/ripple/utils/address-codec"
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// universal flags, allowed on every transaction type
+const (
+	TF_FULLY_CANONICAL_SIG uint32 = 0x80000000
+	TF_INNER_BATCH_TXN     uint32 = 0x40000000
+
+	TF_UNIVERSAL = TF_FULLY_CANONICAL_SIG | TF_INNER_BATCH_TXN
+)
+
+// Payment flags
+const (
+	TF_NO_RIPPLE_DIRECT uint32 = 0x00010000
+	TF_PARTIAL_PAYMENT  uint32 = 0x00020000
+	TF_LIMIT_QUALITY    uint32 = 0x00040000
+)
+
+// TrustSet flags
+const (
+	TF_SETF_AUTH       uint32 = 0x00010000
+	TF_SET_NO_RIPPLE   uint32 = 0x00020000
+	TF_CLEAR_NO_RIPPLE uint32 = 0x00040000
+	TF_SET_FREEZE      uint32 = 0x00100000
+	TF_CLEAR_FREEZE    uint32 = 0x00200000
+)
+
+// OfferCreate flags
+const (
+	TF_PASSIVE             uint32 = 0x00010000
+	TF_IMMEDIATE_OR_CANCEL uint32 = 0x00020000
+	TF_FILL_OR_KILL        uint32 = 0x00040000
+	TF_SELL                uint32 = 0x00080000
+)
+
+// AMMDeposit and AMMWithdraw flags, each one selecting the mode of the transaction
+const (
+	TF_LP_TOKEN               uint32 = 0x00010000
+	TF_WITHDRAW_ALL           uint32 = 0x00020000
+	TF_ONE_ASSET_WITHDRAW_ALL uint32 = 0x00040000
+	TF_SINGLE_ASSET           uint32 = 0x00080000
+	TF_TWO_ASSET              uint32 = 0x00100000
+	TF_ONE_ASSET_LP_TOKEN     uint32 = 0x00200000
+	TF_LIMIT_LP_TOKEN         uint32 = 0x00400000
+	TF_TWO_ASSET_IF_EMPTY     uint32 = 0x00800000
+)
+
+// PaymentChannelClaim flags
+const (
+	TF_RENEW uint32 = 0x00010000
+	TF_CLOSE uint32 = 0x00020000
+)
+
+// AccountSet flags
+const (
+	TF_REQUIRE_DEST_TAG  uint32 = 0x00010000
+	TF_OPTIONAL_DEST_TAG uint32 = 0x00020000
+	TF_REQUIRE_AUTH      uint32 = 0x00040000
+	TF_OPTIONAL_AUTH     uint32 = 0x00080000
+	TF_DISALLOW_XRP      uint32 = 0x00100000
+	TF_ALLOW_XRP         uint32 = 0x00200000
+)
+
+// AccountSet SetFlag and ClearFlag values
+const (
+	ASF_REQUIRE_DEST                    uint32 = 1
+	ASF_REQUIRE_AUTH                    uint32 = 2
+	ASF_DISALLOW_XRP                    uint32 = 3
+	ASF_DISABLE_MASTER                  uint32 = 4
+	ASF_ACCOUNT_TXN_ID                  uint32 = 5
+	ASF_NO_FREEZE                       uint32 = 6
+	ASF_GLOBAL_FREEZE                   uint32 = 7
+	ASF_DEFAULT_RIPPLE                  uint32 = 8
+	ASF_DEPOSIT_AUTH                    uint32 = 9
+	ASF_AUTHORIZED_NFTOKEN_MINTER       uint32 = 10
+	ASF_DISALLOW_INCOMING_NFTOKEN_OFFER uint32 = 12
+	ASF_DISALLOW_INCOMING_CHECK         uint32 = 13
+	ASF_DISALLOW_INCOMING_PAY_CHAN      uint32 = 14
+	ASF_DISALLOW_INCOMING_TRUSTLINE     uint32 = 15
+	ASF_ALLOW_TRUSTLINE_CLAWBACK        uint32 = 16
+)
+
+const (
+	// MAX_DROPS is the total supply of XRP expressed in drops
+	MAX_DROPS = 100000000000000000
+
+	// issued currency values keep up to 16 significant digits
+	MAX_ISSUED_VALUE_DIGITS = 16
+
+	MAX_DOMAIN_LENGTH = 256
+
+	// transfer rates are billionths of a unit, from no fee to a 100% fee
+	MIN_TRANSFER_RATE = 1000000000
+	MAX_TRANSFER_RATE = 2000000000
+
+	MIN_TICK_SIZE = 3
+	MAX_TICK_SIZE = 15
+
+	// a payment takes up to 6 paths of up to 8 steps each
+	MAX_PATHS      = 6
+	MAX_PATH_STEPS = 8
+
+	// AMM trading fees are in units of 1/100,000, up to 1%
+	MAX_AMM_TRADING_FEE = 1000
+)
+
+var (
+	standardCurrencyRegex = regexp.MustCompile(`^[A-Za-z0-9?!@#$%^&*<>(){}\[\]|]{3}$`)
+	hexCurrencyRegex      = regexp.MustCompile(`^[0-9A-Fa-f]{40}$`)
+	hash256Regex          = regexp.MustCompile(`^[0-9A-Fa-f]{64}$`)
+
+	// the fields required by each mode of AMMDeposit and AMMWithdraw, any other amount field being rejected
+	ammDepositModes = map[uint32]ammFields{
+		TF_LP_TOKEN:           {lpToken: true},
+		TF_SINGLE_ASSET:       {amount: true},
+		TF_TWO_ASSET:          {amount: true, amount2: true},
+		TF_ONE_ASSET_LP_TOKEN: {amount: true, lpToken: true},
+		TF_LIMIT_LP_TOKEN:     {amount: true, ePrice: true},
+		TF_TWO_ASSET_IF_EMPTY: {amount: true, amount2: true},
+	}
+
+	ammWithdrawModes = map[uint32]ammFields{
+		TF_LP_TOKEN:               {lpToken: true},
+		TF_WITHDRAW_ALL:           {},
+		TF_ONE_ASSET_WITHDRAW_ALL: {amount: true},
+		TF_SINGLE_ASSET:           {amount: true},
+		TF_TWO_ASSET:              {amount: true, amount2: true},
+		TF_ONE_ASSET_LP_TOKEN:     {amount: true, lpToken: true},
+		TF_LIMIT_LP_TOKEN:         {amount: true, ePrice: true},
+	}
+
+	accountSetFlags = map[uint32]bool{
+		ASF_REQUIRE_DEST: true, ASF_REQUIRE_AUTH: true, ASF_DISALLOW_XRP: true, ASF_DISABLE_MASTER: true,
+		ASF_ACCOUNT_TXN_ID: true, ASF_NO_FREEZE: true, ASF_GLOBAL_FREEZE: true, ASF_DEFAULT_RIPPLE: true,
+		ASF_DEPOSIT_AUTH: true, ASF_AUTHORIZED_NFTOKEN_MINTER: true, ASF_DISALLOW_INCOMING_NFTOKEN_OFFER: true,
+		ASF_DISALLOW_INCOMING_CHECK: true, ASF_DISALLOW_INCOMING_PAY_CHAN: true, ASF_DISALLOW_INCOMING_TRUSTLINE: true,
+		ASF_ALLOW_TRUSTLINE_CLAWBACK: true,
+	}
+)
+
+// XrpTxBuilder is a typed XRPL transaction, validated before being converted to the payload encoded by the binary codec
+type XrpTxBuilder interface {
+	Validate() error
+	Payload() map[string]any
+}
+
+// XrpAmount is an amount of XRP in drops when the currency is empty, otherwise an amount of an issued currency
+type XrpAmount struct {
+	Currency string
+	Issuer   string
+	Value    string
+}
+
+// NewXrpAmount returns an amount of XRP in drops
+func NewXrpAmount(drops string) XrpAmount {
+	return XrpAmount{Value: drops}
+}
+
+// NewIssuedAmount returns an amount of the token, its abbreviation converted to the currency code of the ledger
+func NewIssuedAmount(tokenAbbr, issuer, value string) XrpAmount {
+	return XrpAmount{Currency: ParseStringToHex(tokenAbbr), Issuer: issuer, Value: value}
+}
+
+// ParseXrpAmount reads an amount of a JSON response, a string of drops or an object of an issued currency
+func ParseXrpAmount(value any) (XrpAmount, error) {
+	switch amount := value.(type) {
+	case string:
+		return NewXrpAmount(amount), nil
+	case map[string]any:
+		currency, _ := amount["currency"].(string)
+		issuer, _ := amount["issuer"].(string)
+		value, _ := amount["value"].(string)
+		return XrpAmount{Currency: currency, Issuer: issuer, Value: value}, nil
+	}
+
+	return XrpAmount{}, fmt.Errorf("invalid amount %v", value)
+}
+
+func (a XrpAmount) IsXrp() bool {
+	return a.Currency == ""
+}
+
+// Decimal returns the value of the amount, in drops for XRP
+func (a XrpAmount) Decimal() decimal.Decimal {
+	value, _ := decimal.NewFromString(a.Value)
+	return value
+}
+
+// SameCurrency tells if both amounts are of the same currency and issuer
+func (a XrpAmount) SameCurrency(other XrpAmount) bool {
+	return a.Currency == other.Currency && (a.IsXrp() || a.Issuer == other.Issuer)
+}
+
+// validate checks the amount format, an amount being zero only when allowed
+func (a XrpAmount) validate(field string, allowZero bool) error {
+	if a.IsXrp() {
+		drops, err := strconv.ParseUint(a.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be an integer amount of drops, got %q", field, a.Value)
+		}
+
+		if drops > MAX_DROPS {
+			return fmt.Errorf("%s exceeds the XRP supply", field)
+		}
+
+		if drops == 0 && !allowZero {
+			return fmt.Errorf("%s must be greater than zero", field)
+		}
+
+		return nil
+	}
+
+	if err := validateCurrency(a.Currency); err != nil {
+		return fmt.Errorf("%s %v", field, err)
+	}
+
+	if !addresscodec.IsValidClassicAddress(a.Issuer) {
+		return fmt.Errorf("%s issuer %q is not a valid address", field, a.Issuer)
+	}
+
+	value, err := decimal.NewFromString(a.Value)
+	if err != nil {
+		return fmt.Errorf("%s value %q is not a decimal number", field, a.Value)
+	}
+
+	if value.IsNegative() || (value.IsZero() && !allowZero) {
+		return fmt.Errorf("%s must be greater than zero", field)
+	}
+
+	if digits := strings.TrimRight(value.Coefficient().String(), "0"); len(digits) > MAX_ISSUED_VALUE_DIGITS {
+		return fmt.Errorf("%s value %q exceeds %d significant digits", field, a.Value, MAX_ISSUED_VALUE_DIGITS)
+	}
+
+	return nil
+}
+
+func (a XrpAmount) payload() any {
+	if a.IsXrp() {
+		return a.Value
+	}
+
+	return map[string]any{
+		"currency": a.Currency,
+		"issuer":   a.Issuer,
+		"value":    a.Value,
+	}
+}
+
+// Asset returns the asset of the amount, without its value
+func (a XrpAmount) Asset() XrpAsset {
+	return XrpAsset{Currency: a.Currency, Issuer: a.Issuer}
+}
+
+func validateCurrency(currency string) error {
+	if strings.EqualFold(currency, "XRP") {
+		return fmt.Errorf("currency XRP must be expressed in drops")
+	}
+
+	if standardCurrencyRegex.MatchString(currency) {
+		return nil
+	}
+
+	// non standard codes are 20 bytes, the first one being non zero
+	if hexCurrencyRegex.MatchString(currency) && !strings.HasPrefix(currency, "00") {
+		return nil
+	}
+
+	return fmt.Errorf("currency %q is not a valid currency code", currency)
+}
+
+// XrpAsset is a currency without an amount, XRP when the currency is empty, as the assets of an AMM pool
+type XrpAsset struct {
+	Currency string
+	Issuer   string
+}
+
+func (a XrpAsset) IsXrp() bool {
+	return a.Currency == ""
+}
+
+func (a XrpAsset) validate(field string) error {
+	if a.IsXrp() {
+		if a.Issuer != "" {
+			return fmt.Errorf("%s of XRP can not have an issuer", field)
+		}
+		return nil
+	}
+
+	if err := validateCurrency(a.Currency); err != nil {
+		return fmt.Errorf("%s %v", field, err)
+	}
+
+	if !addresscodec.IsValidClassicAddress(a.Issuer) {
+		return fmt.Errorf("%s issuer %q is not a valid address", field, a.Issuer)
+	}
+
+	return nil
+}
+
+func (a XrpAsset) payload() map[string]any {
+	if a.IsXrp() {
+		return map[string]any{"currency": CURRENCY_XRP}
+	}
+
+	return map[string]any{"currency": a.Currency, "issuer": a.Issuer}
+}
+
+// XrpMemo is a memo of the transaction, with plain text type and data
+type XrpMemo struct {
+	Type string
+	Data string
+}
+
+// XrpTxCommon holds the fields shared by every transaction type
+type XrpTxCommon struct {
+	Account            string
+	Fee                string
+	Sequence           uint32
+	Flags              uint32
+	LastLedgerSequence uint32
+	SigningPubKey      string
+	SourceTag          *uint32
+	Memos              []XrpMemo
+}
+
+func (c *XrpTxCommon) validate(allowedFlags uint32) error {
+	if !addresscodec.IsValidClassicAddress(c.Account) {
+		return fmt.Errorf("Account %q is not a valid address", c.Account)
+	}
+
+	if err := NewXrpAmount(c.Fee).validate("Fee", false); err != nil {
+		return err
+	}
+
+	if c.Sequence == 0 {
+		return fmt.Errorf("Sequence is required")
+	}
+
+	if c.SigningPubKey != "" {
+		if key, err := hex.DecodeString(c.SigningPubKey); err != nil || len(key) != 33 {
+			return fmt.Errorf("SigningPubKey %q is not a 33 bytes hex public key", c.SigningPubKey)
+		}
+	}
+
+	if invalid := c.Flags &^ (allowedFlags | TF_UNIVERSAL); invalid != 0 {
+		return fmt.Errorf("Flags 0x%08X are not valid for the transaction type", invalid)
+	}
+
+	return nil
+}
+
+// payload returns the common fields, the binary codec serializing UInt32 fields from int values
+func (c *XrpTxCommon) payload(transactionType string) map[string]any {
+	payload := map[string]any{
+		"TransactionType": transactionType,
+		"Account":         c.Account,
+		"Fee":             c.Fee,
+		"Sequence":        int(c.Sequence),
+		"Flags":           int(c.Flags),
+	}
+
+	if c.LastLedgerSequence > 0 {
+		payload["LastLedgerSequence"] = int(c.LastLedgerSequence)
+	}
+
+	if c.SigningPubKey != "" {
+		payload["SigningPubKey"] = c.SigningPubKey
+	}
+
+	if c.SourceTag != nil {
+		payload["SourceTag"] = int(*c.SourceTag)
+	}
+
+	if len(c.Memos) > 0 {
+		memos := []any{}
+		for _, memo := range c.Memos {
+			memos = append(memos, binarycodec.NewMemo(memo.Type, memo.Data))
+		}
+		payload["Memos"] = memos
+	}
+
+	return payload
+}
+
+// XrpPaymentTx delivers the Amount to the Destination. Cross-currency payments spend up to SendMax through the Paths,
+// and partial payments may deliver less than the Amount, down to DeliverMin.
+type XrpPaymentTx struct {
+	XrpTxCommon
+	Destination    string
+	DestinationTag *uint32
+	Amount         XrpAmount
+	SendMax        *XrpAmount
+	DeliverMin     *XrpAmount
+	Paths          [][]XrpPathStep
+}
+
+func (tx *XrpPaymentTx) Validate() error {
+	if err := tx.validate(TF_NO_RIPPLE_DIRECT | TF_PARTIAL_PAYMENT | TF_LIMIT_QUALITY); err != nil {
+		return fmt.Errorf("invalid Payment: %v", err)
+	}
+
+	if err := tx.validatePayment(); err != nil {
+		return fmt.Errorf("invalid Payment: %v", err)
+	}
+
+	return nil
+}
+
+func (tx *XrpPaymentTx) validatePayment() error {
+	if !addresscodec.IsValidClassicAddress(tx.Destination) {
+		return fmt.Errorf("Destination %q is not a valid address", tx.Destination)
+	}
+
+	if err := tx.Amount.validate("Amount", false); err != nil {
+		return err
+	}
+
+	if tx.SendMax != nil {
+		if err := tx.SendMax.validate("SendMax", false); err != nil {
+			return err
+		}
+
+		if tx.SendMax.IsXrp() && tx.Amount.IsXrp() {
+			return fmt.Errorf("SendMax can not be set on XRP to XRP payments")
+		}
+	} else if tx.Destination == tx.Account {
+		return fmt.Errorf("Destination can not be the Account without SendMax")
+	}
+
+	xrpToXrp := tx.Amount.IsXrp() && (tx.SendMax == nil || tx.SendMax.IsXrp())
+	if xrpToXrp && tx.Flags&(TF_PARTIAL_PAYMENT|TF_NO_RIPPLE_DIRECT|TF_LIMIT_QUALITY) != 0 {
+		return fmt.Errorf("XRP to XRP payments can not set path or partial payment flags")
+	}
+
+	if tx.DeliverMin != nil {
+		if tx.Flags&TF_PARTIAL_PAYMENT == 0 {
+			return fmt.Errorf("DeliverMin requires the partial payment flag")
+		}
+
+		if err := tx.DeliverMin.validate("DeliverMin", false); err != nil {
+			return err
+		}
+
+		if !tx.DeliverMin.SameCurrency(tx.Amount) {
+			return fmt.Errorf("DeliverMin must be of the Amount currency")
+		}
+
+		if tx.DeliverMin.Decimal().GreaterThan(tx.Amount.Decimal()) {
+			return fmt.Errorf("DeliverMin can not be greater than the Amount")
+		}
+	}
+
+	return tx.validatePaths(xrpToXrp)
+}
+
+func (tx *XrpPaymentTx) validatePaths(xrpToXrp bool) error {
+	if len(tx.Paths) == 0 {
+		return nil
+	}
+
+	if xrpToXrp {
+		return fmt.Errorf("Paths can not be set on XRP to XRP payments")
+	}
+
+	if len(tx.Paths) > MAX_PATHS {
+		return fmt.Errorf("Paths exceeds %d paths", MAX_PATHS)
+	}
+
+	for _, path := range tx.Paths {
+		if len(path) == 0 || len(path) > MAX_PATH_STEPS {
+			return fmt.Errorf("Paths must have from 1 to %d steps", MAX_PATH_STEPS)
+		}
+
+		for _, step := range path {
+			if step.Account == "" && step.Currency == "" && step.Issuer == "" {
+				return fmt.Errorf("Paths step can not be empty")
+			}
+
+			if step.Account != "" && !addresscodec.IsValidClassicAddress(step.Account) {
+				return fmt.Errorf("Paths step account %q is not a valid address", step.Account)
+			}
+
+			if step.Issuer != "" && !addresscodec.IsValidClassicAddress(step.Issuer) {
+				return fmt.Errorf("Paths step issuer %q is not a valid address", step.Issuer)
+			}
+
+			if step.Currency != "" && step.Currency != CURRENCY_XRP {
+				if err := validateCurrency(step.Currency); err != nil {
+					return fmt.Errorf("Paths step %v", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (tx *XrpPaymentTx) Payload() map[string]any {
+	payload := tx.payload("Payment")
+	payload["Destination"] = tx.Destination
+	payload["Amount"] = tx.Amount.payload()
+
+	if tx.DestinationTag != nil {
+		payload["DestinationTag"] = int(*tx.DestinationTag)
+	}
+
+	if tx.SendMax != nil {
+		payload["SendMax"] = tx.SendMax.payload()
+	}
+
+	if tx.DeliverMin != nil {
+		payload["DeliverMin"] = tx.DeliverMin.payload()
+	}
+
+	if len(tx.Paths) > 0 {
+		payload["Paths"] = pathsPayload(tx.Paths)
+	}
+
+	return payload
+}
+
+// pathsPayload converts the paths to the PathSet of the binary codec, each step keeping only its set fields
+func pathsPayload(paths [][]XrpPathStep) []any {
+	pathSet := []any{}
+
+	for _, path := range paths {
+		steps := []any{}
+		for _, step := range path {
+			fields := map[string]any{}
+			if step.Account != "" {
+				fields["account"] = step.Account
+			}
+			if step.Currency != "" {
+				fields["currency"] = step.Currency
+			}
+			if step.Issuer != "" {
+				fields["issuer"] = step.Issuer
+			}
+			steps = append(steps, fields)
+		}
+		pathSet = append(pathSet, steps)
+	}
+
+	return pathSet
+}
+
+type XrpTrustSetTx struct {
+	XrpTxCommon
+	LimitAmount XrpAmount
+	QualityIn   *uint32
+	QualityOut  *uint32
+}
+
+func (tx *XrpTrustSetTx) Validate() error {
+	if err := tx.validate(TF_SETF_AUTH | TF_SET_NO_RIPPLE | TF_CLEAR_NO_RIPPLE | TF_SET_FREEZE | TF_CLEAR_FREEZE); err != nil {
+		return fmt.Errorf("invalid TrustSet: %v", err)
+	}
+
+	if tx.LimitAmount.IsXrp() {
+		return fmt.Errorf("invalid TrustSet: LimitAmount must be an issued currency")
+	}
+
+	if err := tx.LimitAmount.validate("LimitAmount", true); err != nil {
+		return fmt.Errorf("invalid TrustSet: %v", err)
+	}
+
+	if tx.LimitAmount.Issuer == tx.Account {
+		return fmt.Errorf("invalid TrustSet: LimitAmount issuer can not be the Account")
+	}
+
+	if tx.Flags&TF_SET_NO_RIPPLE != 0 && tx.Flags&TF_CLEAR_NO_RIPPLE != 0 {
+		return fmt.Errorf("invalid TrustSet: the no ripple flag can not be set and cleared")
+	}
+
+	if tx.Flags&TF_SET_FREEZE != 0 && tx.Flags&TF_CLEAR_FREEZE != 0 {
+		return fmt.Errorf("invalid TrustSet: the freeze flag can not be set and cleared")
+	}
+
+	return nil
+}
+
+func (tx *XrpTrustSetTx) Payload() map[string]any {
+	payload := tx.payload("TrustSet")
+	payload["LimitAmount"] = tx.LimitAmount.payload()
+
+	if tx.QualityIn != nil {
+		payload["QualityIn"] = int(*tx.QualityIn)
+	}
+
+	if tx.QualityOut != nil {
+		payload["QualityOut"] = int(*tx.QualityOut)
+	}
+
+	return payload
+}
+
+type XrpAccountSetTx struct {
+	XrpTxCommon
+	SetFlag   *uint32
+	ClearFlag *uint32
+	// Domain is the plain text domain, an empty one removing the domain of the account
+	Domain       *string
+	TransferRate *uint32
+	TickSize     *uint8
+}
+
+func (tx *XrpAccountSetTx) Validate() error {
+	if err := tx.validate(TF_REQUIRE_DEST_TAG | TF_OPTIONAL_DEST_TAG | TF_REQUIRE_AUTH | TF_OPTIONAL_AUTH | TF_DISALLOW_XRP | TF_ALLOW_XRP); err != nil {
+		return fmt.Errorf("invalid AccountSet: %v", err)
+	}
+
+	if tx.SetFlag != nil && !accountSetFlags[*tx.SetFlag] {
+		return fmt.Errorf("invalid AccountSet: unknown SetFlag %d", *tx.SetFlag)
+	}
+
+	if tx.ClearFlag != nil && !accountSetFlags[*tx.ClearFlag] {
+		return fmt.Errorf("invalid AccountSet: unknown ClearFlag %d", *tx.ClearFlag)
+	}
+
+	if tx.SetFlag != nil && tx.ClearFlag != nil && *tx.SetFlag == *tx.ClearFlag {
+		return fmt.Errorf("invalid AccountSet: SetFlag and ClearFlag can not be the same flag")
+	}
+
+	if tx.Domain != nil && len(*tx.Domain) > MAX_DOMAIN_LENGTH {
+		return fmt.Errorf("invalid AccountSet: Domain exceeds %d bytes", MAX_DOMAIN_LENGTH)
+	}
+
+	if tx.TransferRate != nil && *tx.TransferRate != 0 && (*tx.TransferRate < MIN_TRANSFER_RATE || *tx.TransferRate > MAX_TRANSFER_RATE) {
+		return fmt.Errorf("invalid AccountSet: TransferRate must be 0 or between %d and %d", MIN_TRANSFER_RATE, MAX_TRANSFER_RATE)
+	}
+
+	if tx.TickSize != nil && *tx.TickSize != 0 && (*tx.TickSize < MIN_TICK_SIZE || *tx.TickSize > MAX_TICK_SIZE) {
+		return fmt.Errorf("invalid AccountSet: TickSize must be 0 or between %d and %d", MIN_TICK_SIZE, MAX_TICK_SIZE)
+	}
+
+	return nil
+}
+
+func (tx *XrpAccountSetTx) Payload() map[string]any {
+	payload := tx.payload("AccountSet")
+
+	if tx.SetFlag != nil {
+		payload["SetFlag"] = int(*tx.SetFlag)
+	}
+
+	if tx.ClearFlag != nil {
+		payload["ClearFlag"] = int(*tx.ClearFlag)
+	}
+
+	if tx.Domain != nil {
+		payload["Domain"] = strings.ToUpper(ConvertStringToHex(*tx.Domain))
+	}
+
+	if tx.TransferRate != nil {
+		payload["TransferRate"] = int(*tx.TransferRate)
+	}
+
+	if tx.TickSize != nil {
+		payload["TickSize"] = int(*tx.TickSize)
+	}
+
+	return payload
+}
+
+// XrpClawbackTx claws back tokens of the issuer Account, the Amount issuer being the holder of the tokens
+type XrpClawbackTx struct {
+	XrpTxCommon
+	Amount XrpAmount
+}
+
+func (tx *XrpClawbackTx) Validate() error {
+	if err := tx.validate(0); err != nil {
+		return fmt.Errorf("invalid Clawback: %v", err)
+	}
+
+	if tx.Amount.IsXrp() {
+		return fmt.Errorf("invalid Clawback: Amount must be an issued currency")
+	}
+
+	if err := tx.Amount.validate("Amount", false); err != nil {
+		return fmt.Errorf("invalid Clawback: %v", err)
+	}
+
+	if tx.Amount.Issuer == tx.Account {
+		return fmt.Errorf("invalid Clawback: Amount holder can not be the Account")
+	}
+
+	return nil
+}
+
+func (tx *XrpClawbackTx) Payload() map[string]any {
+	payload := tx.payload("Clawback")
+	payload["Amount"] = tx.Amount.payload()
+
+	return payload
+}
+
+// XrpOfferCreateTx places an offer on the DEX, the Account giving TakerGets in exchange for TakerPays. The
+// OfferSequence of a previous offer replaces it.
+type XrpOfferCreateTx struct {
+	XrpTxCommon
+	TakerGets     XrpAmount
+	TakerPays     XrpAmount
+	Expiration    *uint32
+	OfferSequence *uint32
+}
+
+func (tx *XrpOfferCreateTx) Validate() error {
+	if err := tx.validate(TF_PASSIVE | TF_IMMEDIATE_OR_CANCEL | TF_FILL_OR_KILL | TF_SELL); err != nil {
+		return fmt.Errorf("invalid OfferCreate: %v", err)
+	}
+
+	if err := tx.TakerGets.validate("TakerGets", false); err != nil {
+		return fmt.Errorf("invalid OfferCreate: %v", err)
+	}
+
+	if err := tx.TakerPays.validate("TakerPays", false); err != nil {
+		return fmt.Errorf("invalid OfferCreate: %v", err)
+	}
+
+	if tx.TakerGets.SameCurrency(tx.TakerPays) {
+		return fmt.Errorf("invalid OfferCreate: TakerGets and TakerPays must be of different currencies")
+	}
+
+	if tx.Flags&TF_IMMEDIATE_OR_CANCEL != 0 && tx.Flags&TF_FILL_OR_KILL != 0 {
+		return fmt.Errorf("invalid OfferCreate: immediate or cancel and fill or kill flags can not be both set")
+	}
+
+	if tx.Expiration != nil && *tx.Expiration == 0 {
+		return fmt.Errorf("invalid OfferCreate: Expiration must be greater than zero")
+	}
+
+	if tx.OfferSequence != nil && *tx.OfferSequence == 0 {
+		return fmt.Errorf("invalid OfferCreate: OfferSequence must be greater than zero")
+	}
+
+	return nil
+}
+
+func (tx *XrpOfferCreateTx) Payload() map[string]any {
+	payload := tx.payload("OfferCreate")
+	payload["TakerGets"] = tx.TakerGets.payload()
+	payload["TakerPays"] = tx.TakerPays.payload()
+
+	if tx.Expiration != nil {
+		payload["Expiration"] = int(*tx.Expiration)
+	}
+
+	if tx.OfferSequence != nil {
+		payload["OfferSequence"] = int(*tx.OfferSequence)
+	}
+
+	return payload
+}
+
+// XrpOfferCancelTx removes the offer of the Account placed by the transaction of sequence OfferSequence
+type XrpOfferCancelTx struct {
+	XrpTxCommon
+	OfferSequence uint32
+}
+
+func (tx *XrpOfferCancelTx) Validate() error {
+	if err := tx.validate(0); err != nil {
+		return fmt.Errorf("invalid OfferCancel: %v", err)
+	}
+
+	if tx.OfferSequence == 0 {
+		return fmt.Errorf("invalid OfferCancel: OfferSequence is required")
+	}
+
+	return nil
+}
+
+func (tx *XrpOfferCancelTx) Payload() map[string]any {
+	payload := tx.payload("OfferCancel")
+	payload["OfferSequence"] = int(tx.OfferSequence)
+
+	return payload
+}
+
+// XrpAMMCreateTx creates the AMM pool of the assets of Amount and Amount2, funding it with both amounts. Its Fee must
+// be at least MinFee, the owner reserve increment of the validated ledger in drops, as the network burns one owner
+// reserve to create the pool instead of the usual transaction cost. MinFee is not part of the transaction.
+type XrpAMMCreateTx struct {
+	XrpTxCommon
+	Amount     XrpAmount
+	Amount2    XrpAmount
+	TradingFee uint16
+	MinFee     string
+}
+
+func (tx *XrpAMMCreateTx) Validate() error {
+	if err := tx.validate(0); err != nil {
+		return fmt.Errorf("invalid AMMCreate: %v", err)
+	}
+
+	if err := NewXrpAmount(tx.MinFee).validate("MinFee", false); err != nil {
+		return fmt.Errorf("invalid AMMCreate: %v", err)
+	}
+
+	if decimal.RequireFromString(tx.Fee).LessThan(decimal.RequireFromString(tx.MinFee)) {
+		return fmt.Errorf("invalid AMMCreate: Fee %s must be at least the owner reserve of %s drops", tx.Fee, tx.MinFee)
+	}
+
+	if err := tx.Amount.validate("Amount", false); err != nil {
+		return fmt.Errorf("invalid AMMCreate: %v", err)
+	}
+
+	if err := tx.Amount2.validate("Amount2", false); err != nil {
+		return fmt.Errorf("invalid AMMCreate: %v", err)
+	}
+
+	if tx.Amount.SameCurrency(tx.Amount2) {
+		return fmt.Errorf("invalid AMMCreate: Amount and Amount2 must be of different currencies")
+	}
+
+	if tx.TradingFee > MAX_AMM_TRADING_FEE {
+		return fmt.Errorf("invalid AMMCreate: TradingFee must be up to %d", MAX_AMM_TRADING_FEE)
+	}
+
+	return nil
+}
+
+func (tx *XrpAMMCreateTx) Payload() map[string]any {
+	payload := tx.payload("AMMCreate")
+	payload["Amount"] = tx.Amount.payload()
+	payload["Amount2"] = tx.Amount2.payload()
+	payload["TradingFee"] = int(tx.TradingFee)
+
+	return payload
+}
+
+// ammFields tells which of the optional amount fields of AMMDeposit and AMMWithdraw are set
+type ammFields struct {
+	amount  bool
+	amount2 bool
+	ePrice  bool
+	lpToken bool
+}
+
+// validateAmmMode checks that exactly one mode flag is set and that the fields set are the ones of the mode
+func validateAmmMode(flags uint32, modes map[uint32]ammFields, fields ammFields, lpTokenField string) error {
+	mode := uint32(0)
+	for flag := range modes {
+		if flags&flag != 0 {
+			if mode != 0 {
+				return fmt.Errorf("Flags must select exactly one mode")
+			}
+			mode = flag
+		}
+	}
+
+	if mode == 0 {
+		return fmt.Errorf("Flags must select exactly one mode")
+	}
+
+	required := modes[mode]
+	for _, field := range []struct {
+		name          string
+		required, set bool
+	}{
+		{"Amount", required.amount, fields.amount},
+		{"Amount2", required.amount2, fields.amount2},
+		{"EPrice", required.ePrice, fields.ePrice},
+		{lpTokenField, required.lpToken, fields.lpToken},
+	} {
+		if field.required && !field.set {
+			return fmt.Errorf("%s is required on mode 0x%08X", field.name, mode)
+		}
+		if !field.required && field.set {
+			return fmt.Errorf("%s is not allowed on mode 0x%08X", field.name, mode)
+		}
+	}
+
+	return nil
+}
+
+// validateAmmAmounts checks that the assets identify a pool and that the amounts are of its assets, Amount2 being of
+// the other asset than Amount
+func validateAmmAmounts(asset, asset2 XrpAsset, amount, amount2, ePrice *XrpAmount) error {
+	if err := asset.validate("Asset"); err != nil {
+		return err
+	}
+
+	if err := asset2.validate("Asset2"); err != nil {
+		return err
+	}
+
+	if asset == asset2 {
+		return fmt.Errorf("Asset and Asset2 must be different")
+	}
+
+	if amount != nil {
+		if err := amount.validate("Amount", false); err != nil {
+			return err
+		}
+
+		if amount.Asset() != asset && amount.Asset() != asset2 {
+			return fmt.Errorf("Amount must be of one of the pool assets")
+		}
+	}
+
+	if amount2 != nil {
+		if err := amount2.validate("Amount2", false); err != nil {
+			return err
+		}
+
+		if (amount2.Asset() != asset && amount2.Asset() != asset2) || amount.SameCurrency(*amount2) {
+			return fmt.Errorf("Amount2 must be of the other pool asset than Amount")
+		}
+	}
+
+	if ePrice != nil {
+		if err := ePrice.validate("EPrice", false); err != nil {
+			return err
+		}
+
+		if !ePrice.SameCurrency(*amount) {
+			return fmt.Errorf("EPrice must be of the currency of Amount")
+		}
+	}
+
+	return nil
+}
+
+// XrpAMMDepositTx deposits into the AMM pool of Asset and Asset2, receiving LP tokens. The mode flag tells which
+// amounts are deposited and how many LP tokens are received.
+type XrpAMMDepositTx struct {
+	XrpTxCommon
+	Asset      XrpAsset
+	Asset2     XrpAsset
+	Amount     *XrpAmount
+	Amount2    *XrpAmount
+	EPrice     *XrpAmount
+	LPTokenOut *XrpAmount
+	TradingFee *uint16
+}
+
+func (tx *XrpAMMDepositTx) Validate() error {
+	modes := TF_LP_TOKEN | TF_SINGLE_ASSET | TF_TWO_ASSET | TF_ONE_ASSET_LP_TOKEN | TF_LIMIT_LP_TOKEN | TF_TWO_ASSET_IF_EMPTY
+	if err := tx.validate(modes); err != nil {
+		return fmt.Errorf("invalid AMMDeposit: %v", err)
+	}
+
+	fields := ammFields{amount: tx.Amount != nil, amount2: tx.Amount2 != nil, ePrice: tx.EPrice != nil, lpToken: tx.LPTokenOut != nil}
+	if err := validateAmmMode(tx.Flags, ammDepositModes, fields, "LPTokenOut"); err != nil {
+		return fmt.Errorf("invalid AMMDeposit: %v", err)
+	}
+
+	if err := validateAmmAmounts(tx.Asset, tx.Asset2, tx.Amount, tx.Amount2, tx.EPrice); err != nil {
+		return fmt.Errorf("invalid AMMDeposit: %v", err)
+	}
+
+	if tx.LPTokenOut != nil {
+		if err := tx.LPTokenOut.validate("LPTokenOut", false); err != nil {
+			return fmt.Errorf("invalid AMMDeposit: %v", err)
+		}
+	}
+
+	// the trading fee is only set when funding an empty pool
+	if tx.TradingFee != nil && (tx.Flags&TF_TWO_ASSET_IF_EMPTY == 0 || *tx.TradingFee > MAX_AMM_TRADING_FEE) {
+		return fmt.Errorf("invalid AMMDeposit: TradingFee must be up to %d and only on two asset if empty deposits", MAX_AMM_TRADING_FEE)
+	}
+
+	return nil
+}
+
+func (tx *XrpAMMDepositTx) Payload() map[string]any {
+	payload := tx.payload("AMMDeposit")
+	payload["Asset"] = tx.Asset.payload()
+	payload["Asset2"] = tx.Asset2.payload()
+
+	if tx.Amount != nil {
+		payload["Amount"] = tx.Amount.payload()
+	}
+
+	if tx.Amount2 != nil {
+		payload["Amount2"] = tx.Amount2.payload()
+	}
+
+	if tx.EPrice != nil {
+		payload["EPrice"] = tx.EPrice.payload()
+	}
+
+	if tx.LPTokenOut != nil {
+		payload["LPTokenOut"] = tx.LPTokenOut.payload()
+	}
+
+	if tx.TradingFee != nil {
+		payload["TradingFee"] = int(*tx.TradingFee)
+	}
+
+	return payload
+}
+
+// XrpAMMWithdrawTx withdraws from the AMM pool of Asset and Asset2, returning LP tokens. The mode flag tells which
+// amounts are withdrawn and how many LP tokens are returned.
+type XrpAMMWithdrawTx struct {
+	XrpTxCommon
+	Asset     XrpAsset
+	Asset2    XrpAsset
+	Amount    *XrpAmount
+	Amount2   *XrpAmount
+	EPrice    *XrpAmount
+	LPTokenIn *XrpAmount
+}
+
+func (tx *XrpAMMWithdrawTx) Validate() error {
+	modes := TF_LP_TOKEN | TF_WITHDRAW_ALL | TF_ONE_ASSET_WITHDRAW_ALL | TF_SINGLE_ASSET | TF_TWO_ASSET | TF_ONE_ASSET_LP_TOKEN | TF_LIMIT_LP_TOKEN
+	if err := tx.validate(modes); err != nil {
+		return fmt.Errorf("invalid AMMWithdraw: %v", err)
+	}
+
+	fields := ammFields{amount: tx.Amount != nil, amount2: tx.Amount2 != nil, ePrice: tx.EPrice != nil, lpToken: tx.LPTokenIn != nil}
+	if err := validateAmmMode(tx.Flags, ammWithdrawModes, fields, "LPTokenIn"); err != nil {
+		return fmt.Errorf("invalid AMMWithdraw: %v", err)
+	}
+
+	if err := validateAmmAmounts(tx.Asset, tx.Asset2, tx.Amount, tx.Amount2, tx.EPrice); err != nil {
+		return fmt.Errorf("invalid AMMWithdraw: %v", err)
+	}
+
+	if tx.LPTokenIn != nil {
+		if err := tx.LPTokenIn.validate("LPTokenIn", false); err != nil {
+			return fmt.Errorf("invalid AMMWithdraw: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (tx *XrpAMMWithdrawTx) Payload() map[string]any {
+	payload := tx.payload("AMMWithdraw")
+	payload["Asset"] = tx.Asset.payload()
+	payload["Asset2"] = tx.Asset2.payload()
+
+	if tx.Amount != nil {
+		payload["Amount"] = tx.Amount.payload()
+	}
+
+	if tx.Amount2 != nil {
+		payload["Amount2"] = tx.Amount2.payload()
+	}
+
+	if tx.EPrice != nil {
+		payload["EPrice"] = tx.EPrice.payload()
+	}
+
+	if tx.LPTokenIn != nil {
+		payload["LPTokenIn"] = tx.LPTokenIn.payload()
+	}
+
+	return payload
+}
+
+// XrpAMMVoteTx votes the trading fee of the AMM pool of Asset and Asset2, weighted by the LP tokens of the Account
+type XrpAMMVoteTx struct {
+	XrpTxCommon
+	Asset      XrpAsset
+	Asset2     XrpAsset
+	TradingFee uint16
+}
+
+func (tx *XrpAMMVoteTx) Validate() error {
+	if err := tx.validate(0); err != nil {
+		return fmt.Errorf("invalid AMMVote: %v", err)
+	}
+
+	if err := validateAmmAmounts(tx.Asset, tx.Asset2, nil, nil, nil); err != nil {
+		return fmt.Errorf("invalid AMMVote: %v", err)
+	}
+
+	if tx.TradingFee > MAX_AMM_TRADING_FEE {
+		return fmt.Errorf("invalid AMMVote: TradingFee must be up to %d", MAX_AMM_TRADING_FEE)
+	}
+
+	return nil
+}
+
+func (tx *XrpAMMVoteTx) Payload() map[string]any {
+	payload := tx.payload("AMMVote")
+	payload["Asset"] = tx.Asset.payload()
+	payload["Asset2"] = tx.Asset2.payload()
+	payload["TradingFee"] = int(tx.TradingFee)
+
+	return payload
+}
+
+// XrpCheckCreateTx sends a check to the Destination, which cashes up to SendMax before the Expiration
+type XrpCheckCreateTx struct {
+	XrpTxCommon
+	Destination    string
+	DestinationTag *uint32
+	SendMax        XrpAmount
+	Expiration     *uint32
+	InvoiceID      string
+}
+
+func (tx *XrpCheckCreateTx) Validate() error {
+	if err := tx.validate(0); err != nil {
+		return fmt.Errorf("invalid CheckCreate: %v", err)
+	}
+
+	if !addresscodec.IsValidClassicAddress(tx.Destination) {
+		return fmt.Errorf("invalid CheckCreate: Destination %q is not a valid address", tx.Destination)
+	}
+
+	if tx.Destination == tx.Account {
+		return fmt.Errorf("invalid CheckCreate: Destination can not be the Account")
+	}
+
+	if err := tx.SendMax.validate("SendMax", false); err != nil {
+		return fmt.Errorf("invalid CheckCreate: %v", err)
+	}
+
+	if tx.Expiration != nil && *tx.Expiration == 0 {
+		return fmt.Errorf("invalid CheckCreate: Expiration must be greater than zero")
+	}
+
+	if tx.InvoiceID != "" && !hash256Regex.MatchString(tx.InvoiceID) {
+		return fmt.Errorf("invalid CheckCreate: InvoiceID must be a 256 bits hex hash")
+	}
+
+	return nil
+}
+
+func (tx *XrpCheckCreateTx) Payload() map[string]any {
+	payload := tx.payload("CheckCreate")
+	payload["Destination"] = tx.Destination
+	payload["SendMax"] = tx.SendMax.payload()
+
+	if tx.DestinationTag != nil {
+		payload["DestinationTag"] = int(*tx.DestinationTag)
+	}
+
+	if tx.Expiration != nil {
+		payload["Expiration"] = int(*tx.Expiration)
+	}
+
+	if tx.InvoiceID != "" {
+		payload["InvoiceID"] = strings.ToUpper(tx.InvoiceID)
+	}
+
+	return payload
+}
+
+// XrpCheckCancelTx removes the check of ledger object ID CheckID, by its sender or receiver, or by anyone once expired
+type XrpCheckCancelTx struct {
+	XrpTxCommon
+	CheckID string
+}
+
+func (tx *XrpCheckCancelTx) Validate() error {
+	if err := tx.validate(0); err != nil {
+		return fmt.Errorf("invalid CheckCancel: %v", err)
+	}
+
+	if !hash256Regex.MatchString(tx.CheckID) {
+		return fmt.Errorf("invalid CheckCancel: CheckID must be a 256 bits hex hash")
+	}
+
+	return nil
+}
+
+func (tx *XrpCheckCancelTx) Payload() map[string]any {
+	payload := tx.payload("CheckCancel")
+	payload["CheckID"] = strings.ToUpper(tx.CheckID)
+
+	return payload
+}
+
+// XrpPaymentChannelCreateTx opens a channel of XRP from the Account to the Destination, funded with the Amount. Claims
+// of the channel are signed by the key of PublicKey, and the channel closes no sooner than SettleDelay seconds after
+// the Account asks it, or at CancelAfter.
+type XrpPaymentChannelCreateTx struct {
+	XrpTxCommon
+	Amount         XrpAmount
+	Destination    string
+	DestinationTag *uint32
+	SettleDelay    uint32
+	PublicKey      string
+	CancelAfter    *uint32
+}
+
+func (tx *XrpPaymentChannelCreateTx) Validate() error {
+	if err := tx.validate(0); err != nil {
+		return fmt.Errorf("invalid PaymentChannelCreate: %v", err)
+	}
+
+	if !tx.Amount.IsXrp() {
+		return fmt.Errorf("invalid PaymentChannelCreate: Amount must be XRP")
+	}
+
+	if err := tx.Amount.validate("Amount", false); err != nil {
+		return fmt.Errorf("invalid PaymentChannelCreate: %v", err)
+	}
+
+	if !addresscodec.IsValidClassicAddress(tx.Destination) {
+		return fmt.Errorf("invalid PaymentChannelCreate: Destination %q is not a valid address", tx.Destination)
+	}
+
+	if tx.Destination == tx.Account {
+		return fmt.Errorf("invalid PaymentChannelCreate: Destination can not be the Account")
+	}
+
+	if key, err := hex.DecodeString(tx.PublicKey); err != nil || len(key) != 33 {
+		return fmt.Errorf("invalid PaymentChannelCreate: PublicKey %q is not a 33 bytes hex public key", tx.PublicKey)
+	}
+
+	if tx.CancelAfter != nil && *tx.CancelAfter == 0 {
+		return fmt.Errorf("invalid PaymentChannelCreate: CancelAfter must be greater than zero")
+	}
+
+	return nil
+}
+
+func (tx *XrpPaymentChannelCreateTx) Payload() map[string]any {
+	payload := tx.payload("PaymentChannelCreate")
+	payload["Amount"] = tx.Amount.payload()
+	payload["Destination"] = tx.Destination
+	payload["SettleDelay"] = int(tx.SettleDelay)
+	payload["PublicKey"] = strings.ToUpper(tx.PublicKey)
+
+	if tx.DestinationTag != nil {
+		payload["DestinationTag"] = int(*tx.DestinationTag)
+	}
+
+	if tx.CancelAfter != nil {
+		payload["CancelAfter"] = int(*tx.CancelAfter)
+	}
+
+	return payload
+}
+
+// XrpPaymentChannelFundTx adds the Amount of XRP to the channel of ledger object ID Channel, optionally changing the
+// Expiration of the channel
+type XrpPaymentChannelFundTx struct {
+	XrpTxCommon
+	Channel    string
+	Amount     XrpAmount
+	Expiration *uint32
+}
+
+func (tx *XrpPaymentChannelFundTx) Validate() error {
+	if err := tx.validate(0); err != nil {
+		return fmt.Errorf("invalid PaymentChannelFund: %v", err)
+	}
+
+	if !hash256Regex.MatchString(tx.Channel) {
+		return fmt.Errorf("invalid PaymentChannelFund: Channel must be a 256 bits hex hash")
+	}
+
+	if !tx.Amount.IsXrp() {
+		return fmt.Errorf("invalid PaymentChannelFund: Amount must be XRP")
+	}
+
+	if err := tx.Amount.validate("Amount", false); err != nil {
+		return fmt.Errorf("invalid PaymentChannelFund: %v", err)
+	}
+
+	if tx.Expiration != nil && *tx.Expiration == 0 {
+		return fmt.Errorf("invalid PaymentChannelFund: Expiration must be greater than zero")
+	}
+
+	return nil
+}
+
+func (tx *XrpPaymentChannelFundTx) Payload() map[string]any {
+	payload := tx.payload("PaymentChannelFund")
+	payload["Channel"] = strings.ToUpper(tx.Channel)
+	payload["Amount"] = tx.Amount.payload()
+
+	if tx.Expiration != nil {
+		payload["Expiration"] = int(*tx.Expiration)
+	}
+
+	return payload
+}
+
+// XrpPaymentChannelClaimTx delivers the XRP of the channel of ledger object ID Channel up to the cumulative Balance.
+// The destination submits the Balance with the Signature of the claim by the channel PublicKey, while the source
+// needs no signature. The close flag asks the channel to be closed, and the renew flag clears its expiration.
+type XrpPaymentChannelClaimTx struct {
+	XrpTxCommon
+	Channel   string
+	Balance   *XrpAmount
+	Amount    *XrpAmount
+	Signature string
+	PublicKey string
+}
+
+func (tx *XrpPaymentChannelClaimTx) Validate() error {
+	if err := tx.validate(TF_RENEW | TF_CLOSE); err != nil {
+		return fmt.Errorf("invalid PaymentChannelClaim: %v", err)
+	}
+
+	if !hash256Regex.MatchString(tx.Channel) {
+		return fmt.Errorf("invalid PaymentChannelClaim: Channel must be a 256 bits hex hash")
+	}
+
+	if tx.Flags&TF_RENEW != 0 && tx.Flags&TF_CLOSE != 0 {
+		return fmt.Errorf("invalid PaymentChannelClaim: renew and close flags can not be both set")
+	}
+
+	for _, field := range []struct {
+		name   string
+		amount *XrpAmount
+	}{{"Balance", tx.Balance}, {"Amount", tx.Amount}} {
+		if field.amount == nil {
+			continue
+		}
+
+		if !field.amount.IsXrp() {
+			return fmt.Errorf("invalid PaymentChannelClaim: %s must be XRP", field.name)
+		}
+
+		if err := field.amount.validate(field.name, false); err != nil {
+			return fmt.Errorf("invalid PaymentChannelClaim: %v", err)
+		}
+	}
+
+	if tx.Balance != nil && tx.Amount != nil && tx.Balance.Decimal().GreaterThan(tx.Amount.Decimal()) {
+		return fmt.Errorf("invalid PaymentChannelClaim: Balance can not be greater than the claimed Amount")
+	}
+
+	if tx.Signature != "" {
+		if tx.Balance == nil {
+			return fmt.Errorf("invalid PaymentChannelClaim: Balance is required with a Signature")
+		}
+
+		if key, err := hex.DecodeString(tx.PublicKey); err != nil || len(key) != 33 {
+			return fmt.Errorf("invalid PaymentChannelClaim: PublicKey %q is not a 33 bytes hex public key", tx.PublicKey)
+		}
+
+		if _, err := hex.DecodeString(tx.Signature); err != nil {
+			return fmt.Errorf("invalid PaymentChannelClaim: Signature must be hex encoded")
+		}
+	}
+
+	return nil
+}
+
+func (tx *XrpPaymentChannelClaimTx) Payload() map[string]any {
+	payload := tx.payload("PaymentChannelClaim")
+	payload["Channel"] = strings.ToUpper(tx.Channel)
+
+	if tx.Balance != nil {
+		payload["Balance"] = tx.Balance.payload()
+	}
+
+	if tx.Amount != nil {
+		payload["Amount"] = tx.Amount.payload()
+	}
+
+	if tx.Signature != "" {
+		payload["Signature"] = strings.ToUpper(tx.Signature)
+		payload["PublicKey"] = strings.ToUpper(tx.PublicKey)
+	}
+
+	return payload
+}
@@ -0,0 +1,568 @@
+package ripple
+
+import (
+	"strings"
+	"testing"
+
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+
+	"github.com/stretchr/testify/require"
+)
+
+const builderPubKey = "031EBB60A3036A67B6AA2055D5BD79A5ADFEC608057407F1EE3720E01489D59E82"
+
+func buildCommon(account string) XrpTxCommon {
+	return XrpTxCommon{
+		Account:            account,
+		Fee:                "12",
+		Sequence:           5,
+		LastLedgerSequence: 120,
+		SigningPubKey:      builderPubKey,
+		Memos:              []XrpMemo{{Type: "operation_id", Data: "66f79f17ba6b56108cb3e81d"}},
+	}
+}
+
+func uint32Ptr(value uint32) *uint32 {
+	return &value
+}
+
+func TestBuildersValidate(t *testing.T) {
+	tokenAmount := NewIssuedAmount("BRZA", metaIssuer, "10.5")
+
+	ammCreateCommon := buildCommon(metaHolder)
+	ammCreateCommon.Fee = "200000"
+
+	tests := []struct {
+		name    string
+		tx      XrpTxBuilder
+		wantErr string
+	}{
+		{
+			name: "token payment",
+			tx:   &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder, DestinationTag: uint32Ptr(7), Amount: tokenAmount},
+		},
+		{
+			name: "xrp payment",
+			tx:   &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder, Amount: NewXrpAmount("1000000")},
+		},
+		{
+			name:    "payment with bad destination checksum",
+			tx:      &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder[:len(metaHolder)-1] + "g", Amount: tokenAmount},
+			wantErr: "Destination",
+		},
+		{
+			name:    "payment of fractional drops",
+			tx:      &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder, Amount: NewXrpAmount("1.5")},
+			wantErr: "integer amount of drops",
+		},
+		{
+			name:    "payment of zero tokens",
+			tx:      &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder, Amount: NewIssuedAmount("BRZA", metaIssuer, "0")},
+			wantErr: "greater than zero",
+		},
+		{
+			name:    "payment value with too many digits",
+			tx:      &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder, Amount: NewIssuedAmount("BRZA", metaIssuer, "1.23456789012345678")},
+			wantErr: "significant digits",
+		},
+		{
+			name:    "payment of XRP currency code",
+			tx:      &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder, Amount: XrpAmount{Currency: "XRP", Issuer: metaIssuer, Value: "1"}},
+			wantErr: "expressed in drops",
+		},
+		{
+			name:    "payment to itself",
+			tx:      &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaIssuer, Amount: tokenAmount},
+			wantErr: "without SendMax",
+		},
+		{
+			name: "payment with flag of another transaction type",
+			tx: &XrpPaymentTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaIssuer); c.Flags = TF_SET_FREEZE; return c }(),
+				Destination: metaHolder,
+				Amount:      tokenAmount,
+			},
+			wantErr: "Flags",
+		},
+		{
+			name: "partial xrp payment",
+			tx: &XrpPaymentTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaIssuer); c.Flags = TF_PARTIAL_PAYMENT; return c }(),
+				Destination: metaHolder,
+				Amount:      NewXrpAmount("10"),
+			},
+			wantErr: "XRP to XRP",
+		},
+		{
+			name:    "payment without sequence",
+			tx:      &XrpPaymentTx{XrpTxCommon: XrpTxCommon{Account: metaIssuer, Fee: "12"}, Destination: metaHolder, Amount: tokenAmount},
+			wantErr: "Sequence",
+		},
+		{
+			name: "trust set",
+			tx: &XrpTrustSetTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_SET_NO_RIPPLE; return c }(),
+				LimitAmount: NewIssuedAmount("BRZA", metaIssuer, "0"),
+			},
+		},
+		{
+			name:    "trust set to itself",
+			tx:      &XrpTrustSetTx{XrpTxCommon: buildCommon(metaIssuer), LimitAmount: tokenAmount},
+			wantErr: "issuer can not be the Account",
+		},
+		{
+			name: "trust set setting and clearing freeze",
+			tx: &XrpTrustSetTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaIssuer); c.Flags = TF_SET_FREEZE | TF_CLEAR_FREEZE; return c }(),
+				LimitAmount: NewIssuedAmount("BRZA", metaHolder, "0"),
+			},
+			wantErr: "freeze",
+		},
+		{
+			name: "account set",
+			tx:   &XrpAccountSetTx{XrpTxCommon: buildCommon(metaIssuer), SetFlag: uint32Ptr(ASF_DEFAULT_RIPPLE), TransferRate: uint32Ptr(1002000000)},
+		},
+		{
+			name:    "account set with unknown flag",
+			tx:      &XrpAccountSetTx{XrpTxCommon: buildCommon(metaIssuer), SetFlag: uint32Ptr(11)},
+			wantErr: "SetFlag",
+		},
+		{
+			name:    "account set with transfer rate over 100%",
+			tx:      &XrpAccountSetTx{XrpTxCommon: buildCommon(metaIssuer), TransferRate: uint32Ptr(2500000000)},
+			wantErr: "TransferRate",
+		},
+		{
+			name: "clawback",
+			tx:   &XrpClawbackTx{XrpTxCommon: buildCommon(metaIssuer), Amount: NewIssuedAmount("BRZA", metaHolder, "3")},
+		},
+		{
+			name:    "clawback of xrp",
+			tx:      &XrpClawbackTx{XrpTxCommon: buildCommon(metaIssuer), Amount: NewXrpAmount("3")},
+			wantErr: "issued currency",
+		},
+		{
+			name: "sell offer",
+			tx: &XrpOfferCreateTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_SELL | TF_PASSIVE; return c }(),
+				TakerGets:   tokenAmount,
+				TakerPays:   NewXrpAmount("2000000"),
+				Expiration:  uint32Ptr(800000000),
+			},
+		},
+		{
+			name:    "offer of the same currency",
+			tx:      &XrpOfferCreateTx{XrpTxCommon: buildCommon(metaHolder), TakerGets: tokenAmount, TakerPays: NewIssuedAmount("BRZA", metaIssuer, "1")},
+			wantErr: "different currencies",
+		},
+		{
+			name: "offer immediate or cancel and fill or kill",
+			tx: &XrpOfferCreateTx{
+				XrpTxCommon: func() XrpTxCommon {
+					c := buildCommon(metaHolder)
+					c.Flags = TF_IMMEDIATE_OR_CANCEL | TF_FILL_OR_KILL
+					return c
+				}(),
+				TakerGets: tokenAmount,
+				TakerPays: NewXrpAmount("2000000"),
+			},
+			wantErr: "can not be both set",
+		},
+		{
+			name:    "offer cancel without sequence",
+			tx:      &XrpOfferCancelTx{XrpTxCommon: buildCommon(metaHolder)},
+			wantErr: "OfferSequence",
+		},
+		{
+			name: "amm create",
+			tx:   &XrpAMMCreateTx{XrpTxCommon: ammCreateCommon, Amount: tokenAmount, Amount2: NewXrpAmount("2000000"), TradingFee: 500, MinFee: "200000"},
+		},
+		{
+			name:    "amm create with trading fee over 1%",
+			tx:      &XrpAMMCreateTx{XrpTxCommon: ammCreateCommon, Amount: tokenAmount, Amount2: NewXrpAmount("2000000"), TradingFee: 1001, MinFee: "200000"},
+			wantErr: "TradingFee",
+		},
+		{
+			name:    "amm create with the transaction cost as fee",
+			tx:      &XrpAMMCreateTx{XrpTxCommon: buildCommon(metaHolder), Amount: tokenAmount, Amount2: NewXrpAmount("2000000"), TradingFee: 500, MinFee: "200000"},
+			wantErr: "owner reserve",
+		},
+		{
+			name:    "amm create without the owner reserve",
+			tx:      &XrpAMMCreateTx{XrpTxCommon: ammCreateCommon, Amount: tokenAmount, Amount2: NewXrpAmount("2000000"), TradingFee: 500},
+			wantErr: "MinFee",
+		},
+		{
+			name: "amm two asset deposit",
+			tx: &XrpAMMDepositTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_TWO_ASSET; return c }(),
+				Asset:       tokenAmount.Asset(),
+				Asset2:      XrpAsset{},
+				Amount:      &tokenAmount,
+				Amount2:     func() *XrpAmount { a := NewXrpAmount("2000000"); return &a }(),
+			},
+		},
+		{
+			name: "amm deposit without mode",
+			tx: &XrpAMMDepositTx{
+				XrpTxCommon: buildCommon(metaHolder),
+				Asset:       tokenAmount.Asset(),
+				Amount:      &tokenAmount,
+			},
+			wantErr: "exactly one mode",
+		},
+		{
+			name: "amm single asset deposit with amount2",
+			tx: &XrpAMMDepositTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_SINGLE_ASSET; return c }(),
+				Asset:       tokenAmount.Asset(),
+				Amount:      &tokenAmount,
+				Amount2:     func() *XrpAmount { a := NewXrpAmount("2000000"); return &a }(),
+			},
+			wantErr: "Amount2 is not allowed",
+		},
+		{
+			name: "amm deposit of another asset",
+			tx: &XrpAMMDepositTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_SINGLE_ASSET; return c }(),
+				Asset:       tokenAmount.Asset(),
+				Amount:      func() *XrpAmount { a := NewIssuedAmount("USD", metaIssuer, "1"); return &a }(),
+			},
+			wantErr: "pool assets",
+		},
+		{
+			name: "amm withdraw all",
+			tx: &XrpAMMWithdrawTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_WITHDRAW_ALL; return c }(),
+				Asset:       tokenAmount.Asset(),
+			},
+		},
+		{
+			name: "amm lp token withdraw without lp tokens",
+			tx: &XrpAMMWithdrawTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_LP_TOKEN; return c }(),
+				Asset:       tokenAmount.Asset(),
+			},
+			wantErr: "LPTokenIn is required",
+		},
+		{
+			name:    "amm vote on the same assets",
+			tx:      &XrpAMMVoteTx{XrpTxCommon: buildCommon(metaHolder), Asset: tokenAmount.Asset(), Asset2: tokenAmount.Asset(), TradingFee: 100},
+			wantErr: "must be different",
+		},
+		{
+			name:    "check create",
+			tx:      &XrpCheckCreateTx{XrpTxCommon: buildCommon(metaHolder), Destination: metaIssuer, SendMax: tokenAmount},
+			wantErr: "",
+		},
+		{
+			name:    "check create to itself",
+			tx:      &XrpCheckCreateTx{XrpTxCommon: buildCommon(metaHolder), Destination: metaHolder, SendMax: tokenAmount},
+			wantErr: "Destination can not be the Account",
+		},
+		{
+			name:    "check create with zero expiration",
+			tx:      &XrpCheckCreateTx{XrpTxCommon: buildCommon(metaHolder), Destination: metaIssuer, SendMax: tokenAmount, Expiration: uint32Ptr(0)},
+			wantErr: "Expiration must be greater than zero",
+		},
+		{
+			name:    "check create with invalid invoice id",
+			tx:      &XrpCheckCreateTx{XrpTxCommon: buildCommon(metaHolder), Destination: metaIssuer, SendMax: tokenAmount, InvoiceID: "ABC"},
+			wantErr: "InvoiceID must be a 256 bits hex hash",
+		},
+		{
+			name:    "check cancel with invalid check id",
+			tx:      &XrpCheckCancelTx{XrpTxCommon: buildCommon(metaHolder), CheckID: "49647F0D748DC3FE"},
+			wantErr: "CheckID must be a 256 bits hex hash",
+		},
+		{
+			name:    "payment channel create of a token",
+			tx:      &XrpPaymentChannelCreateTx{XrpTxCommon: buildCommon(metaHolder), Amount: tokenAmount, Destination: metaIssuer, SettleDelay: 86400, PublicKey: builderPubKey},
+			wantErr: "Amount must be XRP",
+		},
+		{
+			name:    "payment channel create without public key",
+			tx:      &XrpPaymentChannelCreateTx{XrpTxCommon: buildCommon(metaHolder), Amount: NewXrpAmount("250000000"), Destination: metaIssuer, SettleDelay: 86400},
+			wantErr: "PublicKey",
+		},
+		{
+			name:    "payment channel fund with invalid channel",
+			tx:      &XrpPaymentChannelFundTx{XrpTxCommon: buildCommon(metaHolder), Channel: "E357", Amount: NewXrpAmount("1000000")},
+			wantErr: "Channel must be a 256 bits hex hash",
+		},
+		{
+			name: "payment channel claim renewing and closing",
+			tx: &XrpPaymentChannelClaimTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_RENEW | TF_CLOSE; return c }(),
+				Channel:     "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366",
+			},
+			wantErr: "renew and close flags can not be both set",
+		},
+		{
+			name: "payment channel claim signature without balance",
+			tx: &XrpPaymentChannelClaimTx{
+				XrpTxCommon: buildCommon(metaHolder),
+				Channel:     "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366",
+				Signature:   "3044",
+				PublicKey:   builderPubKey,
+			},
+			wantErr: "Balance is required with a Signature",
+		},
+		{
+			name: "payment channel claim balance over the amount",
+			tx: &XrpPaymentChannelClaimTx{
+				XrpTxCommon: buildCommon(metaHolder),
+				Channel:     "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366",
+				Balance:     &XrpAmount{Value: "2000000"},
+				Amount:      &XrpAmount{Value: "1000000"},
+			},
+			wantErr: "Balance can not be greater than the claimed Amount",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.tx.Validate()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBuildersPayloadRoundTrip(t *testing.T) {
+	domain := "braza.com"
+
+	tests := []struct {
+		name     string
+		tx       XrpTxBuilder
+		expected map[string]any
+	}{
+		{
+			name: "token payment",
+			tx:   &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder, DestinationTag: uint32Ptr(7), Amount: NewIssuedAmount("BRZA", metaIssuer, "10.5")},
+			expected: map[string]any{
+				"TransactionType": "Payment",
+				"Destination":     metaHolder,
+				"DestinationTag":  7,
+				"Amount":          map[string]any{"currency": ParseStringToHex("BRZA"), "issuer": metaIssuer, "value": "10.5"},
+			},
+		},
+		{
+			name: "xrp payment",
+			tx:   &XrpPaymentTx{XrpTxCommon: buildCommon(metaIssuer), Destination: metaHolder, Amount: NewXrpAmount("1000000")},
+			expected: map[string]any{
+				"TransactionType": "Payment",
+				"Amount":          "1000000",
+			},
+		},
+		{
+			name: "trust set",
+			tx:   &XrpTrustSetTx{XrpTxCommon: buildCommon(metaHolder), LimitAmount: NewIssuedAmount("USD", metaIssuer, "1000"), QualityIn: uint32Ptr(1)},
+			expected: map[string]any{
+				"TransactionType": "TrustSet",
+				"LimitAmount":     map[string]any{"currency": "USD", "issuer": metaIssuer, "value": "1000"},
+				"QualityIn":       1,
+			},
+		},
+		{
+			name: "account set",
+			tx:   &XrpAccountSetTx{XrpTxCommon: buildCommon(metaIssuer), SetFlag: uint32Ptr(ASF_ALLOW_TRUSTLINE_CLAWBACK), Domain: &domain},
+			expected: map[string]any{
+				"TransactionType": "AccountSet",
+				"SetFlag":         16,
+				"Domain":          strings.ToUpper(ConvertStringToHex(domain)),
+			},
+		},
+		{
+			name: "clawback",
+			tx:   &XrpClawbackTx{XrpTxCommon: buildCommon(metaIssuer), Amount: NewIssuedAmount("BRZA", metaHolder, "3")},
+			expected: map[string]any{
+				"TransactionType": "Clawback",
+				"Amount":          map[string]any{"currency": ParseStringToHex("BRZA"), "issuer": metaHolder, "value": "3"},
+			},
+		},
+		{
+			name: "offer create",
+			tx: &XrpOfferCreateTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_SELL; return c }(),
+				TakerGets:   NewIssuedAmount("BRZA", metaIssuer, "100"),
+				TakerPays:   NewXrpAmount("19000000"),
+				Expiration:  uint32Ptr(800000000),
+			},
+			expected: map[string]any{
+				"TransactionType": "OfferCreate",
+				"Flags":           int(TF_SELL),
+				"TakerGets":       map[string]any{"currency": ParseStringToHex("BRZA"), "issuer": metaIssuer, "value": "100"},
+				"TakerPays":       "19000000",
+				"Expiration":      800000000,
+			},
+		},
+		{
+			name: "offer cancel",
+			tx:   &XrpOfferCancelTx{XrpTxCommon: buildCommon(metaHolder), OfferSequence: 4},
+			expected: map[string]any{
+				"TransactionType": "OfferCancel",
+				"OfferSequence":   4,
+			},
+		},
+		{
+			name: "amm create",
+			tx: &XrpAMMCreateTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Fee = "200000"; return c }(),
+				Amount:      NewIssuedAmount("BRZA", metaIssuer, "100"),
+				Amount2:     NewXrpAmount("19000000"),
+				TradingFee:  500,
+				MinFee:      "200000",
+			},
+			expected: map[string]any{
+				"TransactionType": "AMMCreate",
+				"Fee":             "200000",
+				"Amount":          map[string]any{"currency": ParseStringToHex("BRZA"), "issuer": metaIssuer, "value": "100"},
+				"Amount2":         "19000000",
+				"TradingFee":      500,
+			},
+		},
+		{
+			name: "amm one asset lp token deposit",
+			tx: &XrpAMMDepositTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_ONE_ASSET_LP_TOKEN; return c }(),
+				Asset:       XrpAsset{Currency: ParseStringToHex("BRZA"), Issuer: metaIssuer},
+				Asset2:      XrpAsset{},
+				Amount:      func() *XrpAmount { a := NewXrpAmount("5000000"); return &a }(),
+				LPTokenOut:  &XrpAmount{Currency: "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", Issuer: metaIssuer, Value: "10"},
+			},
+			expected: map[string]any{
+				"TransactionType": "AMMDeposit",
+				"Asset":           map[string]any{"currency": ParseStringToHex("BRZA"), "issuer": metaIssuer},
+				"Asset2":          map[string]any{"currency": "XRP"},
+				"Amount":          "5000000",
+				"LPTokenOut":      map[string]any{"currency": "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", "issuer": metaIssuer, "value": "10"},
+			},
+		},
+		{
+			name: "amm withdraw all",
+			tx: &XrpAMMWithdrawTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_WITHDRAW_ALL; return c }(),
+				Asset:       XrpAsset{Currency: ParseStringToHex("BRZA"), Issuer: metaIssuer},
+				Asset2:      XrpAsset{},
+			},
+			expected: map[string]any{
+				"TransactionType": "AMMWithdraw",
+				"Flags":           int(TF_WITHDRAW_ALL),
+			},
+		},
+		{
+			name: "amm vote",
+			tx:   &XrpAMMVoteTx{XrpTxCommon: buildCommon(metaHolder), Asset: XrpAsset{Currency: ParseStringToHex("BRZA"), Issuer: metaIssuer}, TradingFee: 250},
+			expected: map[string]any{
+				"TransactionType": "AMMVote",
+				"TradingFee":      250,
+			},
+		},
+		{
+			name: "check create",
+			tx: &XrpCheckCreateTx{
+				XrpTxCommon:    buildCommon(metaHolder),
+				Destination:    metaIssuer,
+				DestinationTag: uint32Ptr(12345),
+				SendMax:        NewIssuedAmount("BRZA", metaIssuer, "2.75"),
+				Expiration:     uint32Ptr(786240000),
+			},
+			expected: map[string]any{
+				"TransactionType": "CheckCreate",
+				"Destination":     metaIssuer,
+				"DestinationTag":  12345,
+				"Expiration":      786240000,
+			},
+		},
+		{
+			name: "check cancel",
+			tx:   &XrpCheckCancelTx{XrpTxCommon: buildCommon(metaHolder), CheckID: "49647f0d748dc3fe26bdacbc57f251aadefff391403ec9bf87c97f67e9977fb0"},
+			expected: map[string]any{
+				"TransactionType": "CheckCancel",
+				"CheckID":         "49647F0D748DC3FE26BDACBC57F251AADEFFF391403EC9BF87C97F67E9977FB0",
+			},
+		},
+		{
+			name: "payment channel create",
+			tx: &XrpPaymentChannelCreateTx{
+				XrpTxCommon: buildCommon(metaHolder),
+				Amount:      NewXrpAmount("250000000"),
+				Destination: metaIssuer,
+				SettleDelay: 86400,
+				PublicKey:   builderPubKey,
+				CancelAfter: uint32Ptr(786240000),
+			},
+			expected: map[string]any{
+				"TransactionType": "PaymentChannelCreate",
+				"Amount":          "250000000",
+				"Destination":     metaIssuer,
+				"SettleDelay":     86400,
+				"PublicKey":       builderPubKey,
+				"CancelAfter":     786240000,
+			},
+		},
+		{
+			name: "payment channel fund",
+			tx: &XrpPaymentChannelFundTx{
+				XrpTxCommon: buildCommon(metaHolder),
+				Channel:     "e35708503b3c3143fb522d749aafcc296e8060f0fb371a9a56fae0b1ed127366",
+				Amount:      NewXrpAmount("1000000"),
+				Expiration:  uint32Ptr(786240000),
+			},
+			expected: map[string]any{
+				"TransactionType": "PaymentChannelFund",
+				"Channel":         "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366",
+				"Amount":          "1000000",
+				"Expiration":      786240000,
+			},
+		},
+		{
+			name: "payment channel claim by the destination",
+			tx: &XrpPaymentChannelClaimTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaHolder); c.Flags = TF_CLOSE; return c }(),
+				Channel:     "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3",
+				Balance:     &XrpAmount{Value: "1000000"},
+				Amount:      &XrpAmount{Value: "1000000"},
+				Signature:   "304402204EF0AFB78AC23ED1C472E74F4299C0C21F1B21D07EFC0A3838A420F76D783A400220154FB11B6F54320666E4C36CA7F686C16A3A0456800BBC43746F34AF50290064",
+				PublicKey:   "023693F15967AE357D0327974AD46FE3C127113B1110D6044FD41E723689F81CC6",
+			},
+			expected: map[string]any{
+				"TransactionType": "PaymentChannelClaim",
+				"Flags":           int(TF_CLOSE),
+				"Balance":         "1000000",
+				"Signature":       "304402204EF0AFB78AC23ED1C472E74F4299C0C21F1B21D07EFC0A3838A420F76D783A400220154FB11B6F54320666E4C36CA7F686C16A3A0456800BBC43746F34AF50290064",
+				"PublicKey":       "023693F15967AE357D0327974AD46FE3C127113B1110D6044FD41E723689F81CC6",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.tx.Validate())
+
+			blob, err := binarycodec.Encode(tc.tx.Payload())
+			require.NoError(t, err)
+
+			decoded, err := binarycodec.Decode(blob)
+			require.NoError(t, err)
+
+			// every payload field survives the encoding
+			require.Len(t, decoded, len(tc.tx.Payload()))
+			for field, value := range tc.expected {
+				require.Equal(t, value, decoded[field], field)
+			}
+
+			require.Equal(t, 5, decoded["Sequence"])
+			require.Equal(t, 120, decoded["LastLedgerSequence"])
+			if _, ok := tc.expected["Fee"]; !ok {
+				require.Equal(t, "12", decoded["Fee"])
+			}
+			require.Equal(t, builderPubKey, decoded["SigningPubKey"])
+			require.Equal(t, "66f79f17ba6b56108cb3e81d", binarycodec.DecodeMemos(decoded["Memos"])["operation_id"])
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package ripple
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	addresscodec "crypto-braza-tokens-api/clients/ripple/utils/address-codec"
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+	"crypto-braza-tokens-api/clients/ripple/utils/signature"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+// LEDGER_SPACE_PAYCHAN is the namespace of the ledger object IDs of payment channels, the 'x' character
+const LEDGER_SPACE_PAYCHAN = 0x0078
+
+var ErrPaymentChannelNotFound = errors.New("payment channel not found")
+
+// ChannelID returns the ledger object ID of the payment channel opened by the account to the destination with the
+// transaction of the sequence, so the channel is known before its PaymentChannelCreate is validated
+func ChannelID(account, destination string, sequence uint32) (string, error) {
+	_, accountId, err := addresscodec.DecodeClassicAddressToAccountID(account)
+	if err != nil {
+		return "", err
+	}
+
+	_, destinationId, err := addresscodec.DecodeClassicAddressToAccountID(destination)
+	if err != nil {
+		return "", err
+	}
+
+	data := make([]byte, 0, 2+len(accountId)+len(destinationId)+4)
+	data = binary.BigEndian.AppendUint16(data, LEDGER_SPACE_PAYCHAN)
+	data = append(data, accountId...)
+	data = append(data, destinationId...)
+	data = binary.BigEndian.AppendUint32(data, sequence)
+
+	hash := sha512.Sum512(data)
+	return strings.ToUpper(hex.EncodeToString(hash[:32])), nil
+}
+
+// ClaimSigningContent returns the content signed by the channel key to authorize the claim of the cumulative drops of
+// the channel: the full prefixed claim message for ed25519 keys and its SHA-512Half hash for secp256k1 keys
+func ClaimSigningContent(channelId, drops, publicKey string) (string, error) {
+	algorithm, err := signature.DetectAlgorithm(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	message, err := binarycodec.EncodeForSigningClaim(map[string]any{"Channel": channelId, "Amount": drops})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the claim of channel %s: %v", channelId, err)
+	}
+
+	if algorithm == signature.ALGORITHM_SECP256K1 {
+		return Sha512Half(HASH_SIZE, message)
+	}
+
+	return message, nil
+}
+
+// VerifyClaim checks the signature of the claim of the cumulative drops of the channel against the channel public key,
+// a DER-encoded signature for secp256k1 keys and the raw 64 bytes signature for ed25519 keys
+func VerifyClaim(channelId, drops, publicKey, claimSignature string) error {
+	content, err := ClaimSigningContent(channelId, drops, publicKey)
+	if err != nil {
+		return err
+	}
+
+	algorithm, _ := signature.DetectAlgorithm(publicKey)
+	if algorithm == signature.ALGORITHM_ED25519 {
+		return signature.VerifyEd25519(publicKey, content, claimSignature)
+	}
+
+	return signature.VerifySecp256k1DER(publicKey, content, claimSignature)
+}
+
+// BuildPaymentChannelRequest builds a ledger_entry request of the payment channel on the validated ledger
+func (r *RippleNodeClient) BuildPaymentChannelRequest(channelId string) *XrpJsonRpcRequest {
+	return &XrpJsonRpcRequest{
+		Method: "ledger_entry",
+		Params: []any{
+			map[string]any{
+				"payment_channel": channelId,
+				"ledger_index":    "validated",
+			},
+		},
+	}
+}
+
+// GetPaymentChannel retrieves the payment channel, returning ErrPaymentChannelNotFound when it was never created or
+// is already closed
+func (r *RippleNodeClient) GetPaymentChannel(ctx context.Context, channelId string) (*XrpPayChannel, error) {
+	request := r.BuildPaymentChannelRequest(channelId)
+	result := &XrpPayChannelResponse{}
+
+	err := r.call(ctx, request, &result)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to retreive payment channel", zap.String("channel", channelId), zap.Error(err))
+		return nil, fmt.Errorf("failed to retreive payment channel %s with error: %v", channelId, err)
+	}
+
+	if result.Result == nil {
+		return nil, fmt.Errorf("ledger_entry response without result for payment channel %s", channelId)
+	}
+
+	// rippled answers entryNotFound when there is no such channel on the ledger
+	if result.Result.Error == "entryNotFound" {
+		return nil, ErrPaymentChannelNotFound
+	}
+
+	if result.Result.Error != "" {
+		l.Logger.Error("ripple client: ledger_entry request failed", zap.String("channel", channelId), zap.String("error", result.Result.Error))
+		return nil, fmt.Errorf("failed to retreive payment channel %s with error: %s", channelId, result.Result.Error)
+	}
+
+	if result.Result.Node == nil {
+		return nil, ErrPaymentChannelNotFound
+	}
+
+	return result.Result.Node, nil
+}
@@ -0,0 +1,132 @@
+package ripple
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// claim of the channel_verify example of the rippled docs
+const (
+	docsChannelId      = "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3"
+	docsClaimPublicKey = "023693F15967AE357D0327974AD46FE3C127113B1110D6044FD41E723689F81CC6"
+	docsClaimSignature = "304402204EF0AFB78AC23ED1C472E74F4299C0C21F1B21D07EFC0A3838A420F76D783A400220154FB11B6F54320666E4C36CA7F686C16A3A0456800BBC43746F34AF50290064"
+)
+
+func TestChannelID(t *testing.T) {
+	tests := []struct {
+		name        string
+		account     string
+		destination string
+		sequence    uint32
+		expected    string
+		wantErr     bool
+	}{
+		{
+			name:        "channel of the xrpl.js hashes fixture",
+			account:     "rDx69ebzbowuqztksVDmZXjizTd12BVr4x",
+			destination: "rLFtVprxUEfsH54eCWKsZrEQzMDsx1wqso",
+			sequence:    82,
+			expected:    "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366",
+		},
+		{name: "invalid destination", account: "rDx69ebzbowuqztksVDmZXjizTd12BVr4x", destination: "rInvalid", sequence: 82, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			channelId, err := ChannelID(tc.account, tc.destination, tc.sequence)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, channelId)
+		})
+	}
+}
+
+func TestVerifyClaim(t *testing.T) {
+	HASH_SIZE = 64
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPublicKey := "ED" + strings.ToUpper(hex.EncodeToString(publicKey))
+
+	// ed25519 keys sign the full prefixed claim message
+	content, err := ClaimSigningContent(docsChannelId, "2500000", edPublicKey)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(content, "434C4D00"+docsChannelId))
+
+	message, err := hex.DecodeString(content)
+	require.NoError(t, err)
+	edSignature := strings.ToUpper(hex.EncodeToString(ed25519.Sign(privateKey, message)))
+
+	tests := []struct {
+		name      string
+		channelId string
+		drops     string
+		publicKey string
+		signature string
+		wantErr   bool
+	}{
+		{name: "secp256k1 claim of the docs", channelId: docsChannelId, drops: "1000000", publicKey: docsClaimPublicKey, signature: docsClaimSignature},
+		{name: "secp256k1 claim of another amount", channelId: docsChannelId, drops: "1000001", publicKey: docsClaimPublicKey, signature: docsClaimSignature, wantErr: true},
+		{name: "ed25519 claim", channelId: docsChannelId, drops: "2500000", publicKey: edPublicKey, signature: edSignature},
+		{name: "ed25519 claim of another channel", channelId: "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366", drops: "2500000", publicKey: edPublicKey, signature: edSignature, wantErr: true},
+		{name: "signature of another key", channelId: docsChannelId, drops: "1000000", publicKey: edPublicKey, signature: docsClaimSignature, wantErr: true},
+		{name: "malformed signature", channelId: docsChannelId, drops: "1000000", publicKey: docsClaimPublicKey, signature: "3044", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifyClaim(tc.channelId, tc.drops, tc.publicKey, tc.signature)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGetPaymentChannel(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"ledger_entry": result(map[string]any{
+			"index": docsChannelId,
+			"node": map[string]any{
+				"Account":         metaHolder,
+				"Destination":     metaIssuer,
+				"Amount":          "250000000",
+				"Balance":         "12500000",
+				"PublicKey":       docsClaimPublicKey,
+				"SettleDelay":     86400,
+				"Flags":           0,
+				"LedgerEntryType": "PayChannel",
+				"index":           docsChannelId,
+			},
+			"validated": true,
+		}),
+	})
+
+	channel, err := newTestNodeClient(node).GetPaymentChannel(context.Background(), docsChannelId)
+	require.NoError(t, err)
+	require.Equal(t, metaIssuer, channel.Destination)
+	require.Equal(t, "250000000", channel.Amount)
+	require.Equal(t, "12500000", channel.Balance)
+	require.Equal(t, 86400, channel.SettleDelay)
+	require.Zero(t, channel.Expiration)
+}
+
+func TestGetPaymentChannelNotFound(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"ledger_entry": result(map[string]any{"error": "entryNotFound", "status": "error"}),
+	})
+
+	_, err := newTestNodeClient(node).GetPaymentChannel(context.Background(), docsChannelId)
+	require.ErrorIs(t, err, ErrPaymentChannelNotFound)
+}
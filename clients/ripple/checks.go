@@ -0,0 +1,30 @@
+package ripple
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	addresscodec "crypto-braza-tokens-api/clients/ripple/utils/address-codec"
+)
+
+// LEDGER_SPACE_CHECK is the namespace of the ledger object IDs of checks, the 'C' character
+const LEDGER_SPACE_CHECK = 0x0043
+
+// CheckID returns the ledger object ID of the check created by the account with the transaction of the sequence, so
+// the check is known before its CheckCreate is validated
+func CheckID(account string, sequence uint32) (string, error) {
+	_, accountId, err := addresscodec.DecodeClassicAddressToAccountID(account)
+	if err != nil {
+		return "", err
+	}
+
+	data := make([]byte, 0, 2+len(accountId)+4)
+	data = binary.BigEndian.AppendUint16(data, LEDGER_SPACE_CHECK)
+	data = append(data, accountId...)
+	data = binary.BigEndian.AppendUint32(data, sequence)
+
+	hash := sha512.Sum512(data)
+	return strings.ToUpper(hex.EncodeToString(hash[:32])), nil
+}
@@ -0,0 +1,37 @@
+package ripple
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckID(t *testing.T) {
+	tests := []struct {
+		name     string
+		account  string
+		sequence uint32
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "check of the ledger object docs",
+			account:  "rUn84CUYbNjRoTQ6mSW7BVJPSVJNLb1QLo",
+			sequence: 2,
+			expected: "49647F0D748DC3FE26BDACBC57F251AADEFFF391403EC9BF87C97F67E9977FB0",
+		},
+		{name: "invalid account", account: "rInvalid", sequence: 2, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			checkId, err := CheckID(tc.account, tc.sequence)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, checkId)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package ripple
+
+import (
+	"fmt"
+	"strings"
+
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+	d "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/definitions"
+)
+
+// DecodeTransactionBlob decodes a transaction blob into its JSON representation and computes its hashes.
+// The signing hash is the SHA-512Half of PREFIX_UNSIGNED plus the signing fields, which is the content signed on fireblocks.
+// The transaction ID is the SHA-512Half of PREFIX_SIGNED plus the full blob and is only set for signed transactions.
+func DecodeTransactionBlob(txBlob string) (*XrpDecodedTransaction, error) {
+	txBlob = strings.ToUpper(strings.TrimSpace(txBlob))
+
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tx blob: %v", err)
+	}
+
+	// the codec removes the non signing fields from the given map, so a fresh copy is decoded
+	signingTx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tx blob: %v", err)
+	}
+
+	encodedForSigning, err := binarycodec.EncodeForSigning(signingTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tx for signing: %v", err)
+	}
+
+	// EncodeForSigning already prepends the PREFIX_UNSIGNED value
+	signingHash, err := Sha512Half(HASH_SIZE, encodedForSigning)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &XrpDecodedTransaction{
+		TxBlob:      txBlob,
+		Transaction: tx,
+		SigningHash: signingHash,
+		IsSigned:    tx["TxnSignature"] != nil || tx["Signers"] != nil,
+		Currencies:  map[string]string{},
+		Addresses:   map[string]string{},
+		Memos:       binarycodec.DecodeMemos(tx["Memos"]),
+	}
+
+	if result.IsSigned {
+		result.TransactionId, err = Sha512Half(HASH_SIZE, ConcactPrefixWithTxBlob(PREFIX_SIGNED, txBlob))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for field, value := range tx {
+		switch v := value.(type) {
+		case string:
+			if typeName, _ := d.Get().GetTypeNameByFieldName(field); typeName == "AccountID" {
+				result.Addresses[field] = v
+			}
+		case map[string]any:
+			currency, ok := v["currency"].(string)
+			if !ok {
+				continue
+			}
+
+			result.Currencies[field] = DecodeCurrencyCode(currency)
+			if issuer, ok := v["issuer"].(string); ok {
+				result.Addresses[field+".issuer"] = issuer
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// DecodeCurrencyCode returns the readable representation of a currency code.
+// Standard 3 characters codes are returned as they are, 160-bit hex codes are decoded into text without the zero padding.
+func DecodeCurrencyCode(currency string) string {
+	if len(currency) != 40 {
+		return currency
+	}
+
+	text, err := ConvertHexToString(currency)
+	if err != nil {
+		return currency
+	}
+
+	text = strings.TrimRight(text, "\x00")
+
+	// codes that are not printable text (e.g. LP tokens) are kept in their hex form
+	for _, c := range text {
+		if c < 0x20 || c > 0x7e {
+			return currency
+		}
+	}
+
+	return text
+}
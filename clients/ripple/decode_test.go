@@ -0,0 +1,80 @@
+package ripple
+
+import (
+	"testing"
+
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTransactionBlob(t *testing.T) {
+	HASH_SIZE = 64
+	PREFIX_SIGNED = "54584E00"
+	PREFIX_UNSIGNED = "53545800"
+
+	buildTx := func() map[string]any {
+		return map[string]any{
+			"TransactionType": "Payment",
+			"Account":         "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd",
+			"Destination":     "rwSLnXVuEPNkMw4sR7p1EsMTivsEo1LbsT",
+			"Amount": map[string]any{
+				"currency": ParseStringToHex("BRZA"),
+				"issuer":   "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd",
+				"value":    "2.75",
+			},
+			"Fee":           "12",
+			"Flags":         0,
+			"Sequence":      7,
+			"SigningPubKey": "02A8A44DB3D4C73EEEE11DFE54D2029103B776AA8A8D293A91D645977C9DF5F544",
+			"Memos":         []any{binarycodec.NewMemo("operation_type", "MINT")},
+		}
+	}
+
+	unsignedBlob, err := binarycodec.Encode(buildTx())
+	require.NoError(t, err)
+
+	// the signing hash must match the content submitted to fireblocks by the operation service
+	expectedSigningHash, err := Sha512Half(HASH_SIZE, ConcactPrefixWithTxBlob(PREFIX_UNSIGNED, unsignedBlob))
+	require.NoError(t, err)
+
+	signed := buildTx()
+	signed["TxnSignature"] = "3045022100D184EB4AE5956FF600E7536EE459345C7BBCF097A84CC61A93B9AF7197EDB98702201CEA8009B7BEEBAA2AACC0359B41C427C1C5B550A4CA4B80CF2174AF2D6D5DCE"
+	signedBlob, err := binarycodec.Encode(signed)
+	require.NoError(t, err)
+
+	expectedTxId, err := Sha512Half(HASH_SIZE, ConcactPrefixWithTxBlob(PREFIX_SIGNED, signedBlob))
+	require.NoError(t, err)
+
+	t.Run("decodes an unsigned blob", func(t *testing.T) {
+		got, err := DecodeTransactionBlob(unsignedBlob)
+		require.NoError(t, err)
+		require.False(t, got.IsSigned)
+		require.Empty(t, got.TransactionId)
+		require.Equal(t, expectedSigningHash, got.SigningHash)
+		require.Equal(t, "Payment", got.Transaction["TransactionType"])
+		require.Equal(t, "BRZA", got.Currencies["Amount"])
+		require.Equal(t, "rwSLnXVuEPNkMw4sR7p1EsMTivsEo1LbsT", got.Addresses["Destination"])
+		require.Equal(t, "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd", got.Addresses["Amount.issuer"])
+		require.Equal(t, map[string]string{"operation_type": "MINT"}, got.Memos)
+	})
+
+	t.Run("decodes a signed blob", func(t *testing.T) {
+		got, err := DecodeTransactionBlob(signedBlob)
+		require.NoError(t, err)
+		require.True(t, got.IsSigned)
+		require.Equal(t, expectedSigningHash, got.SigningHash)
+		require.Equal(t, expectedTxId, got.TransactionId)
+	})
+
+	t.Run("fails with an invalid blob", func(t *testing.T) {
+		_, err := DecodeTransactionBlob("ZZ")
+		require.Error(t, err)
+	})
+}
+
+func TestDecodeCurrencyCode(t *testing.T) {
+	require.Equal(t, "USD", DecodeCurrencyCode("USD"))
+	require.Equal(t, "BRZA", DecodeCurrencyCode(ParseStringToHex("BRZA")))
+	require.Equal(t, "03A0B1C2D3E4F5A6B7C8D9E0F1A2B3C4D5E6F7A8", DecodeCurrencyCode("03A0B1C2D3E4F5A6B7C8D9E0F1A2B3C4D5E6F7A8"))
+}
@@ -0,0 +1,88 @@
+package ripple
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func (r *RippleNodeClient) BuildFeeRequest() *XrpJsonRpcRequest {
+	return &XrpJsonRpcRequest{
+		Method: "fee",
+		Params: []any{
+			map[string]any{},
+		},
+	}
+}
+
+// GetFee retrieves the current transaction cost from the ripple node
+func (r *RippleNodeClient) GetFee(ctx context.Context) (*XrpFeeResponse, error) {
+	request := r.BuildFeeRequest()
+	result := &XrpFeeResponse{}
+
+	err := r.call(ctx, request, &result)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to retreive fee", zap.Error(err))
+		return nil, fmt.Errorf("failed to retreive fee with error: %v", err)
+	}
+
+	if result.Result == nil || result.Result.Drops == nil {
+		l.Logger.Error("ripple client: fee response without drops", zap.Any("response", result))
+		return nil, fmt.Errorf("fee response without drops")
+	}
+
+	return result, nil
+}
+
+// CalculateFee computes the fee in drops to be used on a transaction.
+// The open ledger fee (never lower than the base fee) is multiplied by the configured multiplier and capped by the configured max fee.
+// For multisigned transactions the result is scaled by (1 + signersCount) as required by the XRPL.
+func (r *RippleNodeClient) CalculateFee(feeResult *XrpFeeResult, signersCount int) (*XrpFeeCalculation, error) {
+	if feeResult == nil || feeResult.Drops == nil {
+		return nil, fmt.Errorf("fee result without drops")
+	}
+
+	baseFee, err := decimal.NewFromString(feeResult.Drops.BaseFee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base fee %s with error: %v", feeResult.Drops.BaseFee, err)
+	}
+
+	openLedgerFee, err := decimal.NewFromString(feeResult.Drops.OpenLedgerFee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse open ledger fee %s with error: %v", feeResult.Drops.OpenLedgerFee, err)
+	}
+
+	if signersCount < 0 {
+		return nil, fmt.Errorf("invalid signers count: %d", signersCount)
+	}
+
+	fee := decimal.Max(baseFee, openLedgerFee).Mul(r.feeMultiplier).Ceil()
+
+	capped := false
+	if fee.GreaterThan(r.maxFee) {
+		fee = r.maxFee
+		capped = true
+	}
+
+	// the cap must never push the fee below the network reference cost
+	if fee.LessThan(baseFee) {
+		fee = baseFee
+	}
+
+	if signersCount > 0 {
+		fee = fee.Mul(decimal.NewFromInt(int64(1 + signersCount)))
+	}
+
+	return &XrpFeeCalculation{
+		Fee:           fee.String(),
+		BaseFee:       baseFee.String(),
+		OpenLedgerFee: openLedgerFee.String(),
+		Multiplier:    r.feeMultiplier.String(),
+		MaxFee:        r.maxFee.String(),
+		SignersCount:  signersCount,
+		Capped:        capped,
+	}, nil
+}
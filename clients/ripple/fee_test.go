@@ -0,0 +1,67 @@
+package ripple
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFee(t *testing.T) {
+	client := &RippleNodeClient{
+		feeMultiplier: decimal.RequireFromString("1.5"),
+		maxFee:        decimal.NewFromInt(1000),
+	}
+
+	tests := []struct {
+		name         string
+		drops        *XrpFeeDrops
+		signersCount int
+		expectedFee  string
+		capped       bool
+		expErr       bool
+	}{
+		{
+			name:        "uses the base fee when the open ledger fee is lower",
+			drops:       &XrpFeeDrops{BaseFee: "10", OpenLedgerFee: "8"},
+			expectedFee: "15",
+		},
+		{
+			name:        "applies the multiplier over the open ledger fee",
+			drops:       &XrpFeeDrops{BaseFee: "10", OpenLedgerFee: "25"},
+			expectedFee: "38",
+		},
+		{
+			name:        "caps the fee with the configured max fee",
+			drops:       &XrpFeeDrops{BaseFee: "10", OpenLedgerFee: "5000"},
+			expectedFee: "1000",
+			capped:      true,
+		},
+		{
+			name:         "scales the fee by the number of signers",
+			drops:        &XrpFeeDrops{BaseFee: "10", OpenLedgerFee: "10"},
+			signersCount: 2,
+			expectedFee:  "45",
+		},
+		{
+			name:   "fails with an invalid open ledger fee",
+			drops:  &XrpFeeDrops{BaseFee: "10", OpenLedgerFee: "abc"},
+			expErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := client.CalculateFee(&XrpFeeResult{Drops: tc.drops}, tc.signersCount)
+
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFee, got.Fee)
+			require.Equal(t, tc.capped, got.Capped)
+		})
+	}
+}
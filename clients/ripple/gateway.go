@@ -0,0 +1,52 @@
+package ripple
+
+import (
+	"context"
+	"fmt"
+
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+// BuildGatewayBalancesRequest builds a gateway_balances request of the tokens issued by the account on the last validated
+// ledger, the balances of the hot wallets being listed apart and left out of the obligations
+func (r *RippleNodeClient) BuildGatewayBalancesRequest(account string, hotWallets []string) *XrpJsonRpcRequest {
+	params := map[string]any{
+		"account":      account,
+		"ledger_index": "validated",
+		"strict":       true,
+	}
+
+	if len(hotWallets) > 0 {
+		params["hotwallet"] = hotWallets
+	}
+
+	return &XrpJsonRpcRequest{
+		Method: "gateway_balances",
+		Params: []any{params},
+	}
+}
+
+// GetGatewayBalances retrieves the obligations of the issuer and the balances held by its hot wallets
+func (r *RippleNodeClient) GetGatewayBalances(ctx context.Context, account string, hotWallets []string) (*XrpGatewayBalancesResult, error) {
+	request := r.BuildGatewayBalancesRequest(account, hotWallets)
+	result := &XrpGatewayBalancesResponse{}
+
+	err := r.call(ctx, request, &result)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to retreive gateway balances", zap.String("account", account), zap.Error(err))
+		return nil, fmt.Errorf("failed to retreive gateway balances of account %s with error: %v", account, err)
+	}
+
+	if result.Result == nil {
+		return nil, fmt.Errorf("gateway_balances response without result for account %s", account)
+	}
+
+	if result.Result.Error != "" {
+		l.Logger.Error("ripple client: gateway_balances request failed", zap.String("account", account), zap.String("error", result.Result.Error))
+		return nil, fmt.Errorf("failed to retreive gateway balances of account %s with error: %s", account, result.Result.Error)
+	}
+
+	return result.Result, nil
+}
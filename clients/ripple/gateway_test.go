@@ -0,0 +1,49 @@
+package ripple
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGatewayBalances(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"gateway_balances": result(map[string]any{
+			"account":      metaIssuer,
+			"ledger_index": 90000123,
+			"ledger_hash":  "7D9B8F37A2A37DCBEA08F4A1E94A53A4F5C4E3B0BDF0E8A2C1F32E9A0E3A1B8C",
+			"obligations":  map[string]any{"BRZ": "1000"},
+			"balances": map[string]any{
+				metaHolder: []any{map[string]any{"currency": "BRZ", "value": "500"}},
+			},
+			"validated": true,
+		}),
+	})
+
+	gateway, err := newTestNodeClient(node).GetGatewayBalances(context.Background(), metaIssuer, []string{metaHolder})
+	require.NoError(t, err)
+	require.Equal(t, 90000123, gateway.LedgerIndex)
+	require.Equal(t, "1000", gateway.Obligations["BRZ"])
+	require.Equal(t, []XrpGatewayCurrency{{Currency: "BRZ", Value: "500"}}, gateway.Balances[metaHolder])
+}
+
+func TestBuildGatewayBalancesRequest(t *testing.T) {
+	client := &RippleNodeClient{}
+
+	params := client.BuildGatewayBalancesRequest(metaIssuer, nil).Params[0].(map[string]any)
+	require.Equal(t, "validated", params["ledger_index"])
+	require.NotContains(t, params, "hotwallet")
+
+	params = client.BuildGatewayBalancesRequest(metaIssuer, []string{metaHolder}).Params[0].(map[string]any)
+	require.Equal(t, []string{metaHolder}, params["hotwallet"])
+}
+
+func TestGetGatewayBalancesError(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"gateway_balances": result(map[string]any{"error": "actNotFound", "status": "error"}),
+	})
+
+	_, err := newTestNodeClient(node).GetGatewayBalances(context.Background(), metaIssuer, nil)
+	require.ErrorContains(t, err, "actNotFound")
+}
@@ -0,0 +1,242 @@
+package ripple
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	NODE_TYPE_CREATED  = "CreatedNode"
+	NODE_TYPE_MODIFIED = "ModifiedNode"
+	NODE_TYPE_DELETED  = "DeletedNode"
+
+	CURRENCY_XRP = "XRP"
+)
+
+// DecodeTransactionMetadata decodes the metadata of a validated transaction.
+// The metadata can be the hex meta_blob of binary responses or the meta object of JSON responses.
+func DecodeTransactionMetadata(meta any) (*XrpTransactionMetadata, error) {
+	var fields map[string]any
+
+	switch m := meta.(type) {
+	case string:
+		decoded, err := binarycodec.Decode(strings.ToUpper(strings.TrimSpace(m)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode meta blob: %v", err)
+		}
+		fields = decoded
+	case map[string]any:
+		fields = m
+	default:
+		return nil, fmt.Errorf("invalid transaction metadata of type %T", meta)
+	}
+
+	result := &XrpTransactionMetadata{
+		TransactionIndex: toInt(fields["TransactionIndex"]),
+		AffectedNodes:    []*XrpAffectedNode{},
+	}
+
+	result.TransactionResult, _ = fields["TransactionResult"].(string)
+
+	// JSON responses add the delivered_amount of every payment, the binary one only has it for partial payments
+	if delivered, ok := fields["delivered_amount"]; ok && delivered != "unavailable" {
+		result.DeliveredAmount = delivered
+	} else if delivered, ok := fields["DeliveredAmount"]; ok {
+		result.DeliveredAmount = delivered
+	}
+
+	nodes, _ := fields["AffectedNodes"].([]any)
+	for _, n := range nodes {
+		wrapper, ok := n.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid affected node %v", n)
+		}
+
+		for nodeType, v := range wrapper {
+			node, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid affected node %v", n)
+			}
+
+			affectedNode := &XrpAffectedNode{
+				NodeType:       nodeType,
+				NewFields:      toMap(node["NewFields"]),
+				FinalFields:    toMap(node["FinalFields"]),
+				PreviousFields: toMap(node["PreviousFields"]),
+			}
+			affectedNode.LedgerEntryType, _ = node["LedgerEntryType"].(string)
+			affectedNode.LedgerIndex, _ = node["LedgerIndex"].(string)
+
+			result.AffectedNodes = append(result.AffectedNodes, affectedNode)
+		}
+	}
+
+	return result, nil
+}
+
+// BalanceChanges computes the balance deltas per account and currency from the AccountRoot and RippleState nodes.
+// XRP changes are given in XRP and include the fee paid by the sender. A trust line change is reported
+// for both sides of the line, the issuer of each change being the counterparty of the line.
+func (m *XrpTransactionMetadata) BalanceChanges() ([]*XrpBalanceChange, error) {
+	totals := map[[3]string]decimal.Decimal{}
+
+	add := func(account, currency, issuer string, value decimal.Decimal) {
+		key := [3]string{account, currency, issuer}
+		totals[key] = totals[key].Add(value)
+	}
+
+	for _, node := range m.AffectedNodes {
+		fields := node.FinalFields
+		if node.NodeType == NODE_TYPE_CREATED {
+			fields = node.NewFields
+		}
+
+		delta, ok, err := node.balanceDelta()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		switch node.LedgerEntryType {
+		case "AccountRoot":
+			account, _ := fields["Account"].(string)
+			add(account, CURRENCY_XRP, "", delta.Shift(-6))
+		case "RippleState":
+			lowLimit, highLimit := toMap(fields["LowLimit"]), toMap(fields["HighLimit"])
+			low, _ := lowLimit["issuer"].(string)
+			high, _ := highLimit["issuer"].(string)
+			balance := toMap(fields["Balance"])
+			currency, _ := balance["currency"].(string)
+			currency = DecodeCurrencyCode(currency)
+
+			// a positive balance is owed by the high account to the low account
+			add(low, currency, high, delta)
+			add(high, currency, low, delta.Neg())
+		}
+	}
+
+	changes := make([]*XrpBalanceChange, 0, len(totals))
+	for key, value := range totals {
+		if value.IsZero() {
+			continue
+		}
+		changes = append(changes, &XrpBalanceChange{
+			Account:  key[0],
+			Currency: key[1],
+			Issuer:   key[2],
+			Value:    value.String(),
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Account != changes[j].Account {
+			return changes[i].Account < changes[j].Account
+		}
+		if changes[i].Currency != changes[j].Currency {
+			return changes[i].Currency < changes[j].Currency
+		}
+		return changes[i].Issuer < changes[j].Issuer
+	})
+
+	return changes, nil
+}
+
+// balanceDelta returns the change of the Balance field of the node, in drops for AccountRoot nodes.
+// Nodes without a previous Balance did not have their balance changed.
+func (n *XrpAffectedNode) balanceDelta() (decimal.Decimal, bool, error) {
+	if n.NodeType == NODE_TYPE_CREATED {
+		if n.NewFields["Balance"] == nil {
+			return decimal.Zero, false, nil
+		}
+		value, err := balanceValue(n.NewFields["Balance"])
+		return value, err == nil, err
+	}
+
+	if n.PreviousFields["Balance"] == nil || n.FinalFields["Balance"] == nil {
+		return decimal.Zero, false, nil
+	}
+
+	final, err := balanceValue(n.FinalFields["Balance"])
+	if err != nil {
+		return decimal.Zero, false, err
+	}
+
+	previous, err := balanceValue(n.PreviousFields["Balance"])
+	if err != nil {
+		return decimal.Zero, false, err
+	}
+
+	return final.Sub(previous), true, nil
+}
+
+// balanceValue parses an amount, which is a string of drops for XRP or an object with the value for issued currencies.
+func balanceValue(amount any) (decimal.Decimal, error) {
+	switch a := amount.(type) {
+	case string:
+		return decimal.NewFromString(a)
+	case map[string]any:
+		value, _ := a["value"].(string)
+		return decimal.NewFromString(value)
+	}
+	return decimal.Zero, fmt.Errorf("invalid balance %v", amount)
+}
+
+// DeliveredAmount returns the amount received by the destination of a payment, as a value in the currency of the Amount.
+// The delivered amount of the metadata is used when present, otherwise it is taken from the destination balance changes.
+func DeliveredAmount(tx map[string]any, meta *XrpTransactionMetadata, changes []*XrpBalanceChange) string {
+	if meta != nil && meta.DeliveredAmount != nil {
+		if value, err := balanceValue(meta.DeliveredAmount); err == nil {
+			if _, isXrp := meta.DeliveredAmount.(string); isXrp {
+				return value.Shift(-6).String()
+			}
+			return value.String()
+		}
+	}
+
+	destination, _ := tx["Destination"].(string)
+
+	currency := CURRENCY_XRP
+	if amount, ok := tx["Amount"].(map[string]any); ok {
+		code, _ := amount["currency"].(string)
+		currency = DecodeCurrencyCode(code)
+	}
+
+	total := decimal.Zero
+	for _, change := range changes {
+		if change.Account != destination || change.Currency != currency {
+			continue
+		}
+		value, err := decimal.NewFromString(change.Value)
+		if err != nil {
+			continue
+		}
+		total = total.Add(value)
+	}
+
+	return total.String()
+}
+
+func toMap(v any) map[string]any {
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{}
+}
+
+// toInt reads the numbers of both decoded blobs (int) and JSON responses (float64)
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
@@ -0,0 +1,147 @@
+package ripple
+
+import (
+	"encoding/json"
+	"testing"
+
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	metaIssuer = "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"
+	metaHolder = "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"
+	metaNewAcc = "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+)
+
+// buildIssuedPaymentMeta is the metadata of a payment of 10 BRZA from the issuer (high account of the line) to the holder
+func buildIssuedPaymentMeta() map[string]any {
+	brza := ParseStringToHex("BRZA")
+	return map[string]any{
+		"TransactionIndex":  3,
+		"TransactionResult": "tesSUCCESS",
+		"AffectedNodes": []any{
+			map[string]any{"ModifiedNode": map[string]any{
+				"LedgerEntryType": "AccountRoot",
+				"LedgerIndex":     "13F1A95D7AAB7108D5CE7EEAF504B2894B8C674E6D68499076441C4837282BF8",
+				"FinalFields":     map[string]any{"Account": metaIssuer, "Balance": "99999988", "Flags": 0, "OwnerCount": 1, "Sequence": 6},
+				"PreviousFields":  map[string]any{"Balance": "100000000", "Sequence": 5},
+			}},
+			map[string]any{"ModifiedNode": map[string]any{
+				"LedgerEntryType": "RippleState",
+				"LedgerIndex":     "4E3B3F2B8B4B3E2F16D0C7C2D5A8C0E1B7E3F7A1C2D3E4F5A6B7C8D9E0F1A2B3",
+				"FinalFields": map[string]any{
+					"Balance":   map[string]any{"currency": brza, "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "110"},
+					"Flags":     131072,
+					"HighLimit": map[string]any{"currency": brza, "issuer": metaIssuer, "value": "0"},
+					"LowLimit":  map[string]any{"currency": brza, "issuer": metaHolder, "value": "1000"},
+				},
+				"PreviousFields": map[string]any{
+					"Balance": map[string]any{"currency": brza, "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "100"},
+				},
+			}},
+		},
+	}
+}
+
+// buildAccountCreationMeta is the metadata of a payment of 20 XRP funding a new account
+func buildAccountCreationMeta() map[string]any {
+	return map[string]any{
+		"TransactionIndex":  0,
+		"TransactionResult": "tesSUCCESS",
+		"AffectedNodes": []any{
+			map[string]any{"CreatedNode": map[string]any{
+				"LedgerEntryType": "AccountRoot",
+				"LedgerIndex":     "A0E4F3B5D2C1E6F7A8B9C0D1E2F3A4B5C6D7E8F9A0B1C2D3E4F5A6B7C8D9E0F1",
+				"NewFields":       map[string]any{"Account": metaNewAcc, "Balance": "20000000", "Sequence": 1},
+			}},
+			map[string]any{"ModifiedNode": map[string]any{
+				"LedgerEntryType": "AccountRoot",
+				"LedgerIndex":     "13F1A95D7AAB7108D5CE7EEAF504B2894B8C674E6D68499076441C4837282BF8",
+				"FinalFields":     map[string]any{"Account": metaIssuer, "Balance": "79999988", "Flags": 0, "OwnerCount": 0, "Sequence": 2},
+				"PreviousFields":  map[string]any{"Balance": "100000000", "Sequence": 1},
+			}},
+		},
+	}
+}
+
+// asJsonResponse converts the metadata as it is received from a JSON response, with float64 numbers
+func asJsonResponse(t *testing.T, meta map[string]any) map[string]any {
+	b, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(b, &result))
+	return result
+}
+
+func TestBalanceChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     func() map[string]any
+		expected []*XrpBalanceChange
+	}{
+		{
+			name: "issued currency payment",
+			meta: buildIssuedPaymentMeta,
+			expected: []*XrpBalanceChange{
+				{Account: metaHolder, Currency: "BRZA", Issuer: metaIssuer, Value: "10"},
+				{Account: metaIssuer, Currency: "BRZA", Issuer: metaHolder, Value: "-10"},
+				{Account: metaIssuer, Currency: CURRENCY_XRP, Value: "-0.000012"},
+			},
+		},
+		{
+			name: "account creation",
+			meta: buildAccountCreationMeta,
+			expected: []*XrpBalanceChange{
+				{Account: metaIssuer, Currency: CURRENCY_XRP, Value: "-20.000012"},
+				{Account: metaNewAcc, Currency: CURRENCY_XRP, Value: "20"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name+" from meta blob", func(t *testing.T) {
+			metaBlob, err := binarycodec.Encode(tc.meta())
+			require.NoError(t, err)
+
+			meta, err := DecodeTransactionMetadata(metaBlob)
+			require.NoError(t, err)
+			require.Equal(t, "tesSUCCESS", meta.TransactionResult)
+
+			changes, err := meta.BalanceChanges()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, changes)
+		})
+
+		t.Run(tc.name+" from meta object", func(t *testing.T) {
+			meta, err := DecodeTransactionMetadata(asJsonResponse(t, tc.meta()))
+			require.NoError(t, err)
+
+			changes, err := meta.BalanceChanges()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, changes)
+		})
+	}
+}
+
+func TestDeliveredAmount(t *testing.T) {
+	meta, err := DecodeTransactionMetadata(buildIssuedPaymentMeta())
+	require.NoError(t, err)
+
+	changes, err := meta.BalanceChanges()
+	require.NoError(t, err)
+
+	tx := map[string]any{
+		"TransactionType": "Payment",
+		"Destination":     metaHolder,
+		"Amount":          map[string]any{"currency": ParseStringToHex("BRZA"), "issuer": metaIssuer, "value": "12"},
+	}
+
+	// the requested amount is ignored, the amount comes from the destination balance
+	require.Equal(t, "10", DeliveredAmount(tx, meta, changes))
+
+	meta.DeliveredAmount = "9500000"
+	require.Equal(t, "9.5", DeliveredAmount(tx, meta, changes))
+}
@@ -0,0 +1,281 @@
+package ripple
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	l "crypto-braza-tokens-api/utils/logger"
+	"crypto-braza-tokens-api/utils/requests"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// the server_info of the nodes is refreshed in background when older than the interval
+	NODE_CHECK_INTERVAL = 30 * time.Second
+	NODE_CHECK_TIMEOUT  = 5 * time.Second
+
+	// a node is unhealthy after consecutive failures, until one of its requests succeeds again
+	NODE_MAX_ERRORS = 3
+
+	// a node whose validated ledger is older, in seconds, or behind the other nodes is out of sync
+	NODE_MAX_LEDGER_AGE = 20
+	NODE_MAX_LEDGER_LAG = 5
+)
+
+var (
+	ErrNoNodeAvailable = errors.New("no ripple node available")
+
+	// rippled errors that tell the node itself can not serve the request, which is then retried on another node
+	nodeErrors = map[string]bool{
+		"amendmentBlocked": true,
+		"failedToForward":  true,
+		"internal":         true,
+		"noClosed":         true,
+		"noCurrent":        true,
+		"noNetwork":        true,
+		"notReady":         true,
+		"notSynced":        true,
+		"slowDown":         true,
+		"tooBusy":          true,
+	}
+
+	// server states of a node in sync with the network
+	syncedStates = map[string]bool{"full": true, "proposing": true, "validating": true}
+)
+
+type rippleNode struct {
+	url             string
+	latency         time.Duration
+	errors          int
+	validatedLedger int
+	ledgerAge       int
+	serverState     string
+	checked         bool
+}
+
+// rippleNodePool keeps the health of the configured nodes, scored by their errors, latency and validated ledger
+type rippleNodePool struct {
+	mu        sync.Mutex
+	nodes     []*rippleNode
+	checkedAt time.Time
+	checking  bool
+}
+
+// parseNodeUrls reads the comma separated list of node urls, the first ones being preferred when equally healthy
+func parseNodeUrls(value string) []string {
+	urls := []string{}
+	seen := map[string]bool{}
+
+	for _, url := range strings.Split(value, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+		urls = append(urls, url)
+	}
+
+	return urls
+}
+
+func newRippleNodePool(urls []string) *rippleNodePool {
+	pool := &rippleNodePool{}
+	for _, url := range urls {
+		pool.nodes = append(pool.nodes, &rippleNode{url: url})
+	}
+	return pool
+}
+
+// ordered returns the nodes from the healthiest to the least healthy. Healthy nodes are ordered by their
+// consecutive errors and latency, keeping the configured order on ties.
+func (p *rippleNodePool) ordered() []*rippleNode {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	maxLedger := 0
+	for _, node := range p.nodes {
+		maxLedger = max(maxLedger, node.validatedLedger)
+	}
+
+	nodes := make([]*rippleNode, len(p.nodes))
+	copy(nodes, p.nodes)
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+
+		healthyA, healthyB := a.healthy(maxLedger), b.healthy(maxLedger)
+		if healthyA != healthyB {
+			return healthyA
+		}
+		if a.errors != b.errors {
+			return a.errors < b.errors
+		}
+		return a.latency < b.latency
+	})
+
+	return nodes
+}
+
+func (n *rippleNode) healthy(maxLedger int) bool {
+	if n.errors >= NODE_MAX_ERRORS {
+		return false
+	}
+
+	if !n.checked {
+		return true
+	}
+
+	return syncedStates[n.serverState] && n.ledgerAge <= NODE_MAX_LEDGER_AGE && maxLedger-n.validatedLedger <= NODE_MAX_LEDGER_LAG
+}
+
+// record updates the node with the result of a request, the latency being a moving average of the successful ones
+func (p *rippleNodePool) record(node *rippleNode, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err != nil {
+		node.errors++
+		return
+	}
+
+	node.errors = 0
+	if node.latency == 0 {
+		node.latency = latency
+	} else {
+		node.latency = (4*node.latency + latency) / 5
+	}
+}
+
+func (p *rippleNodePool) recordServerInfo(node *rippleNode, info *XrpServerInfo) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	node.checked = true
+	node.serverState = info.ServerState
+	node.validatedLedger = info.ValidatedLedger.Seq
+	node.ledgerAge = info.ValidatedLedger.Age
+}
+
+// stale tells if the nodes must be checked again, marking the check as started so only one runs at a time
+func (p *rippleNodePool) stale() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.checking || time.Since(p.checkedAt) < NODE_CHECK_INTERVAL {
+		return false
+	}
+
+	p.checking = true
+	return true
+}
+
+func (p *rippleNodePool) checked() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checking = false
+	p.checkedAt = time.Now()
+}
+
+// checkNodes retrieves the server_info of every node, updating their validated ledger and server state
+func (r *RippleNodeClient) checkNodes(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, node := range r.nodes.nodes {
+		wg.Add(1)
+		go func(node *rippleNode) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, NODE_CHECK_TIMEOUT)
+			defer cancel()
+
+			result := &XrpServerInfoResponse{}
+			if err := r.execute(checkCtx, node, r.BuildServerInfoRequest(), &result); err != nil {
+				l.Logger.Error("ripple client: node health check failed", zap.String("node", node.url), zap.Error(err))
+				return
+			}
+
+			if result.Result == nil || result.Result.Info == nil || result.Result.Info.ValidatedLedger == nil {
+				r.nodes.record(node, 0, fmt.Errorf("server info response without validated ledger"))
+				return
+			}
+
+			r.nodes.recordServerInfo(node, result.Result.Info)
+		}(node)
+	}
+
+	wg.Wait()
+	r.nodes.checked()
+}
+
+// call executes a read request on the healthiest node, failing over to the next nodes when a node is
+// unreachable or reports that it can not serve the request
+func (r *RippleNodeClient) call(ctx context.Context, request *XrpJsonRpcRequest, target any) error {
+	if r.nodes.stale() {
+		go r.checkNodes(context.Background())
+	}
+
+	var lastErr error = ErrNoNodeAvailable
+	for _, node := range r.nodes.ordered() {
+		err := r.execute(ctx, node, request, target)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return err
+		}
+
+		l.Logger.Error("ripple client: node request failed, trying next node", zap.String("node", node.url), zap.String("method", request.Method), zap.Error(err))
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// execute sends the request to the given node and records its latency and errors
+func (r *RippleNodeClient) execute(ctx context.Context, node *rippleNode, request *XrpJsonRpcRequest, target any) error {
+	parameters := map[string]any{"payload": request}
+	raw := json.RawMessage{}
+
+	start := time.Now()
+	err := requests.Execute(ctx, "POST", node.url, &raw, parameters)
+	latency := time.Since(start)
+
+	if err == nil {
+		err = nodeError(raw)
+	}
+
+	r.nodes.record(node, latency, err)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, target)
+}
+
+// nodeError returns the error of responses that failed because of the node instead of the request
+func nodeError(raw json.RawMessage) error {
+	probe := struct {
+		Result *struct {
+			Error string `json:"error"`
+		} `json:"result"`
+	}{}
+
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return fmt.Errorf("invalid node response: %v", err)
+	}
+
+	if probe.Result != nil && nodeErrors[probe.Result.Error] {
+		return fmt.Errorf("node unavailable with error: %s", probe.Result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package ripple
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeNode is a JSON-RPC rippled node answering each method with the given handler
+type fakeNode struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	calls    map[string]int
+	handlers map[string]func() (int, any)
+}
+
+func newFakeNode(t *testing.T, handlers map[string]func() (int, any)) *fakeNode {
+	node := &fakeNode{calls: map[string]int{}, handlers: handlers}
+
+	node.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		request := &XrpJsonRpcRequest{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(request))
+
+		node.mu.Lock()
+		node.calls[request.Method]++
+		node.mu.Unlock()
+
+		handler, ok := node.handlers[request.Method]
+		if !ok {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+
+		status, body := handler()
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(node.server.Close)
+
+	return node
+}
+
+func (n *fakeNode) count(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+func result(value map[string]any) func() (int, any) {
+	return func() (int, any) {
+		return http.StatusOK, map[string]any{"result": value}
+	}
+}
+
+func unavailable() (int, any) {
+	return http.StatusServiceUnavailable, map[string]any{"error": "unavailable"}
+}
+
+// newTestNodeClient builds a client of the fake nodes with the health checks already done, so none runs in background
+func newTestNodeClient(nodes ...*fakeNode) *RippleNodeClient {
+	l.Logger = zap.NewNop()
+	HASH_SIZE = 64
+	PREFIX_SIGNED = "54584E00"
+
+	urls := []string{}
+	for _, node := range nodes {
+		urls = append(urls, node.server.URL)
+	}
+
+	pool := newRippleNodePool(urls)
+	pool.checkedAt = time.Now()
+
+	return &RippleNodeClient{nodes: pool}
+}
+
+func TestParseNodeUrls(t *testing.T) {
+	require.Equal(t, []string{"https://a", "https://b"}, parseNodeUrls(" https://a, https://b,,https://a "))
+	require.Equal(t, []string{"https://a"}, parseNodeUrls("https://a"))
+	require.Empty(t, parseNodeUrls(" , "))
+}
+
+func TestNodesOrdering(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodes    []*rippleNode
+		expected []string
+	}{
+		{
+			name:     "unchecked nodes keep the configured order",
+			nodes:    []*rippleNode{{url: "a"}, {url: "b"}},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "failing node goes last",
+			nodes:    []*rippleNode{{url: "a", errors: NODE_MAX_ERRORS}, {url: "b", errors: 1}},
+			expected: []string{"b", "a"},
+		},
+		{
+			name:     "faster node first",
+			nodes:    []*rippleNode{{url: "a", latency: 300 * time.Millisecond}, {url: "b", latency: 100 * time.Millisecond}},
+			expected: []string{"b", "a"},
+		},
+		{
+			name: "node behind the network goes last",
+			nodes: []*rippleNode{
+				{url: "a", checked: true, serverState: "full", validatedLedger: 100, latency: time.Millisecond},
+				{url: "b", checked: true, serverState: "full", validatedLedger: 120, latency: time.Second},
+			},
+			expected: []string{"b", "a"},
+		},
+		{
+			name: "node with an old validated ledger or not synced goes last",
+			nodes: []*rippleNode{
+				{url: "a", checked: true, serverState: "full", validatedLedger: 120, ledgerAge: 60},
+				{url: "b", checked: true, serverState: "connected", validatedLedger: 120},
+				{url: "c", checked: true, serverState: "proposing", validatedLedger: 120, ledgerAge: 2},
+			},
+			expected: []string{"c", "a", "b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := &rippleNodePool{nodes: tc.nodes}
+
+			urls := []string{}
+			for _, node := range pool.ordered() {
+				urls = append(urls, node.url)
+			}
+			require.Equal(t, tc.expected, urls)
+		})
+	}
+}
+
+func TestCallFailover(t *testing.T) {
+	accountInfo := result(map[string]any{"account_data": map[string]any{"Account": metaHolder, "Sequence": 7}, "status": "success"})
+
+	tests := []struct {
+		name    string
+		primary func() (int, any)
+	}{
+		{name: "node unreachable", primary: unavailable},
+		{name: "node not synced", primary: result(map[string]any{"error": "noNetwork", "status": "error"})},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			primary := newFakeNode(t, map[string]func() (int, any){"account_info": tc.primary})
+			secondary := newFakeNode(t, map[string]func() (int, any){"account_info": accountInfo})
+			client := newTestNodeClient(primary, secondary)
+
+			info, err := client.GetAccountInfo(context.Background(), metaHolder)
+			require.NoError(t, err)
+			require.Equal(t, 7, info.Result.AccountData.Sequence)
+			require.Equal(t, 1, primary.count("account_info"))
+			require.Equal(t, 1, secondary.count("account_info"))
+
+			// only the failing node is penalized
+			require.Equal(t, 1, client.nodes.nodes[0].errors)
+			require.Equal(t, 0, client.nodes.nodes[1].errors)
+		})
+	}
+
+	t.Run("request error is not failed over", func(t *testing.T) {
+		primary := newFakeNode(t, map[string]func() (int, any){"account_info": result(map[string]any{"error": "actNotFound", "status": "error"})})
+		secondary := newFakeNode(t, map[string]func() (int, any){"account_info": accountInfo})
+		client := newTestNodeClient(primary, secondary)
+
+		_, err := client.GetAccountInfo(context.Background(), metaHolder)
+		require.NoError(t, err)
+		require.Equal(t, 0, secondary.count("account_info"))
+	})
+
+	t.Run("all nodes down", func(t *testing.T) {
+		client := newTestNodeClient(
+			newFakeNode(t, map[string]func() (int, any){"account_info": unavailable}),
+			newFakeNode(t, map[string]func() (int, any){"account_info": unavailable}),
+		)
+
+		_, err := client.GetAccountInfo(context.Background(), metaHolder)
+		require.Error(t, err)
+	})
+}
+
+func TestCheckNodes(t *testing.T) {
+	serverInfo := func(state string, seq int) func() (int, any) {
+		return result(map[string]any{
+			"info":   map[string]any{"server_state": state, "validated_ledger": map[string]any{"seq": seq, "age": 1}},
+			"status": "success",
+		})
+	}
+
+	behind := newFakeNode(t, map[string]func() (int, any){"server_info": serverInfo("full", 90)})
+	synced := newFakeNode(t, map[string]func() (int, any){"server_info": serverInfo("full", 100)})
+	client := newTestNodeClient(behind, synced)
+
+	client.checkNodes(context.Background())
+
+	ordered := client.nodes.ordered()
+	require.Equal(t, synced.server.URL, ordered[0].url)
+	require.Equal(t, 90, ordered[1].validatedLedger)
+}
+
+func TestSubmitSignedTransaction(t *testing.T) {
+	txBlob := "1200002280000000240000000561D4838D7EA4C6800000000000000000000000000055534400000000004B4E9C06F24296074F7BC48F92A97916C6DC5EA968400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD0208114B5F762798A53D543A014CAF8B297CFF8F2F937E883143E9D4A2B8AA0780F682D136F7A56D6724EF53754"
+	submitted := result(map[string]any{"engine_result": "tesSUCCESS", "accepted": true, "status": "success"})
+	notFound := result(map[string]any{"error": "txnNotFound", "status": "error"})
+
+	t.Run("submitted to the healthiest node only", func(t *testing.T) {
+		primary := newFakeNode(t, map[string]func() (int, any){"submit": submitted})
+		secondary := newFakeNode(t, map[string]func() (int, any){"submit": submitted})
+		client := newTestNodeClient(primary, secondary)
+
+		response, err := client.SubmitSignedTransaction(context.Background(), txBlob, client.BuildRawTransactionRequest(txBlob))
+		require.NoError(t, err)
+		require.Equal(t, "tesSUCCESS", response.Result.EngineResult)
+		require.Equal(t, 1, primary.count("submit"))
+		require.Equal(t, 0, secondary.count("submit"))
+	})
+
+	t.Run("not submitted again when relayed before the failure", func(t *testing.T) {
+		primary := newFakeNode(t, map[string]func() (int, any){"submit": unavailable, "tx": unavailable})
+		secondary := newFakeNode(t, map[string]func() (int, any){
+			"submit": submitted,
+			"tx":     result(map[string]any{"tx_blob": txBlob, "validated": false, "status": "success"}),
+		})
+		client := newTestNodeClient(primary, secondary)
+
+		response, err := client.SubmitSignedTransaction(context.Background(), txBlob, client.BuildRawTransactionRequest(txBlob))
+		require.NoError(t, err)
+		require.Equal(t, "tesSUCCESS", response.Result.EngineResult)
+		require.NotEmpty(t, response.Result.TxJSON.Hash)
+		require.Equal(t, 0, secondary.count("submit"))
+		require.Equal(t, 1, secondary.count("tx"))
+	})
+
+	t.Run("submitted to the next node when no node knows it", func(t *testing.T) {
+		primary := newFakeNode(t, map[string]func() (int, any){"submit": unavailable, "tx": notFound})
+		secondary := newFakeNode(t, map[string]func() (int, any){"submit": submitted, "tx": notFound})
+		client := newTestNodeClient(primary, secondary)
+
+		response, err := client.SubmitSignedTransaction(context.Background(), txBlob, client.BuildRawTransactionRequest(txBlob))
+		require.NoError(t, err)
+		require.Equal(t, "tesSUCCESS", response.Result.EngineResult)
+		require.Equal(t, 1, secondary.count("submit"))
+	})
+}
@@ -0,0 +1,65 @@
+package ripple
+
+import (
+	"context"
+	"fmt"
+
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+// ACCOUNT_OFFERS_PAGE_SIZE is the amount of offers read on each account_offers page
+const ACCOUNT_OFFERS_PAGE_SIZE = 200
+
+// BuildAccountOffersRequest builds an account_offers request of the open offers of the account on the current ledger,
+// so the offers just placed are listed before their ledger is validated
+func (r *RippleNodeClient) BuildAccountOffersRequest(account string, marker any) *XrpJsonRpcRequest {
+	params := map[string]any{
+		"account":      account,
+		"ledger_index": "current",
+		"limit":        ACCOUNT_OFFERS_PAGE_SIZE,
+	}
+
+	if marker != nil {
+		params["marker"] = marker
+	}
+
+	return &XrpJsonRpcRequest{
+		Method: "account_offers",
+		Params: []any{params},
+	}
+}
+
+// GetAccountOffers retrieves every open offer of the account, reading all the account_offers pages
+func (r *RippleNodeClient) GetAccountOffers(ctx context.Context, account string) ([]*XrpAccountOffer, error) {
+	offers := []*XrpAccountOffer{}
+
+	var marker any
+	for {
+		request := r.BuildAccountOffersRequest(account, marker)
+		result := &XrpAccountOffersResponse{}
+
+		err := r.call(ctx, request, &result)
+		if err != nil {
+			l.Logger.Error("ripple client: failed to retreive account offers", zap.String("account", account), zap.Error(err))
+			return nil, fmt.Errorf("failed to retreive offers of account %s with error: %v", account, err)
+		}
+
+		if result.Result == nil {
+			return nil, fmt.Errorf("account_offers response without result for account %s", account)
+		}
+
+		if result.Result.Error != "" {
+			l.Logger.Error("ripple client: account_offers request failed", zap.String("account", account), zap.String("error", result.Result.Error))
+			return nil, fmt.Errorf("failed to retreive offers of account %s with error: %s", account, result.Result.Error)
+		}
+
+		offers = append(offers, result.Result.Offers...)
+
+		if result.Result.Marker == nil {
+			return offers, nil
+		}
+		marker = result.Result.Marker
+	}
+}
@@ -0,0 +1,54 @@
+package ripple
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountOffersPages(t *testing.T) {
+	page := 0
+	node := newFakeNode(t, map[string]func() (int, any){
+		"account_offers": func() (int, any) {
+			page++
+			if page == 1 {
+				return result(map[string]any{
+					"account": metaHolder,
+					"offers": []any{
+						map[string]any{"flags": 0, "seq": 4, "taker_gets": "19000000", "taker_pays": map[string]any{"currency": "BRZ", "issuer": metaIssuer, "value": "100"}, "quality": "0.0000052"},
+					},
+					"marker": "F0B9A528CE25FE77C51C38040A7FEC016C2C841E74C1418D5A0F2F2A4E5E59A8,0",
+				})()
+			}
+			return result(map[string]any{
+				"account": metaHolder,
+				"offers": []any{
+					map[string]any{"flags": int(TF_SELL), "seq": 7, "taker_gets": map[string]any{"currency": "BRZ", "issuer": metaIssuer, "value": "50"}, "taker_pays": "9500000", "quality": "190000", "expiration": 800000000},
+				},
+			})()
+		},
+	})
+
+	offers, err := newTestNodeClient(node).GetAccountOffers(context.Background(), metaHolder)
+	require.NoError(t, err)
+	require.Equal(t, 2, node.count("account_offers"))
+	require.Len(t, offers, 2)
+
+	require.Equal(t, 4, offers[0].Seq)
+	require.Equal(t, 7, offers[1].Seq)
+	require.Equal(t, 800000000, offers[1].Expiration)
+
+	takerGets, err := ParseXrpAmount(offers[1].TakerGets)
+	require.NoError(t, err)
+	require.Equal(t, NewIssuedAmount("BRZ", metaIssuer, "50"), takerGets)
+}
+
+func TestGetAccountOffersError(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"account_offers": result(map[string]any{"error": "actNotFound", "status": "error"}),
+	})
+
+	_, err := newTestNodeClient(node).GetAccountOffers(context.Background(), metaHolder)
+	require.ErrorContains(t, err, "actNotFound")
+}
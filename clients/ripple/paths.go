@@ -0,0 +1,94 @@
+package ripple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+var ErrNoPathFound = errors.New("no payment path found")
+
+// BuildPathFindRequest builds the ripple_path_find request of the paths from the source account, spending the
+// given source currency, to deliver the destination amount. An empty source currency is XRP.
+func (r *RippleNodeClient) BuildPathFindRequest(sourceAccount, destinationAccount string, destinationAmount XrpAmount, sourceCurrency, sourceIssuer string) *XrpJsonRpcRequest {
+	currency := map[string]any{"currency": CURRENCY_XRP}
+	if sourceCurrency != "" {
+		currency = map[string]any{"currency": sourceCurrency, "issuer": sourceIssuer}
+	}
+
+	return &XrpJsonRpcRequest{
+		Method: "ripple_path_find",
+		Params: []any{
+			map[string]any{
+				"source_account":      sourceAccount,
+				"destination_account": destinationAccount,
+				"destination_amount":  destinationAmount.payload(),
+				"source_currencies":   []any{currency},
+				"ledger_index":        "current",
+			},
+		},
+	}
+}
+
+// FindPaths retrieves the alternatives to deliver the destination amount spending the source currency, each one with
+// the paths to be set on the payment and the source amount it costs on the current ledger
+func (r *RippleNodeClient) FindPaths(ctx context.Context, sourceAccount, destinationAccount string, destinationAmount XrpAmount, sourceCurrency, sourceIssuer string) (*XrpPathFindResult, error) {
+	request := r.BuildPathFindRequest(sourceAccount, destinationAccount, destinationAmount, sourceCurrency, sourceIssuer)
+	result := &XrpPathFindResponse{}
+
+	err := r.call(ctx, request, &result)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to find paths", zap.String("source", sourceAccount), zap.String("destination", destinationAccount), zap.Error(err))
+		return nil, fmt.Errorf("failed to find paths from %s to %s with error: %v", sourceAccount, destinationAccount, err)
+	}
+
+	if result.Result == nil || result.Result.Error != "" {
+		l.Logger.Error("ripple client: path find response with error", zap.Any("response", result))
+		return nil, fmt.Errorf("failed to find paths from %s to %s: %s", sourceAccount, destinationAccount, result.Result.errorMessage())
+	}
+
+	if len(result.Result.Alternatives) == 0 {
+		return nil, ErrNoPathFound
+	}
+
+	return result.Result, nil
+}
+
+// BestAlternative returns the alternative with the lowest source amount
+func (p *XrpPathFindResult) BestAlternative() (*XrpPathAlternative, XrpAmount, error) {
+	var best *XrpPathAlternative
+	var bestAmount XrpAmount
+
+	for _, alternative := range p.Alternatives {
+		amount, err := ParseXrpAmount(alternative.SourceAmount)
+		if err != nil {
+			return nil, XrpAmount{}, err
+		}
+
+		if best == nil || amount.Decimal().LessThan(bestAmount.Decimal()) {
+			best, bestAmount = alternative, amount
+		}
+	}
+
+	if best == nil {
+		return nil, XrpAmount{}, ErrNoPathFound
+	}
+
+	return best, bestAmount, nil
+}
+
+func (p *XrpPathFindResult) errorMessage() string {
+	if p == nil {
+		return "empty response"
+	}
+
+	if p.ErrorMessage != "" {
+		return p.ErrorMessage
+	}
+
+	return p.Error
+}
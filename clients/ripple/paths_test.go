@@ -0,0 +1,152 @@
+package ripple
+
+import (
+	"context"
+	"testing"
+
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPaths(t *testing.T) {
+	usdb := ParseStringToHex("USDB")
+	bbrl := ParseStringToHex("BBRL")
+
+	node := newFakeNode(t, map[string]func() (int, any){
+		"ripple_path_find": result(map[string]any{
+			"alternatives": []any{
+				map[string]any{
+					"paths_computed": []any{[]any{map[string]any{"currency": usdb, "issuer": metaIssuer, "type": 48, "type_hex": "0000000000000030"}}},
+					"source_amount":  map[string]any{"currency": bbrl, "issuer": metaIssuer, "value": "520.1"},
+				},
+				map[string]any{
+					"paths_computed": []any{
+						[]any{map[string]any{"currency": "XRP", "type": 16, "type_hex": "0000000000000010"}, map[string]any{"currency": usdb, "issuer": metaIssuer, "type": 48, "type_hex": "0000000000000030"}},
+						[]any{map[string]any{"account": metaHolder, "type": 1, "type_hex": "0000000000000001"}},
+					},
+					"source_amount": map[string]any{"currency": bbrl, "issuer": metaIssuer, "value": "512.35"},
+				},
+			},
+			"destination_account": metaHolder,
+			"status":              "success",
+		}),
+	})
+	client := newTestNodeClient(node)
+
+	paths, err := client.FindPaths(context.Background(), metaIssuer, metaHolder, NewIssuedAmount("USDB", metaIssuer, "100"), bbrl, metaIssuer)
+	require.NoError(t, err)
+	require.Len(t, paths.Alternatives, 2)
+
+	alternative, sourceAmount, err := paths.BestAlternative()
+	require.NoError(t, err)
+	require.Equal(t, "512.35", sourceAmount.Value)
+	require.Len(t, alternative.PathsComputed, 2)
+	require.Equal(t, XrpPathStep{Currency: "XRP"}, alternative.PathsComputed[0][0])
+
+	// the found paths are set on the payment spending up to the send max
+	payment := &XrpPaymentTx{
+		XrpTxCommon: buildCommon(metaIssuer),
+		Destination: metaHolder,
+		Amount:      NewIssuedAmount("USDB", metaIssuer, "100"),
+		SendMax:     &XrpAmount{Currency: bbrl, Issuer: metaIssuer, Value: "517.4735"},
+		Paths:       alternative.PathsComputed,
+	}
+	require.NoError(t, payment.Validate())
+
+	blob, err := binarycodec.Encode(payment.Payload())
+	require.NoError(t, err)
+
+	decoded, err := binarycodec.Decode(blob)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"currency": bbrl, "issuer": metaIssuer, "value": "517.4735"}, decoded["SendMax"])
+
+	decodedPaths := decoded["Paths"].([]any)
+	require.Len(t, decodedPaths, 2)
+	require.Equal(t, "XRP", decodedPaths[0].([]any)[0].(map[string]any)["currency"])
+	require.Equal(t, metaIssuer, decodedPaths[0].([]any)[1].(map[string]any)["issuer"])
+	require.Equal(t, metaHolder, decodedPaths[1].([]any)[0].(map[string]any)["account"])
+}
+
+func TestFindPathsWithoutPath(t *testing.T) {
+	node := newFakeNode(t, map[string]func() (int, any){
+		"ripple_path_find": result(map[string]any{"alternatives": []any{}, "status": "success"}),
+	})
+	client := newTestNodeClient(node)
+
+	_, err := client.FindPaths(context.Background(), metaIssuer, metaHolder, NewIssuedAmount("USDB", metaIssuer, "100"), "", "")
+	require.ErrorIs(t, err, ErrNoPathFound)
+}
+
+func TestPaymentPathsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tx      *XrpPaymentTx
+		wantErr string
+	}{
+		{
+			name: "xrp to xrp payment with paths",
+			tx: &XrpPaymentTx{
+				XrpTxCommon: buildCommon(metaIssuer),
+				Destination: metaHolder,
+				Amount:      NewXrpAmount("100"),
+				Paths:       [][]XrpPathStep{{{Account: metaHolder}}},
+			},
+			wantErr: "Paths can not be set",
+		},
+		{
+			name: "empty path step",
+			tx: &XrpPaymentTx{
+				XrpTxCommon: buildCommon(metaIssuer),
+				Destination: metaHolder,
+				Amount:      NewIssuedAmount("USDB", metaIssuer, "100"),
+				SendMax:     &XrpAmount{Value: "1000000"},
+				Paths:       [][]XrpPathStep{{{}}},
+			},
+			wantErr: "step can not be empty",
+		},
+		{
+			name: "partial payment delivering at least the deliver min",
+			tx: &XrpPaymentTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaIssuer); c.Flags = TF_PARTIAL_PAYMENT; return c }(),
+				Destination: metaHolder,
+				Amount:      NewIssuedAmount("USDB", metaIssuer, "100"),
+				SendMax:     &XrpAmount{Value: "1000000"},
+				DeliverMin:  &XrpAmount{Currency: ParseStringToHex("USDB"), Issuer: metaIssuer, Value: "95"},
+			},
+		},
+		{
+			name: "deliver min greater than the amount",
+			tx: &XrpPaymentTx{
+				XrpTxCommon: func() XrpTxCommon { c := buildCommon(metaIssuer); c.Flags = TF_PARTIAL_PAYMENT; return c }(),
+				Destination: metaHolder,
+				Amount:      NewIssuedAmount("USDB", metaIssuer, "100"),
+				SendMax:     &XrpAmount{Value: "1000000"},
+				DeliverMin:  &XrpAmount{Currency: ParseStringToHex("USDB"), Issuer: metaIssuer, Value: "101"},
+			},
+			wantErr: "greater than the Amount",
+		},
+		{
+			name: "deliver min without partial payment flag",
+			tx: &XrpPaymentTx{
+				XrpTxCommon: buildCommon(metaIssuer),
+				Destination: metaHolder,
+				Amount:      NewIssuedAmount("USDB", metaIssuer, "100"),
+				SendMax:     &XrpAmount{Value: "1000000"},
+				DeliverMin:  &XrpAmount{Currency: ParseStringToHex("USDB"), Issuer: metaIssuer, Value: "95"},
+			},
+			wantErr: "partial payment flag",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.tx.Validate()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
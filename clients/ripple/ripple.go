@@ -4,10 +4,11 @@ import (
 	"context"
 	kvs "crypto-braza-tokens-api/utils/keys-values"
 	l "crypto-braza-tokens-api/utils/logger"
-	"crypto-braza-tokens-api/utils/requests"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -20,19 +21,29 @@ var (
 )
 
 type RippleNodeClient struct {
-	nodeApiUrl           string
+	nodes                *rippleNodePool
 	xrpScanApiUrl        string
 	xrpScanExplorerUrl   string
 	xrpLedgerExplorerUrl string
+	feeMultiplier        decimal.Decimal
+	maxFee               decimal.Decimal
+	network              string
 }
 
 func NewRippleNodeClient() (*RippleNodeClient, error) {
-	nodeApiUrl, err := kvs.Get("XRP_NODE_API_URL")
+	// a comma separated list of nodes, the requests being routed to the healthiest one
+	nodeApiUrls, err := kvs.Get("XRP_NODE_API_URL")
 	if err != nil {
 		l.Logger.Error("ripple client: error getting XRP_NODE_API_URL from KVS", zap.Error(err))
 		return nil, err
 	}
 
+	nodeUrls := parseNodeUrls(nodeApiUrls)
+	if len(nodeUrls) == 0 {
+		l.Logger.Error("ripple client: no node url set on XRP_NODE_API_URL")
+		return nil, ErrNoNodeAvailable
+	}
+
 	xrpScanApiUrl, err := kvs.Get("XRP_SCAN_API_URL")
 	if err != nil {
 		l.Logger.Error("ripple client: error getting XRP_SCAN_API_URL from KVS", zap.Error(err))
@@ -93,7 +104,42 @@ func NewRippleNodeClient() (*RippleNodeClient, error) {
 		return nil, err
 	}
 
-	return &RippleNodeClient{nodeApiUrl, xrpScanApiUrl, xrpScanExplorerUrl, xrpLedgerExplorerUrl}, nil
+	feeMultiplierStr, err := kvs.Get("XRP_FEE_MULTIPLIER")
+	if err != nil {
+		l.Logger.Error("ripple client: error getting XRP_FEE_MULTIPLIER from KVS", zap.Error(err))
+		return nil, err
+	}
+
+	feeMultiplier, err := decimal.NewFromString(feeMultiplierStr)
+	if err != nil {
+		l.Logger.Error("ripple client: error converting fee multiplier to decimal", zap.Error(err))
+		return nil, fmt.Errorf("failed to convert fee multiplier to decimal with error: %v", err)
+	}
+
+	maxFeeStr, err := kvs.Get("XRP_MAX_FEE")
+	if err != nil {
+		l.Logger.Error("ripple client: error getting XRP_MAX_FEE from KVS", zap.Error(err))
+		return nil, err
+	}
+
+	maxFee, err := decimal.NewFromString(maxFeeStr)
+	if err != nil {
+		l.Logger.Error("ripple client: error converting max fee to decimal", zap.Error(err))
+		return nil, fmt.Errorf("failed to convert max fee to decimal with error: %v", err)
+	}
+
+	network, err := kvs.Get("XRP_NETWORK")
+	if err != nil {
+		l.Logger.Error("ripple client: error getting XRP_NETWORK from KVS", zap.Error(err))
+		return nil, err
+	}
+
+	return &RippleNodeClient{newRippleNodePool(nodeUrls), xrpScanApiUrl, xrpScanExplorerUrl, xrpLedgerExplorerUrl, feeMultiplier, maxFee, network}, nil
+}
+
+// IsTestnet tells whether the client is connected to a test network, any network other than the MAINNET
+func (r *RippleNodeClient) IsTestnet() bool {
+	return !strings.EqualFold(r.network, "MAINNET")
 }
 
 func (r *RippleNodeClient) GetAccountInfo(ctx context.Context, address string) (*XrpAccountInfo, error) {
@@ -108,11 +154,9 @@ func (r *RippleNodeClient) GetAccountInfo(ctx context.Context, address string) (
 		},
 	}
 
-	parameters := map[string]any{"payload": request}
-
 	result := &XrpAccountInfo{}
 
-	err := requests.Execute(ctx, "POST", r.nodeApiUrl, &result, parameters)
+	err := r.call(ctx, request, &result)
 	if err != nil {
 		l.Logger.Error("ripple client: failed to retreive account info for address", zap.String("address", address), zap.Error(err))
 		return nil, fmt.Errorf("failed to retreive account info for address: %s with error: %v", address, err)
@@ -139,17 +183,80 @@ func (r *RippleNodeClient) BuildAccountLinesRequest(address string) *XrpJsonRpcR
 	}
 }
 
+// SubmitSignedTransaction submits the blob to a single node, the healthiest one. When that node fails, the blob is
+// only submitted to the next node if no node knows the transaction yet, so a blob relayed before the failure is not
+// submitted twice and its result is taken from the node that has it.
 func (r *RippleNodeClient) SubmitSignedTransaction(ctx context.Context, txBlob string, request *XrpJsonRpcRequest) (*SubmitTxResultResponse, error) {
-	parameters := map[string]any{"payload": request}
-	result := &SubmitTxResultResponse{}
-
-	err := requests.Execute(ctx, "POST", r.nodeApiUrl, &result, parameters)
+	hash, err := Sha512Half(HASH_SIZE, ConcactPrefixWithTxBlob(PREFIX_SIGNED, strings.ToUpper(txBlob)))
 	if err != nil {
-		l.Logger.Error("ripple client: failed to submit tx_blob", zap.String("tx_blob", txBlob), zap.Error(err))
-		return nil, fmt.Errorf("failed to submit tx_blob \n%s \non ripple node with error: %v", txBlob, err)
+		l.Logger.Error("ripple client: failed to compute the tx_blob hash", zap.Error(err))
+		return nil, err
 	}
 
-	return result, nil
+	var lastErr error = ErrNoNodeAvailable
+	for attempt, node := range r.nodes.ordered() {
+		if attempt > 0 {
+			if submitted := r.findSubmittedTransaction(ctx, hash, txBlob); submitted != nil {
+				return submitted, nil
+			}
+		}
+
+		result := &SubmitTxResultResponse{}
+		err := r.execute(ctx, node, request, &result)
+		if err == nil {
+			return result, nil
+		}
+
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+
+		l.Logger.Error("ripple client: failed to submit tx_blob to node", zap.String("node", node.url), zap.String("hash", hash), zap.Error(err))
+	}
+
+	l.Logger.Error("ripple client: failed to submit tx_blob", zap.String("tx_blob", txBlob), zap.Error(lastErr))
+	return nil, fmt.Errorf("failed to submit tx_blob \n%s \non ripple node with error: %v", txBlob, lastErr)
+}
+
+// findSubmittedTransaction looks for the transaction on the nodes, returning a submission result built from the
+// first node that knows it. The result of a validated transaction is the one of its metadata.
+func (r *RippleNodeClient) findSubmittedTransaction(ctx context.Context, hash, txBlob string) *SubmitTxResultResponse {
+	request := r.BuildTransactionRequest(hash)
+
+	for _, node := range r.nodes.ordered() {
+		result := &XrpTxResponse{}
+		if err := r.execute(ctx, node, request, &result); err != nil || result.Result == nil || result.Result.Error != "" {
+			continue
+		}
+
+		engineResult := "tesSUCCESS"
+		if result.Result.Validated {
+			metaBlob := result.Result.MetaBlob
+			if metaBlob == "" {
+				metaBlob, _ = result.Result.Meta.(string)
+			}
+			if meta, err := DecodeTransactionMetadata(metaBlob); err == nil {
+				engineResult = meta.TransactionResult
+			}
+		}
+
+		l.Logger.Info("ripple client: transaction already known by node, not submitted again", zap.String("node", node.url), zap.String("hash", hash))
+
+		return &SubmitTxResultResponse{
+			Result: &SubmitTxResult{
+				Accepted:            true,
+				Broadcast:           true,
+				EngineResult:        engineResult,
+				EngineResultMessage: fmt.Sprintf("transaction already known by node %s", node.url),
+				Status:              "success",
+				TxBlob:              txBlob,
+				TxJSON:              SubmitTxResultTxJSON{Hash: hash},
+			},
+		}
+	}
+
+	return nil
 }
 
 func (r *RippleNodeClient) GetTransactionLink(txHash string) string {
@@ -158,10 +265,9 @@ func (r *RippleNodeClient) GetTransactionLink(txHash string) string {
 
 func (r *RippleNodeClient) GetAccountLines(ctx context.Context, address string) (*AccountLinesResponse, error) {
 	request := r.BuildAccountLinesRequest(address)
-	parameters := map[string]any{"payload": request}
 	result := &AccountLinesResponse{}
 
-	err := requests.Execute(ctx, "POST", r.nodeApiUrl, &result, parameters)
+	err := r.call(ctx, request, &result)
 	if err != nil {
 		l.Logger.Error("ripple client: failed to retreive account lines for address", zap.String("address", address), zap.Error(err))
 		return nil, fmt.Errorf("failed to retreive account lines for address: %s with error: %v", address, err)
@@ -169,3 +275,30 @@ func (r *RippleNodeClient) GetAccountLines(ctx context.Context, address string)
 
 	return result, nil
 }
+
+func (r *RippleNodeClient) BuildServerInfoRequest() *XrpJsonRpcRequest {
+	return &XrpJsonRpcRequest{
+		Method: "server_info",
+		Params: []any{
+			map[string]any{},
+		},
+	}
+}
+
+func (r *RippleNodeClient) GetServerInfo(ctx context.Context) (*XrpServerInfoResponse, error) {
+	request := r.BuildServerInfoRequest()
+	result := &XrpServerInfoResponse{}
+
+	err := r.call(ctx, request, &result)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to retreive server info", zap.Error(err))
+		return nil, fmt.Errorf("failed to retreive server info with error: %v", err)
+	}
+
+	if result.Result == nil || result.Result.Info == nil || result.Result.Info.ValidatedLedger == nil {
+		l.Logger.Error("ripple client: server info response without validated ledger", zap.Any("response", result))
+		return nil, fmt.Errorf("server info response without validated ledger")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,503 @@
+package ripple
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	addresscodec "crypto-braza-tokens-api/clients/ripple/utils/address-codec"
+	kvs "crypto-braza-tokens-api/utils/keys-values"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	STREAM_LEDGER = "ledger"
+
+	// keepalive of the websocket connection, the connection is dropped when no message or pong arrives in two intervals
+	STREAM_PING_INTERVAL = 30 * time.Second
+	STREAM_WRITE_TIMEOUT = 10 * time.Second
+
+	// delay before reconnecting, doubled on each failed attempt up to the max delay
+	STREAM_RECONNECT_DELAY     = time.Second
+	STREAM_MAX_RECONNECT_DELAY = time.Minute
+
+	STREAM_BUFFER_SIZE = 100
+)
+
+var (
+	ErrStreamDisconnected = errors.New("ripple stream: not connected to the node")
+	ErrStreamClosed       = errors.New("ripple stream: client closed")
+)
+
+// RippleStreamClient subscribes to the ledger and accounts streams of a rippled node through its websocket api.
+// The connection is reestablished when lost and the subscriptions are sent again, the events received meanwhile
+// being missed, so consumers must fill the gaps from their own checkpoints (e.g. with account_tx).
+type RippleStreamClient struct {
+	url            string
+	reconnectDelay time.Duration
+	pingInterval   time.Duration
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	lastId   int
+	pending  map[int]chan *XrpStreamResponse
+	streams  map[string]bool
+	accounts map[string]bool
+
+	writeMu sync.Mutex
+
+	ledgers      chan *XrpLedgerClosed
+	transactions chan *XrpTransaction
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+func NewRippleStreamClient() (*RippleStreamClient, error) {
+	nodeWsUrl, err := kvs.Get("XRP_NODE_WS_URL")
+	if err != nil {
+		l.Logger.Error("ripple stream: error getting XRP_NODE_WS_URL from KVS", zap.Error(err))
+		return nil, err
+	}
+
+	return newRippleStreamClient(nodeWsUrl, STREAM_RECONNECT_DELAY, STREAM_PING_INTERVAL), nil
+}
+
+func newRippleStreamClient(url string, reconnectDelay, pingInterval time.Duration) *RippleStreamClient {
+	return &RippleStreamClient{
+		url:            url,
+		reconnectDelay: reconnectDelay,
+		pingInterval:   pingInterval,
+		pending:        map[int]chan *XrpStreamResponse{},
+		streams:        map[string]bool{},
+		accounts:       map[string]bool{},
+		ledgers:        make(chan *XrpLedgerClosed, STREAM_BUFFER_SIZE),
+		transactions:   make(chan *XrpTransaction, STREAM_BUFFER_SIZE),
+		done:           make(chan struct{}),
+	}
+}
+
+// Ledgers returns the channel of the validated ledgers of the ledger stream, closed when the client stops
+func (c *RippleStreamClient) Ledgers() <-chan *XrpLedgerClosed {
+	return c.ledgers
+}
+
+// Transactions returns the channel of the validated transactions of the subscribed accounts, closed when the client stops.
+// The events are delivered in order and the client waits for the consumer, so the channel must be drained.
+func (c *RippleStreamClient) Transactions() <-chan *XrpTransaction {
+	return c.transactions
+}
+
+// Connect opens the websocket connection and starts reading the streams until the context is done or the client is closed
+func (c *RippleStreamClient) Connect(ctx context.Context) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.url, nil)
+	if err != nil {
+		l.Logger.Error("ripple stream: failed to connect to node", zap.String("url", c.url), zap.Error(err))
+		return fmt.Errorf("failed to connect to %s with error: %v", c.url, err)
+	}
+
+	c.mu.Lock()
+	c.conn = conn
+	c.mu.Unlock()
+
+	go c.run(ctx, conn)
+
+	return nil
+}
+
+// Close stops the client, closing the connection and the events channels
+func (c *RippleStreamClient) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.done)
+
+		c.mu.Lock()
+		conn := c.conn
+		c.mu.Unlock()
+
+		if conn != nil {
+			err = conn.Close()
+		}
+	})
+	return err
+}
+
+// SubscribeLedger subscribes to the ledger stream, that notifies each validated ledger
+func (c *RippleStreamClient) SubscribeLedger(ctx context.Context) error {
+	return c.subscribe(ctx, "subscribe", map[string]any{"streams": []string{STREAM_LEDGER}}, func() {
+		c.streams[STREAM_LEDGER] = true
+	})
+}
+
+// SubscribeAccounts subscribes to the validated transactions that affect the given accounts
+func (c *RippleStreamClient) SubscribeAccounts(ctx context.Context, accounts ...string) error {
+	// an invalid account fails the whole subscription, so it must not be recorded to be sent again
+	for _, account := range accounts {
+		if !addresscodec.IsValidClassicAddress(account) {
+			return fmt.Errorf("invalid account %s", account)
+		}
+	}
+
+	return c.subscribe(ctx, "subscribe", map[string]any{"accounts": accounts}, func() {
+		for _, account := range accounts {
+			c.accounts[account] = true
+		}
+	})
+}
+
+// UnsubscribeAccounts stops receiving the transactions of the given accounts
+func (c *RippleStreamClient) UnsubscribeAccounts(ctx context.Context, accounts ...string) error {
+	return c.subscribe(ctx, "unsubscribe", map[string]any{"accounts": accounts}, func() {
+		for _, account := range accounts {
+			delete(c.accounts, account)
+		}
+	})
+}
+
+// subscribe records the subscription before sending it, so it is sent again on reconnection. A subscription made
+// while the client is reconnecting is sent along with the others once the connection is back.
+func (c *RippleStreamClient) subscribe(ctx context.Context, command string, params map[string]any, record func()) error {
+	c.mu.Lock()
+	record()
+	c.mu.Unlock()
+
+	_, err := c.request(ctx, command, params)
+	if errors.Is(err, ErrStreamDisconnected) {
+		return nil
+	}
+
+	return err
+}
+
+// request sends a command and waits for its response
+func (c *RippleStreamClient) request(ctx context.Context, command string, params map[string]any) (*XrpStreamResponse, error) {
+	c.mu.Lock()
+	conn := c.conn
+	if conn == nil {
+		c.mu.Unlock()
+		return nil, ErrStreamDisconnected
+	}
+
+	c.lastId++
+	id := c.lastId
+	response := make(chan *XrpStreamResponse, 1)
+	c.pending[id] = response
+	c.mu.Unlock()
+
+	message := map[string]any{"id": id, "command": command}
+	for key, value := range params {
+		message[key] = value
+	}
+
+	if err := c.send(conn, message); err != nil {
+		c.removePending(id)
+		l.Logger.Error("ripple stream: failed to send command", zap.String("command", command), zap.Error(err))
+		return nil, ErrStreamDisconnected
+	}
+
+	select {
+	case result, ok := <-response:
+		if !ok {
+			return nil, ErrStreamDisconnected
+		}
+		if result.Status == "error" {
+			l.Logger.Error("ripple stream: command failed", zap.String("command", command), zap.String("error", result.Error))
+			return nil, fmt.Errorf("%s failed with error: %s %s", command, result.Error, result.ErrorMessage)
+		}
+		return result, nil
+	case <-ctx.Done():
+		c.removePending(id)
+		return nil, ctx.Err()
+	case <-c.done:
+		return nil, ErrStreamClosed
+	}
+}
+
+func (c *RippleStreamClient) send(conn *websocket.Conn, message any) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(STREAM_WRITE_TIMEOUT)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(message)
+}
+
+func (c *RippleStreamClient) removePending(id int) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// run reads the connection and reconnects when it is lost, until the context is done or the client is closed
+func (c *RippleStreamClient) run(ctx context.Context, conn *websocket.Conn) {
+	defer close(c.ledgers)
+	defer close(c.transactions)
+
+	for conn != nil {
+		err := c.read(ctx, conn)
+
+		c.mu.Lock()
+		c.conn = nil
+		for id, response := range c.pending {
+			close(response)
+			delete(c.pending, id)
+		}
+		c.mu.Unlock()
+		conn.Close()
+
+		if c.stopped(ctx) {
+			return
+		}
+
+		l.Logger.Error("ripple stream: connection lost, reconnecting", zap.String("url", c.url), zap.Error(err))
+		conn = c.reconnect(ctx)
+	}
+}
+
+func (c *RippleStreamClient) stopped(ctx context.Context) bool {
+	select {
+	case <-c.done:
+		return true
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// reconnect dials the node until it succeeds and sends the recorded subscriptions again.
+// The responses of the resubscription are read by the read loop, that only logs failures.
+func (c *RippleStreamClient) reconnect(ctx context.Context) *websocket.Conn {
+	delay := c.reconnectDelay
+
+	for {
+		select {
+		case <-c.done:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.url, nil)
+		if err != nil {
+			l.Logger.Error("ripple stream: failed to reconnect to node", zap.String("url", c.url), zap.Error(err))
+			delay = min(delay*2, STREAM_MAX_RECONNECT_DELAY)
+			continue
+		}
+
+		c.mu.Lock()
+		c.conn = conn
+		c.lastId++
+		message := c.subscriptions(c.lastId)
+		c.mu.Unlock()
+
+		if message != nil {
+			if err := c.send(conn, message); err != nil {
+				l.Logger.Error("ripple stream: failed to resubscribe", zap.Error(err))
+
+				c.mu.Lock()
+				c.conn = nil
+				c.mu.Unlock()
+				conn.Close()
+				continue
+			}
+		}
+
+		l.Logger.Info("ripple stream: reconnected to node", zap.String("url", c.url))
+		return conn
+	}
+}
+
+// subscriptions builds the subscribe command of the recorded streams and accounts, nil when there are none
+func (c *RippleStreamClient) subscriptions(id int) map[string]any {
+	if len(c.streams) == 0 && len(c.accounts) == 0 {
+		return nil
+	}
+
+	message := map[string]any{"id": id, "command": "subscribe"}
+	if len(c.streams) > 0 {
+		message["streams"] = sortedKeys(c.streams)
+	}
+	if len(c.accounts) > 0 {
+		message["accounts"] = sortedKeys(c.accounts)
+	}
+	return message
+}
+
+// read dispatches the messages of the connection until it fails, pinging the node to detect dead connections
+func (c *RippleStreamClient) read(ctx context.Context, conn *websocket.Conn) error {
+	deadline := func() error {
+		return conn.SetReadDeadline(time.Now().Add(2 * c.pingInterval))
+	}
+	conn.SetPongHandler(func(string) error { return deadline() })
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		ticker := time.NewTicker(c.pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				c.writeMu.Lock()
+				err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(STREAM_WRITE_TIMEOUT))
+				c.writeMu.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		if err := deadline(); err != nil {
+			return err
+		}
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		if err := c.dispatch(ctx, data); err != nil {
+			return err
+		}
+	}
+}
+
+// dispatch routes a message to the pending request or to the events channels
+func (c *RippleStreamClient) dispatch(ctx context.Context, data []byte) error {
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		l.Logger.Error("ripple stream: invalid message", zap.ByteString("message", data), zap.Error(err))
+		return nil
+	}
+
+	switch envelope.Type {
+	case "response":
+		response := &XrpStreamResponse{}
+		if err := json.Unmarshal(data, response); err != nil {
+			l.Logger.Error("ripple stream: invalid response", zap.ByteString("message", data), zap.Error(err))
+			return nil
+		}
+
+		c.mu.Lock()
+		pending, ok := c.pending[response.ID]
+		delete(c.pending, response.ID)
+		c.mu.Unlock()
+
+		if ok {
+			pending <- response
+		} else if response.Status == "error" {
+			l.Logger.Error("ripple stream: resubscription failed", zap.String("error", response.Error), zap.String("message", response.ErrorMessage))
+		}
+
+	case "ledgerClosed":
+		ledger := &XrpLedgerClosed{}
+		if err := json.Unmarshal(data, ledger); err != nil {
+			l.Logger.Error("ripple stream: invalid ledger message", zap.ByteString("message", data), zap.Error(err))
+			return nil
+		}
+
+		select {
+		case c.ledgers <- ledger:
+		case <-c.done:
+			return ErrStreamClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+	case "transaction":
+		stream := &XrpTransactionStream{}
+		if err := json.Unmarshal(data, stream); err != nil {
+			l.Logger.Error("ripple stream: invalid transaction message", zap.ByteString("message", data), zap.Error(err))
+			return nil
+		}
+
+		tx, err := decodeTransactionStream(stream)
+		if err != nil {
+			l.Logger.Error("ripple stream: failed to decode transaction", zap.String("hash", stream.Hash), zap.Error(err))
+			return nil
+		}
+
+		select {
+		case c.transactions <- tx:
+		case <-c.done:
+			return ErrStreamClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// decodeTransactionStream converts a transaction of the accounts stream, with the tx_json and hash
+// of api v2 or the transaction of api v1, computing its balance changes and delivered amount
+func decodeTransactionStream(stream *XrpTransactionStream) (*XrpTransaction, error) {
+	transaction := stream.TxJson
+	if transaction == nil {
+		transaction = stream.Transaction
+	}
+	if transaction == nil {
+		return nil, fmt.Errorf("transaction message without transaction")
+	}
+
+	tx := &XrpTransaction{
+		Hash:        stream.Hash,
+		LedgerIndex: stream.LedgerIndex,
+		Validated:   stream.Validated,
+		Transaction: transaction,
+	}
+
+	if tx.Hash == "" {
+		tx.Hash, _ = transaction["hash"].(string)
+	}
+
+	if date, ok := transaction["date"].(float64); ok {
+		tx.Date = int(date)
+	}
+
+	if stream.Meta == nil {
+		return tx, nil
+	}
+
+	var err error
+	tx.Metadata, err = DecodeTransactionMetadata(stream.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.BalanceChanges, err = tx.Metadata.BalanceChanges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute balance changes: %v", err)
+	}
+
+	if transaction["TransactionType"] == "Payment" {
+		tx.DeliveredAmount = DeliveredAmount(transaction, tx.Metadata, tx.BalanceChanges)
+	}
+
+	return tx, nil
+}
+
+func sortedKeys(values map[string]bool) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,222 @@
+package ripple
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeRippled is a websocket server that answers the subscribe commands as rippled and lets the test push stream messages
+type fakeRippled struct {
+	server   *httptest.Server
+	conns    chan *fakeConn
+	commands chan map[string]any
+}
+
+type fakeConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+func (f *fakeConn) send(t *testing.T, message map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	require.NoError(t, f.conn.WriteJSON(message))
+}
+
+func newFakeRippled(t *testing.T) *fakeRippled {
+	fake := &fakeRippled{
+		conns:    make(chan *fakeConn, 10),
+		commands: make(chan map[string]any, 10),
+	}
+
+	upgrader := websocket.Upgrader{}
+	fake.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		fc := &fakeConn{conn: conn}
+		fake.conns <- fc
+
+		for {
+			var command map[string]any
+			if err := conn.ReadJSON(&command); err != nil {
+				return
+			}
+			fake.commands <- command
+
+			fc.mu.Lock()
+			err := conn.WriteJSON(map[string]any{"id": command["id"], "status": "success", "type": "response", "result": map[string]any{}})
+			fc.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+func (f *fakeRippled) url() string {
+	return "ws" + strings.TrimPrefix(f.server.URL, "http")
+}
+
+func (f *fakeRippled) nextConn(t *testing.T) *fakeConn {
+	select {
+	case conn := <-f.conns:
+		return conn
+	case <-time.After(5 * time.Second):
+		t.Fatal("no connection received")
+		return nil
+	}
+}
+
+func (f *fakeRippled) nextCommand(t *testing.T) map[string]any {
+	select {
+	case command := <-f.commands:
+		return command
+	case <-time.After(5 * time.Second):
+		t.Fatal("no command received")
+		return nil
+	}
+}
+
+func connectStream(t *testing.T, fake *fakeRippled) *RippleStreamClient {
+	// the client logs the lost connections
+	l.Logger = zap.NewNop()
+
+	client := newRippleStreamClient(fake.url(), 10*time.Millisecond, time.Second)
+	require.NoError(t, client.Connect(context.Background()))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func ledgerClosedMessage(index int) map[string]any {
+	return map[string]any{
+		"type":              "ledgerClosed",
+		"ledger_index":      index,
+		"ledger_hash":       "F7C3D8A1E6B5A4C3D2E1F0A9B8C7D6E5F4A3B2C1D0E9F8A7B6C5D4E3F2A1B0C9",
+		"ledger_time":       780000000,
+		"fee_base":          10,
+		"reserve_base":      1000000,
+		"reserve_inc":       200000,
+		"txn_count":         3,
+		"validated_ledgers": fmt.Sprintf("1-%d", index),
+	}
+}
+
+func receiveLedger(t *testing.T, client *RippleStreamClient) *XrpLedgerClosed {
+	select {
+	case ledger := <-client.Ledgers():
+		return ledger
+	case <-time.After(5 * time.Second):
+		t.Fatal("no ledger received")
+		return nil
+	}
+}
+
+func TestStreamSubscriptions(t *testing.T) {
+	fake := newFakeRippled(t)
+	client := connectStream(t, fake)
+	conn := fake.nextConn(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.SubscribeLedger(ctx))
+	command := fake.nextCommand(t)
+	require.Equal(t, "subscribe", command["command"])
+	require.Equal(t, []any{STREAM_LEDGER}, command["streams"])
+
+	require.NoError(t, client.SubscribeAccounts(ctx, metaHolder))
+	command = fake.nextCommand(t)
+	require.Equal(t, []any{metaHolder}, command["accounts"])
+
+	require.Error(t, client.SubscribeAccounts(ctx, "not-an-account"))
+
+	conn.send(t, ledgerClosedMessage(90))
+	ledger := receiveLedger(t, client)
+	require.Equal(t, 90, ledger.LedgerIndex)
+	require.Equal(t, 1000000, ledger.ReserveBase)
+
+	// api v1 transaction message of the issued currency payment
+	conn.send(t, map[string]any{
+		"type":          "transaction",
+		"engine_result": "tesSUCCESS",
+		"ledger_index":  91,
+		"validated":     true,
+		"transaction": map[string]any{
+			"TransactionType": "Payment",
+			"Account":         metaIssuer,
+			"Destination":     metaHolder,
+			"Amount":          map[string]any{"currency": ParseStringToHex("BRZA"), "issuer": metaIssuer, "value": "10"},
+			"Fee":             "12",
+			"date":            780000003,
+			"hash":            "C0FFEE",
+		},
+		"meta": buildIssuedPaymentMeta(),
+	})
+
+	select {
+	case tx := <-client.Transactions():
+		require.Equal(t, "C0FFEE", tx.Hash)
+		require.Equal(t, 91, tx.LedgerIndex)
+		require.Equal(t, 780000003, tx.Date)
+		require.True(t, tx.Validated)
+		require.Equal(t, "tesSUCCESS", tx.Metadata.TransactionResult)
+		require.Equal(t, "10", tx.DeliveredAmount)
+		require.Len(t, tx.BalanceChanges, 3)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no transaction received")
+	}
+
+	require.NoError(t, client.UnsubscribeAccounts(ctx, metaHolder))
+	command = fake.nextCommand(t)
+	require.Equal(t, "unsubscribe", command["command"])
+}
+
+func TestStreamReconnect(t *testing.T) {
+	fake := newFakeRippled(t)
+	client := connectStream(t, fake)
+	conn := fake.nextConn(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.SubscribeLedger(ctx))
+	fake.nextCommand(t)
+	require.NoError(t, client.SubscribeAccounts(ctx, metaIssuer, metaHolder))
+	fake.nextCommand(t)
+
+	// the node drops the connection, the client reconnects and subscribes again to everything
+	conn.conn.Close()
+
+	conn = fake.nextConn(t)
+	command := fake.nextCommand(t)
+	require.Equal(t, "subscribe", command["command"])
+	require.Equal(t, []any{STREAM_LEDGER}, command["streams"])
+	require.ElementsMatch(t, []any{metaIssuer, metaHolder}, command["accounts"])
+
+	conn.send(t, ledgerClosedMessage(95))
+	require.Equal(t, 95, receiveLedger(t, client).LedgerIndex)
+
+	// closing the client closes the events channels
+	require.NoError(t, client.Close())
+	select {
+	case _, ok := <-client.Ledgers():
+		require.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ledgers channel not closed")
+	}
+}
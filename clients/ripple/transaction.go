@@ -0,0 +1,101 @@
+package ripple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+func (r *RippleNodeClient) BuildTransactionRequest(hash string) *XrpJsonRpcRequest {
+	return &XrpJsonRpcRequest{
+		Method: "tx",
+		Params: []any{
+			map[string]any{
+				"transaction": hash,
+				"binary":      true,
+			},
+		},
+	}
+}
+
+// GetTransaction retrieves a transaction by its hash in binary form and decodes it along with its metadata.
+// The metadata and balance changes are only set once the transaction is included in a ledger.
+func (r *RippleNodeClient) GetTransaction(ctx context.Context, hash string) (*XrpTransaction, error) {
+	request := r.BuildTransactionRequest(hash)
+	result := &XrpTxResponse{}
+
+	err := r.call(ctx, request, &result)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to retreive transaction", zap.String("hash", hash), zap.Error(err))
+		return nil, fmt.Errorf("failed to retreive transaction %s with error: %v", hash, err)
+	}
+
+	if result.Result == nil {
+		return nil, fmt.Errorf("tx response without result for transaction %s", hash)
+	}
+
+	if result.Result.Error == "txnNotFound" {
+		return nil, ErrTransactionNotFound
+	}
+
+	if result.Result.Error != "" {
+		l.Logger.Error("ripple client: tx request failed", zap.String("hash", hash), zap.String("error", result.Result.Error))
+		return nil, fmt.Errorf("failed to retreive transaction %s with error: %s", hash, result.Result.Error)
+	}
+
+	// api v1 returns the binary metadata as meta, api v2 as meta_blob
+	metaBlob := result.Result.MetaBlob
+	if metaBlob == "" {
+		metaBlob, _ = result.Result.Meta.(string)
+	}
+
+	tx, err := DecodeBinaryTransaction(result.Result.TxBlob, metaBlob)
+	if err != nil {
+		l.Logger.Error("ripple client: failed to decode transaction", zap.String("hash", hash), zap.Error(err))
+		return nil, err
+	}
+
+	tx.Hash = result.Result.Hash
+	tx.LedgerIndex = result.Result.LedgerIndex
+	tx.Validated = result.Result.Validated
+	tx.Date = result.Result.Date
+
+	return tx, nil
+}
+
+// DecodeBinaryTransaction decodes a transaction blob and, when given, its metadata blob,
+// computing the balance changes and the delivered amount of the transaction.
+func DecodeBinaryTransaction(txBlob, metaBlob string) (*XrpTransaction, error) {
+	decoded, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tx blob: %v", err)
+	}
+
+	tx := &XrpTransaction{Transaction: decoded}
+	if metaBlob == "" {
+		return tx, nil
+	}
+
+	tx.Metadata, err = DecodeTransactionMetadata(metaBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.BalanceChanges, err = tx.Metadata.BalanceChanges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute balance changes: %v", err)
+	}
+
+	if decoded["TransactionType"] == "Payment" {
+		tx.DeliveredAmount = DeliveredAmount(decoded, tx.Metadata, tx.BalanceChanges)
+	}
+
+	return tx, nil
+}
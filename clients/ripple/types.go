@@ -1,5 +1,7 @@
 package ripple
 
+import "encoding/json"
+
 type XrpJsonRpcRequest struct {
 	Method string `json:"method"`
 	Params []any  `json:"params"`
@@ -53,25 +55,6 @@ type XrpAccountQueueData struct {
 	TxnCount int `json:"txn_count"`
 }
 
-type XrpPaymentTx struct {
-	TransactionType    string              `json:"TransactionType"`
-	Account            string              `json:"Account"`
-	Destination        string              `json:"Destination"`
-	DestinationTag     uint32              `json:"DestinationTag"`
-	Amount             *XrpPaymentTxAmount `json:"Amount"`
-	Flags              uint32              `json:"Flags"`
-	Sequence           uint32              `json:"Sequence"`
-	Fee                string              `json:"Fee"`
-	LastLedgerSequence uint32              `json:"LastLedgerSequence"`
-	SigningPubKey      string              `json:"SigningPubKey"`
-}
-
-type XrpPaymentTxAmount struct {
-	Currency string `json:"currency"`
-	Issuer   string `json:"issuer"`
-	Value    string `json:"value"`
-}
-
 type SubmitTxResultTxAmount struct {
 	Currency string `json:"currency"`
 	Issuer   string `json:"issuer"`
@@ -116,15 +99,19 @@ type SubmitTxResultResponse struct {
 }
 
 type Line struct {
-	Account      string `json:"account"`
-	Balance      string `json:"balance"`
-	Currency     string `json:"currency"`
-	Limit        string `json:"limit"`
-	LimitPeer    string `json:"limit_peer"`
-	NoRipple     bool   `json:"no_ripple"`
-	NoRipplePeer bool   `json:"no_ripple_peer"`
-	QualityIn    int    `json:"quality_in"`
-	QualityOut   int    `json:"quality_out"`
+	Account        string `json:"account"`
+	Balance        string `json:"balance"`
+	Currency       string `json:"currency"`
+	Limit          string `json:"limit"`
+	LimitPeer      string `json:"limit_peer"`
+	NoRipple       bool   `json:"no_ripple"`
+	NoRipplePeer   bool   `json:"no_ripple_peer"`
+	Freeze         bool   `json:"freeze"`
+	FreezePeer     bool   `json:"freeze_peer"`
+	Authorized     bool   `json:"authorized"`
+	PeerAuthorized bool   `json:"peer_authorized"`
+	QualityIn      int    `json:"quality_in"`
+	QualityOut     int    `json:"quality_out"`
 }
 
 type Result struct {
@@ -138,3 +125,368 @@ type Result struct {
 type AccountLinesResponse struct {
 	Result `json:"result"`
 }
+
+type XrpAccountLinesPageResponse struct {
+	Result *XrpAccountLinesPageResult `json:"result"`
+}
+
+type XrpAccountLinesPageResult struct {
+	Account     string `json:"account"`
+	Lines       []Line `json:"lines"`
+	LedgerIndex int    `json:"ledger_index"`
+	LedgerHash  string `json:"ledger_hash"`
+	Marker      any    `json:"marker"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+}
+
+type XrpFeeResponse struct {
+	Result *XrpFeeResult `json:"result"`
+}
+
+type XrpFeeResult struct {
+	CurrentLedgerSize  string        `json:"current_ledger_size"`
+	CurrentQueueSize   string        `json:"current_queue_size"`
+	Drops              *XrpFeeDrops  `json:"drops"`
+	ExpectedLedgerSize string        `json:"expected_ledger_size"`
+	LedgerCurrentIndex int           `json:"ledger_current_index"`
+	Levels             *XrpFeeLevels `json:"levels"`
+	MaxQueueSize       string        `json:"max_queue_size"`
+	Status             string        `json:"status"`
+}
+
+type XrpFeeDrops struct {
+	BaseFee       string `json:"base_fee"`
+	MedianFee     string `json:"median_fee"`
+	MinimumFee    string `json:"minimum_fee"`
+	OpenLedgerFee string `json:"open_ledger_fee"`
+}
+
+type XrpFeeLevels struct {
+	MedianLevel     string `json:"median_level"`
+	MinimumLevel    string `json:"minimum_level"`
+	OpenLedgerLevel string `json:"open_ledger_level"`
+	ReferenceLevel  string `json:"reference_level"`
+}
+
+type XrpFeeCalculation struct {
+	Fee           string `json:"fee"`
+	BaseFee       string `json:"base_fee"`
+	OpenLedgerFee string `json:"open_ledger_fee"`
+	Multiplier    string `json:"multiplier"`
+	MaxFee        string `json:"max_fee"`
+	SignersCount  int    `json:"signers_count"`
+	Capped        bool   `json:"capped"`
+}
+
+type XrpServerInfoResponse struct {
+	Result *XrpServerInfoResult `json:"result"`
+}
+
+type XrpServerInfoResult struct {
+	Info   *XrpServerInfo `json:"info"`
+	Status string         `json:"status"`
+}
+
+type XrpServerInfo struct {
+	BuildVersion     string               `json:"build_version"`
+	CompleteLedgers  string               `json:"complete_ledgers"`
+	LoadFactor       float64              `json:"load_factor"`
+	NetworkID        int                  `json:"network_id"`
+	Peers            int                  `json:"peers"`
+	ServerState      string               `json:"server_state"`
+	Time             string               `json:"time"`
+	ValidatedLedger  *XrpServerInfoLedger `json:"validated_ledger"`
+	ValidationQuorum int                  `json:"validation_quorum"`
+}
+
+type XrpServerInfoLedger struct {
+	Age            int     `json:"age"`
+	BaseFeeXrp     float64 `json:"base_fee_xrp"`
+	Hash           string  `json:"hash"`
+	ReserveBaseXrp float64 `json:"reserve_base_xrp"`
+	ReserveIncXrp  float64 `json:"reserve_inc_xrp"`
+	Seq            int     `json:"seq"`
+}
+
+type XrpAccountReserve struct {
+	BaseReserve  string `json:"base_reserve"`
+	OwnerReserve string `json:"owner_reserve"`
+	OwnerCount   int    `json:"owner_count"`
+	Total        string `json:"total"`
+}
+
+type XrpDecodedTransaction struct {
+	TxBlob        string            `json:"tx_blob"`
+	Transaction   map[string]any    `json:"transaction"`
+	SigningHash   string            `json:"signing_hash"`
+	TransactionId string            `json:"transaction_id,omitempty"`
+	IsSigned      bool              `json:"is_signed"`
+	Currencies    map[string]string `json:"currencies"`
+	Addresses     map[string]string `json:"addresses"`
+	Memos         map[string]string `json:"memos"`
+}
+
+type XrpTransactionMetadata struct {
+	TransactionIndex  int                `json:"transaction_index"`
+	TransactionResult string             `json:"transaction_result"`
+	DeliveredAmount   any                `json:"delivered_amount,omitempty"`
+	AffectedNodes     []*XrpAffectedNode `json:"affected_nodes"`
+}
+
+type XrpAffectedNode struct {
+	NodeType        string         `json:"node_type"`
+	LedgerEntryType string         `json:"ledger_entry_type"`
+	LedgerIndex     string         `json:"ledger_index"`
+	NewFields       map[string]any `json:"new_fields,omitempty"`
+	FinalFields     map[string]any `json:"final_fields,omitempty"`
+	PreviousFields  map[string]any `json:"previous_fields,omitempty"`
+}
+
+type XrpBalanceChange struct {
+	Account  string `json:"account"`
+	Currency string `json:"currency"`
+	Issuer   string `json:"issuer,omitempty"`
+	Value    string `json:"value"`
+}
+
+type XrpTxResponse struct {
+	Result *XrpTxResult `json:"result"`
+}
+
+type XrpTxResult struct {
+	Hash        string `json:"hash"`
+	LedgerIndex int    `json:"ledger_index"`
+	Validated   bool   `json:"validated"`
+	Date        int    `json:"date"`
+	TxBlob      string `json:"tx_blob"`
+	Meta        any    `json:"meta"`
+	MetaBlob    string `json:"meta_blob"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+}
+
+type XrpTransaction struct {
+	Hash            string                  `json:"hash"`
+	LedgerIndex     int                     `json:"ledger_index"`
+	Validated       bool                    `json:"validated"`
+	Date            int                     `json:"date"`
+	Transaction     map[string]any          `json:"transaction"`
+	Metadata        *XrpTransactionMetadata `json:"metadata,omitempty"`
+	BalanceChanges  []*XrpBalanceChange     `json:"balance_changes,omitempty"`
+	DeliveredAmount string                  `json:"delivered_amount,omitempty"`
+}
+
+type XrpAccountTxResponse struct {
+	Result *XrpAccountTxResult `json:"result"`
+}
+
+type XrpAccountTxResult struct {
+	Account        string               `json:"account"`
+	LedgerIndexMin int                  `json:"ledger_index_min"`
+	LedgerIndexMax int                  `json:"ledger_index_max"`
+	Limit          int                  `json:"limit"`
+	Marker         *XrpAccountTxMarker  `json:"marker,omitempty"`
+	Transactions   []*XrpAccountTxEntry `json:"transactions"`
+	Validated      bool                 `json:"validated"`
+	Status         string               `json:"status"`
+	Error          string               `json:"error"`
+}
+
+// XrpAccountTxMarker is the opaque pagination marker of account_tx, the ledger and sequence where the next page starts
+type XrpAccountTxMarker struct {
+	Ledger int `json:"ledger"`
+	Seq    int `json:"seq"`
+}
+
+type XrpAccountTxEntry struct {
+	Hash        string `json:"hash"`
+	LedgerIndex int    `json:"ledger_index"`
+	Validated   bool   `json:"validated"`
+	Date        int    `json:"date"`
+	TxBlob      string `json:"tx_blob"`
+	Meta        any    `json:"meta"`
+	MetaBlob    string `json:"meta_blob"`
+}
+
+type XrpAccountTransactions struct {
+	Account        string              `json:"account"`
+	LedgerIndexMin int                 `json:"ledger_index_min"`
+	LedgerIndexMax int                 `json:"ledger_index_max"`
+	Marker         *XrpAccountTxMarker `json:"marker,omitempty"`
+	Transactions   []*XrpTransaction   `json:"transactions"`
+}
+
+type XrpStreamResponse struct {
+	ID           int             `json:"id"`
+	Type         string          `json:"type"`
+	Status       string          `json:"status"`
+	Error        string          `json:"error"`
+	ErrorMessage string          `json:"error_message"`
+	Result       json.RawMessage `json:"result"`
+}
+
+type XrpLedgerClosed struct {
+	LedgerIndex      int    `json:"ledger_index"`
+	LedgerHash       string `json:"ledger_hash"`
+	LedgerTime       int    `json:"ledger_time"`
+	FeeBase          int    `json:"fee_base"`
+	ReserveBase      int    `json:"reserve_base"`
+	ReserveInc       int    `json:"reserve_inc"`
+	TxnCount         int    `json:"txn_count"`
+	ValidatedLedgers string `json:"validated_ledgers"`
+}
+
+type XrpTransactionStream struct {
+	EngineResult string         `json:"engine_result"`
+	LedgerIndex  int            `json:"ledger_index"`
+	Validated    bool           `json:"validated"`
+	CloseTimeIso string         `json:"close_time_iso"`
+	Hash         string         `json:"hash"`
+	Transaction  map[string]any `json:"transaction"`
+	TxJson       map[string]any `json:"tx_json"`
+	Meta         map[string]any `json:"meta"`
+}
+
+type XrpPathFindResponse struct {
+	Result *XrpPathFindResult `json:"result"`
+}
+
+type XrpPathFindResult struct {
+	Alternatives          []*XrpPathAlternative `json:"alternatives"`
+	DestinationAccount    string                `json:"destination_account"`
+	DestinationAmount     any                   `json:"destination_amount"`
+	DestinationCurrencies []string              `json:"destination_currencies"`
+	Status                string                `json:"status"`
+	Error                 string                `json:"error"`
+	ErrorMessage          string                `json:"error_message"`
+}
+
+type XrpPathAlternative struct {
+	PathsComputed [][]XrpPathStep `json:"paths_computed"`
+	SourceAmount  any             `json:"source_amount"`
+}
+
+// XrpPathStep is a step of a payment path, rippled adding type fields that are not part of the serialized step
+type XrpPathStep struct {
+	Account  string `json:"account,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	Issuer   string `json:"issuer,omitempty"`
+}
+
+type XrpAccountOffersResponse struct {
+	Result *XrpAccountOffersResult `json:"result"`
+}
+
+type XrpAccountOffersResult struct {
+	Account     string             `json:"account"`
+	Offers      []*XrpAccountOffer `json:"offers"`
+	LedgerIndex int                `json:"ledger_index"`
+	Marker      any                `json:"marker"`
+	Status      string             `json:"status"`
+	Error       string             `json:"error"`
+}
+
+// XrpAccountOffer is an open offer of the account, Seq being the sequence of the OfferCreate that placed it
+type XrpAccountOffer struct {
+	Flags      int    `json:"flags"`
+	Seq        int    `json:"seq"`
+	TakerGets  any    `json:"taker_gets"`
+	TakerPays  any    `json:"taker_pays"`
+	Quality    string `json:"quality"`
+	Expiration int    `json:"expiration,omitempty"`
+}
+
+type XrpAmmInfoResponse struct {
+	Result *XrpAmmInfoResult `json:"result"`
+}
+
+type XrpAmmInfoResult struct {
+	Amm          *XrpAmm `json:"amm"`
+	LedgerIndex  int     `json:"ledger_index"`
+	Validated    bool    `json:"validated"`
+	Status       string  `json:"status"`
+	Error        string  `json:"error"`
+	ErrorMessage string  `json:"error_message"`
+}
+
+// XrpAmm is the state of an AMM pool, LPToken being the LP tokens in circulation or the ones held by the account
+// given on the request
+type XrpAmm struct {
+	Account      string             `json:"account"`
+	Amount       any                `json:"amount"`
+	Amount2      any                `json:"amount2"`
+	LPToken      any                `json:"lp_token"`
+	TradingFee   int                `json:"trading_fee"`
+	AssetFrozen  bool               `json:"asset_frozen,omitempty"`
+	Asset2Frozen bool               `json:"asset2_frozen,omitempty"`
+	VoteSlots    []*XrpAmmVoteSlot  `json:"vote_slots,omitempty"`
+	AuctionSlot  *XrpAmmAuctionSlot `json:"auction_slot,omitempty"`
+}
+
+type XrpAmmVoteSlot struct {
+	Account    string `json:"account"`
+	TradingFee int    `json:"trading_fee"`
+	VoteWeight int    `json:"vote_weight"`
+}
+
+type XrpAmmAuctionSlot struct {
+	Account       string `json:"account"`
+	DiscountedFee int    `json:"discounted_fee"`
+	Expiration    string `json:"expiration"`
+	Price         any    `json:"price"`
+	TimeInterval  int    `json:"time_interval"`
+}
+
+type XrpPayChannelResponse struct {
+	Result *XrpPayChannelResult `json:"result"`
+}
+
+type XrpPayChannelResult struct {
+	Index        string         `json:"index"`
+	Node         *XrpPayChannel `json:"node"`
+	LedgerIndex  int            `json:"ledger_index"`
+	Validated    bool           `json:"validated"`
+	Status       string         `json:"status"`
+	Error        string         `json:"error"`
+	ErrorMessage string         `json:"error_message"`
+}
+
+// XrpPayChannel is the ledger object of a payment channel, Amount being the XRP drops funded on it and Balance the
+// drops already delivered to the destination
+type XrpPayChannel struct {
+	Account        string `json:"Account"`
+	Destination    string `json:"Destination"`
+	Amount         string `json:"Amount"`
+	Balance        string `json:"Balance"`
+	PublicKey      string `json:"PublicKey"`
+	SettleDelay    int    `json:"SettleDelay"`
+	Expiration     int    `json:"Expiration,omitempty"`
+	CancelAfter    int    `json:"CancelAfter,omitempty"`
+	DestinationTag *int   `json:"DestinationTag,omitempty"`
+	SourceTag      *int   `json:"SourceTag,omitempty"`
+	Flags          int    `json:"Flags"`
+	Index          string `json:"index"`
+}
+
+type XrpGatewayBalancesResponse struct {
+	Result *XrpGatewayBalancesResult `json:"result"`
+}
+
+// XrpGatewayBalancesResult has the obligations of the issuer by currency, without the balances of the hot wallets,
+// which are listed by hot wallet address
+type XrpGatewayBalancesResult struct {
+	Account     string                          `json:"account"`
+	Obligations map[string]string               `json:"obligations"`
+	Balances    map[string][]XrpGatewayCurrency `json:"balances"`
+	LedgerIndex int                             `json:"ledger_index"`
+	LedgerHash  string                          `json:"ledger_hash"`
+	Validated   bool                            `json:"validated"`
+	Status      string                          `json:"status"`
+	Error       string                          `json:"error"`
+}
+
+type XrpGatewayCurrency struct {
+	Currency string `json:"currency"`
+	Value    string `json:"value"`
+}
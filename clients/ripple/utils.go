@@ -1,10 +1,22 @@
 package ripple
 
 import (
+	addresscodec "crypto-braza-tokens-api/clients/ripple/utils/address-codec"
 	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DROPS_PER_XRP is the amount of drops that represents a single XRP
+	DROPS_PER_XRP = 1000000
+
+	// RIPPLE_EPOCH is the unix time of 2000-01-01T00:00:00Z, the origin of the ledger close times
+	RIPPLE_EPOCH = 946684800
 )
 
 // ConvertStringToHex converts a string to its hexadecimal representation
@@ -88,6 +100,14 @@ func EncodeDER(rHex, sHex string) (string, error) {
 		return "", fmt.Errorf("failed to decode sHex: %v", err)
 	}
 
+	if len(rBytes) == 0 || len(sBytes) == 0 {
+		return "", fmt.Errorf("r and s values must not be empty")
+	}
+
+	// DER integers must be minimal, so leading zero bytes of fixed size values are removed
+	rBytes = trimLeadingZeros(rBytes)
+	sBytes = trimLeadingZeros(sBytes)
+
 	// Ensure the r value is correctly encoded with the 00 byte
 	if rBytes[0]&0x80 != 0 {
 		rBytes = append([]byte{0x00}, rBytes...)
@@ -104,3 +124,101 @@ func EncodeDER(rHex, sHex string) (string, error) {
 
 	return hex.EncodeToString(der), nil
 }
+
+// trimLeadingZeros removes the leading zero bytes of a big endian value, keeping at least one byte
+func trimLeadingZeros(b []byte) []byte {
+	for len(b) > 1 && b[0] == 0x00 {
+		b = b[1:]
+	}
+	return b
+}
+
+// ConvertXrpToDrops converts a XRP decimal amount into its integer drops representation
+func ConvertXrpToDrops(xrp string) (string, error) {
+	value, err := decimal.NewFromString(xrp)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse xrp amount %s: %v", xrp, err)
+	}
+
+	drops := value.Mul(decimal.NewFromInt(DROPS_PER_XRP))
+	if !drops.IsInteger() {
+		return "", fmt.Errorf("xrp amount %s has more than 6 decimal places", xrp)
+	}
+
+	if drops.IsNegative() {
+		return "", fmt.Errorf("xrp amount %s must not be negative", xrp)
+	}
+
+	return drops.String(), nil
+}
+
+// ConvertDropsToXrp converts an integer drops amount into its XRP decimal representation
+func ConvertDropsToXrp(drops string) (string, error) {
+	value, err := decimal.NewFromString(drops)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse drops amount %s: %v", drops, err)
+	}
+
+	return value.Div(decimal.NewFromInt(DROPS_PER_XRP)).String(), nil
+}
+
+// CalculateAccountReserve computes the XRP reserve (in drops) an account must keep according to the validated ledger
+// reserve settings: the base reserve plus the owner reserve for each object owned by the account
+func CalculateAccountReserve(ledger *XrpServerInfoLedger, ownerCount int) (*XrpAccountReserve, error) {
+	if ledger == nil {
+		return nil, fmt.Errorf("validated ledger info is required to calculate the account reserve")
+	}
+
+	dropsPerXrp := decimal.NewFromInt(DROPS_PER_XRP)
+	baseReserve := decimal.NewFromFloat(ledger.ReserveBaseXrp).Mul(dropsPerXrp).Round(0)
+	ownerReserve := decimal.NewFromFloat(ledger.ReserveIncXrp).Mul(dropsPerXrp).Round(0)
+	total := baseReserve.Add(ownerReserve.Mul(decimal.NewFromInt(int64(ownerCount))))
+
+	return &XrpAccountReserve{
+		BaseReserve:  baseReserve.String(),
+		OwnerReserve: ownerReserve.String(),
+		OwnerCount:   ownerCount,
+		Total:        total.String(),
+	}, nil
+}
+
+// NormalizeAddressAndTag accepts a classic address or an X-address and returns the classic address and destination tag
+// to be used on transactions. A tag embedded on the X-address must match the given tag, when both are provided, and
+// the X-address must be bound to the network the service runs on, a test network when isTestnet is set.
+func NormalizeAddressAndTag(address string, destinationTag *uint32, isTestnet bool) (string, *uint32, error) {
+	classicAddress, embeddedTag, isXAddress, xAddressTestnet, err := addresscodec.NormalizeAddress(address)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if isXAddress && xAddressTestnet != isTestnet {
+		return "", nil, fmt.Errorf("x-address %s is bound to the %s and can not be used on the %s", address, networkName(xAddressTestnet), networkName(isTestnet))
+	}
+
+	if embeddedTag == nil {
+		return classicAddress, destinationTag, nil
+	}
+
+	if destinationTag != nil && *destinationTag != *embeddedTag {
+		return "", nil, fmt.Errorf("destination tag %d does not match the tag %d embedded on the x-address", *destinationTag, *embeddedTag)
+	}
+
+	return classicAddress, embeddedTag, nil
+}
+
+func networkName(isTestnet bool) string {
+	if isTestnet {
+		return "test network"
+	}
+	return "mainnet"
+}
+
+// ConvertRippleTime converts a ledger time, in seconds since the ripple epoch, into a UTC time
+func ConvertRippleTime(seconds int) time.Time {
+	return time.Unix(int64(seconds)+RIPPLE_EPOCH, 0).UTC()
+}
+
+// ToRippleTime converts a time to the seconds since the ripple epoch, as used by the Expiration fields
+func ToRippleTime(t time.Time) uint32 {
+	return uint32(t.Unix() - RIPPLE_EPOCH)
+}
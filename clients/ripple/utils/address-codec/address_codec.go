@@ -132,10 +132,15 @@ func DecodeClassicAddressToAccountID(cAddress string) (typePrefix, accountID []b
 
 }
 
+// IsValidClassicAddress reports whether the input is a classic address with the account prefix, a 20 bytes account ID
+// and a valid checksum
 func IsValidClassicAddress(cAddress string) bool {
-	_, _, c := DecodeClassicAddressToAccountID(cAddress)
+	decoded, err := Base58CheckDecode(cAddress)
+	if err != nil {
+		return false
+	}
 
-	return c == nil
+	return len(decoded) == AccountAddressLength+1 && decoded[0] == AccountAddressPrefix
 }
 
 // Returns a base58 encoding of a seed.
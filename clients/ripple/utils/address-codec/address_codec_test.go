@@ -0,0 +1,61 @@
+package addresscodec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidClassicAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		expected bool
+	}{
+		{name: "valid address", address: "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", expected: true},
+		{name: "invalid checksum", address: "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpg", expected: false},
+		{name: "x-address", address: "XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb", expected: false},
+		{name: "empty address", address: "", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsValidClassicAddress(tc.address))
+		})
+	}
+}
+
+func TestEncodeClassicAddressFromPublicKeyHex(t *testing.T) {
+	tests := []struct {
+		name      string
+		publicKey string
+		expected  string
+		expErr    bool
+	}{
+		{
+			name:      "secp256k1 compressed public key",
+			publicKey: "0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020",
+			expected:  "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		},
+		{
+			name:      "ed25519 public key",
+			publicKey: "ED9434799226374926EDA3B54B1B461B4ABF7237962EAE18528FEA67595397FA32",
+			expected:  "rDTXLQ7ZKZVKz33zJbHjgVShjsBnqMBhmN",
+		},
+		{name: "invalid length", publicKey: "0102", expErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := EncodeClassicAddressFromPublicKeyHex(tc.publicKey)
+
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package addresscodec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// X-address prefixes - mainnet addresses start with X and testnet addresses start with T
+	XAddressMainnetPrefix = []byte{0x05, 0x44}
+	XAddressTestnetPrefix = []byte{0x04, 0x93}
+
+	ErrInvalidXAddress = errors.New("invalid x-address")
+)
+
+const (
+	// prefix (2) + account id (20) + tag flag (1) + tag (8)
+	xAddressPayloadLength = 31
+)
+
+// EncodeXAddress encodes an account ID and an optional destination tag into an X-address.
+// The tag is stored as a 64 bits little endian value after a flag byte that tells whether a tag is present.
+func EncodeXAddress(accountID []byte, tag *uint32, isTestnet bool) (string, error) {
+	if len(accountID) != AccountAddressLength {
+		return "", &EncodeLengthError{Instance: "AccountID", Expected: AccountAddressLength, Input: len(accountID)}
+	}
+
+	prefix := XAddressMainnetPrefix
+	if isTestnet {
+		prefix = XAddressTestnetPrefix
+	}
+
+	payload := make([]byte, 0, xAddressPayloadLength)
+	payload = append(payload, prefix...)
+	payload = append(payload, accountID...)
+
+	tagBytes := make([]byte, 8)
+	flag := byte(0x00)
+	if tag != nil {
+		flag = 0x01
+		binary.LittleEndian.PutUint32(tagBytes, *tag)
+	}
+
+	payload = append(payload, flag)
+	payload = append(payload, tagBytes...)
+
+	return Base58CheckEncode(payload), nil
+}
+
+// DecodeXAddress decodes an X-address into its account ID, optional destination tag and network
+func DecodeXAddress(xAddress string) (accountID []byte, tag *uint32, isTestnet bool, err error) {
+	payload, err := Base58CheckDecode(xAddress)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("%w: %v", ErrInvalidXAddress, err)
+	}
+
+	if len(payload) != xAddressPayloadLength {
+		return nil, nil, false, fmt.Errorf("%w: unexpected length %d", ErrInvalidXAddress, len(payload))
+	}
+
+	switch {
+	case bytes.Equal(payload[:2], XAddressMainnetPrefix):
+		isTestnet = false
+	case bytes.Equal(payload[:2], XAddressTestnetPrefix):
+		isTestnet = true
+	default:
+		return nil, nil, false, fmt.Errorf("%w: unknown prefix", ErrInvalidXAddress)
+	}
+
+	accountID = payload[2:22]
+	flag := payload[22]
+	tagBytes := payload[23:]
+
+	// the upper 32 bits are reserved for 64 bits tags, which are not supported by the XRPL
+	if !bytes.Equal(tagBytes[4:], make([]byte, 4)) {
+		return nil, nil, false, fmt.Errorf("%w: unsupported 64 bits tag", ErrInvalidXAddress)
+	}
+
+	switch flag {
+	case 0x00:
+		if !bytes.Equal(tagBytes[:4], make([]byte, 4)) {
+			return nil, nil, false, fmt.Errorf("%w: tag value without tag flag", ErrInvalidXAddress)
+		}
+	case 0x01:
+		value := binary.LittleEndian.Uint32(tagBytes[:4])
+		tag = &value
+	default:
+		return nil, nil, false, fmt.Errorf("%w: unsupported tag flag", ErrInvalidXAddress)
+	}
+
+	return accountID, tag, isTestnet, nil
+}
+
+// ClassicAddressToXAddress converts a classic address and an optional destination tag into an X-address
+func ClassicAddressToXAddress(classicAddress string, tag *uint32, isTestnet bool) (string, error) {
+	accountID, err := Decode(classicAddress, []byte{AccountAddressPrefix})
+	if err != nil || len(accountID) != AccountAddressLength {
+		return "", &InvalidClassicAddressError{Input: classicAddress}
+	}
+
+	return EncodeXAddress(accountID, tag, isTestnet)
+}
+
+// XAddressToClassicAddress converts an X-address into its classic address, optional destination tag and network
+func XAddressToClassicAddress(xAddress string) (classicAddress string, tag *uint32, isTestnet bool, err error) {
+	accountID, tag, isTestnet, err := DecodeXAddress(xAddress)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return Encode(accountID, []byte{AccountAddressPrefix}, AccountAddressLength), tag, isTestnet, nil
+}
+
+// IsValidXAddress reports whether the input is a valid X-address
+func IsValidXAddress(xAddress string) bool {
+	_, _, _, err := DecodeXAddress(xAddress)
+	return err == nil
+}
+
+// NormalizeAddress accepts either a classic address or an X-address and returns the classic address with the
+// destination tag embedded on the X-address, if any, and whether the address is bound to a network. An X-address
+// is bound to the mainnet or to a test network, as told by isTestnet, while a classic address is valid on any network.
+func NormalizeAddress(address string) (classicAddress string, tag *uint32, isXAddress bool, isTestnet bool, err error) {
+	if IsValidXAddress(address) {
+		classicAddress, tag, isTestnet, err := XAddressToClassicAddress(address)
+		return classicAddress, tag, err == nil, isTestnet, err
+	}
+
+	if !IsValidClassicAddress(address) {
+		return "", nil, false, false, &InvalidClassicAddressError{Input: address}
+	}
+
+	return address, nil, false, false, nil
+}
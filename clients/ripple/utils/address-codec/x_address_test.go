@@ -0,0 +1,94 @@
+package addresscodec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func TestXAddressConversion(t *testing.T) {
+	tests := []struct {
+		name      string
+		classic   string
+		tag       *uint32
+		isTestnet bool
+		xAddress  string
+	}{
+		{
+			name:     "mainnet without tag",
+			classic:  "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",
+			xAddress: "XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb",
+		},
+		{
+			name:     "mainnet with tag 1",
+			classic:  "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",
+			tag:      uint32Ptr(1),
+			xAddress: "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC",
+		},
+		{
+			name:     "mainnet with max tag",
+			classic:  "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",
+			tag:      uint32Ptr(4294967295),
+			xAddress: "XVLhHMPHU98es4dbozjVtdWzVrDjtV18pX8yuPT7y4xaEHi",
+		},
+		{
+			name:      "testnet without tag",
+			classic:   "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",
+			isTestnet: true,
+			xAddress:  "TVE26TYGhfLC7tQDno7G8dGtxSkYQn49b3qD26PK7FcGSKE",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ClassicAddressToXAddress(tc.classic, tc.tag, tc.isTestnet)
+			require.NoError(t, err)
+			require.Equal(t, tc.xAddress, got)
+
+			classic, tag, isTestnet, err := XAddressToClassicAddress(got)
+			require.NoError(t, err)
+			require.Equal(t, tc.classic, classic)
+			require.Equal(t, tc.tag, tag)
+			require.Equal(t, tc.isTestnet, isTestnet)
+		})
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	classic, tag, isXAddress, isTestnet, err := NormalizeAddress("XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC")
+	require.NoError(t, err)
+	require.Equal(t, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", classic)
+	require.Equal(t, uint32Ptr(1), tag)
+	require.True(t, isXAddress)
+	require.False(t, isTestnet)
+
+	classic, tag, isXAddress, isTestnet, err = NormalizeAddress("TVE26TYGhfLC7tQDno7G8dGtxSkYQn49b3qD26PK7FcGSKE")
+	require.NoError(t, err)
+	require.Equal(t, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", classic)
+	require.Nil(t, tag)
+	require.True(t, isXAddress)
+	require.True(t, isTestnet)
+
+	classic, tag, isXAddress, isTestnet, err = NormalizeAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	require.NoError(t, err)
+	require.Equal(t, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", classic)
+	require.Nil(t, tag)
+	require.False(t, isXAddress)
+	require.False(t, isTestnet)
+
+	_, _, _, _, err = NormalizeAddress("not-an-address")
+	require.Error(t, err)
+}
+
+func TestDecodeXAddressInvalid(t *testing.T) {
+	// changes the last character to break the checksum
+	_, _, _, err := DecodeXAddress("XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDD")
+	require.ErrorIs(t, err, ErrInvalidXAddress)
+
+	_, _, _, err = DecodeXAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	require.ErrorIs(t, err, ErrInvalidXAddress)
+}
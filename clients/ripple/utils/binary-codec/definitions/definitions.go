@@ -51,6 +51,9 @@ type Definitions struct {
 	TransactionResults map[string]int32
 	TransactionTypes   map[string]int32
 	FieldIdNameMap     map[FieldHeader]string
+	// DelegatablePermissions maps the permission names of a DelegateSet to their PermissionValue, the granular
+	// permissions along with every transaction type, whose permission is its type code plus one.
+	DelegatablePermissions map[string]int32
 }
 type definitionsDoc struct {
 	Types              map[string]int32 `json:"TYPES"`
@@ -76,19 +79,44 @@ func (fi *fieldInstanceMap) CodecDecodeSelf(d *codec.Decoder) {
 // canonical binary serialization format:
 // `Serialization <https://xrpl.org/serialization.html>`_
 func loadDefinitions() error {
+	return Load(docBytes)
+}
+
+// Load replaces the definitions in use by the codec with the given definitions document.
+// The document has the format of the embedded definitions.json, see FromServerDefinitions
+// to build one from the server_definitions output of a rippled node.
+// It is meant to be called at start up, before the codec is used.
+func Load(doc []byte) error {
+	d, err := parseDefinitions(doc)
+	if err != nil {
+		return err
+	}
+
+	definitions = d
+
+	return nil
+}
+
+// parseDefinitions decodes a definitions document and computes the field headers and ordinals.
+func parseDefinitions(doc []byte) (*Definitions, error) {
 
 	var jh codec.JsonHandle
 
 	jh.MapKeyAsString = true
 	jh.SignedInteger = true
 
-	dec := codec.NewDecoderBytes(docBytes, &jh)
+	dec := codec.NewDecoderBytes(doc, &jh)
 	var data definitionsDoc
 	err := dec.Decode(&data)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if len(data.Types) == 0 || len(data.Fields) == 0 {
+		return nil, errors.New("definitions without types or fields")
 	}
-	definitions = &Definitions{
+
+	d := &Definitions{
 		Types:              data.Types,
 		Fields:             data.Fields,
 		LedgerEntryTypes:   data.LedgerEntryTypes,
@@ -96,10 +124,11 @@ func loadDefinitions() error {
 		TransactionTypes:   data.TransactionTypes,
 	}
 
-	addFieldHeadersAndOrdinals()
-	createFieldIdNameMap()
+	d.addFieldHeadersAndOrdinals()
+	d.createFieldIdNameMap()
+	d.createDelegatablePermissions()
 
-	return nil
+	return d, nil
 }
 
 func convertToFieldInstanceMap(m [][]interface{}) map[string]*FieldInstance {
@@ -130,26 +159,53 @@ func castFieldInfo(v interface{}) (fieldInfo, error) {
 	return fieldInfo{}, errors.New("unable to cast to field info")
 }
 
-func addFieldHeadersAndOrdinals() {
-	for k := range definitions.Fields {
-		t, _ := definitions.GetTypeCodeByTypeName(definitions.Fields[k].Type)
+func (d *Definitions) addFieldHeadersAndOrdinals() {
+	for k := range d.Fields {
+		t, _ := d.GetTypeCodeByTypeName(d.Fields[k].Type)
 
-		if fi, ok := definitions.Fields[k]; ok {
+		if fi, ok := d.Fields[k]; ok {
 			fi.FieldHeader = &FieldHeader{
 				TypeCode:  t,
-				FieldCode: definitions.Fields[k].Nth,
+				FieldCode: d.Fields[k].Nth,
 			}
-			fi.Ordinal = (t<<16 | definitions.Fields[k].Nth)
+			fi.Ordinal = (t<<16 | d.Fields[k].Nth)
 		}
 	}
 }
 
-func createFieldIdNameMap() {
-	definitions.FieldIdNameMap = make(map[FieldHeader]string, len(definitions.Fields))
-	for k := range definitions.Fields {
-		fh, _ := definitions.GetFieldHeaderByFieldName(k)
+func (d *Definitions) createFieldIdNameMap() {
+	d.FieldIdNameMap = make(map[FieldHeader]string, len(d.Fields))
+	for k := range d.Fields {
+		fh, _ := d.GetFieldHeaderByFieldName(k)
 
-		definitions.FieldIdNameMap[*fh] = k
+		d.FieldIdNameMap[*fh] = k
+	}
+}
+
+// granularPermissions are the permissions of a DelegateSet on a part of a transaction type, they are not in the
+// definitions document.
+var granularPermissions = map[string]int32{
+	"TrustlineAuthorize":     65537,
+	"TrustlineFreeze":        65538,
+	"TrustlineUnfreeze":      65539,
+	"AccountDomainSet":       65540,
+	"AccountEmailHashSet":    65541,
+	"AccountMessageKeySet":   65542,
+	"AccountTransferRateSet": 65543,
+	"AccountTickSizeSet":     65544,
+	"PaymentMint":            65545,
+	"PaymentBurn":            65546,
+	"MPTokenIssuanceLock":    65547,
+	"MPTokenIssuanceUnlock":  65548,
+}
+
+func (d *Definitions) createDelegatablePermissions() {
+	d.DelegatablePermissions = make(map[string]int32, len(granularPermissions)+len(d.TransactionTypes))
+	for k, v := range granularPermissions {
+		d.DelegatablePermissions[k] = v
+	}
+	for k, v := range d.TransactionTypes {
+		d.DelegatablePermissions[k] = v + 1
 	}
 }
 
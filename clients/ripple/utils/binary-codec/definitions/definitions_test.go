@@ -1,6 +1,7 @@
 package definitions
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -28,6 +29,61 @@ func TestLoadDefinitions(t *testing.T) {
 	require.Equal(t, "NFTokenSellOffer", definitions.FieldIdNameMap[FieldHeader{TypeCode: 5, FieldCode: 29}])
 	require.Equal(t, int32(131076), definitions.Fields["Sequence"].Ordinal)
 	require.Equal(t, int32(131097), definitions.Fields["OfferSequence"].Ordinal)
+	require.Equal(t, int32(9), definitions.Types["Number"])
+	require.Equal(t, int32(24), definitions.Types["Issue"])
+	require.Equal(t, int32(35), definitions.TransactionTypes["AMMCreate"])
+	require.Equal(t, &FieldHeader{TypeCode: 24, FieldCode: 3}, definitions.Fields["Asset"].FieldHeader)
+	require.Equal(t, &FieldHeader{TypeCode: 25, FieldCode: 1}, definitions.Fields["XChainBridge"].FieldHeader)
+	require.Equal(t, &FieldHeader{TypeCode: 21, FieldCode: 1}, definitions.Fields["MPTokenIssuanceID"].FieldHeader)
+	require.Equal(t, int32(1), definitions.DelegatablePermissions["Payment"])
+	require.Equal(t, int32(65540), definitions.DelegatablePermissions["AccountDomainSet"])
+}
+
+func TestFromServerDefinitions(t *testing.T) {
+	result := strings.TrimSuffix(strings.TrimSpace(string(docBytes)), "}") + `, "hash": "2DA7F3F6", "status": "success"}`
+
+	tests := []struct {
+		name   string
+		input  string
+		expErr string
+	}{
+		{
+			name:  "json-rpc response",
+			input: `{"result": ` + result + `}`,
+		},
+		{
+			name:  "result only",
+			input: result,
+		},
+		{
+			name:   "error response",
+			input:  `{"result": {"error": "unknownCmd", "status": "error"}}`,
+			expErr: "server definitions request failed with error: unknownCmd",
+		},
+		{
+			name:   "missing sections",
+			input:  `{"TYPES": {"UInt16": 1}}`,
+			expErr: "server definitions without TYPES, FIELDS, LEDGER_ENTRY_TYPES, TRANSACTION_RESULTS or TRANSACTION_TYPES",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := FromServerDefinitions([]byte(tc.input))
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotContains(t, string(doc), "status")
+
+			d, err := parseDefinitions(doc)
+			require.NoError(t, err)
+			require.Equal(t, Get().Types, d.Types)
+			require.Equal(t, Get().TransactionTypes, d.TransactionTypes)
+			require.Equal(t, Get().FieldIdNameMap, d.FieldIdNameMap)
+		})
+	}
 }
 
 // Helper functions to create and test ordinals.
@@ -0,0 +1,71 @@
+// Command generate updates the definitions.json of the binary codec from the server_definitions
+// output of a rippled node, so new transaction types, fields and types enabled by amendments
+// are encoded and decoded by the codec.
+//
+// Usage:
+//
+//	go run ./clients/ripple/utils/binary-codec/definitions/generate -node https://s1.ripple.com:51234
+//	go run ./clients/ripple/utils/binary-codec/definitions/generate -input server_definitions.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	d "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/definitions"
+	"crypto-braza-tokens-api/utils/requests"
+)
+
+func main() {
+	node := flag.String("node", "", "JSON-RPC url of the rippled node queried with server_definitions")
+	input := flag.String("input", "", "file with a stored server_definitions response, used instead of -node")
+	output := flag.String("output", "clients/ripple/utils/binary-codec/definitions/definitions.json", "definitions file to be written")
+	flag.Parse()
+
+	var raw []byte
+	var err error
+
+	switch {
+	case *input != "":
+		raw, err = os.ReadFile(*input)
+	case *node != "":
+		raw, err = fetchServerDefinitions(*node)
+	default:
+		log.Fatal("one of -node or -input must be provided")
+	}
+	if err != nil {
+		log.Fatalf("failed to retrieve server definitions with error: %v", err)
+	}
+
+	doc, err := d.FromServerDefinitions(raw)
+	if err != nil {
+		log.Fatalf("failed to convert server definitions with error: %v", err)
+	}
+
+	if err := os.WriteFile(*output, doc, 0644); err != nil {
+		log.Fatalf("failed to write %s with error: %v", *output, err)
+	}
+
+	log.Printf("definitions written to %s", *output)
+}
+
+func fetchServerDefinitions(url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	payload := map[string]any{
+		"method": "server_definitions",
+		"params": []any{map[string]any{}},
+	}
+
+	var result json.RawMessage
+	if err := requests.Execute(ctx, "POST", url, &result, map[string]any{"payload": payload}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
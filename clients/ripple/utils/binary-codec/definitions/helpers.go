@@ -72,7 +72,7 @@ func (d *Definitions) GetFieldHeaderByFieldName(n string) (*FieldHeader, error)
 // Returns the field name associated with the given field header struct.
 func (d *Definitions) GetFieldNameByFieldHeader(fh FieldHeader) (string, error) {
 
-	fim, ok := definitions.FieldIdNameMap[fh]
+	fim, ok := d.FieldIdNameMap[fh]
 
 	if !ok {
 		return "", &NotFoundErrorFieldHeader{
@@ -197,3 +197,32 @@ func (d *Definitions) GetLedgerEntryTypeNameByLedgerEntryTypeCode(c int32) (stri
 		Input:    c,
 	}
 }
+
+// Returns the permission value of a DelegateSet associated with the permission name.
+func (d *Definitions) GetDelegatablePermissionValueByName(n string) (int32, error) {
+
+	permissionValue, ok := d.DelegatablePermissions[n]
+
+	if !ok {
+		return 0, &NotFoundError{
+			Instance: "DelegatablePermissionName",
+			Input:    n,
+		}
+	}
+	return permissionValue, nil
+}
+
+// Returns the permission name of a DelegateSet associated with the permission value.
+func (d *Definitions) GetDelegatablePermissionNameByValue(v int32) (string, error) {
+
+	for permissionName, value := range d.DelegatablePermissions {
+		if value == v {
+			return permissionName, nil
+		}
+	}
+
+	return "", &NotFoundErrorInt{
+		Instance: "DelegatablePermissionValue",
+		Input:    v,
+	}
+}
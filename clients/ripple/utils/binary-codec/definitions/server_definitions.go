@@ -0,0 +1,60 @@
+package definitions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// serverDefinitionsDoc keeps the sections of the server_definitions output used by the codec.
+// The sections are kept raw so the fields order given by the node is preserved.
+type serverDefinitionsDoc struct {
+	Types              json.RawMessage `json:"TYPES"`
+	LedgerEntryTypes   json.RawMessage `json:"LEDGER_ENTRY_TYPES"`
+	Fields             json.RawMessage `json:"FIELDS"`
+	TransactionResults json.RawMessage `json:"TRANSACTION_RESULTS"`
+	TransactionTypes   json.RawMessage `json:"TRANSACTION_TYPES"`
+}
+
+// FromServerDefinitions converts the output of the rippled server_definitions method into a definitions document.
+// It accepts the whole JSON-RPC response or only its result, so the response of a node can be stored as it is.
+// The returned document has the format of the embedded definitions.json and is validated without
+// changing the definitions in use by the codec.
+func FromServerDefinitions(raw []byte) ([]byte, error) {
+	var response struct {
+		Result *struct {
+			serverDefinitionsDoc
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"result"`
+	}
+
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse server definitions: %v", err)
+	}
+
+	var doc serverDefinitionsDoc
+	if response.Result != nil {
+		if response.Result.Status == "error" {
+			return nil, fmt.Errorf("server definitions request failed with error: %s", response.Result.Error)
+		}
+		doc = response.Result.serverDefinitionsDoc
+	} else if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse server definitions: %v", err)
+	}
+
+	if doc.Types == nil || doc.Fields == nil || doc.LedgerEntryTypes == nil || doc.TransactionResults == nil || doc.TransactionTypes == nil {
+		return nil, errors.New("server definitions without TYPES, FIELDS, LEDGER_ENTRY_TYPES, TRANSACTION_RESULTS or TRANSACTION_TYPES")
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := parseDefinitions(out); err != nil {
+		return nil, fmt.Errorf("invalid server definitions: %v", err)
+	}
+
+	return append(out, '\n'), nil
+}
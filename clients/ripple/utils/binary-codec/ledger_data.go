@@ -0,0 +1,96 @@
+package binarycodec
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+	t "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/types"
+)
+
+var ErrInvalidTotalCoins = errors.New("'total_coins' must be the amount of drops as a decimal string")
+
+// ledgerHeaderField is a field of a ledger header. The header has no field ids, its fields are written in this order.
+type ledgerHeaderField struct {
+	name string
+	st   t.SerializedType
+}
+
+var ledgerHeaderFields = []ledgerHeaderField{
+	{name: "ledger_index", st: &t.UInt32{}},
+	{name: "total_coins", st: &totalCoins{}},
+	{name: "parent_hash", st: t.NewHash256()},
+	{name: "transaction_hash", st: t.NewHash256()},
+	{name: "account_hash", st: t.NewHash256()},
+	{name: "parent_close_time", st: &t.UInt32{}},
+	{name: "close_time", st: &t.UInt32{}},
+	{name: "close_time_resolution", st: &t.UInt8{}},
+	{name: "close_flags", st: &t.UInt8{}},
+}
+
+// EncodeLedgerData converts a ledger header, as returned by the ledger method, to a hex string in the canonical
+// binary format, the one hashed to get the ledger hash.
+func EncodeLedgerData(json map[string]any) (string, error) {
+	var b []byte
+	for _, f := range ledgerHeaderFields {
+		v, ok := json[f.name]
+		if !ok {
+			return "", fmt.Errorf("'%s' is required in the ledger data", f.name)
+		}
+		fb, err := f.st.FromJson(v)
+		if err != nil {
+			return "", err
+		}
+		b = append(b, fb...)
+	}
+
+	return strings.ToUpper(hex.EncodeToString(b)), nil
+}
+
+// DecodeLedgerData decodes a ledger header in the canonical binary format into its JSON form.
+func DecodeLedgerData(hexEncoded string) (map[string]any, error) {
+	b, err := hex.DecodeString(hexEncoded)
+	if err != nil {
+		return nil, err
+	}
+	p := s.NewBinaryParser(b)
+
+	m := make(map[string]any, len(ledgerHeaderFields))
+	for _, f := range ledgerHeaderFields {
+		v, err := f.st.ToJson(p)
+		if err != nil {
+			return nil, err
+		}
+		m[f.name] = v
+	}
+
+	return m, nil
+}
+
+// totalCoins is the UInt64 total_coins of a ledger header, whose JSON value is the amount of drops as a decimal string
+// instead of the hex string of the UInt64 fields of ledger objects.
+type totalCoins struct{}
+
+func (c *totalCoins) FromJson(json any) ([]byte, error) {
+	v, ok := json.(string)
+	if !ok {
+		return nil, ErrInvalidTotalCoins
+	}
+	drops, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidTotalCoins
+	}
+	return binary.BigEndian.AppendUint64(nil, drops), nil
+}
+
+func (c *totalCoins) ToJson(p *s.BinaryParser, opts ...int) (any, error) {
+	b, err := p.ReadBytes(8)
+	if err != nil {
+		return nil, err
+	}
+	return strconv.FormatUint(binary.BigEndian.Uint64(b), 10), nil
+}
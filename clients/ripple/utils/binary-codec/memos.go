@@ -0,0 +1,56 @@
+package binarycodec
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// NewMemo builds a Memo object to be added to the Memos array of a transaction.
+// MemoType and MemoData are Blob fields, so both values are hex encoded as the codec expects.
+func NewMemo(memoType, memoData string) map[string]any {
+	return map[string]any{
+		"Memo": map[string]any{
+			"MemoType": strings.ToUpper(hex.EncodeToString([]byte(memoType))),
+			"MemoData": strings.ToUpper(hex.EncodeToString([]byte(memoData))),
+		},
+	}
+}
+
+// DecodeMemos returns the MemoType and MemoData pairs of a decoded transaction Memos array as plain text.
+// Values that are not valid hex are returned as they are.
+func DecodeMemos(memos any) map[string]string {
+	result := map[string]string{}
+
+	list, ok := memos.([]any)
+	if !ok {
+		return result
+	}
+
+	for _, item := range list {
+		wrapper, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		memo, ok := wrapper["Memo"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		memoType, _ := memo["MemoType"].(string)
+		memoData, _ := memo["MemoData"].(string)
+
+		result[decodeHexText(memoType)] = decodeHexText(memoData)
+	}
+
+	return result
+}
+
+func decodeHexText(value string) string {
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return value
+	}
+
+	return string(b)
+}
@@ -0,0 +1,34 @@
+package binarycodec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemosRoundTrip(t *testing.T) {
+	tx := map[string]any{
+		"TransactionType": "Payment",
+		"Account":         "rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd",
+		"Destination":     "rwSLnXVuEPNkMw4sR7p1EsMTivsEo1LbsT",
+		"DestinationTag":  42,
+		"Amount":          "1000000",
+		"Fee":             "12",
+		"Sequence":        1,
+		"Memos": []any{
+			NewMemo("operation_id", "6710545e6f65500962b1346b"),
+			NewMemo("operation_type", "MINT"),
+		},
+	}
+
+	encoded, err := Encode(tx)
+	require.NoError(t, err)
+
+	decoded, err := Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, 42, decoded["DestinationTag"])
+	require.Equal(t, map[string]string{
+		"operation_id":   "6710545e6f65500962b1346b",
+		"operation_type": "MINT",
+	}, DecodeMemos(decoded["Memos"]))
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+)
+
+// CurrencyByteLength is the length of a serialized currency code.
+const CurrencyByteLength = 20
+
+// Currency represents a 160-bit currency code, as used by the Issue type and fields such as AMM LPTokenBalance currencies.
+// Unlike the currency of an issued Amount, XRP is a valid Currency and is serialized as 20 zero bytes.
+type Currency struct{}
+
+// FromJson serializes a currency code, which can be XRP, 3 allowed string characters or 20 bytes of hex.
+func (c *Currency) FromJson(json any) ([]byte, error) {
+	currency, ok := json.(string)
+	if !ok {
+		return nil, ErrInvalidCurrencyCode
+	}
+
+	if currency == "XRP" {
+		return make([]byte, CurrencyByteLength), nil
+	}
+
+	return serializeIssuedCurrencyCode(currency)
+}
+
+// ToJson reads a 160-bit currency code from the BinaryParser and returns its JSON representation.
+func (c *Currency) ToJson(p *s.BinaryParser, opts ...int) (any, error) {
+	b, err := p.ReadBytes(CurrencyByteLength)
+	if err != nil {
+		return nil, err
+	}
+	return deserializeCurrencyCode(b)
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	addresscodec "crypto-braza-tokens-api/clients/ripple/utils/address-codec"
+	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+)
+
+var ErrInvalidIssue = errors.New("invalid issue, expected an object with currency and issuer, only the XRP currency or an mpt_issuance_id")
+
+// mptIssuanceIdLength is the length of an MPTokenIssuanceID, the issuance sequence followed by the issuer AccountID.
+const mptIssuanceIdLength = 24
+
+// noAccount is the AccountID written in place of the issuer of an issued currency to tell an MPT issue apart.
+var noAccount = append(make([]byte, 19), 0x01)
+
+// Issue represents an asset without an amount, such as the Asset and Asset2 fields of AMM and Vault transactions.
+// XRP is serialized as its 20 bytes currency only, issued currencies as the currency followed by the issuer AccountID
+// and MPTs as the issuer AccountID, the no account AccountID and the little-endian issuance sequence.
+type Issue struct{}
+
+// FromJson serializes an issue from a JSON object like {"currency": "XRP"}, {"currency": "USD", "issuer": "r..."}
+// or {"mpt_issuance_id": "..."}.
+func (i *Issue) FromJson(json any) ([]byte, error) {
+	v, ok := json.(map[string]any)
+	if !ok {
+		return nil, ErrInvalidIssue
+	}
+
+	if id, ok := v["mpt_issuance_id"].(string); ok {
+		return mptIssueFromId(id)
+	}
+
+	currency, ok := v["currency"].(string)
+	if !ok {
+		return nil, ErrInvalidIssue
+	}
+
+	currencyBytes, err := (&Currency{}).FromJson(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(currencyBytes, zeroByteArray) {
+		if _, ok := v["issuer"]; ok {
+			return nil, ErrInvalidIssue
+		}
+		return currencyBytes, nil
+	}
+
+	issuer, ok := v["issuer"].(string)
+	if !ok {
+		return nil, ErrInvalidIssue
+	}
+
+	// the issuer is not length-prefixed, as the issuer of an Amount
+	_, issuerBytes, err := addresscodec.DecodeClassicAddressToAccountID(issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(currencyBytes, issuerBytes...), nil
+}
+
+// mptIssueFromId serializes the issue of an MPT from its MPTokenIssuanceID.
+func mptIssueFromId(id string) ([]byte, error) {
+	idBytes, err := hex.DecodeString(id)
+	if err != nil || len(idBytes) != mptIssuanceIdLength {
+		return nil, ErrInvalidIssue
+	}
+
+	b := make([]byte, 0, 2*addresscodec.AccountAddressLength+4)
+	b = append(b, idBytes[4:]...)
+	b = append(b, noAccount...)
+	return binary.LittleEndian.AppendUint32(b, binary.BigEndian.Uint32(idBytes[:4])), nil
+}
+
+// ToJson deserializes an issue, reading the issuer only when the currency is not XRP and the issuance sequence only
+// when the issuer is the no account AccountID.
+func (i *Issue) ToJson(p *s.BinaryParser, opts ...int) (any, error) {
+	currencyBytes, err := p.ReadBytes(CurrencyByteLength)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := deserializeCurrencyCode(currencyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency == "XRP" {
+		return map[string]any{"currency": currency}, nil
+	}
+
+	issuerBytes, err := p.ReadBytes(addresscodec.AccountAddressLength)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(issuerBytes, noAccount) {
+		// the currency holds the issuer of the MPT
+		sequence, err := p.ReadBytes(4)
+		if err != nil {
+			return nil, err
+		}
+		id := binary.BigEndian.AppendUint32(nil, binary.LittleEndian.Uint32(sequence))
+		return map[string]any{
+			"mpt_issuance_id": strings.ToUpper(hex.EncodeToString(append(id, currencyBytes...))),
+		}, nil
+	}
+
+	issuer, err := deserializeIssuer(issuerBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"currency": currency,
+		"issuer":   issuer,
+	}, nil
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	xrpCurrencyHex = "0000000000000000000000000000000000000000"
+	usdCurrencyHex = "0000000000000000000000005553440000000000"
+	genesisHex     = "B5F762798A53D543A014CAF8B297CFF8F2F937E8"
+	issuerHex      = "AA066C988C712815CC37AF71472B7CBBBD4E2A0A"
+)
+
+func TestIssue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+		expErr   error
+	}{
+		{
+			name:     "xrp",
+			input:    map[string]any{"currency": "XRP"},
+			expected: xrpCurrencyHex,
+		},
+		{
+			name:     "issued currency",
+			input:    map[string]any{"currency": "USD", "issuer": "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"},
+			expected: usdCurrencyHex + issuerHex,
+		},
+		{
+			name:     "non standard currency",
+			input:    map[string]any{"currency": "5553444300000000000000000000000000000000", "issuer": "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"},
+			expected: "5553444300000000000000000000000000000000" + issuerHex,
+		},
+		{
+			name:     "mpt",
+			input:    map[string]any{"mpt_issuance_id": "000002D2E0739D43718DB5815CE070D4D514A261EC872C93"},
+			expected: "E0739D43718DB5815CE070D4D514A261EC872C93" + "0000000000000000000000000000000000000001" + "D2020000",
+		},
+		{
+			name:   "mpt issuance id of another length",
+			input:  map[string]any{"mpt_issuance_id": "E0739D43718DB5815CE070D4D514A261EC872C93"},
+			expErr: ErrInvalidIssue,
+		},
+		{
+			name:   "issued currency without issuer",
+			input:  map[string]any{"currency": "USD"},
+			expErr: ErrInvalidIssue,
+		},
+		{
+			name:   "xrp with issuer",
+			input:  map[string]any{"currency": "XRP", "issuer": "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"},
+			expErr: ErrInvalidIssue,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := &Issue{}
+
+			got, err := issue.FromJson(tc.input)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, strings.ToUpper(hex.EncodeToString(got)))
+
+			json, err := issue.ToJson(s.NewBinaryParser(got))
+			require.NoError(t, err)
+			require.Equal(t, tc.input, json)
+		})
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	for _, currency := range []string{"XRP", "USD", "5553444300000000000000000000000000000000"} {
+		t.Run(currency, func(t *testing.T) {
+			c := &Currency{}
+
+			got, err := c.FromJson(currency)
+			require.NoError(t, err)
+			require.Len(t, got, CurrencyByteLength)
+
+			json, err := c.ToJson(s.NewBinaryParser(got))
+			require.NoError(t, err)
+			require.Equal(t, currency, json)
+		})
+	}
+}
+
+func TestXChainBridge(t *testing.T) {
+	bridge := map[string]any{
+		"LockingChainDoor":  "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",
+		"LockingChainIssue": map[string]any{"currency": "XRP"},
+		"IssuingChainDoor":  "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		"IssuingChainIssue": map[string]any{"currency": "USD", "issuer": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+	}
+
+	x := &XChainBridge{}
+
+	got, err := x.FromJson(bridge)
+	require.NoError(t, err)
+	// the doors are length-prefixed AccountIDs, the issues are not
+	require.Equal(t, "14"+issuerHex+xrpCurrencyHex+"14"+genesisHex+usdCurrencyHex+genesisHex, strings.ToUpper(hex.EncodeToString(got)))
+
+	json, err := x.ToJson(s.NewBinaryParser(got))
+	require.NoError(t, err)
+	require.Equal(t, bridge, json)
+
+	_, err = x.FromJson(map[string]any{"LockingChainDoor": "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"})
+	require.ErrorIs(t, err, ErrInvalidXChainBridge)
+}
+
+func TestUInt64FromHex(t *testing.T) {
+	got, err := (&UInt64{}).FromJson("2030FC8")
+	require.NoError(t, err)
+	require.Equal(t, "0000000002030FC8", strings.ToUpper(hex.EncodeToString(got)))
+}
@@ -0,0 +1,185 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+)
+
+const (
+	MinNumberMantissa = 1000000000000000
+	MaxNumberMantissa = 9999999999999999
+	MinNumberExponent = -32768
+	MaxNumberExponent = 32768
+
+	// ZeroNumberExponent is the exponent used by rippled to represent a zero Number
+	ZeroNumberExponent = math.MinInt32
+
+	NumberByteLength = 12
+)
+
+var (
+	ErrInvalidNumber  = errors.New("invalid number, expected a decimal string such as 1.5, -12 or 2e-8")
+	ErrNumberOverflow = errors.New("number exponent is out of range")
+
+	numberRegex = regexp.MustCompile(`^([-+]?)([0-9]+)(?:\.([0-9]+))?(?:[eE]([-+]?[0-9]+))?$`)
+)
+
+// Number represents the rippled STNumber, an arbitrary decimal used by the ledger objects and transactions
+// of recent amendments. It is serialized as a signed 64-bit mantissa followed by a signed 32-bit exponent,
+// the mantissa being normalized in the range [1e15, 1e16) as rippled does.
+type Number struct{}
+
+// FromJson serializes a decimal string (or an integer) into its mantissa and exponent bytes.
+func (n *Number) FromJson(json any) ([]byte, error) {
+	var value string
+	switch v := json.(type) {
+	case string:
+		value = v
+	case int:
+		value = strconv.Itoa(v)
+	default:
+		return nil, ErrInvalidNumber
+	}
+
+	mantissa, exponent, err := parseNumber(value)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, NumberByteLength)
+	binary.BigEndian.PutUint64(b[:8], uint64(mantissa))
+	binary.BigEndian.PutUint32(b[8:], uint32(exponent))
+
+	return b, nil
+}
+
+// ToJson reads a Number and formats it as rippled does, using the scientific notation for exponents
+// smaller than -25 or greater than -5 and the decimal notation otherwise.
+func (n *Number) ToJson(p *s.BinaryParser, opts ...int) (any, error) {
+	b, err := p.ReadBytes(NumberByteLength)
+	if err != nil {
+		return nil, err
+	}
+
+	mantissa := int64(binary.BigEndian.Uint64(b[:8]))
+	exponent := int32(binary.BigEndian.Uint32(b[8:]))
+
+	return formatNumber(mantissa, exponent), nil
+}
+
+// parseNumber parses a decimal string and normalizes its mantissa, rounding half to even
+// the digits that do not fit into the 16 digits of precision.
+func parseNumber(value string) (int64, int32, error) {
+	m := numberRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return 0, 0, ErrInvalidNumber
+	}
+
+	mantissa, ok := new(big.Int).SetString(m[2]+m[3], 10)
+	if !ok {
+		return 0, 0, ErrInvalidNumber
+	}
+
+	exponent := -int64(len(m[3]))
+	if m[4] != "" {
+		e, err := strconv.ParseInt(m[4], 10, 32)
+		if err != nil {
+			return 0, 0, ErrNumberOverflow
+		}
+		exponent += e
+	}
+
+	if mantissa.Sign() == 0 {
+		return 0, ZeroNumberExponent, nil
+	}
+
+	ten := big.NewInt(10)
+	minMantissa := big.NewInt(MinNumberMantissa)
+	maxMantissa := big.NewInt(MaxNumberMantissa)
+
+	for mantissa.Cmp(minMantissa) < 0 {
+		mantissa.Mul(mantissa, ten)
+		exponent--
+	}
+
+	// the dropped digits are kept to round the mantissa
+	var lastDropped int64
+	var sticky bool
+	remainder := new(big.Int)
+	for mantissa.Cmp(maxMantissa) > 0 {
+		if lastDropped != 0 {
+			sticky = true
+		}
+		mantissa.QuoRem(mantissa, ten, remainder)
+		lastDropped = remainder.Int64()
+		exponent++
+	}
+
+	if lastDropped > 5 || (lastDropped == 5 && (sticky || mantissa.Bit(0) == 1)) {
+		mantissa.Add(mantissa, big.NewInt(1))
+		if mantissa.Cmp(maxMantissa) > 0 {
+			mantissa.Quo(mantissa, ten)
+			exponent++
+		}
+	}
+
+	if exponent < MinNumberExponent {
+		return 0, ZeroNumberExponent, nil
+	}
+	if exponent > MaxNumberExponent {
+		return 0, 0, ErrNumberOverflow
+	}
+
+	result := mantissa.Int64()
+	if m[1] == "-" {
+		result = -result
+	}
+
+	return result, int32(exponent), nil
+}
+
+// formatNumber returns the string representation of a Number as rippled's to_string.
+func formatNumber(mantissa int64, exponent int32) string {
+	if mantissa == 0 {
+		return "0"
+	}
+
+	if exponent != 0 && (exponent < -25 || exponent > -5) {
+		return strconv.FormatInt(mantissa, 10) + "e" + strconv.FormatInt(int64(exponent), 10)
+	}
+
+	sign := ""
+	if mantissa < 0 {
+		sign = "-"
+	}
+
+	digits := new(big.Int).Abs(big.NewInt(mantissa)).String()
+	if exponent == 0 {
+		return sign + digits
+	}
+
+	point := len(digits) + int(exponent)
+
+	var pre, post string
+	if point <= 0 {
+		pre = "0"
+		post = strings.Repeat("0", -point) + digits
+	} else {
+		pre = digits[:point]
+		post = digits[point:]
+	}
+
+	post = strings.TrimRight(post, "0")
+	if post == "" {
+		return sign + pre
+	}
+
+	return sign + pre + "." + post
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+		json     string
+		expErr   error
+	}{
+		{
+			name:     "zero",
+			input:    "0",
+			expected: "000000000000000080000000",
+			json:     "0",
+		},
+		{
+			name:     "integer",
+			input:    1,
+			expected: "00038D7EA4C68000FFFFFFF1",
+			json:     "1",
+		},
+		{
+			name:     "negative decimal",
+			input:    "-1.5",
+			expected: "FFFAABC208D64000FFFFFFF1",
+			json:     "-1.5",
+		},
+		{
+			name:     "small decimal",
+			input:    "0.000123",
+			expected: "00045EADB112E000FFFFFFED",
+			json:     "0.000123",
+		},
+		{
+			name:     "large value in scientific notation",
+			input:    "1e20",
+			expected: "00038D7EA4C6800000000005",
+			json:     "1000000000000000e5",
+		},
+		{
+			name:     "rounds the digits beyond the precision",
+			input:    "12345678901234567",
+			expected: "000462D53C8ABAC100000001",
+			json:     "1234567890123457e1",
+		},
+		{
+			name:     "rounds half to even",
+			input:    "12345678901234565",
+			expected: "000462D53C8ABAC000000001",
+			json:     "1234567890123456e1",
+		},
+		{
+			name:   "invalid number",
+			input:  "1.2.3",
+			expErr: ErrInvalidNumber,
+		},
+		{
+			name:   "exponent out of range",
+			input:  "1e40000",
+			expErr: ErrNumberOverflow,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			number := &Number{}
+
+			got, err := number.FromJson(tc.input)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, strings.ToUpper(hex.EncodeToString(got)))
+
+			json, err := number.ToJson(s.NewBinaryParser(got))
+			require.NoError(t, err)
+			require.Equal(t, tc.json, json)
+		})
+	}
+}
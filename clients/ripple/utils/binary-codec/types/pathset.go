@@ -69,14 +69,19 @@ func (p PathSet) ToJson(parser *s.BinaryParser, opts ...int) (any, error) {
 				if !ok {
 					return nil, fmt.Errorf("step is not of type map[string]any")
 				}
+				// the step type combines the flags of its fields, as on the paths returned by rippled
+				stepType := 0
 				if _, ok := stepMap["account"]; ok {
-					stepMap["type"] = 1
-					stepMap["type_hex"] = "0000000000000001"
+					stepType |= typeAccount
 				}
 				if _, ok := stepMap["currency"]; ok {
-					stepMap["type"] = 16
-					stepMap["type_hex"] = "0000000000000010"
+					stepType |= typeCurrency
 				}
+				if _, ok := stepMap["issuer"]; ok {
+					stepType |= typeIssuer
+				}
+				stepMap["type"] = stepType
+				stepMap["type_hex"] = fmt.Sprintf("%016X", stepType)
 				path[i] = stepMap
 			}
 			pathSet = append(pathSet, path)
@@ -111,7 +116,11 @@ func newPathStep(v map[string]any) []byte {
 		dataType |= typeAccount
 	}
 	if v["currency"] != nil {
-		currency, _ := serializeIssuedCurrencyCode(v["currency"].(string))
+		// XRP steps of the paths found by rippled are serialized as the all zeros currency code
+		currency := make([]byte, 20)
+		if code := v["currency"].(string); code != "XRP" {
+			currency, _ = serializeIssuedCurrencyCode(code)
+		}
 		b = append(b, currency...)
 		dataType |= typeCurrency
 	}
@@ -140,11 +149,9 @@ func newPath(v []any) []byte {
 func newPathSet(v []any) []byte {
 
 	b := make([]byte, 0)
-	padding := make([]byte, 20)
 
 	for _, path := range v { // for each path in the path set (slice of paths)
 		b = append(b, newPath(path.([]any))...) // append the path to the byte array
-		b = append(b, padding...)               // append 20 empty bytes to the byte array between paths
 		b = append(b, pathSeparatorByte)        // between each path, append a path separator byte
 	}
 
@@ -1,6 +1,22 @@
 package types
 
-import s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+import (
+	"fmt"
+
+	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+)
+
+// ErrUnsupportedType is returned when a field has a type that the codec is not able to serialize,
+// usually a type added by an amendment after the codec types were written.
+type ErrUnsupportedType struct {
+	Type      string
+	FieldName string
+}
+
+// Error method for ErrUnsupportedType formats the error message.
+func (e *ErrUnsupportedType) Error() string {
+	return fmt.Sprintf("unsupported type %v of field %v", e.Type, e.FieldName)
+}
 
 // SerializedType is an interface representing any type that can be serialized
 // and deserialized to and from JSON.
@@ -47,6 +63,22 @@ func GetSerializedType(t string) SerializedType {
 		return &STArray{}
 	case "PathSet":
 		return &PathSet{}
+	case "Number":
+		return &Number{}
+	case "UInt96":
+		return NewUInt96()
+	case "Hash192", "UInt192":
+		return NewHash192()
+	case "UInt384":
+		return NewUInt384()
+	case "UInt512":
+		return NewUInt512()
+	case "Issue":
+		return &Issue{}
+	case "XChainBridge":
+		return &XChainBridge{}
+	case "Currency":
+		return &Currency{}
 	}
 	return nil
 }
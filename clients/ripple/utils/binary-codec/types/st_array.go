@@ -62,6 +62,9 @@ func (t *STArray) ToJson(p *s.BinaryParser, opts ...int) (any, error) {
 		}
 		fn := fi.FieldName
 		st := GetSerializedType(fi.Type)
+		if st == nil {
+			return nil, &ErrUnsupportedType{Type: fi.Type, FieldName: fi.FieldName}
+		}
 		res, err := st.ToJson(p)
 		if err != nil {
 			return nil, err
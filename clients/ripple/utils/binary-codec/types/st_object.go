@@ -37,6 +37,9 @@ func (t *STObject) FromJson(json any) ([]byte, error) {
 		}
 
 		st := GetSerializedType(v.Type)
+		if st == nil {
+			return nil, &ErrUnsupportedType{Type: v.Type, FieldName: v.FieldName}
+		}
 		b, err := st.FromJson(fimap[v])
 		if err != nil {
 			return nil, err
@@ -67,6 +70,9 @@ func (t *STObject) ToJson(p *s.BinaryParser, opts ...int) (any, error) {
 		}
 
 		st := GetSerializedType(fi.Type)
+		if st == nil {
+			return nil, &ErrUnsupportedType{Type: fi.Type, FieldName: fi.FieldName}
+		}
 
 		var res any
 		if fi.IsVLEncoded {
@@ -138,8 +144,8 @@ func getSortedKeys(m map[d.FieldInstance]any) []d.FieldInstance {
 
 // enumToStr is a helper function that takes a field name and its associated value,
 // and returns a string representation of the value if the field is an enumerated type
-// (i.e., TransactionType, TransactionResult, LedgerEntryType).
-// If the field is not an enumerated type, the original value is returned.
+// (i.e., TransactionType, TransactionResult, LedgerEntryType, PermissionValue).
+// If the field is not an enumerated type, or a PermissionValue without a name, the original value is returned.
 func enumToStr(fieldType string, value any) (any, error) {
 	switch fieldType {
 	case "TransactionType":
@@ -148,6 +154,11 @@ func enumToStr(fieldType string, value any) (any, error) {
 		return d.Get().GetTransactionResultNameByTransactionResultTypeCode(int32(value.(int)))
 	case "LedgerEntryType":
 		return d.Get().GetLedgerEntryTypeNameByLedgerEntryTypeCode(int32(value.(int)))
+	case "PermissionValue":
+		if name, err := d.Get().GetDelegatablePermissionNameByValue(int32(value.(int))); err == nil {
+			return name, nil
+		}
+		return value, nil
 	default:
 		return value, nil
 	}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 
+	d "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/definitions"
 	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
 )
 
@@ -11,8 +12,18 @@ import (
 type UInt32 struct{}
 
 // FromJson converts a JSON value into a serialized byte slice representing a 32-bit unsigned integer.
-// The input value is assumed to be an integer. If the serialization fails, an error is returned.
+// If the input value is a string, it's assumed to be the permission name of a PermissionValue, and the method will
+// attempt to convert it into the corresponding permission value. If the serialization fails, an error is returned.
 func (u *UInt32) FromJson(value any) ([]byte, error) {
+
+	if name, ok := value.(string); ok {
+		pv, err := d.Get().GetDelegatablePermissionValueByName(name)
+		if err != nil {
+			return nil, err
+		}
+		value = int(pv)
+	}
+
 	buf := new(bytes.Buffer)
 	err := binary.Write(buf, binary.BigEndian, uint32(value.(int)))
 
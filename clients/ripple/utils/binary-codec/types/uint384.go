@@ -0,0 +1,13 @@
+package types
+
+// UInt384 struct represents a 384-bit value, serialized as a fixed length hexadecimal string.
+type UInt384 struct {
+	hashI
+}
+
+// NewUInt384 is a constructor for creating a new 384-bit value.
+func NewUInt384() *UInt384 {
+	return &UInt384{
+		newHash(48),
+	}
+}
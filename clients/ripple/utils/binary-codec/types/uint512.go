@@ -0,0 +1,13 @@
+package types
+
+// UInt512 struct represents a 512-bit value, serialized as a fixed length hexadecimal string.
+type UInt512 struct {
+	hashI
+}
+
+// NewUInt512 is a constructor for creating a new 512-bit value.
+func NewUInt512() *UInt512 {
+	return &UInt512{
+		newHash(64),
+	}
+}
@@ -0,0 +1,13 @@
+package types
+
+// UInt96 struct represents a 96-bit value, serialized as a fixed length hexadecimal string.
+type UInt96 struct {
+	hashI
+}
+
+// NewUInt96 is a constructor for creating a new 96-bit value.
+func NewUInt96() *UInt96 {
+	return &UInt96{
+		newHash(12),
+	}
+}
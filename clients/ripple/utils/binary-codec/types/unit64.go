@@ -28,9 +28,12 @@ func (u *UInt64) FromJson(value any) ([]byte, error) {
 	}
 
 	if !isNumeric(value.(string)) {
-		if hex, err := hex.DecodeString(value.(string)); err == nil {
-			buf.Write(hex)
-			return buf.Bytes(), nil
+		// hex values are right justified as the numeric ones, e.g. the AssetPrice of oracles
+		if len(value.(string)) <= 16 {
+			if hex, err := hex.DecodeString(strings.Repeat("0", 16-len(value.(string))) + value.(string)); err == nil {
+				buf.Write(hex)
+				return buf.Bytes(), nil
+			}
 		}
 		stringToUint64, err := strconv.ParseUint(value.(string), 10, 64)
 		if err != nil {
@@ -24,15 +24,25 @@ func (e *ErrInvalidVector256Type) Error() string {
 type Vector256 struct{}
 
 // FromJson converts a JSON value into a serialized byte slice representing a Vector256.
-// The input value is assumed to be an array of strings representing Hash256 values.
-// If the serialization fails, an error is returned.
+// The input value is assumed to be an array of strings representing Hash256 values, either as a []string or as the
+// []any of a decoded JSON document. If the serialization fails, an error is returned.
 func (v *Vector256) FromJson(json any) ([]byte, error) {
 
-	if _, ok := json.([]string); !ok {
-		return nil, &ErrInvalidVector256Type{fmt.Sprintf("%T", json)}
+	value, ok := json.([]string)
+	if !ok {
+		values, isArray := json.([]any)
+		if !isArray {
+			return nil, &ErrInvalidVector256Type{fmt.Sprintf("%T", json)}
+		}
+		value = make([]string, len(values))
+		for i, x := range values {
+			if value[i], ok = x.(string); !ok {
+				return nil, &ErrInvalidVector256Type{fmt.Sprintf("%T", json)}
+			}
+		}
 	}
 
-	b, err := vector256FromValue(json.([]string))
+	b, err := vector256FromValue(value)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"errors"
+
+	s "crypto-braza-tokens-api/clients/ripple/utils/binary-codec/serdes"
+)
+
+var ErrInvalidXChainBridge = errors.New("invalid xchain bridge, expected LockingChainDoor, LockingChainIssue, IssuingChainDoor and IssuingChainIssue")
+
+// xChainBridgeDoorPrefix is the length prefix of the door accounts, which are serialized as stand-alone AccountIDs.
+const xChainBridgeDoorPrefix = 0x14
+
+// XChainBridge represents the bridge between a locking chain and an issuing chain used by the XChain transactions.
+// It is serialized as the locking chain door and issue followed by the issuing chain door and issue,
+// the doors are length-prefixed AccountIDs and the issues follow the Issue serialization.
+type XChainBridge struct{}
+
+// FromJson serializes an XChainBridge JSON object.
+func (x *XChainBridge) FromJson(json any) ([]byte, error) {
+	v, ok := json.(map[string]any)
+	if !ok {
+		return nil, ErrInvalidXChainBridge
+	}
+
+	var sink []byte
+	for _, door := range [][2]string{{"LockingChainDoor", "LockingChainIssue"}, {"IssuingChainDoor", "IssuingChainIssue"}} {
+		account, ok := v[door[0]].(string)
+		if !ok || v[door[1]] == nil {
+			return nil, ErrInvalidXChainBridge
+		}
+
+		accountBytes, err := (&AccountID{}).FromJson(account)
+		if err != nil {
+			return nil, err
+		}
+
+		issueBytes, err := (&Issue{}).FromJson(v[door[1]])
+		if err != nil {
+			return nil, err
+		}
+
+		sink = append(sink, xChainBridgeDoorPrefix)
+		sink = append(sink, accountBytes...)
+		sink = append(sink, issueBytes...)
+	}
+
+	return sink, nil
+}
+
+// ToJson deserializes an XChainBridge from the BinaryParser into its JSON object.
+func (x *XChainBridge) ToJson(p *s.BinaryParser, opts ...int) (any, error) {
+	result := make(map[string]any, 4)
+
+	for _, door := range [][2]string{{"LockingChainDoor", "LockingChainIssue"}, {"IssuingChainDoor", "IssuingChainIssue"}} {
+		length, err := p.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		account, err := (&AccountID{}).ToJson(p, int(length))
+		if err != nil {
+			return nil, err
+		}
+
+		issue, err := (&Issue{}).ToJson(p)
+		if err != nil {
+			return nil, err
+		}
+
+		result[door[0]] = account
+		result[door[1]] = issue
+	}
+
+	return result, nil
+}
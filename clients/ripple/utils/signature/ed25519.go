@@ -0,0 +1,85 @@
+package signature
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	ALGORITHM_SECP256K1 = "SECP256K1"
+	ALGORITHM_ED25519   = "ED25519"
+
+	// ed25519 public keys are prefixed with 0xED on the XRPL to tell them apart from secp256k1 compressed keys
+	ed25519KeyPrefix = "ED"
+)
+
+// DetectAlgorithm returns the signing algorithm of a public key from its length and prefix.
+// Ed25519 keys are 32 bytes long, or 33 bytes with the 0xED prefix. Secp256k1 keys are 33 bytes compressed
+// (0x02 or 0x03 prefix) or 65 bytes uncompressed (0x04 prefix).
+func DetectAlgorithm(publicKeyHex string) (string, error) {
+	b, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode public key: %v", err)
+	}
+
+	switch {
+	case len(b) == ed25519.PublicKeySize:
+		return ALGORITHM_ED25519, nil
+	case len(b) == 33 && b[0] == 0xED:
+		return ALGORITHM_ED25519, nil
+	case len(b) == 33 && (b[0] == 0x02 || b[0] == 0x03):
+		return ALGORITHM_SECP256K1, nil
+	case len(b) == 65 && b[0] == 0x04:
+		return ALGORITHM_SECP256K1, nil
+	default:
+		return "", fmt.Errorf("unsupported public key format with %d bytes", len(b))
+	}
+}
+
+// FormatSigningPubKey returns the public key as expected on the SigningPubKey field of a transaction,
+// adding the 0xED prefix to raw ed25519 keys
+func FormatSigningPubKey(publicKeyHex string) (string, error) {
+	algorithm, err := DetectAlgorithm(publicKeyHex)
+	if err != nil {
+		return "", err
+	}
+
+	publicKeyHex = strings.ToUpper(publicKeyHex)
+	if algorithm == ALGORITHM_ED25519 && len(publicKeyHex) == ed25519.PublicKeySize*2 {
+		return ed25519KeyPrefix + publicKeyHex, nil
+	}
+
+	return publicKeyHex, nil
+}
+
+// VerifyEd25519 verifies an ed25519 signature against the signing public key (with or without the 0xED prefix)
+// and the full signed message, since ed25519 on the XRPL signs the prefixed transaction instead of its hash
+func VerifyEd25519(publicKeyHex, messageHex, signatureHex string) error {
+	publicKeyHex = strings.ToUpper(publicKeyHex)
+	if len(publicKeyHex) == (ed25519.PublicKeySize+1)*2 {
+		publicKeyHex = strings.TrimPrefix(publicKeyHex, ed25519KeyPrefix)
+	}
+
+	publicKey, err := hex.DecodeString(publicKeyHex)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid ed25519 public key: %s", publicKeyHex)
+	}
+
+	message, err := hex.DecodeString(messageHex)
+	if err != nil {
+		return fmt.Errorf("failed to decode message: %v", err)
+	}
+
+	sig, err := hex.DecodeString(signatureHex)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid ed25519 signature: %s", signatureHex)
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), message, sig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package signature
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectAlgorithm(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	secpKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		publicKey string
+		expected  string
+		expErr    bool
+	}{
+		{name: "detects a raw ed25519 key", publicKey: hex.EncodeToString(edPub), expected: ALGORITHM_ED25519},
+		{name: "detects a prefixed ed25519 key", publicKey: "ED" + hex.EncodeToString(edPub), expected: ALGORITHM_ED25519},
+		{name: "detects a compressed secp256k1 key", publicKey: hex.EncodeToString(secpKey.PubKey().SerializeCompressed()), expected: ALGORITHM_SECP256K1},
+		{name: "detects an uncompressed secp256k1 key", publicKey: hex.EncodeToString(secpKey.PubKey().SerializeUncompressed()), expected: ALGORITHM_SECP256K1},
+		{name: "fails with an unsupported key", publicKey: "0102", expErr: true},
+		{name: "fails with an invalid hex", publicKey: "XYZ", expErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DetectAlgorithm(tc.publicKey)
+
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestVerifyEd25519(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	signingPubKey, err := FormatSigningPubKey(hex.EncodeToString(edPub))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(signingPubKey, "ED"))
+	require.Len(t, signingPubKey, 66)
+
+	messageHex := "535458001200002280000000"
+	message, _ := hex.DecodeString(messageHex)
+	sigHex := hex.EncodeToString(ed25519.Sign(edPriv, message))
+
+	require.NoError(t, VerifyEd25519(signingPubKey, messageHex, sigHex))
+	require.ErrorIs(t, VerifyEd25519(signingPubKey, "535458001200002280000001", sigHex), ErrInvalidSignature)
+	require.Error(t, VerifyEd25519(signingPubKey, messageHex, "ABCD"))
+}
@@ -0,0 +1,127 @@
+package signature
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+var (
+	ErrInvalidSignature      = errors.New("signature does not match the public key and hash")
+	ErrSignedContentMismatch = errors.New("signed content does not match the requested hash")
+)
+
+// parseScalar decodes a big endian hex value into a scalar modulo the curve order, failing on overflow or zero values
+func parseScalar(name, valueHex string) (*secp256k1.ModNScalar, error) {
+	b, err := hex.DecodeString(valueHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %v", name, err)
+	}
+
+	if len(b) > 32 {
+		return nil, fmt.Errorf("invalid %s length: %d bytes", name, len(b))
+	}
+
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(b); overflow || scalar.IsZero() {
+		return nil, fmt.Errorf("invalid %s value: out of the curve order range", name)
+	}
+
+	return &scalar, nil
+}
+
+// CanonicalizeS returns the low half form of the S value of a secp256k1 signature.
+// The XRPL requires fully canonical signatures, where S must not be greater than half of the curve order,
+// so a high S is replaced by (N - S), which is an equally valid signature for the same R.
+// The boolean result reports whether S was changed.
+func CanonicalizeS(sHex string) (string, bool, error) {
+	s, err := parseScalar("s", sHex)
+	if err != nil {
+		return "", false, err
+	}
+
+	if !s.IsOverHalfOrder() {
+		return strings.ToUpper(sHex), false, nil
+	}
+
+	s.Negate()
+	sBytes := s.Bytes()
+
+	return strings.ToUpper(hex.EncodeToString(sBytes[:])), true, nil
+}
+
+// VerifySecp256k1 verifies a secp256k1 signature given by its R and S values against the signing public key
+// (compressed or uncompressed hex) and the 32 bytes hash that was signed
+func VerifySecp256k1(publicKeyHex, hashHex, rHex, sHex string) error {
+	pubKeyBytes, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		return fmt.Errorf("failed to decode public key: %v", err)
+	}
+
+	pubKey, err := secp256k1.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %v", err)
+	}
+
+	hash, err := hex.DecodeString(hashHex)
+	if err != nil {
+		return fmt.Errorf("failed to decode hash: %v", err)
+	}
+
+	if len(hash) != 32 {
+		return fmt.Errorf("invalid hash length: %d bytes", len(hash))
+	}
+
+	r, err := parseScalar("r", rHex)
+	if err != nil {
+		return err
+	}
+
+	s, err := parseScalar("s", sHex)
+	if err != nil {
+		return err
+	}
+
+	if !ecdsa.NewSignature(r, s).Verify(hash, pubKey) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// VerifySecp256k1DER verifies a DER-encoded secp256k1 signature, as found on signed transactions and claims, against
+// the signing public key and the 32 bytes hash that was signed. Only fully canonical signatures, with a low S, are valid.
+func VerifySecp256k1DER(publicKeyHex, hashHex, derHex string) error {
+	der, err := hex.DecodeString(derHex)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %v", err)
+	}
+
+	sig, err := ecdsa.ParseDERSignature(der)
+	if err != nil {
+		return fmt.Errorf("failed to parse DER signature: %v", err)
+	}
+
+	s := sig.S()
+	if s.IsOverHalfOrder() {
+		return fmt.Errorf("signature is not fully canonical")
+	}
+
+	r := sig.R()
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+
+	return VerifySecp256k1(publicKeyHex, hashHex, hex.EncodeToString(rBytes[:]), hex.EncodeToString(sBytes[:]))
+}
+
+// VerifySignedContent checks that the content signed by the custody provider is the hash that was requested
+func VerifySignedContent(expectedHashHex, signedContentHex string) error {
+	if !strings.EqualFold(expectedHashHex, signedContentHex) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrSignedContentMismatch, expectedHashHex, signedContentHex)
+	}
+
+	return nil
+}
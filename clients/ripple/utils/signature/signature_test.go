@@ -0,0 +1,86 @@
+package signature
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/require"
+)
+
+func scalarHex(s secp256k1.ModNScalar) string {
+	b := s.Bytes()
+	return strings.ToUpper(hex.EncodeToString(b[:]))
+}
+
+func TestVerifySecp256k1(t *testing.T) {
+	privKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	pubKeyHex := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+	hashHex := "A3C1F1B5D2E4F60718293A4B5C6D7E8F90A1B2C3D4E5F60718293A4B5C6D7E8F"
+	hash, _ := hex.DecodeString(hashHex)
+
+	sig := ecdsa.Sign(privKey, hash)
+	r, s := sig.R(), sig.S()
+	rHex, sHex := scalarHex(r), scalarHex(s)
+
+	// builds the high S form of the same signature
+	highS := s
+	highS.Negate()
+	highSHex := scalarHex(highS)
+
+	t.Run("verifies a valid signature", func(t *testing.T) {
+		require.NoError(t, VerifySecp256k1(pubKeyHex, hashHex, rHex, sHex))
+	})
+
+	t.Run("canonicalizes a high S into the low S form", func(t *testing.T) {
+		canonical, changed, err := CanonicalizeS(highSHex)
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Equal(t, sHex, canonical)
+		require.NoError(t, VerifySecp256k1(pubKeyHex, hashHex, rHex, canonical))
+	})
+
+	t.Run("keeps a low S untouched", func(t *testing.T) {
+		canonical, changed, err := CanonicalizeS(sHex)
+		require.NoError(t, err)
+		require.False(t, changed)
+		require.Equal(t, sHex, canonical)
+	})
+
+	t.Run("fails with a different hash", func(t *testing.T) {
+		otherHash := "B3C1F1B5D2E4F60718293A4B5C6D7E8F90A1B2C3D4E5F60718293A4B5C6D7E8F"
+		require.ErrorIs(t, VerifySecp256k1(pubKeyHex, otherHash, rHex, sHex), ErrInvalidSignature)
+	})
+
+	t.Run("fails with another public key", func(t *testing.T) {
+		otherKey, err := secp256k1.GeneratePrivateKey()
+		require.NoError(t, err)
+		otherPubKeyHex := hex.EncodeToString(otherKey.PubKey().SerializeCompressed())
+		require.ErrorIs(t, VerifySecp256k1(otherPubKeyHex, hashHex, rHex, sHex), ErrInvalidSignature)
+	})
+
+	t.Run("fails with a malformed S", func(t *testing.T) {
+		require.Error(t, VerifySecp256k1(pubKeyHex, hashHex, rHex, "XYZ"))
+	})
+
+	t.Run("verifies a DER-encoded signature", func(t *testing.T) {
+		derHex := hex.EncodeToString(sig.Serialize())
+		require.NoError(t, VerifySecp256k1DER(pubKeyHex, hashHex, derHex))
+
+		otherHash := "B3C1F1B5D2E4F60718293A4B5C6D7E8F90A1B2C3D4E5F60718293A4B5C6D7E8F"
+		require.ErrorIs(t, VerifySecp256k1DER(pubKeyHex, otherHash, derHex), ErrInvalidSignature)
+	})
+
+	t.Run("fails with a malformed DER signature", func(t *testing.T) {
+		require.Error(t, VerifySecp256k1DER(pubKeyHex, hashHex, "3044"))
+	})
+}
+
+func TestVerifySignedContent(t *testing.T) {
+	require.NoError(t, VerifySignedContent("ABCDEF", "abcdef"))
+	require.ErrorIs(t, VerifySignedContent("ABCDEF", "ABCDEE"), ErrSignedContentMismatch)
+}
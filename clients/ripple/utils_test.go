@@ -0,0 +1,126 @@
+package ripple
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertXrpToDrops(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		expErr   bool
+	}{
+		{name: "converts an integer xrp amount", input: "25", expected: "25000000"},
+		{name: "converts a decimal xrp amount", input: "0.000012", expected: "12"},
+		{name: "fails with more than 6 decimal places", input: "1.0000001", expErr: true},
+		{name: "fails with a negative amount", input: "-1", expErr: true},
+		{name: "fails with an invalid amount", input: "abc", expErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ConvertXrpToDrops(tc.input)
+
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestCalculateAccountReserve(t *testing.T) {
+	ledger := &XrpServerInfoLedger{ReserveBaseXrp: 10, ReserveIncXrp: 2}
+
+	got, err := CalculateAccountReserve(ledger, 3)
+	require.NoError(t, err)
+	require.Equal(t, "10000000", got.BaseReserve)
+	require.Equal(t, "2000000", got.OwnerReserve)
+	require.Equal(t, "16000000", got.Total)
+
+	_, err = CalculateAccountReserve(nil, 3)
+	require.Error(t, err)
+}
+
+func TestNormalizeAddressAndTag(t *testing.T) {
+	tag := func(value uint32) *uint32 { return &value }
+	classic := "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"
+
+	tests := []struct {
+		name      string
+		address   string
+		tag       *uint32
+		isTestnet bool
+		expected  string
+		expTag    *uint32
+		expErr    bool
+	}{
+		{name: "keeps a classic address and its tag", address: classic, tag: tag(7), expected: classic, expTag: tag(7)},
+		{name: "accepts a classic address on a test network", address: classic, isTestnet: true, expected: classic},
+		{name: "takes the tag of a mainnet x-address", address: "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC", expected: classic, expTag: tag(1)},
+		{name: "accepts a testnet x-address on a test network", address: "TVE26TYGhfLC7tQDno7G8dGtxSkYQn49b3qD26PK7FcGSKE", isTestnet: true, expected: classic},
+		{name: "rejects a testnet x-address on the mainnet", address: "TVE26TYGhfLC7tQDno7G8dGtxSkYQn49b3qD26PK7FcGSKE", expErr: true},
+		{name: "rejects a mainnet x-address on a test network", address: "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC", isTestnet: true, expErr: true},
+		{name: "rejects a tag different from the x-address tag", address: "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC", tag: tag(2), expErr: true},
+		{name: "rejects an invalid address", address: "not-an-address", expErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotTag, err := NormalizeAddressAndTag(tc.address, tc.tag, tc.isTestnet)
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, got)
+			require.Equal(t, tc.expTag, gotTag)
+		})
+	}
+}
+
+func TestEncodeDER(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        string
+		s        string
+		expected string
+		expErr   bool
+	}{
+		{
+			name:     "pads values with the high bit set",
+			r:        "80",
+			s:        "7F",
+			expected: "30070202008002017f",
+		},
+		{
+			name:     "removes leading zero bytes of fixed size values",
+			r:        "0000000000000000000000000000000000000000000000000000000000000001",
+			s:        "00000000000000000000000000000000000000000000000000000000000000FF",
+			expected: "3007020101020200ff",
+		},
+		{name: "fails with an empty value", r: "", s: "01", expErr: true},
+		{name: "fails with an invalid hex", r: "XY", s: "01", expErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := EncodeDER(tc.r, tc.s)
+
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	ts "crypto-braza-tokens-api/services/token"
 	txs "crypto-braza-tokens-api/services/transaction"
 	ws "crypto-braza-tokens-api/services/wallet"
+	xs "crypto-braza-tokens-api/services/xrpl"
 	ow "crypto-braza-tokens-api/workers"
 )
 
@@ -19,5 +20,6 @@ type Resources struct {
 	WalletService      *ws.WalletService
 	OperationService   *ops.OperationService
 	TransactionService *txs.TransactionService
+	XrplService        *xs.XrplService
 	Worker             *ow.OperationsWorker
 }
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func (r *Repository) SaveCheck(ctx context.Context, check *Check) (primitive.ObjectID, error) {
+	if check.ID.IsZero() {
+		check.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.checksCollection.InsertOne(ctx, check)
+	if err != nil {
+		l.Logger.Error("repository: error saving check", zap.Error(err))
+		return primitive.NilObjectID, err
+	}
+
+	return check.ID, nil
+}
+
+// FindChecks returns the checks with the status, or all of them when no status is given, from the newest to the oldest
+func (r *Repository) FindChecks(ctx context.Context, status string) ([]*Check, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.checksCollection.Find(ctx, filter, opts)
+	if err != nil {
+		l.Logger.Error("repository: error finding checks", zap.Error(err))
+		return nil, err
+	}
+
+	checks := []*Check{}
+	if err := cursor.All(ctx, &checks); err != nil {
+		l.Logger.Error("repository: error decoding checks", zap.Error(err))
+		return nil, err
+	}
+
+	return checks, nil
+}
+
+// FindCheckByCheckId returns the check of the ledger object ID or nil when the service did not send it
+func (r *Repository) FindCheckByCheckId(ctx context.Context, checkId string) (*Check, error) {
+	return r.findCheck(ctx, bson.M{"check_id": checkId})
+}
+
+// FindCheckByOperationId returns the check sent by the operation or nil when the operation did not send a check
+func (r *Repository) FindCheckByOperationId(ctx context.Context, operationId string) (*Check, error) {
+	return r.findCheck(ctx, bson.M{"operation_id": operationId})
+}
+
+func (r *Repository) findCheck(ctx context.Context, filter bson.M) (*Check, error) {
+	var result *Check
+
+	err := r.checksCollection.FindOne(ctx, filter, nil).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error finding check %v", filter), zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *Repository) UpdateCheckStatus(ctx context.Context, checkId primitive.ObjectID, status string) error {
+	filter := bson.M{"_id": checkId}
+	update := bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}}
+
+	_, err := r.checksCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error updating status of check %s", checkId.Hex()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// CloseCheck sets the final status of the check removed from the ledger by the transaction of the hash
+func (r *Repository) CloseCheck(ctx context.Context, checkId primitive.ObjectID, status, cashedAmount, closedBy string) error {
+	filter := bson.M{"_id": checkId}
+	update := bson.M{"$set": bson.M{"status": status, "cashed_amount": cashedAmount, "closed_by": closedBy, "updated_at": time.Now()}}
+
+	_, err := r.checksCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error closing check %s", checkId.Hex()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateCheckCancelOperation(ctx context.Context, checkId primitive.ObjectID, cancelOperationId string) error {
+	filter := bson.M{"_id": checkId}
+	update := bson.M{"$set": bson.M{"cancel_operation_id": cancelOperationId, "updated_at": time.Now()}}
+
+	_, err := r.checksCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error updating cancel operation of check %s", checkId.Hex()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ExpireChecks sets the expired status on the checks of the status whose expiration is past, returning how many expired
+func (r *Repository) ExpireChecks(ctx context.Context, status, expiredStatus string, now time.Time) (int64, error) {
+	filter := bson.M{"status": status, "expiration": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"status": expiredStatus, "updated_at": time.Now()}}
+
+	result, err := r.checksCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error("repository: error expiring checks", zap.Error(err))
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func (r *Repository) SaveHolderSnapshot(ctx context.Context, snapshot *HolderSnapshot) (primitive.ObjectID, error) {
+	if snapshot.ID.IsZero() {
+		snapshot.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.holderSnapshotsCollection.InsertOne(ctx, snapshot)
+	if err != nil {
+		l.Logger.Error("repository: error saving holder snapshot", zap.Error(err))
+		return primitive.NilObjectID, err
+	}
+
+	return snapshot.ID, nil
+}
+
+func (r *Repository) SaveHolderBalances(ctx context.Context, balances []*HolderBalance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+
+	documents := make([]any, 0, len(balances))
+	for _, balance := range balances {
+		if balance.ID.IsZero() {
+			balance.ID = primitive.NewObjectID()
+		}
+		documents = append(documents, balance)
+	}
+
+	_, err := r.holderBalancesCollection.InsertMany(ctx, documents)
+	if err != nil {
+		l.Logger.Error("repository: error saving holder balances", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// FindLatestHolderSnapshot returns the last snapshot of the holders of the token or nil when it was never snapshotted
+func (r *Repository) FindLatestHolderSnapshot(ctx context.Context, tokenId string) (*HolderSnapshot, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	return r.findHolderSnapshot(ctx, bson.M{"token_id": tokenId}, opts)
+}
+
+// FindHolderSnapshotById returns the snapshot of the holders of the token or nil when it does not exist
+func (r *Repository) FindHolderSnapshotById(ctx context.Context, tokenId, snapshotId string) (*HolderSnapshot, error) {
+	id, err := primitive.ObjectIDFromHex(snapshotId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot id %s", snapshotId)
+	}
+
+	return r.findHolderSnapshot(ctx, bson.M{"_id": id, "token_id": tokenId}, nil)
+}
+
+func (r *Repository) findHolderSnapshot(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*HolderSnapshot, error) {
+	var result *HolderSnapshot
+
+	err := r.holderSnapshotsCollection.FindOne(ctx, filter, opts).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error finding holder snapshot %v", filter), zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindHolderSnapshots returns the snapshots of the holders of the token taken between the dates, from the oldest to the newest
+func (r *Repository) FindHolderSnapshots(ctx context.Context, tokenId string, from, to time.Time) ([]*HolderSnapshot, error) {
+	filter := bson.M{"token_id": tokenId, "created_at": bson.M{"$gte": from, "$lte": to}}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.holderSnapshotsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		l.Logger.Error("repository: error finding holder snapshots", zap.Error(err))
+		return nil, err
+	}
+
+	snapshots := []*HolderSnapshot{}
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		l.Logger.Error("repository: error decoding holder snapshots", zap.Error(err))
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// FindHolderBalances returns the trust lines of the holders on the snapshot
+func (r *Repository) FindHolderBalances(ctx context.Context, snapshotId string) ([]*HolderBalance, error) {
+	return r.findHolderBalances(ctx, bson.M{"snapshot_id": snapshotId}, nil)
+}
+
+// FindHolderBalancesByAccount returns the trust lines of the holder on the snapshots of the token taken between the
+// dates, from the oldest to the newest
+func (r *Repository) FindHolderBalancesByAccount(ctx context.Context, tokenId, account string, from, to time.Time) ([]*HolderBalance, error) {
+	filter := bson.M{"token_id": tokenId, "account": account, "created_at": bson.M{"$gte": from, "$lte": to}}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	return r.findHolderBalances(ctx, filter, opts)
+}
+
+func (r *Repository) findHolderBalances(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*HolderBalance, error) {
+	cursor, err := r.holderBalancesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		l.Logger.Error("repository: error finding holder balances", zap.Error(err))
+		return nil, err
+	}
+
+	balances := []*HolderBalance{}
+	if err := cursor.All(ctx, &balances); err != nil {
+		l.Logger.Error("repository: error decoding holder balances", zap.Error(err))
+		return nil, err
+	}
+
+	return balances, nil
+}
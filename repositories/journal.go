@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// SaveLedgerJournalEntry upserts the entry posted from an indexed transaction by its hash, so a transaction indexed
+// from the wallets of both of its sides is posted only once
+func (r *Repository) SaveLedgerJournalEntry(ctx context.Context, entry *JournalEntry) error {
+	now := time.Now()
+
+	filter := bson.M{"transaction_hash": entry.TransactionHash}
+	update := bson.M{
+		"$set": bson.M{
+			"type":         entry.Type,
+			"source":       entry.Source,
+			"description":  entry.Description,
+			"domain":       entry.Domain,
+			"operation_id": entry.OperationID,
+			"operator":     entry.Operator,
+			"lines":        entry.Lines,
+			"posted_at":    entry.PostedAt,
+			"updated_at":   now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"created_at": now,
+		},
+	}
+
+	_, err := r.journalEntriesCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error saving journal entry of transaction %s", entry.TransactionHash), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) SaveJournalEntry(ctx context.Context, entry *JournalEntry) (primitive.ObjectID, error) {
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.journalEntriesCollection.InsertOne(ctx, entry)
+	if err != nil {
+		l.Logger.Error("repository: error saving journal entry", zap.Error(err))
+		return primitive.NilObjectID, err
+	}
+
+	return entry.ID, nil
+}
+
+// FindJournalEntries returns the entries with a line of the account in the currency posted between the dates, in the
+// order they were posted. The account, the currency and the dates are not filtered when they are not given.
+func (r *Repository) FindJournalEntries(ctx context.Context, account, currency string, from, to time.Time) ([]*JournalEntry, error) {
+	line := bson.M{}
+	if account != "" {
+		line["account"] = account
+	}
+	if currency != "" {
+		line["currency"] = currency
+	}
+
+	filter := bson.M{}
+	if len(line) > 0 {
+		filter["lines"] = bson.M{"$elemMatch": line}
+	}
+
+	postedAt := bson.M{}
+	if !from.IsZero() {
+		postedAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		postedAt["$lte"] = to
+	}
+	if len(postedAt) > 0 {
+		filter["posted_at"] = postedAt
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "posted_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.journalEntriesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		l.Logger.Error("repository: error finding journal entries", zap.Error(err))
+		return nil, err
+	}
+
+	entries := []*JournalEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		l.Logger.Error("repository: error decoding journal entries", zap.Error(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// FindJournalAccountTotals sums the debits and the credits of the lines posted before the date to each account,
+// currency and issuer, or only to the account when it is given
+func (r *Repository) FindJournalAccountTotals(ctx context.Context, account string, before time.Time) ([]*JournalAccountTotal, error) {
+	match := bson.M{"posted_at": bson.M{"$lt": before}}
+	if account != "" {
+		match["lines.account"] = account
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$lines"}},
+	}
+	if account != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"lines.account": account}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":     bson.M{"account": "$lines.account", "currency": "$lines.currency", "issuer": "$lines.issuer"},
+			"debits":  bson.M{"$sum": bson.M{"$toDecimal": "$lines.debit"}},
+			"credits": bson.M{"$sum": bson.M{"$toDecimal": "$lines.credit"}},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":      0,
+			"account":  "$_id.account",
+			"currency": "$_id.currency",
+			"issuer":   bson.M{"$ifNull": bson.A{"$_id.issuer", ""}},
+			"debits":   bson.M{"$toString": "$debits"},
+			"credits":  bson.M{"$toString": "$credits"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "account", Value: 1}, {Key: "currency", Value: 1}, {Key: "issuer", Value: 1}}}},
+	)
+
+	cursor, err := r.journalEntriesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		l.Logger.Error("repository: error finding journal account totals", zap.Error(err))
+		return nil, err
+	}
+
+	totals := []*JournalAccountTotal{}
+	if err := cursor.All(ctx, &totals); err != nil {
+		l.Logger.Error("repository: error decoding journal account totals", zap.Error(err))
+		return nil, err
+	}
+
+	return totals, nil
+}
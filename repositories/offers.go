@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func (r *Repository) SaveOffer(ctx context.Context, offer *Offer) (primitive.ObjectID, error) {
+	if offer.ID.IsZero() {
+		offer.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.offersCollection.InsertOne(ctx, offer)
+	if err != nil {
+		l.Logger.Error("repository: error saving offer", zap.Error(err))
+		return primitive.NilObjectID, err
+	}
+
+	return offer.ID, nil
+}
+
+// FindOffersByWallet returns the offers placed by the wallet, from the newest to the oldest
+func (r *Repository) FindOffersByWallet(ctx context.Context, walletId string) ([]*Offer, error) {
+	filter := bson.M{"wallet_id": walletId}
+	opts := options.Find().SetSort(bson.D{{Key: "sequence", Value: -1}})
+
+	cursor, err := r.offersCollection.Find(ctx, filter, opts)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error finding offers of wallet %s", walletId), zap.Error(err))
+		return nil, err
+	}
+
+	offers := []*Offer{}
+	if err := cursor.All(ctx, &offers); err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error decoding offers of wallet %s", walletId), zap.Error(err))
+		return nil, err
+	}
+
+	return offers, nil
+}
+
+// FindOfferByWalletAndSequence returns the offer placed by the wallet with the sequence or nil when the service did not place it
+func (r *Repository) FindOfferByWalletAndSequence(ctx context.Context, walletId string, sequence int) (*Offer, error) {
+	filter := bson.M{"wallet_id": walletId, "sequence": sequence}
+
+	var result *Offer
+
+	err := r.offersCollection.FindOne(ctx, filter, nil).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error finding offer %d of wallet %s", sequence, walletId), zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *Repository) UpdateOfferStatus(ctx context.Context, offerId primitive.ObjectID, status string) error {
+	filter := bson.M{"_id": offerId}
+	update := bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}}
+
+	_, err := r.offersCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error updating status of offer %s", offerId.Hex()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateOfferCancelOperation(ctx context.Context, offerId primitive.ObjectID, cancelOperationId string) error {
+	filter := bson.M{"_id": offerId}
+	update := bson.M{"$set": bson.M{"cancel_operation_id": cancelOperationId, "updated_at": time.Now()}}
+
+	_, err := r.offersCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error updating cancel operation of offer %s", offerId.Hex()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
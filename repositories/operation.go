@@ -9,6 +9,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
@@ -121,6 +122,42 @@ func (r *Repository) UpdateOperationBlockchainStatus(ctx context.Context, operat
 		return err
 	}
 
+	// a failed operation releases the fiat deposits backing it, so they can back another operation
+	if newStatus == "FAILED" {
+		filter := bson.M{"_id": objectID, "backing_held": true}
+		update := bson.M{"$unset": bson.M{"backing_held": ""}}
+
+		if _, err := r.operationsCollection.UpdateOne(ctx, filter, update); err != nil {
+			l.Logger.Error("error releasing operation backing", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateOperationBalanceChanges(ctx context.Context, operationId, deliveredAmount string, balanceChanges []*BalanceChange) error {
+	objectID, err := primitive.ObjectIDFromHex(operationId)
+	if err != nil {
+		l.Logger.Error("error converting operation Id to ObjectID", zap.Error(err))
+		return err
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{
+		"$set": bson.M{
+			"delivered_amount": deliveredAmount,
+			"balance_changes":  balanceChanges,
+			"updated_at":       time.Now(),
+		},
+	}
+
+	_, err = r.operationsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error("error updating operation balance changes", zap.Error(err))
+		return err
+	}
+
 	return nil
 }
 
@@ -275,3 +312,62 @@ func (r *Repository) FindFilteredAndPaginatedOperations(ctx context.Context, par
 
 	return paginatedResult, nil
 }
+
+// FindOperationByTransactionHash returns the operation that submitted the transaction or nil when there is none
+func (r *Repository) FindOperationByTransactionHash(ctx context.Context, hash string) (*Operation, error) {
+	filter := bson.M{"transaction_hash": hash}
+
+	var result *Operation
+	err := r.operationsCollection.FindOne(ctx, filter, nil).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		l.Logger.Error("error finding operation by transaction hash", zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindOperationsByBankTransactionIds returns the operations backed by any of the bank transactions, except the ones
+// that failed on the blockchain, whose deposits can back another operation
+func (r *Repository) FindOperationsByBankTransactionIds(ctx context.Context, bankTransactionIds []string) ([]*Operation, error) {
+	filter := bson.M{
+		"backing.bank_transaction_id": bson.M{"$in": bankTransactionIds},
+		"backing_held":                true,
+	}
+
+	cursor, err := r.operationsCollection.Find(ctx, filter)
+	if err != nil {
+		l.Logger.Error("error finding operations by bank transaction ids", zap.Error(err))
+		return nil, err
+	}
+
+	operations := []*Operation{}
+	if err := cursor.All(ctx, &operations); err != nil {
+		l.Logger.Error("error decoding operations by bank transaction ids", zap.Error(err))
+		return nil, err
+	}
+
+	return operations, nil
+}
+
+// createBackingIndex makes each bank transaction back a single operation across every instance of the service. Only
+// the operations holding their backing are indexed, so the deposits of a failed operation can back another one.
+func (r *Repository) createBackingIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "backing.bank_transaction_id", Value: 1}},
+		Options: options.Index().
+			SetName("backing_bank_transaction_id_held").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"backing_held": true}),
+	}
+
+	if _, err := r.operationsCollection.Indexes().CreateOne(ctx, index); err != nil {
+		l.Logger.Error("error creating operations backing index", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
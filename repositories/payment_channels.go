@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func (r *Repository) SavePaymentChannel(ctx context.Context, channel *PaymentChannel) (primitive.ObjectID, error) {
+	if channel.ID.IsZero() {
+		channel.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.paymentChannelsCollection.InsertOne(ctx, channel)
+	if err != nil {
+		l.Logger.Error("repository: error saving payment channel", zap.Error(err))
+		return primitive.NilObjectID, err
+	}
+
+	return channel.ID, nil
+}
+
+// FindPaymentChannels returns the payment channels opened by the service, from the newest to the oldest
+func (r *Repository) FindPaymentChannels(ctx context.Context) ([]*PaymentChannel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.paymentChannelsCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		l.Logger.Error("repository: error finding payment channels", zap.Error(err))
+		return nil, err
+	}
+
+	channels := []*PaymentChannel{}
+	if err := cursor.All(ctx, &channels); err != nil {
+		l.Logger.Error("repository: error decoding payment channels", zap.Error(err))
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+// FindPaymentChannelByChannelId returns the payment channel of the ledger object ID or nil when the service did not open it
+func (r *Repository) FindPaymentChannelByChannelId(ctx context.Context, channelId string) (*PaymentChannel, error) {
+	var result *PaymentChannel
+
+	err := r.paymentChannelsCollection.FindOne(ctx, bson.M{"channel_id": channelId}, nil).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error finding payment channel %s", channelId), zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *Repository) UpdatePaymentChannelStatus(ctx context.Context, channelId primitive.ObjectID, status string) error {
+	filter := bson.M{"_id": channelId}
+	update := bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}}
+
+	_, err := r.paymentChannelsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error updating status of payment channel %s", channelId.Hex()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// AddPaymentChannelFundOperation links the operation funding the payment channel to it
+func (r *Repository) AddPaymentChannelFundOperation(ctx context.Context, channelId primitive.ObjectID, operationId string) error {
+	filter := bson.M{"_id": channelId}
+	update := bson.M{"$push": bson.M{"fund_operation_ids": operationId}, "$set": bson.M{"updated_at": time.Now()}}
+
+	_, err := r.paymentChannelsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error adding fund operation to payment channel %s", channelId.Hex()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) SaveChannelClaim(ctx context.Context, claim *ChannelClaim) (primitive.ObjectID, error) {
+	if claim.ID.IsZero() {
+		claim.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.channelClaimsCollection.InsertOne(ctx, claim)
+	if err != nil {
+		l.Logger.Error("repository: error saving channel claim", zap.Error(err))
+		return primitive.NilObjectID, err
+	}
+
+	return claim.ID, nil
+}
+
+// FindChannelClaims returns the claims signed for the payment channel, from the newest to the oldest
+func (r *Repository) FindChannelClaims(ctx context.Context, channelId string) ([]*ChannelClaim, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.channelClaimsCollection.Find(ctx, bson.M{"channel_id": channelId}, opts)
+	if err != nil {
+		l.Logger.Error("repository: error finding channel claims", zap.Error(err))
+		return nil, err
+	}
+
+	claims := []*ChannelClaim{}
+	if err := cursor.All(ctx, &claims); err != nil {
+		l.Logger.Error("repository: error decoding channel claims", zap.Error(err))
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// UpdateChannelClaimSignature sets the status of the claim signed by the operation with its signature
+func (r *Repository) UpdateChannelClaimSignature(ctx context.Context, operationId, status, signature string) error {
+	filter := bson.M{"operation_id": operationId}
+	update := bson.M{"$set": bson.M{"status": status, "signature": signature, "updated_at": time.Now()}}
+
+	_, err := r.channelClaimsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error updating signature of the claim of operation %s", operationId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+const (
+	// periods the rows of a report can be grouped by, in the timezone of the report
+	REPORT_PERIOD_DAY   = "day"
+	REPORT_PERIOD_WEEK  = "week"
+	REPORT_PERIOD_MONTH = "month"
+)
+
+// reportPeriodFormats are the $dateToString formats of the periods, the weeks being ISO 8601 weeks
+var reportPeriodFormats = map[string]string{
+	REPORT_PERIOD_DAY:   "%Y-%m-%d",
+	REPORT_PERIOD_WEEK:  "%G-W%V",
+	REPORT_PERIOD_MONTH: "%Y-%m",
+}
+
+// ReportQuery selects the operations created between the dates and the fields their rows are grouped by, with the
+// period of their creation date in the timezone when a period is given
+type ReportQuery struct {
+	From     time.Time
+	To       time.Time
+	GroupBy  []string
+	Period   string
+	Timezone string
+}
+
+// AggregateSupplyReport sums the amounts minted and burned by the completed operations of the query, delivered amounts
+// being preferred to requested ones. The rows are read from the cursor so large ranges can be streamed.
+func (r *Repository) AggregateSupplyReport(ctx context.Context, query *ReportQuery) (*mongo.Cursor, error) {
+	match := reportMatch(query)
+	match["type"] = bson.M{"$in": bson.A{"MINT", "BURN"}}
+	match["blockchain_status"] = "COMPLETED"
+
+	minted := bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", "MINT"}}, "$value", 0}}
+	burned := bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", "BURN"}}, "$value", 0}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: reportFields(query)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        reportGroup(query),
+			"minted":     bson.M{"$sum": minted},
+			"burned":     bson.M{"$sum": burned},
+			"mint_count": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", "MINT"}}, 1, 0}}},
+			"burn_count": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", "BURN"}}, 1, 0}}},
+		}}},
+		{{Key: "$project", Value: reportProjection(query, bson.M{
+			"minted":     bson.M{"$toString": "$minted"},
+			"burned":     bson.M{"$toString": "$burned"},
+			"net":        bson.M{"$toString": bson.M{"$subtract": bson.A{"$minted", "$burned"}}},
+			"mint_count": 1,
+			"burn_count": 1,
+		})}},
+		{{Key: "$sort", Value: reportSort(query)}},
+	}
+
+	cursor, err := r.operationsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		l.Logger.Error("repository: error aggregating supply report", zap.Error(err))
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// AggregateOperationsReport counts the operations of the query by their blockchain status and, when the rows are
+// grouped by token, sums the amounts of the completed ones, as amounts of different tokens do not add up. The
+// attestations are not summed, their amount being the supply attested instead of an amount moved. The logs of the
+// operations give the time each completed operation took to be processed, from its creation to its last log, and how
+// many of their steps failed.
+func (r *Repository) AggregateOperationsReport(ctx context.Context, query *ReportQuery) (*mongo.Cursor, error) {
+	completed := bson.M{"$eq": bson.A{"$blockchain_status", "COMPLETED"}}
+	failed := bson.M{"$eq": bson.A{"$blockchain_status", "FAILED"}}
+	moved := bson.M{"$and": bson.A{completed, bson.M{"$ne": bson.A{"$type", "RESERVE_ATTESTATION"}}}}
+
+	group := bson.M{
+		"_id":       reportGroup(query),
+		"count":     bson.M{"$sum": 1},
+		"completed": bson.M{"$sum": bson.M{"$cond": bson.A{completed, 1, 0}}},
+		"failed":    bson.M{"$sum": bson.M{"$cond": bson.A{failed, 1, 0}}},
+		"errors":    bson.M{"$sum": "$logs.errors"},
+		"duration": bson.M{"$avg": bson.M{"$cond": bson.A{
+			bson.M{"$and": bson.A{completed, bson.M{"$gt": bson.A{"$logs.last_log_at", nil}}}},
+			bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{"$logs.last_log_at", "$created_at"}}, 1000}},
+			nil,
+		}}},
+	}
+
+	metrics := bson.M{
+		"count":                1,
+		"completed":            1,
+		"failed":               1,
+		"pending":              bson.M{"$subtract": bson.A{"$count", bson.M{"$add": bson.A{"$completed", "$failed"}}}},
+		"failed_steps":         "$errors",
+		"avg_duration_seconds": bson.M{"$ifNull": bson.A{bson.M{"$round": bson.A{"$duration", 3}}, 0}},
+	}
+
+	if slices.Contains(query.GroupBy, "token") {
+		group["amount"] = bson.M{"$sum": bson.M{"$cond": bson.A{moved, "$value", 0}}}
+		metrics["amount"] = bson.M{"$toString": "$amount"}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: reportMatch(query)}},
+		{{Key: "$addFields", Value: reportFields(query)}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": r.operationsLogsCollection.Name(),
+			"let":  bson.M{"operation_id": bson.M{"$toString": "$_id"}},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$operation_id", "$$operation_id"}}}},
+				bson.M{"$group": bson.M{
+					"_id":         nil,
+					"last_log_at": bson.M{"$max": "$created_at"},
+					"errors": bson.M{"$sum": bson.M{"$cond": bson.A{
+						bson.M{"$in": bson.A{bson.M{"$ifNull": bson.A{"$error", "null"}}, bson.A{"null", "", "{}"}}}, 0, 1,
+					}}},
+				}},
+			},
+			"as": "logs",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"logs": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$logs", 0}}, bson.M{"errors": 0}}},
+		}}},
+		{{Key: "$group", Value: group}},
+		{{Key: "$project", Value: reportProjection(query, metrics)}},
+		{{Key: "$sort", Value: reportSort(query)}},
+	}
+
+	cursor, err := r.operationsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		l.Logger.Error("repository: error aggregating operations report", zap.Error(err))
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// AggregateMintsReport lists the mints of the query with the fiat deposits backing them, one row per deposit, the
+// dates being given in the timezone of the query. The mints without backing have a single row without deposit.
+func (r *Repository) AggregateMintsReport(ctx context.Context, query *ReportQuery) (*mongo.Cursor, error) {
+	match := reportMatch(query)
+	match["type"] = "MINT"
+
+	dateFormat := "%Y-%m-%dT%H:%M:%S%z"
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$addFields", Value: reportFields(query)}},
+		{{Key: "$unwind", Value: bson.M{"path": "$backing", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":                 0,
+			"operation_id":        bson.M{"$toString": "$_id"},
+			"created_at":          bson.M{"$dateToString": bson.M{"format": dateFormat, "date": "$created_at", "timezone": query.Timezone}},
+			"token":               1,
+			"domain":              1,
+			"operator":            1,
+			"amount":              1,
+			"blockchain_status":   1,
+			"transaction_hash":    1,
+			"bank_transaction_id": "$backing.bank_transaction_id",
+			"backing_amount":      "$backing.amount",
+			"backing_currency":    "$backing.currency",
+			"received_at":         bson.M{"$dateToString": bson.M{"format": dateFormat, "date": "$backing.received_at", "timezone": query.Timezone}},
+		}}},
+	}
+
+	cursor, err := r.operationsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		l.Logger.Error("repository: error aggregating mints report", zap.Error(err))
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+func reportMatch(query *ReportQuery) bson.M {
+	return bson.M{"created_at": bson.M{"$gte": query.From, "$lt": query.To}}
+}
+
+// reportFields adds the token of the operation, the value of its amount and the period of its creation. The operations
+// created before the token was recorded take it from their balance changes, preferring the currency delivered to the
+// destination, as a cross currency payout also changes the balance of the currency spent.
+func reportFields(query *ReportQuery) bson.M {
+	changedCurrency := func(cond bson.M) bson.M {
+		return bson.M{"$arrayElemAt": bson.A{
+			bson.M{"$map": bson.M{
+				"input": bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$balance_changes", bson.A{}}},
+					"as":    "change",
+					"cond":  cond,
+				}},
+				"as": "change",
+				"in": "$$change.currency",
+			}},
+			0,
+		}}
+	}
+
+	tokenDelivered := changedCurrency(bson.M{"$eq": bson.A{"$$change.account", "$destination"}})
+	tokenFromChanges := changedCurrency(bson.M{"$ne": bson.A{"$$change.currency", "XRP"}})
+
+	amount := bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$delivered_amount", ""}}, ""}},
+		"$delivered_amount",
+		"$amount",
+	}}
+
+	fields := bson.M{
+		"token": bson.M{"$ifNull": bson.A{"$token", tokenDelivered, tokenFromChanges, ""}},
+		"value": bson.M{"$convert": bson.M{"input": amount, "to": "decimal", "onError": 0, "onNull": 0}},
+	}
+
+	if format, ok := reportPeriodFormats[query.Period]; ok {
+		fields["period"] = bson.M{"$dateToString": bson.M{"format": format, "date": "$created_at", "timezone": query.Timezone}}
+	}
+
+	return fields
+}
+
+// reportGroup groups the rows by their period and by the fields of the query
+func reportGroup(query *ReportQuery) bson.M {
+	group := bson.M{}
+	if query.Period != "" {
+		group["period"] = "$period"
+	}
+	for _, field := range query.GroupBy {
+		group[field] = "$" + field
+	}
+	return group
+}
+
+func reportProjection(query *ReportQuery, metrics bson.M) bson.M {
+	projection := bson.M{"_id": 0}
+	if query.Period != "" {
+		projection["period"] = "$_id.period"
+	}
+	for _, field := range query.GroupBy {
+		projection[field] = "$_id." + field
+	}
+	for key, value := range metrics {
+		projection[key] = value
+	}
+	return projection
+}
+
+func reportSort(query *ReportQuery) bson.D {
+	sort := bson.D{}
+	if query.Period != "" {
+		sort = append(sort, bson.E{Key: "period", Value: 1})
+	}
+	for _, field := range query.GroupBy {
+		sort = append(sort, bson.E{Key: field, Value: 1})
+	}
+	if len(sort) == 0 {
+		// a report without groups has a single row
+		sort = append(sort, bson.E{Key: "_id", Value: 1})
+	}
+	return sort
+}
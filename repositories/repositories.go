@@ -25,6 +25,16 @@ type Repository struct {
 	operationsLogsCollection     *mongo.Collection
 	transactionsCollection       *mongo.Collection
 	transactionsTypesCollection  *mongo.Collection
+	walletsCheckpointsCollection *mongo.Collection
+	offersCollection             *mongo.Collection
+	checksCollection             *mongo.Collection
+	paymentChannelsCollection    *mongo.Collection
+	channelClaimsCollection      *mongo.Collection
+	holderSnapshotsCollection    *mongo.Collection
+	holderBalancesCollection     *mongo.Collection
+	reserveBalancesCollection    *mongo.Collection
+	attestationsCollection       *mongo.Collection
+	journalEntriesCollection     *mongo.Collection
 }
 
 func NewRepository() *Repository {
@@ -105,6 +115,66 @@ func NewRepository() *Repository {
 	}
 	transactionsTypes := database.Collection(transactionsTypesCollection)
 
+	walletsCheckpointsCollection, err := kvs.Get("MONGO_WALLETS_CHECKPOINTS_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	walletsCheckpoints := database.Collection(walletsCheckpointsCollection)
+
+	offersCollection, err := kvs.Get("MONGO_OFFERS_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	offers := database.Collection(offersCollection)
+
+	checksCollection, err := kvs.Get("MONGO_CHECKS_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	checks := database.Collection(checksCollection)
+
+	paymentChannelsCollection, err := kvs.Get("MONGO_PAYMENT_CHANNELS_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	paymentChannels := database.Collection(paymentChannelsCollection)
+
+	channelClaimsCollection, err := kvs.Get("MONGO_CHANNEL_CLAIMS_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	channelClaims := database.Collection(channelClaimsCollection)
+
+	holderSnapshotsCollection, err := kvs.Get("MONGO_HOLDER_SNAPSHOTS_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	holderSnapshots := database.Collection(holderSnapshotsCollection)
+
+	holderBalancesCollection, err := kvs.Get("MONGO_HOLDER_BALANCES_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	holderBalances := database.Collection(holderBalancesCollection)
+
+	reserveBalancesCollection, err := kvs.Get("MONGO_RESERVE_BALANCES_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	reserveBalances := database.Collection(reserveBalancesCollection)
+
+	attestationsCollection, err := kvs.Get("MONGO_RESERVE_ATTESTATIONS_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	attestations := database.Collection(attestationsCollection)
+
+	journalEntriesCollection, err := kvs.Get("MONGO_JOURNAL_ENTRIES_COLLECTION")
+	if err != nil {
+		l.Logger.Fatal("repository: " + err.Error())
+	}
+	journalEntries := database.Collection(journalEntriesCollection)
+
 	repo = &Repository{
 		database,
 		blockchains,
@@ -117,6 +187,20 @@ func NewRepository() *Repository {
 		operationsLogs,
 		transactions,
 		transactionsTypes,
+		walletsCheckpoints,
+		offers,
+		checks,
+		paymentChannels,
+		channelClaims,
+		holderSnapshots,
+		holderBalances,
+		reserveBalances,
+		attestations,
+		journalEntries,
+	}
+
+	if err := repo.createBackingIndex(context.Background()); err != nil {
+		l.Logger.Fatal("repository: failed to create operations indexes", zap.Error(err))
 	}
 
 	return repo
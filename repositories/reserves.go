@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func (r *Repository) SaveReserveBalance(ctx context.Context, balance *ReserveBalance) (primitive.ObjectID, error) {
+	if balance.ID.IsZero() {
+		balance.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.reserveBalancesCollection.InsertOne(ctx, balance)
+	if err != nil {
+		l.Logger.Error("repository: error saving reserve balance", zap.Error(err))
+		return primitive.NilObjectID, err
+	}
+
+	return balance.ID, nil
+}
+
+// FindReserveBalances returns the reserve balances recorded for the token, or for all the tokens when no token is
+// given, from the newest to the oldest
+func (r *Repository) FindReserveBalances(ctx context.Context, tokenId string) ([]*ReserveBalance, error) {
+	filter := bson.M{}
+	if tokenId != "" {
+		filter["token_id"] = tokenId
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "as_of", Value: -1}, {Key: "created_at", Value: -1}})
+
+	cursor, err := r.reserveBalancesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		l.Logger.Error("repository: error finding reserve balances", zap.Error(err))
+		return nil, err
+	}
+
+	balances := []*ReserveBalance{}
+	if err := cursor.All(ctx, &balances); err != nil {
+		l.Logger.Error("repository: error decoding reserve balances", zap.Error(err))
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+// FindLatestReserveBalances returns the last balance recorded for each custodian account of the token
+func (r *Repository) FindLatestReserveBalances(ctx context.Context, tokenId string) ([]*ReserveBalance, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"token_id": tokenId}}},
+		{{Key: "$sort", Value: bson.D{{Key: "as_of", Value: -1}, {Key: "created_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    bson.M{"custodian": "$custodian", "account": "$account"},
+			"latest": bson.M{"$first": "$$ROOT"},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$latest"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "custodian", Value: 1}, {Key: "account", Value: 1}}}},
+	}
+
+	cursor, err := r.reserveBalancesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		l.Logger.Error("repository: error finding latest reserve balances", zap.Error(err))
+		return nil, err
+	}
+
+	balances := []*ReserveBalance{}
+	if err := cursor.All(ctx, &balances); err != nil {
+		l.Logger.Error("repository: error decoding latest reserve balances", zap.Error(err))
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+func (r *Repository) SaveReserveAttestation(ctx context.Context, attestation *ReserveAttestation) (primitive.ObjectID, error) {
+	if attestation.ID.IsZero() {
+		attestation.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.attestationsCollection.InsertOne(ctx, attestation)
+	if err != nil {
+		l.Logger.Error("repository: error saving reserve attestation", zap.Error(err))
+		return primitive.NilObjectID, err
+	}
+
+	return attestation.ID, nil
+}
+
+// FindReserveAttestations returns the attestations of the token with the status, or of all the tokens or with any
+// status when they are not given, from the newest to the oldest
+func (r *Repository) FindReserveAttestations(ctx context.Context, tokenId, status string) ([]*ReserveAttestation, error) {
+	filter := bson.M{}
+	if tokenId != "" {
+		filter["token_id"] = tokenId
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "attested_at", Value: -1}})
+
+	cursor, err := r.attestationsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		l.Logger.Error("repository: error finding reserve attestations", zap.Error(err))
+		return nil, err
+	}
+
+	attestations := []*ReserveAttestation{}
+	if err := cursor.All(ctx, &attestations); err != nil {
+		l.Logger.Error("repository: error decoding reserve attestations", zap.Error(err))
+		return nil, err
+	}
+
+	return attestations, nil
+}
+
+// FindReserveAttestationById returns the attestation or nil when it does not exist
+func (r *Repository) FindReserveAttestationById(ctx context.Context, attestationId string) (*ReserveAttestation, error) {
+	id, err := primitive.ObjectIDFromHex(attestationId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation id %s", attestationId)
+	}
+
+	var result *ReserveAttestation
+
+	err = r.attestationsCollection.FindOne(ctx, bson.M{"_id": id}, nil).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error finding reserve attestation %s", attestationId), zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateReserveAttestationSignature sets the status and the signature of the attestation signed by the operation
+func (r *Repository) UpdateReserveAttestationSignature(ctx context.Context, operationId, status, signature string) error {
+	filter := bson.M{"operation_id": operationId}
+	update := bson.M{"$set": bson.M{"status": status, "signature": signature, "updated_at": time.Now()}}
+
+	_, err := r.attestationsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error updating signature of reserve attestation of operation %s", operationId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// SaveIndexedTransaction inserts or updates an on-chain transaction by its hash. A transaction between two of our
+// wallets is indexed for both, so the wallet is added to the transaction wallets instead of replacing them.
+func (r *Repository) SaveIndexedTransaction(ctx context.Context, transaction *Transaction, walletId string) error {
+	now := time.Now()
+
+	filter := bson.M{"transaction_hash": transaction.TransactionHash}
+	update := bson.M{
+		"$set": bson.M{
+			"type":             transaction.Type,
+			"amount":           transaction.Amount,
+			"operator":         transaction.Operator,
+			"status":           transaction.Status,
+			"external_id":      transaction.ExternalId,
+			"fireblocks_id":    transaction.FireblocksId,
+			"transaction_link": transaction.TransactionLink,
+			"blockchain":       transaction.Blockchain,
+			"account":          transaction.Account,
+			"destination":      transaction.Destination,
+			"destination_tag":  transaction.DestinationTag,
+			"currency":         transaction.Currency,
+			"issuer":           transaction.Issuer,
+			"fee":              transaction.Fee,
+			"ledger_index":     transaction.LedgerIndex,
+			"ledger_date":      transaction.LedgerDate,
+			"balance_changes":  transaction.BalanceChanges,
+			"memos":            transaction.Memos,
+			"operation_id":     transaction.OperationId,
+			"is_external":      transaction.IsExternal,
+			"updated_at":       now,
+		},
+		"$addToSet": bson.M{
+			"wallets": walletId,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"domain":     transaction.Domain,
+			"created_at": now,
+		},
+	}
+
+	_, err := r.transactionsCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error saving transaction %s", transaction.TransactionHash), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -32,15 +32,17 @@ type Token struct {
 }
 
 type Wallet struct {
-	ID         primitive.ObjectID `bson:"_id" json:"id"`
-	Blockchain string             `bson:"blockchain" json:"blockchain"`
-	Name       string             `bson:"name" json:"name"`
-	Address    string             `bson:"address" json:"address"`
-	Type       string             `bson:"type" json:"type"`
-	Domain     string             `bson:"domain" json:"domain"`
-	IsActive   bool               `bson:"is_active" json:"is_active"`
-	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+	ID             primitive.ObjectID `bson:"_id" json:"id"`
+	Blockchain     string             `bson:"blockchain" json:"blockchain"`
+	Name           string             `bson:"name" json:"name"`
+	Address        string             `bson:"address" json:"address"`
+	Type           string             `bson:"type" json:"type"`
+	Domain         string             `bson:"domain" json:"domain"`
+	DestinationTag *uint32            `bson:"destination_tag,omitempty" json:"destination_tag,omitempty"`
+	Algorithm      string             `bson:"algorithm,omitempty" json:"algorithm,omitempty"`
+	IsActive       bool               `bson:"is_active" json:"is_active"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
 type FireblocksAccount struct {
@@ -63,17 +65,43 @@ type Operation struct {
 	ID               primitive.ObjectID `bson:"_id" json:"id"`
 	Type             string             `bson:"type" json:"type"`
 	Domain           string             `bson:"domain" json:"domain"`
+	Token            string             `bson:"token,omitempty" json:"token,omitempty"`
 	Amount           string             `bson:"amount" json:"amount"`
 	Operator         string             `bson:"operator" json:"operator"`
+	Origin           string             `bson:"origin,omitempty" json:"origin,omitempty"`
+	Destination      string             `bson:"destination,omitempty" json:"destination,omitempty"`
+	DestinationTag   *uint32            `bson:"destination_tag,omitempty" json:"destination_tag,omitempty"`
+	SourceCurrency   string             `bson:"source_currency,omitempty" json:"source_currency,omitempty"`
+	SendMax          string             `bson:"send_max,omitempty" json:"send_max,omitempty"`
+	DeliverMin       string             `bson:"deliver_min,omitempty" json:"deliver_min,omitempty"`
 	FireblocksStatus string             `bson:"fireblocks_status" json:"fireblocks_status"`
 	BlockchainStatus string             `bson:"blockchain_status" json:"blockchain_status"`
 	FireblocksId     string             `bson:"fireblocks_id" json:"fireblocks_id"`
 	TransactionHash  string             `bson:"transaction_hash" json:"transaction_hash"`
 	TransactionLink  string             `bson:"transaction_link" json:"transaction_link"`
+	DeliveredAmount  string             `bson:"delivered_amount,omitempty" json:"delivered_amount,omitempty"`
+	BalanceChanges   []*BalanceChange   `bson:"balance_changes,omitempty" json:"balance_changes,omitempty"`
+	Backing          []*BackingRef      `bson:"backing,omitempty" json:"backing,omitempty"`
+	BackingHeld      bool               `bson:"backing_held,omitempty" json:"-"`
 	CreatedAt        time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt        time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
+// BackingRef is a confirmed fiat deposit backing a mint, each bank transaction backing a single operation
+type BackingRef struct {
+	BankTransactionID string    `bson:"bank_transaction_id" json:"bank_transaction_id" example:"E18236120202411151830s0123456789"`
+	Amount            string    `bson:"amount" json:"amount" example:"1000.00"`
+	Currency          string    `bson:"currency" json:"currency" example:"BRL"`
+	ReceivedAt        time.Time `bson:"received_at" json:"received_at" example:"2024-11-15T18:30:00Z"`
+}
+
+type BalanceChange struct {
+	Account  string `bson:"account" json:"account"`
+	Currency string `bson:"currency" json:"currency"`
+	Issuer   string `bson:"issuer,omitempty" json:"issuer,omitempty"`
+	Value    string `bson:"value" json:"value"`
+}
+
 type OperationType struct {
 	ID        primitive.ObjectID `bson:"_id" json:"id"`
 	Name      string             `bson:"name" json:"name"`
@@ -140,10 +168,234 @@ type Transaction struct {
 	FireblocksId    string             `bson:"fireblocks_id" json:"fireblocks_id"`
 	TransactionHash string             `bson:"transaction_hash" json:"transaction_hash"`
 	TransactionLink string             `bson:"transaction_link" json:"transaction_link"`
+	Blockchain      string             `bson:"blockchain,omitempty" json:"blockchain,omitempty"`
+	Wallets         []string           `bson:"wallets,omitempty" json:"wallets,omitempty"`
+	Account         string             `bson:"account,omitempty" json:"account,omitempty"`
+	Destination     string             `bson:"destination,omitempty" json:"destination,omitempty"`
+	DestinationTag  *uint32            `bson:"destination_tag,omitempty" json:"destination_tag,omitempty"`
+	Currency        string             `bson:"currency,omitempty" json:"currency,omitempty"`
+	Issuer          string             `bson:"issuer,omitempty" json:"issuer,omitempty"`
+	Fee             string             `bson:"fee,omitempty" json:"fee,omitempty"`
+	LedgerIndex     int                `bson:"ledger_index,omitempty" json:"ledger_index,omitempty"`
+	LedgerDate      time.Time          `bson:"ledger_date,omitempty" json:"ledger_date,omitempty"`
+	BalanceChanges  []*BalanceChange   `bson:"balance_changes,omitempty" json:"balance_changes,omitempty"`
+	Memos           map[string]string  `bson:"memos,omitempty" json:"memos,omitempty"`
+	OperationId     string             `bson:"operation_id,omitempty" json:"operation_id,omitempty"`
+	IsExternal      bool               `bson:"is_external" json:"is_external"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// WalletCheckpoint keeps the last ledger indexed for a wallet and, while a range of ledgers is being read,
+// the marker of the next page to be read
+type WalletCheckpoint struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	WalletID    string             `bson:"wallet_id" json:"wallet_id"`
+	Address     string             `bson:"address" json:"address"`
+	Blockchain  string             `bson:"blockchain" json:"blockchain"`
+	LedgerIndex int                `bson:"ledger_index" json:"ledger_index"`
+	Marker      *LedgerMarker      `bson:"marker" json:"marker"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type LedgerMarker struct {
+	Ledger int `bson:"ledger" json:"ledger"`
+	Seq    int `bson:"seq" json:"seq"`
+}
+
+// Offer is a DEX offer placed by the service, Sequence being the sequence of the OfferCreate transaction that
+// identifies the offer of the account on the ledger
+type Offer struct {
+	ID                primitive.ObjectID `bson:"_id" json:"id"`
+	WalletID          string             `bson:"wallet_id" json:"wallet_id"`
+	Account           string             `bson:"account" json:"account"`
+	Blockchain        string             `bson:"blockchain" json:"blockchain"`
+	Domain            string             `bson:"domain" json:"domain"`
+	Sequence          int                `bson:"sequence" json:"sequence"`
+	Side              string             `bson:"side" json:"side"`
+	TakerGets         *OfferAmount       `bson:"taker_gets" json:"taker_gets"`
+	TakerPays         *OfferAmount       `bson:"taker_pays" json:"taker_pays"`
+	Flags             int                `bson:"flags" json:"flags"`
+	Expiration        *time.Time         `bson:"expiration,omitempty" json:"expiration,omitempty"`
+	Status            string             `bson:"status" json:"status"`
+	OperationID       string             `bson:"operation_id" json:"operation_id"`
+	CancelOperationID string             `bson:"cancel_operation_id,omitempty" json:"cancel_operation_id,omitempty"`
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type OfferAmount struct {
+	Currency string `bson:"currency" json:"currency"`
+	Issuer   string `bson:"issuer,omitempty" json:"issuer,omitempty"`
+	Value    string `bson:"value" json:"value"`
+}
+
+// Check is a check sent by the service, CheckID being its ledger object ID. ExternalID links the check to the
+// transaction request that originated the payout.
+type Check struct {
+	ID                primitive.ObjectID `bson:"_id" json:"id"`
+	CheckID           string             `bson:"check_id" json:"check_id"`
+	WalletID          string             `bson:"wallet_id" json:"wallet_id"`
+	Account           string             `bson:"account" json:"account"`
+	Blockchain        string             `bson:"blockchain" json:"blockchain"`
+	Domain            string             `bson:"domain" json:"domain"`
+	Sequence          int                `bson:"sequence" json:"sequence"`
+	Destination       string             `bson:"destination" json:"destination"`
+	DestinationTag    *uint32            `bson:"destination_tag,omitempty" json:"destination_tag,omitempty"`
+	SendMax           *OfferAmount       `bson:"send_max" json:"send_max"`
+	Expiration        *time.Time         `bson:"expiration,omitempty" json:"expiration,omitempty"`
+	Status            string             `bson:"status" json:"status"`
+	CashedAmount      string             `bson:"cashed_amount,omitempty" json:"cashed_amount,omitempty"`
+	ClosedBy          string             `bson:"closed_by,omitempty" json:"closed_by,omitempty"` // hash of the CheckCash or CheckCancel
+	ExternalID        string             `bson:"external_id,omitempty" json:"external_id,omitempty"`
+	OperationID       string             `bson:"operation_id" json:"operation_id"`
+	CancelOperationID string             `bson:"cancel_operation_id,omitempty" json:"cancel_operation_id,omitempty"`
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type PaymentChannel struct {
+	ID               primitive.ObjectID `bson:"_id" json:"id"`
+	ChannelID        string             `bson:"channel_id" json:"channel_id"`
+	WalletID         string             `bson:"wallet_id" json:"wallet_id"`
+	Account          string             `bson:"account" json:"account"`
+	Blockchain       string             `bson:"blockchain" json:"blockchain"`
+	Domain           string             `bson:"domain" json:"domain"`
+	Sequence         int                `bson:"sequence" json:"sequence"`
+	Destination      string             `bson:"destination" json:"destination"`
+	DestinationTag   *uint32            `bson:"destination_tag,omitempty" json:"destination_tag,omitempty"`
+	Amount           string             `bson:"amount" json:"amount"` // XRP funded on creation
+	SettleDelay      int                `bson:"settle_delay" json:"settle_delay"`
+	PublicKey        string             `bson:"public_key" json:"public_key"`
+	CancelAfter      *time.Time         `bson:"cancel_after,omitempty" json:"cancel_after,omitempty"`
+	Status           string             `bson:"status" json:"status"`
+	OperationID      string             `bson:"operation_id" json:"operation_id"`
+	FundOperationIDs []string           `bson:"fund_operation_ids,omitempty" json:"fund_operation_ids,omitempty"`
+	CreatedAt        time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt        time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// ChannelClaim is an off-ledger claim of a payment channel, Amount being the cumulative XRP the destination can claim
+type ChannelClaim struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	ChannelID   string             `bson:"channel_id" json:"channel_id"`
+	Amount      string             `bson:"amount" json:"amount"`
+	Drops       string             `bson:"drops" json:"drops"`
+	PublicKey   string             `bson:"public_key" json:"public_key"`
+	Signature   string             `bson:"signature,omitempty" json:"signature,omitempty"`
+	Status      string             `bson:"status" json:"status"`
+	OperationID string             `bson:"operation_id" json:"operation_id"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// HolderSnapshot is a snapshot of the trust lines of the issuer of a token on a validated ledger, with the
+// distribution metrics of the holders with a positive balance
+type HolderSnapshot struct {
+	ID              primitive.ObjectID `bson:"_id" json:"id"`
+	TokenID         string             `bson:"token_id" json:"token_id"`
+	Abbr            string             `bson:"abbr" json:"abbr"`
+	Issuer          string             `bson:"issuer" json:"issuer"`
+	Currency        string             `bson:"currency" json:"currency"`
+	LedgerIndex     int                `bson:"ledger_index" json:"ledger_index"`
+	TrustLinesCount int                `bson:"trust_lines_count" json:"trust_lines_count"`
+	HoldersCount    int                `bson:"holders_count" json:"holders_count"`
+	TotalBalance    string             `bson:"total_balance" json:"total_balance"`
+	Top10Share      float64            `bson:"top_10_share" json:"top_10_share"`
+	HHI             float64            `bson:"hhi" json:"hhi"`
+	Gini            float64            `bson:"gini" json:"gini"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// HolderBalance is the trust line of a holder on a snapshot, seen from the holder: Balance and Limit are what the
+// holder holds and accepts to hold of the token, Frozen and Authorized whether the issuer froze or authorized the line
+type HolderBalance struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	SnapshotID string             `bson:"snapshot_id" json:"snapshot_id"`
+	TokenID    string             `bson:"token_id" json:"token_id"`
+	Account    string             `bson:"account" json:"account"`
+	Balance    string             `bson:"balance" json:"balance"`
+	Limit      string             `bson:"limit" json:"limit"`
+	NoRipple   bool               `bson:"no_ripple" json:"no_ripple"`
+	Frozen     bool               `bson:"frozen" json:"frozen"`
+	Authorized bool               `bson:"authorized" json:"authorized"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// ReserveBalance is the off-chain fiat balance backing a token held on a custodian account on a date, recorded by
+// finance with the references of the evidences of the balance (e.g. bank statements)
+type ReserveBalance struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	TokenID   string             `bson:"token_id" json:"token_id"`
+	Abbr      string             `bson:"abbr" json:"abbr"`
+	Custodian string             `bson:"custodian" json:"custodian"`
+	Account   string             `bson:"account" json:"account"`
+	Currency  string             `bson:"currency" json:"currency"`
+	Amount    string             `bson:"amount" json:"amount"`
+	AsOf      time.Time          `bson:"as_of" json:"as_of"`
+	Evidences []string           `bson:"evidences" json:"evidences"`
+	Operator  string             `bson:"operator" json:"operator"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// ReserveAttestation is an attestation of the reserves backing the circulating supply of a token. Document is the
+// attested JSON and Signature the detached signature of the SHA-256 Digest of it by the issuer key.
+type ReserveAttestation struct {
+	ID                     primitive.ObjectID `bson:"_id" json:"id"`
+	TokenID                string             `bson:"token_id" json:"token_id"`
+	Abbr                   string             `bson:"abbr" json:"abbr"`
+	LedgerIndex            int                `bson:"ledger_index" json:"ledger_index"`
+	CirculatingSupply      string             `bson:"circulating_supply" json:"circulating_supply"`
+	TotalReserves          string             `bson:"total_reserves" json:"total_reserves"`
+	CollateralizationRatio string             `bson:"collateralization_ratio" json:"collateralization_ratio"`
+	Document               string             `bson:"document" json:"document"`
+	Digest                 string             `bson:"digest" json:"digest"`
+	Algorithm              string             `bson:"algorithm" json:"algorithm"`
+	PublicKey              string             `bson:"public_key" json:"public_key"`
+	Signature              string             `bson:"signature,omitempty" json:"signature,omitempty"`
+	Status                 string             `bson:"status" json:"status"`
+	OperationID            string             `bson:"operation_id" json:"operation_id"`
+	AttestedAt             time.Time          `bson:"attested_at" json:"attested_at"`
+	CreatedAt              time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt              time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// JournalEntry is a balanced posting of the internal ledger: for each currency the debits of its lines equal their
+// credits. Entries of the LEDGER source are posted from an indexed transaction, one per transaction hash.
+type JournalEntry struct {
+	ID              primitive.ObjectID `bson:"_id" json:"id"`
+	Type            string             `bson:"type" json:"type"`
+	Source          string             `bson:"source" json:"source"`
+	Description     string             `bson:"description" json:"description"`
+	Domain          string             `bson:"domain,omitempty" json:"domain,omitempty"`
+	TransactionHash string             `bson:"transaction_hash,omitempty" json:"transaction_hash,omitempty"`
+	OperationID     string             `bson:"operation_id,omitempty" json:"operation_id,omitempty"`
+	Operator        string             `bson:"operator,omitempty" json:"operator,omitempty"`
+	Lines           []*JournalLine     `bson:"lines" json:"lines"`
+	PostedAt        time.Time          `bson:"posted_at" json:"posted_at"`
 	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
+// JournalLine is a debit or a credit of an amount of a currency to an account, Issuer being empty for XRP
+type JournalLine struct {
+	Account  string `bson:"account" json:"account" example:"ASSET:BRAZA-ON:SUPPLY"`
+	Currency string `bson:"currency" json:"currency" example:"BBRL"`
+	Issuer   string `bson:"issuer,omitempty" json:"issuer,omitempty" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"`
+	Debit    string `bson:"debit" json:"debit" example:"100"`
+	Credit   string `bson:"credit" json:"credit" example:"0"`
+}
+
+// JournalAccountTotal is the sum of the debits and credits posted to an account in a currency
+type JournalAccountTotal struct {
+	Account  string `bson:"account" json:"account"`
+	Currency string `bson:"currency" json:"currency"`
+	Issuer   string `bson:"issuer" json:"issuer"`
+	Debits   string `bson:"debits" json:"debits"`
+	Credits  string `bson:"credits" json:"credits"`
+}
+
 var _ IDocument = (*TransactionType)(nil)
 
 type TransactionType struct {
@@ -145,13 +145,15 @@ func (r *Repository) EditWallet(ctx context.Context, wallet *Wallet) (primitive.
 	filter := bson.M{"_id": wallet.ID}
 	update := bson.M{
 		"$set": bson.M{
-			"Blockchain": wallet.Blockchain,
-			"Name":       wallet.Name,
-			"Address":    wallet.Address,
-			"Type":       wallet.Type,
-			"Domain":     wallet.Domain,
-			"IsActive":   wallet.IsActive,
-			"UpdatedAt":  wallet.UpdatedAt,
+			"Blockchain":      wallet.Blockchain,
+			"Name":            wallet.Name,
+			"Address":         wallet.Address,
+			"Type":            wallet.Type,
+			"Domain":          wallet.Domain,
+			"IsActive":        wallet.IsActive,
+			"destination_tag": wallet.DestinationTag,
+			"algorithm":       wallet.Algorithm,
+			"UpdatedAt":       wallet.UpdatedAt,
 		},
 	}
 
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	l "crypto-braza-tokens-api/utils/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// FindWalletCheckpoint returns the indexing checkpoint of a wallet or nil when the wallet was never indexed
+func (r *Repository) FindWalletCheckpoint(ctx context.Context, walletId string) (*WalletCheckpoint, error) {
+	filter := bson.M{"wallet_id": walletId}
+
+	var result *WalletCheckpoint
+
+	err := r.walletsCheckpointsCollection.FindOne(ctx, filter, nil).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error finding checkpoint for wallet %s", walletId), zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *Repository) SaveWalletCheckpoint(ctx context.Context, checkpoint *WalletCheckpoint) error {
+	now := time.Now()
+
+	filter := bson.M{"wallet_id": checkpoint.WalletID}
+	update := bson.M{
+		"$set": bson.M{
+			"address":      checkpoint.Address,
+			"blockchain":   checkpoint.Blockchain,
+			"ledger_index": checkpoint.LedgerIndex,
+			"marker":       checkpoint.Marker,
+			"updated_at":   now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"created_at": now,
+		},
+	}
+
+	_, err := r.walletsCheckpointsCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		l.Logger.Error(fmt.Sprintf("repository: error saving checkpoint for wallet %s", checkpoint.WalletID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package fireblocks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	addresscodec "crypto-braza-tokens-api/clients/ripple/utils/address-codec"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ADDRESS_STATUS_MATCH            = "MATCH"
+	ADDRESS_STATUS_MISMATCH         = "MISMATCH"
+	ADDRESS_STATUS_WALLET_NOT_FOUND = "WALLET_NOT_FOUND"
+	ADDRESS_STATUS_INVALID_ADDRESS  = "INVALID_ADDRESS"
+	ADDRESS_STATUS_PUBLIC_KEY_ERROR = "PUBLIC_KEY_ERROR"
+)
+
+// verifyAccountAddress derives the XRPL address of the fireblocks account public key and compares it with the address
+// of the linked wallet. The public key is retrieved from fireblocks, falling back to the stored one when unavailable.
+func (fb *FireblocksService) verifyAccountAddress(ctx context.Context, account *r.FireblocksAccount, wallet *r.Wallet) *AddressVerification {
+	verification := &AddressVerification{
+		FireblocksAccountID: account.ID.Hex(),
+		VaultID:             account.VaultID,
+		AssetID:             account.AssetID,
+		WalletID:            account.WalletID,
+		PublicKey:           account.PublicKey,
+	}
+
+	if wallet == nil {
+		verification.Status = ADDRESS_STATUS_WALLET_NOT_FOUND
+		verification.Error = fmt.Sprintf("wallet %s not found", account.WalletID)
+		return verification
+	}
+
+	verification.WalletAddress = wallet.Address
+
+	if !addresscodec.IsValidClassicAddress(wallet.Address) {
+		verification.Status = ADDRESS_STATUS_INVALID_ADDRESS
+		verification.Error = fmt.Sprintf("wallet address %s is not a valid classic address", wallet.Address)
+		return verification
+	}
+
+	fbAccResult, err := fb.fbCli.GetPublicKeyInfoFromVaultAccount(ctx, account.VaultID, account.AssetID, 0, 0)
+	if err != nil {
+		l.Logger.Error("fireblocks service: error getting public key info from Fireblocks API", zap.Error(err))
+	} else if fbAccResult != nil && fbAccResult.PublicKey != "" {
+		verification.PublicKey = fbAccResult.PublicKey
+	}
+
+	if verification.PublicKey == "" {
+		verification.Status = ADDRESS_STATUS_PUBLIC_KEY_ERROR
+		verification.Error = fmt.Sprintf("no public key available for vault %s and asset %s", account.VaultID, account.AssetID)
+		return verification
+	}
+
+	derivedAddress, err := addresscodec.EncodeClassicAddressFromPublicKeyHex(verification.PublicKey)
+	if err != nil {
+		verification.Status = ADDRESS_STATUS_PUBLIC_KEY_ERROR
+		verification.Error = err.Error()
+		return verification
+	}
+
+	verification.DerivedAddress = derivedAddress
+	verification.Status = ADDRESS_STATUS_MATCH
+
+	if derivedAddress != wallet.Address {
+		verification.Status = ADDRESS_STATUS_MISMATCH
+		verification.Error = fmt.Sprintf("public key controls address %s, but the wallet address is %s", derivedAddress, wallet.Address)
+	}
+
+	return verification
+}
+
+// validateAccountWallet checks that the wallet linked to a fireblocks account exists and is controlled by the
+// public key of the fireblocks vault, so operations are never signed by a key that does not own the address
+func (fb *FireblocksService) validateAccountWallet(ctx context.Context, account *r.FireblocksAccount) error {
+	wallet, err := fb.repo.FindWalletById(ctx, account.WalletID)
+	if err != nil {
+		l.Logger.Error("fireblocks service: error finding wallet", zap.Error(err))
+		return fmt.Errorf("wallet %s not found", account.WalletID)
+	}
+
+	verification := fb.verifyAccountAddress(ctx, account, wallet)
+	if verification.Status != ADDRESS_STATUS_MATCH {
+		return fmt.Errorf("fireblocks account address verification failed with status %s: %s", verification.Status, verification.Error)
+	}
+
+	// keeps the public key retrieved from fireblocks when none was informed
+	if account.PublicKey == "" {
+		account.PublicKey = verification.PublicKey
+	}
+
+	if !strings.EqualFold(account.PublicKey, verification.PublicKey) {
+		return fmt.Errorf("informed public key %s differs from the fireblocks public key %s", account.PublicKey, verification.PublicKey)
+	}
+
+	return nil
+}
+
+// AuditFireblocksAccounts verifies the addresses of all fireblocks accounts against their wallets and reports any drift
+func (fb *FireblocksService) AuditFireblocksAccounts(ctx context.Context) (*AddressAudit, error) {
+	accounts, err := fb.repo.FindFireblocksAccounts(ctx)
+	if err != nil {
+		l.Logger.Error("fireblocks service: error finding fireblocks accounts", zap.Error(err))
+		return nil, err
+	}
+
+	wallets, err := fb.repo.FindWallets(ctx)
+	if err != nil {
+		l.Logger.Error("fireblocks service: error finding wallets", zap.Error(err))
+		return nil, err
+	}
+
+	walletsMap := make(map[string]*r.Wallet)
+	for _, wallet := range wallets {
+		walletsMap[wallet.ID.Hex()] = wallet
+	}
+
+	audit := &AddressAudit{
+		Accounts:  []*AddressVerification{},
+		CheckedAt: time.Now(),
+	}
+
+	for _, account := range accounts {
+		verification := fb.verifyAccountAddress(ctx, account, walletsMap[account.WalletID])
+
+		if verification.Status != ADDRESS_STATUS_MATCH {
+			audit.DriftCount++
+			l.Logger.Warn("fireblocks service: fireblocks account address drift",
+				zap.String("fireblocks_account_id", verification.FireblocksAccountID),
+				zap.String("status", verification.Status),
+				zap.String("error", verification.Error),
+			)
+		}
+
+		audit.Accounts = append(audit.Accounts, verification)
+	}
+
+	audit.Total = len(audit.Accounts)
+
+	return audit, nil
+}
@@ -279,6 +279,12 @@ func (fb *FireblocksService) SaveFireblocksAccount(ctx context.Context, vaultID,
 		VaultID: vaultID, AssetID: assetID, Name: name, Alias: alias, Domain: domain, PublicKey: publicKey, Flags: accFlags, IsActive: isActive, WalletID: walletID, CreatedAt: time.Now(), UpdatedAt: time.Now(),
 	}
 
+	// the fireblocks public key must control the address of the linked wallet
+	if err := fb.validateAccountWallet(ctx, fireblocksAccount); err != nil {
+		l.Logger.Error("fireblocks service: invalid fireblocks account wallet", zap.Error(err))
+		return primitive.ObjectID{}, err
+	}
+
 	id, err := fb.repo.SaveFireblocksAccount(ctx, fireblocksAccount)
 	if err != nil {
 		l.Logger.Error("fireblocks service: error saving fireblocks account", zap.Error(err))
@@ -303,6 +309,14 @@ func (fb *FireblocksService) EditFireblocksAccount(ctx context.Context, vaultID,
 		VaultID: vaultID, AssetID: assetID, Name: name, Alias: alias, Domain: domain, PublicKey: publicKey, Flags: accFlags, IsActive: isActive, WalletID: walletID, UpdatedAt: time.Now(),
 	}
 
+	// the fireblocks public key must control the address of the linked wallet
+	if walletID != "" {
+		if err := fb.validateAccountWallet(ctx, fireblocksAccount); err != nil {
+			l.Logger.Error("fireblocks service: invalid fireblocks account wallet", zap.Error(err))
+			return primitive.ObjectID{}, err
+		}
+	}
+
 	id, err := fb.repo.EditFireblocksAccount(ctx, fireblocksAccount)
 	if err != nil {
 		l.Logger.Error("fireblocks service: error updating fireblocks account", zap.Error(err))
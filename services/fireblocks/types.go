@@ -54,3 +54,22 @@ type FireblocksAccountAssets struct {
 	Name   string   `json:"name"`
 	Assets []*Asset `json:"assets"`
 }
+
+type AddressVerification struct {
+	FireblocksAccountID string `json:"fireblocks_account_id"`
+	VaultID             string `json:"vault_id"`
+	AssetID             string `json:"asset_id"`
+	WalletID            string `json:"wallet_id"`
+	WalletAddress       string `json:"wallet_address"`
+	PublicKey           string `json:"public_key"`
+	DerivedAddress      string `json:"derived_address"`
+	Status              string `json:"status"`
+	Error               string `json:"error,omitempty"`
+}
+
+type AddressAudit struct {
+	Total      int                    `json:"total"`
+	DriftCount int                    `json:"drift_count"`
+	CheckedAt  time.Time              `json:"checked_at"`
+	Accounts   []*AddressVerification `json:"accounts"`
+}
@@ -0,0 +1,411 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	OPERATION_TYPE_AMM_CREATE   = "AMM_CREATE"
+	OPERATION_TYPE_AMM_DEPOSIT  = "AMM_DEPOSIT"
+	OPERATION_TYPE_AMM_WITHDRAW = "AMM_WITHDRAW"
+	OPERATION_TYPE_AMM_VOTE     = "AMM_VOTE"
+)
+
+// ammParams are the MARKET_MAKER wallet of the domain, which provides the liquidity, and the assets of the pool of
+// the token against XRP
+type ammParams struct {
+	wallet    *r.Wallet
+	fbAccount *r.FireblocksAccount
+	token     *r.Token
+	asset     xrpn.XrpAsset
+	asset2    xrpn.XrpAsset
+}
+
+func (p *ammParams) poolName() string {
+	return fmt.Sprintf("%s/%s", p.token.Abbr, xrpn.CURRENCY_XRP)
+}
+
+// amounts returns the amounts of the token and of XRP given in XRP, Amount being the token one when both are given
+func (p *ammParams) amounts(tokenAmount, xrpAmount string) (*xrpn.XrpAmount, *xrpn.XrpAmount, error) {
+	var amounts []*xrpn.XrpAmount
+
+	if tokenAmount != "" {
+		amount := xrpn.NewIssuedAmount(p.token.Abbr, p.token.Address, tokenAmount)
+		amounts = append(amounts, &amount)
+	}
+
+	if xrpAmount != "" {
+		drops, err := xrpn.ConvertXrpToDrops(xrpAmount)
+		if err != nil {
+			return nil, nil, err
+		}
+		amount := xrpn.NewXrpAmount(drops)
+		amounts = append(amounts, &amount)
+	}
+
+	switch len(amounts) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+		return amounts[0], nil, nil
+	}
+
+	return amounts[0], amounts[1], nil
+}
+
+// GetAmmPool returns the state of the pool of the token against XRP and the LP tokens held by the MARKET_MAKER wallet
+// of the domain
+func (o *OperationService) GetAmmPool(ctx context.Context, opDomain, blockchainId, tokenId string) (*AmmPool, error) {
+	params, err := o.retrieveAmmParams(ctx, opDomain, blockchainId, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	amm, err := o.xrpClient.GetAmmInfo(ctx, params.asset, params.asset2, "")
+	if err != nil {
+		l.Logger.Error("operation service: failed to get amm info", zap.String("pool", params.poolName()), zap.Error(err))
+		return nil, err
+	}
+
+	held, err := o.xrpClient.GetAmmInfo(ctx, params.asset, params.asset2, params.wallet.Address)
+	if err != nil {
+		l.Logger.Error("operation service: failed to get amm info of the wallet", zap.String("pool", params.poolName()), zap.Error(err))
+		return nil, err
+	}
+
+	return buildAmmPool(params, amm, held)
+}
+
+// CreateAmmPool creates the pool of the token against XRP from the MARKET_MAKER wallet of the domain, funding it with
+// the token amount and the XRP amount, which set the initial price
+func (o *OperationService) CreateAmmPool(ctx context.Context, opDomain, blockchainId, tokenId, tokenAmount, xrpAmount string, tradingFee int, operator string, callback func()) (string, error) {
+	params, err := o.retrieveAmmParams(ctx, opDomain, blockchainId, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = o.xrpClient.GetAmmInfo(ctx, params.asset, params.asset2, "")
+	if err == nil {
+		return "", fmt.Errorf("the amm pool %s already exists", params.poolName())
+	}
+
+	if !errors.Is(err, xrpn.ErrAmmNotFound) {
+		l.Logger.Error("operation service: failed to get amm info", zap.String("pool", params.poolName()), zap.Error(err))
+		return "", err
+	}
+
+	amount, amount2, err := params.amounts(tokenAmount, xrpAmount)
+	if err == nil && amount2 == nil {
+		err = fmt.Errorf("the pool must be created with both the token and the XRP amounts")
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: invalid amm amounts", zap.Error(err))
+		return "", err
+	}
+
+	// the network burns one owner reserve to create the pool, so its fee is the owner reserve increment of the validated
+	// ledger instead of the transaction cost capped by XRP_MAX_FEE
+	serverInfo, err := o.xrpClient.GetServerInfo(ctx)
+	if err != nil {
+		l.Logger.Error("operation service: failed to get server info", zap.Error(err))
+		return "", err
+	}
+
+	reserve, err := xrpn.CalculateAccountReserve(serverInfo.Result.Info.ValidatedLedger, 0)
+	if err != nil {
+		l.Logger.Error("operation service: failed to calculate the owner reserve", zap.Error(err))
+		return "", err
+	}
+
+	description := fmt.Sprintf("%s of the pool %s with %s %s and %s XRP, trading fee %d", OPERATION_TYPE_AMM_CREATE, params.poolName(), tokenAmount, params.token.Abbr, xrpAmount, tradingFee)
+
+	return o.executeAmmOperation(ctx, params, OPERATION_TYPE_AMM_CREATE, opDomain, tokenAmount, description, operator, func(common xrpn.XrpTxCommon) xrpn.XrpTxBuilder {
+		if fee, err := decimal.NewFromString(common.Fee); err != nil || fee.LessThan(decimal.RequireFromString(reserve.OwnerReserve)) {
+			common.Fee = reserve.OwnerReserve
+		}
+		return &xrpn.XrpAMMCreateTx{XrpTxCommon: common, Amount: *amount, Amount2: *amount2, TradingFee: uint16(tradingFee), MinFee: reserve.OwnerReserve}
+	}, callback)
+}
+
+// DepositAmm deposits into the pool of the token against XRP from the MARKET_MAKER wallet of the domain. Both amounts
+// are deposited at the pool ratio, a single amount is deposited alone, and the LP token amount is the exact amount of
+// LP tokens to receive, alone or for a single amount.
+func (o *OperationService) DepositAmm(ctx context.Context, opDomain, blockchainId, tokenId, tokenAmount, xrpAmount, lpTokenAmount, operator string, callback func()) (string, error) {
+	params, err := o.retrieveAmmParams(ctx, opDomain, blockchainId, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	amm, err := o.xrpClient.GetAmmInfo(ctx, params.asset, params.asset2, "")
+	if err != nil {
+		l.Logger.Error("operation service: failed to get amm info", zap.String("pool", params.poolName()), zap.Error(err))
+		return "", err
+	}
+
+	amount, amount2, lpToken, flags, err := ammModeAmounts(params, amm, tokenAmount, xrpAmount, lpTokenAmount, false)
+	if err != nil {
+		l.Logger.Error("operation service: invalid amm deposit", zap.Error(err))
+		return "", err
+	}
+
+	description := fmt.Sprintf("%s into the pool %s of %s", OPERATION_TYPE_AMM_DEPOSIT, params.poolName(), describeAmmAmounts(params, tokenAmount, xrpAmount, lpTokenAmount))
+
+	return o.executeAmmOperation(ctx, params, OPERATION_TYPE_AMM_DEPOSIT, opDomain, tokenAmount, description, operator, func(common xrpn.XrpTxCommon) xrpn.XrpTxBuilder {
+		common.Flags |= flags
+		return &xrpn.XrpAMMDepositTx{XrpTxCommon: common, Asset: params.asset, Asset2: params.asset2, Amount: amount, Amount2: amount2, LPTokenOut: lpToken}
+	}, callback)
+}
+
+// WithdrawAmm withdraws from the pool of the token against XRP to the MARKET_MAKER wallet of the domain, the amounts
+// selecting the mode as on deposits. Withdrawing all returns every LP token of the wallet for both assets.
+func (o *OperationService) WithdrawAmm(ctx context.Context, opDomain, blockchainId, tokenId, tokenAmount, xrpAmount, lpTokenAmount string, all bool, operator string, callback func()) (string, error) {
+	params, err := o.retrieveAmmParams(ctx, opDomain, blockchainId, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	amm, err := o.xrpClient.GetAmmInfo(ctx, params.asset, params.asset2, "")
+	if err != nil {
+		l.Logger.Error("operation service: failed to get amm info", zap.String("pool", params.poolName()), zap.Error(err))
+		return "", err
+	}
+
+	amount, amount2, lpToken, flags, err := ammModeAmounts(params, amm, tokenAmount, xrpAmount, lpTokenAmount, all)
+	if err != nil {
+		l.Logger.Error("operation service: invalid amm withdraw", zap.Error(err))
+		return "", err
+	}
+
+	description := fmt.Sprintf("%s from the pool %s of %s", OPERATION_TYPE_AMM_WITHDRAW, params.poolName(), describeAmmAmounts(params, tokenAmount, xrpAmount, lpTokenAmount))
+	if all {
+		description = fmt.Sprintf("%s of all the liquidity from the pool %s", OPERATION_TYPE_AMM_WITHDRAW, params.poolName())
+	}
+
+	return o.executeAmmOperation(ctx, params, OPERATION_TYPE_AMM_WITHDRAW, opDomain, tokenAmount, description, operator, func(common xrpn.XrpTxCommon) xrpn.XrpTxBuilder {
+		common.Flags |= flags
+		return &xrpn.XrpAMMWithdrawTx{XrpTxCommon: common, Asset: params.asset, Asset2: params.asset2, Amount: amount, Amount2: amount2, LPTokenIn: lpToken}
+	}, callback)
+}
+
+// VoteAmm votes the trading fee of the pool of the token against XRP with the LP tokens of the MARKET_MAKER wallet of
+// the domain
+func (o *OperationService) VoteAmm(ctx context.Context, opDomain, blockchainId, tokenId string, tradingFee int, operator string, callback func()) (string, error) {
+	params, err := o.retrieveAmmParams(ctx, opDomain, blockchainId, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := o.xrpClient.GetAmmInfo(ctx, params.asset, params.asset2, ""); err != nil {
+		l.Logger.Error("operation service: failed to get amm info", zap.String("pool", params.poolName()), zap.Error(err))
+		return "", err
+	}
+
+	description := fmt.Sprintf("%s of the trading fee %d on the pool %s", OPERATION_TYPE_AMM_VOTE, tradingFee, params.poolName())
+
+	return o.executeAmmOperation(ctx, params, OPERATION_TYPE_AMM_VOTE, opDomain, "", description, operator, func(common xrpn.XrpTxCommon) xrpn.XrpTxBuilder {
+		return &xrpn.XrpAMMVoteTx{XrpTxCommon: common, Asset: params.asset, Asset2: params.asset2, TradingFee: uint16(tradingFee)}
+	}, callback)
+}
+
+// executeAmmOperation saves the operation and signs the AMM transaction built from the common fields with fireblocks
+func (o *OperationService) executeAmmOperation(ctx context.Context, params *ammParams, opType, opDomain, amount, description, operator string, build func(common xrpn.XrpTxCommon) xrpn.XrpTxBuilder, callback func()) (string, error) {
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             opType,
+		Domain:           opDomain,
+		Token:            params.token.Abbr,
+		Amount:           amount,
+		Operator:         operator,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation from %s", description, params.wallet.Name)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), params.wallet, params.fbAccount)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s from %s", description, params.wallet.Name)
+	l.Logger.Info(note)
+
+	transaction := build(buildRippleTxCommon(params.wallet.Address, signingParams, params.fbAccount.Flags, buildOperationMemos(operationId.Hex(), opType)))
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), params.fbAccount, note, transaction, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+func (o *OperationService) retrieveAmmParams(ctx context.Context, opDomain, blockchainId, tokenId string) (*ammParams, error) {
+	wallet, err := o.findMarketMakerWallet(ctx, opDomain, blockchainId)
+	if err != nil {
+		return nil, err
+	}
+
+	// retrieve token info for the pool
+	token, err := o.repo.FindTokenById(ctx, tokenId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find token", zap.Error(err))
+		return nil, err
+	}
+
+	// retrieve fireblocks account for the origin wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return nil, err
+	}
+
+	return &ammParams{
+		wallet:    wallet,
+		fbAccount: fbAccount,
+		token:     token,
+		asset:     xrpn.XrpAsset{Currency: xrpn.ParseStringToHex(token.Abbr), Issuer: token.Address},
+		asset2:    xrpn.XrpAsset{},
+	}, nil
+}
+
+// ammModeAmounts returns the amounts of a deposit or withdraw and the flag of the mode they select
+func ammModeAmounts(params *ammParams, amm *xrpn.XrpAmm, tokenAmount, xrpAmount, lpTokenAmount string, all bool) (*xrpn.XrpAmount, *xrpn.XrpAmount, *xrpn.XrpAmount, uint32, error) {
+	if all {
+		if tokenAmount != "" || xrpAmount != "" || lpTokenAmount != "" {
+			return nil, nil, nil, 0, fmt.Errorf("no amount is accepted when withdrawing all the liquidity")
+		}
+		return nil, nil, nil, xrpn.TF_WITHDRAW_ALL, nil
+	}
+
+	amount, amount2, err := params.amounts(tokenAmount, xrpAmount)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+
+	var lpToken *xrpn.XrpAmount
+	if lpTokenAmount != "" {
+		lpTokenSupply, err := xrpn.ParseXrpAmount(amm.LPToken)
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+
+		lpToken = &xrpn.XrpAmount{Currency: lpTokenSupply.Currency, Issuer: lpTokenSupply.Issuer, Value: lpTokenAmount}
+	}
+
+	switch {
+	case amount == nil && lpToken != nil:
+		return nil, nil, lpToken, xrpn.TF_LP_TOKEN, nil
+	case amount != nil && amount2 == nil && lpToken != nil:
+		return amount, nil, lpToken, xrpn.TF_ONE_ASSET_LP_TOKEN, nil
+	case amount != nil && amount2 == nil:
+		return amount, nil, nil, xrpn.TF_SINGLE_ASSET, nil
+	case amount2 != nil && lpToken == nil:
+		return amount, amount2, nil, xrpn.TF_TWO_ASSET, nil
+	}
+
+	return nil, nil, nil, 0, fmt.Errorf("the amounts must be both assets, a single asset, the LP tokens or a single asset for the LP tokens")
+}
+
+func describeAmmAmounts(params *ammParams, tokenAmount, xrpAmount, lpTokenAmount string) string {
+	description := ""
+
+	for _, amount := range []struct{ value, currency string }{
+		{tokenAmount, params.token.Abbr},
+		{xrpAmount, xrpn.CURRENCY_XRP},
+		{lpTokenAmount, "LP tokens"},
+	} {
+		if amount.value == "" {
+			continue
+		}
+
+		if description != "" {
+			description += " and "
+		}
+		description += fmt.Sprintf("%s %s", amount.value, amount.currency)
+	}
+
+	return description
+}
+
+func buildAmmPool(params *ammParams, amm, held *xrpn.XrpAmm) (*AmmPool, error) {
+	pool := &AmmPool{
+		Account:    amm.Account,
+		Wallet:     params.wallet.Address,
+		TradingFee: amm.TradingFee,
+		VoteSlots:  amm.VoteSlots,
+	}
+
+	for _, amount := range []any{amm.Amount, amm.Amount2} {
+		parsed, err := xrpn.ParseXrpAmount(amount)
+		if err != nil {
+			return nil, err
+		}
+
+		if parsed.IsXrp() {
+			pool.XrpAmount = toOfferAmount(parsed)
+		} else {
+			pool.TokenAmount = toOfferAmount(parsed)
+		}
+	}
+
+	lpTokenSupply, err := xrpn.ParseXrpAmount(amm.LPToken)
+	if err != nil {
+		return nil, err
+	}
+
+	lpTokenBalance, err := xrpn.ParseXrpAmount(held.LPToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.LPTokenCurrency = lpTokenSupply.Currency
+	pool.LPTokenSupply = lpTokenSupply.Value
+	pool.LPTokenBalance = lpTokenBalance.Value
+
+	return pool, nil
+}
@@ -0,0 +1,90 @@
+package operation
+
+import (
+	"context"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// tokenFiatCurrencies is the fiat currency each token is backed by
+var tokenFiatCurrencies = map[string]string{
+	"BBRL": "BRL",
+	"USDB": "USD",
+}
+
+// validateBacking checks that a mint is backed by confirmed fiat deposits in the currency of the token whose sum covers
+// the amount minted, and that none of the deposits already backs another operation. Only mints can be backed.
+func (o *OperationService) validateBacking(ctx context.Context, opType, token, amount string, backing []*r.BackingRef) error {
+	if !strings.EqualFold(opType, "MINT") {
+		if len(backing) > 0 {
+			return fmt.Errorf("only MINT operations can be backed by fiat deposits")
+		}
+		return nil
+	}
+
+	if len(backing) == 0 {
+		return fmt.Errorf("a MINT operation must be backed by at least one fiat deposit")
+	}
+
+	minted, err := decimal.NewFromString(amount)
+	if err != nil {
+		return fmt.Errorf("invalid amount %s: %w", amount, err)
+	}
+
+	currency, ok := tokenFiatCurrencies[strings.ToUpper(token)]
+	if !ok {
+		return fmt.Errorf("token %s is not backed by a fiat currency", token)
+	}
+
+	seen := map[string]bool{}
+	ids := []string{}
+	total := decimal.Zero
+	for _, ref := range backing {
+		if seen[ref.BankTransactionID] {
+			return fmt.Errorf("bank transaction %s is referenced more than once", ref.BankTransactionID)
+		}
+		seen[ref.BankTransactionID] = true
+		ids = append(ids, ref.BankTransactionID)
+
+		if !strings.EqualFold(ref.Currency, currency) {
+			return fmt.Errorf("the deposits backing a %s mint must be in %s, got %s for bank transaction %s", strings.ToUpper(token), currency, strings.ToUpper(ref.Currency), ref.BankTransactionID)
+		}
+
+		value, err := decimal.NewFromString(ref.Amount)
+		if err != nil || !value.IsPositive() {
+			return fmt.Errorf("invalid amount %s of bank transaction %s", ref.Amount, ref.BankTransactionID)
+		}
+
+		if ref.ReceivedAt.After(time.Now()) {
+			return fmt.Errorf("bank transaction %s was received in the future", ref.BankTransactionID)
+		}
+
+		total = total.Add(value)
+	}
+
+	if total.LessThan(minted) {
+		return fmt.Errorf("the deposits backing the operation sum %s %s, which does not cover the %s minted", total.String(), currency, minted.String())
+	}
+
+	operations, err := o.repo.FindOperationsByBankTransactionIds(ctx, ids)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find operations by bank transaction ids", zap.Error(err))
+		return err
+	}
+
+	for _, operation := range operations {
+		for _, ref := range operation.Backing {
+			if seen[ref.BankTransactionID] {
+				return fmt.Errorf("bank transaction %s already backs operation %s", ref.BankTransactionID, operation.ID.Hex())
+			}
+		}
+	}
+
+	return nil
+}
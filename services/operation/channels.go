@@ -0,0 +1,795 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	fb "crypto-braza-tokens-api/clients/fireblocks"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	"crypto-braza-tokens-api/clients/ripple/utils/signature"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	ow "crypto-braza-tokens-api/workers"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	OPERATION_TYPE_CHANNEL_CREATE     = "CHANNEL_CREATE"
+	OPERATION_TYPE_CHANNEL_FUND       = "CHANNEL_FUND"
+	OPERATION_TYPE_CHANNEL_CLAIM_SIGN = "CHANNEL_CLAIM_SIGN"
+	OPERATION_TYPE_CHANNEL_CLAIM      = "CHANNEL_CLAIM"
+)
+
+// a payment channel is PENDING until its PaymentChannelCreate is validated, then OPEN until it is CLOSED
+const (
+	CHANNEL_STATUS_PENDING = "PENDING"
+	CHANNEL_STATUS_OPEN    = "OPEN"
+	CHANNEL_STATUS_CLOSED  = "CLOSED"
+	CHANNEL_STATUS_FAILED  = "FAILED"
+)
+
+// CreatePaymentChannel opens a payment channel of XRP from the wallet to the destination, funded with the amount. The
+// claims of the channel are signed by the fireblocks key of the wallet, and the destination has settleDelay seconds
+// to redeem them once the wallet asks the channel to close. The channel is closed after cancelAfter seconds, never
+// when zero.
+func (o *OperationService) CreatePaymentChannel(ctx context.Context, blockchainId, walletId, destination string, destinationTag *uint32, amount string, settleDelay, cancelAfter int, operator string, callback func()) (string, error) {
+	// accepts classic addresses and X-addresses, which carry the destination tag
+	destination, destinationTag, err := xrpn.NormalizeAddressAndTag(destination, destinationTag, o.xrpClient.IsTestnet())
+	if err != nil {
+		l.Logger.Error("operation service: invalid destination address", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve blockchain info for the operation
+	blockchain, err := o.repo.FindBlockchainById(ctx, blockchainId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find blockchain", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve the wallet funding the channel
+	wallet, err := o.repo.FindWalletById(ctx, walletId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	if wallet.Blockchain != blockchain.ID.Hex() || !wallet.IsActive {
+		return "", fmt.Errorf("wallet %s is not an active wallet of blockchain %s", wallet.Name, blockchain.Name)
+	}
+
+	if wallet.Address == destination {
+		return "", fmt.Errorf("destination address must be different from the wallet address")
+	}
+
+	amountDrops, err := xrpn.ConvertXrpToDrops(amount)
+	if err != nil {
+		l.Logger.Error("operation service: invalid xrp amount", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve fireblocks account for the wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_CHANNEL_CREATE,
+		Domain:           wallet.Domain,
+		Token:            xrpn.CURRENCY_XRP,
+		Amount:           amount,
+		Operator:         operator,
+		Destination:      destination,
+		DestinationTag:   destinationTag,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of a channel of %s XRP from %s to %s", OPERATION_TYPE_CHANNEL_CREATE, amount, wallet.Name, destination)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// validates the destination tag requirements of the destination account
+	if err := o.validateDestinationTag(ctx, operationId.Hex(), destination, destinationTag); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), wallet, fbAccount)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// the channel is a new object owned by the wallet, raising its reserve
+	if err := o.validateChannelReserve(ctx, operationId.Hex(), wallet, signingParams, amountDrops, 1); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// the ledger object ID of the channel derives from the sequence of its PaymentChannelCreate
+	channelId, err := xrpn.ChannelID(wallet.Address, destination, uint32(signingParams.Sequence))
+	if err != nil {
+		l.Logger.Error("operation service: failed to compute channel id", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s of %s XRP from %s to %s", OPERATION_TYPE_CHANNEL_CREATE, amount, wallet.Name, destination)
+	l.Logger.Info(note)
+
+	channelCreate := &xrpn.XrpPaymentChannelCreateTx{
+		XrpTxCommon:    buildRippleTxCommon(wallet.Address, signingParams, fbAccount.Flags, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_CHANNEL_CREATE)),
+		Amount:         xrpn.NewXrpAmount(amountDrops),
+		Destination:    destination,
+		DestinationTag: destinationTag,
+		SettleDelay:    uint32(settleDelay),
+		PublicKey:      signingParams.PublicKey,
+	}
+
+	var cancelAt *time.Time
+	if cancelAfter > 0 {
+		cancelTime := time.Now().Add(time.Duration(cancelAfter) * time.Second).UTC().Truncate(time.Second)
+		rippleCancelAfter := xrpn.ToRippleTime(cancelTime)
+		channelCreate.CancelAfter = &rippleCancelAfter
+		cancelAt = &cancelTime
+	}
+
+	// the channel is tracked before being signed, so its claims can be signed once it is on the ledger
+	channel := &r.PaymentChannel{
+		ChannelID:      channelId,
+		WalletID:       wallet.ID.Hex(),
+		Account:        wallet.Address,
+		Blockchain:     blockchain.ID.Hex(),
+		Domain:         wallet.Domain,
+		Sequence:       signingParams.Sequence,
+		Destination:    destination,
+		DestinationTag: destinationTag,
+		Amount:         amount,
+		SettleDelay:    settleDelay,
+		PublicKey:      signingParams.PublicKey,
+		CancelAfter:    cancelAt,
+		Status:         CHANNEL_STATUS_PENDING,
+		OperationID:    operationId.Hex(),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	channelObjectId, err := o.repo.SavePaymentChannel(ctx, channel)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save payment channel", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccount, note, channelCreate, callback); err != nil {
+		if errUpdate := o.repo.UpdatePaymentChannelStatus(ctx, channelObjectId, CHANNEL_STATUS_FAILED); errUpdate != nil {
+			l.Logger.Error("operation service: failed to update payment channel status", zap.Error(errUpdate))
+		}
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+// FundPaymentChannel adds the amount of XRP to a payment channel opened by the service. The channel expires after
+// expiresIn seconds when given, which keeps it open for the destination to redeem the claims before it closes.
+func (o *OperationService) FundPaymentChannel(ctx context.Context, channelId, amount string, expiresIn int, operator string, callback func()) (string, error) {
+	channel, err := o.findOpenChannel(ctx, channelId)
+	if err != nil {
+		return "", err
+	}
+
+	amountDrops, err := xrpn.ConvertXrpToDrops(amount)
+	if err != nil {
+		l.Logger.Error("operation service: invalid xrp amount", zap.Error(err))
+		return "", err
+	}
+
+	wallet, err := o.repo.FindWalletById(ctx, channel.WalletID)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve fireblocks account for the wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_CHANNEL_FUND,
+		Domain:           channel.Domain,
+		Token:            xrpn.CURRENCY_XRP,
+		Amount:           amount,
+		Operator:         operator,
+		Destination:      channel.Destination,
+		DestinationTag:   channel.DestinationTag,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of %s XRP to the channel %s from %s to %s", OPERATION_TYPE_CHANNEL_FUND, amount, channelId, wallet.Name, channel.Destination)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), wallet, fbAccount)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	if err := o.validateChannelReserve(ctx, operationId.Hex(), wallet, signingParams, amountDrops, 0); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s of %s XRP to the channel %s from %s to %s", OPERATION_TYPE_CHANNEL_FUND, amount, channelId, wallet.Name, channel.Destination)
+	l.Logger.Info(note)
+
+	channelFund := &xrpn.XrpPaymentChannelFundTx{
+		XrpTxCommon: buildRippleTxCommon(wallet.Address, signingParams, fbAccount.Flags, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_CHANNEL_FUND)),
+		Channel:     channelId,
+		Amount:      xrpn.NewXrpAmount(amountDrops),
+	}
+
+	if expiresIn > 0 {
+		expiration := xrpn.ToRippleTime(time.Now().Add(time.Duration(expiresIn) * time.Second))
+		channelFund.Expiration = &expiration
+	}
+
+	if err := o.repo.AddPaymentChannelFundOperation(ctx, channel.ID, operationId.Hex()); err != nil {
+		l.Logger.Error("operation service: failed to add payment channel fund operation", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccount, note, channelFund, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+// SignChannelClaim signs off-ledger, through fireblocks RAW, the claim of the cumulative amount of XRP of a payment
+// channel opened by the service. The destination redeems the signed claim whenever it wants, so each claim must be
+// greater than the previous ones and covered by the XRP funded on the channel.
+func (o *OperationService) SignChannelClaim(ctx context.Context, channelId, amount, operator string, callback func()) (string, error) {
+	channel, err := o.findOpenChannel(ctx, channelId)
+	if err != nil {
+		return "", err
+	}
+
+	amountDrops, err := xrpn.ConvertXrpToDrops(amount)
+	if err != nil {
+		l.Logger.Error("operation service: invalid xrp amount", zap.Error(err))
+		return "", err
+	}
+
+	ledgerChannel, err := o.xrpClient.GetPaymentChannel(ctx, channelId)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.EqualFold(ledgerChannel.PublicKey, channel.PublicKey) {
+		return "", fmt.Errorf("channel %s claims are signed by the key %s, not by the wallet key %s", channelId, ledgerChannel.PublicKey, channel.PublicKey)
+	}
+
+	drops := decimal.RequireFromString(amountDrops)
+	if drops.GreaterThan(decimal.RequireFromString(ledgerChannel.Amount)) {
+		return "", fmt.Errorf("claim of %s XRP exceeds the %s XRP funded on channel %s", amount, xrpFromDrops(ledgerChannel.Amount), channelId)
+	}
+
+	if !drops.GreaterThan(decimal.RequireFromString(ledgerChannel.Balance)) {
+		return "", fmt.Errorf("claim of %s XRP must be greater than the %s XRP already delivered by channel %s", amount, xrpFromDrops(ledgerChannel.Balance), channelId)
+	}
+
+	claims, err := o.repo.FindChannelClaims(ctx, channelId)
+	if err != nil {
+		return "", err
+	}
+
+	for _, claim := range claims {
+		if claim.Status != ow.CLAIM_STATUS_FAILED && !drops.GreaterThan(decimal.RequireFromString(claim.Drops)) {
+			return "", fmt.Errorf("claim of %s XRP must be greater than the claim of %s XRP already signed for channel %s", amount, claim.Amount, channelId)
+		}
+	}
+
+	wallet, err := o.repo.FindWalletById(ctx, channel.WalletID)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve fireblocks account for the wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_CHANNEL_CLAIM_SIGN,
+		Domain:           channel.Domain,
+		Token:            xrpn.CURRENCY_XRP,
+		Amount:           amount,
+		Operator:         operator,
+		Destination:      channel.Destination,
+		DestinationTag:   channel.DestinationTag,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of a claim of %s XRP of the channel %s from %s to %s", OPERATION_TYPE_CHANNEL_CLAIM_SIGN, amount, channelId, wallet.Name, channel.Destination)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     parseStructToJson(ledgerChannel),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// ed25519 signs the full prefixed claim message, while secp256k1 signs its 32 bytes SHA-512Half hash
+	algorithm, err := signature.DetectAlgorithm(channel.PublicKey)
+	if err != nil {
+		l.Logger.Error("operation service: failed to detect the signing algorithm", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	fbAlgorithm := fb.ALGORITHM_EDDSA_ED25519
+	if algorithm == signature.ALGORITHM_SECP256K1 {
+		fbAlgorithm = fb.ALGORITHM_ECDSA_SECP256K1
+	}
+
+	content, err := xrpn.ClaimSigningContent(channelId, amountDrops, channel.PublicKey)
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Encode XRP Payment Channel Claim",
+		Description:  fmt.Sprintf("Encoded the claim of %s drops of the payment channel %s to be signed", amountDrops, channelId),
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(map[string]any{"channel": channelId, "amount": amountDrops, "public_key": channel.PublicKey}),
+		Response:     content,
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to encode payment channel claim", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	claim := &r.ChannelClaim{
+		ChannelID:   channelId,
+		Amount:      amount,
+		Drops:       amountDrops,
+		PublicKey:   channel.PublicKey,
+		Status:      ow.CLAIM_STATUS_PENDING,
+		OperationID: operationId.Hex(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if _, err := o.repo.SaveChannelClaim(ctx, claim); err != nil {
+		l.Logger.Error("operation service: failed to save channel claim", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW claim
+	note := fmt.Sprintf("%s of %s XRP of the channel %s from %s to %s", OPERATION_TYPE_CHANNEL_CLAIM_SIGN, amount, channelId, wallet.Name, channel.Destination)
+	l.Logger.Info(note)
+
+	if err := o.submitRawMessage(ctx, operationId.Hex(), fbAccount, note, fbAlgorithm, content); err != nil {
+		if errUpdate := o.repo.UpdateChannelClaimSignature(ctx, operationId.Hex(), ow.CLAIM_STATUS_FAILED, ""); errUpdate != nil {
+			l.Logger.Error("operation service: failed to update channel claim", zap.Error(errUpdate))
+		}
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// start a worker to store the signature of the claim once signed
+	go o.worker.SignClaim(operationId.Hex(), claim, callback)
+
+	l.Logger.Info(fmt.Sprintf("operation service: starting claim worker for operation %s", operationId.Hex()))
+
+	return operationId.Hex(), nil
+}
+
+// VerifyChannelClaim verifies a claim of the cumulative amount of XRP of a payment channel against the channel on the
+// ledger: the signature must be of the channel key and the amount covered by the XRP funded on the channel. The public
+// key of the channel is used when none is given.
+func (o *OperationService) VerifyChannelClaim(ctx context.Context, channelId, amount, publicKey, claimSignature string) (*ClaimVerification, error) {
+	amountDrops, err := xrpn.ConvertXrpToDrops(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerChannel, err := o.xrpClient.GetPaymentChannel(ctx, channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifyChannelClaim(channelId, ledgerChannel, amount, amountDrops, publicKey, claimSignature), nil
+}
+
+// SubmitChannelClaim redeems, from the destination wallet of the channel, a claim signed by the channel source. The
+// claim is verified before being submitted with a PaymentChannelClaim, which also asks the channel to close when close
+// is set.
+func (o *OperationService) SubmitChannelClaim(ctx context.Context, blockchainId, channelId, amount, publicKey, claimSignature string, closeChannel bool, operator string, callback func()) (string, error) {
+	amountDrops, err := xrpn.ConvertXrpToDrops(amount)
+	if err != nil {
+		l.Logger.Error("operation service: invalid xrp amount", zap.Error(err))
+		return "", err
+	}
+
+	ledgerChannel, err := o.xrpClient.GetPaymentChannel(ctx, channelId)
+	if err != nil {
+		return "", err
+	}
+
+	verification := verifyChannelClaim(channelId, ledgerChannel, amount, amountDrops, publicKey, claimSignature)
+	if !verification.Valid {
+		return "", fmt.Errorf("invalid claim of channel %s: %s", channelId, verification.Reason)
+	}
+
+	// the claim is redeemed by the destination of the channel, which must be one of the wallets
+	wallet, err := o.repo.FindWalletByAddressAndBlockchain(ctx, ledgerChannel.Destination, blockchainId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", fmt.Errorf("destination %s of channel %s is not a wallet of the blockchain: %v", ledgerChannel.Destination, channelId, err)
+	}
+
+	// retrieve fireblocks account for the wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_CHANNEL_CLAIM,
+		Domain:           wallet.Domain,
+		Token:            xrpn.CURRENCY_XRP,
+		Amount:           verification.Claimable,
+		Operator:         operator,
+		Destination:      wallet.Address,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of a claim of %s XRP of the channel %s from %s to %s", OPERATION_TYPE_CHANNEL_CLAIM, amount, channelId, ledgerChannel.Account, wallet.Name)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     parseStructToJson(verification),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), wallet, fbAccount)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s of %s XRP of the channel %s from %s to %s", OPERATION_TYPE_CHANNEL_CLAIM, amount, channelId, ledgerChannel.Account, wallet.Name)
+	l.Logger.Info(note)
+
+	flags := fbAccount.Flags
+	if closeChannel {
+		flags |= int(xrpn.TF_CLOSE)
+	}
+
+	balance := xrpn.NewXrpAmount(amountDrops)
+	channelClaim := &xrpn.XrpPaymentChannelClaimTx{
+		XrpTxCommon: buildRippleTxCommon(wallet.Address, signingParams, flags, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_CHANNEL_CLAIM)),
+		Channel:     channelId,
+		Balance:     &balance,
+		Amount:      &balance,
+		Signature:   claimSignature,
+		PublicKey:   verification.PublicKey,
+	}
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccount, note, channelClaim, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+// ListPaymentChannels returns the payment channels opened by the service with their state on the ledger, updating the
+// status of the channels created, failed or closed since the last listing
+func (o *OperationService) ListPaymentChannels(ctx context.Context) ([]*PaymentChannelState, error) {
+	channels, err := o.repo.FindPaymentChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*PaymentChannelState{}
+	for _, channel := range channels {
+		state := &PaymentChannelState{PaymentChannel: channel}
+
+		if channel.Status == CHANNEL_STATUS_PENDING || channel.Status == CHANNEL_STATUS_OPEN {
+			status, err := o.reconcileChannel(ctx, state)
+			if err != nil {
+				return nil, err
+			}
+
+			if status != channel.Status {
+				if err := o.repo.UpdatePaymentChannelStatus(ctx, channel.ID, status); err != nil {
+					return nil, err
+				}
+				channel.Status = status
+			}
+		}
+
+		result = append(result, state)
+	}
+
+	return result, nil
+}
+
+// ListChannelClaims returns the claims signed for a payment channel opened by the service
+func (o *OperationService) ListChannelClaims(ctx context.Context, channelId string) ([]*r.ChannelClaim, error) {
+	return o.repo.FindChannelClaims(ctx, channelId)
+}
+
+// reconcileChannel fills the ledger state of the channel, returning its status according to the ledger
+func (o *OperationService) reconcileChannel(ctx context.Context, state *PaymentChannelState) (string, error) {
+	ledgerChannel, err := o.xrpClient.GetPaymentChannel(ctx, state.ChannelID)
+	if err == nil {
+		state.LedgerAmount = xrpFromDrops(ledgerChannel.Amount)
+		state.LedgerBalance = xrpFromDrops(ledgerChannel.Balance)
+		state.Claimable = xrpFromDrops(decimal.RequireFromString(ledgerChannel.Amount).Sub(decimal.RequireFromString(ledgerChannel.Balance)).String())
+		if ledgerChannel.Expiration > 0 {
+			expiration := xrpn.ConvertRippleTime(ledgerChannel.Expiration)
+			state.Expiration = &expiration
+		}
+		return CHANNEL_STATUS_OPEN, nil
+	}
+
+	if !errors.Is(err, xrpn.ErrPaymentChannelNotFound) {
+		return "", err
+	}
+
+	// an open channel missing from the ledger was closed, while a pending one is only failed with its operation
+	if state.Status == CHANNEL_STATUS_OPEN {
+		return CHANNEL_STATUS_CLOSED, nil
+	}
+
+	operation, err := o.repo.FindOperationById(ctx, state.OperationID)
+	if err == nil && operation.BlockchainStatus == "FAILED" {
+		return CHANNEL_STATUS_FAILED, nil
+	}
+
+	return state.Status, nil
+}
+
+// findOpenChannel returns the payment channel opened by the service, failing when it is not open
+func (o *OperationService) findOpenChannel(ctx context.Context, channelId string) (*r.PaymentChannel, error) {
+	channel, err := o.repo.FindPaymentChannelByChannelId(ctx, channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel == nil {
+		return nil, fmt.Errorf("payment channel %s was not opened by this service", channelId)
+	}
+
+	if channel.Status == CHANNEL_STATUS_CLOSED || channel.Status == CHANNEL_STATUS_FAILED {
+		return nil, fmt.Errorf("payment channel %s is %s", channelId, channel.Status)
+	}
+
+	return channel, nil
+}
+
+// validateChannelReserve checks that the wallet keeps its reserve after moving the drops to a channel, counting the
+// new objects the transaction makes the wallet own
+func (o *OperationService) validateChannelReserve(ctx context.Context, operationId string, wallet *r.Wallet, signingParams *SigningParams, amountDrops string, newObjects int) error {
+	serverInfo, err := o.xrpClient.GetServerInfo(ctx)
+
+	var reserve *xrpn.XrpAccountReserve
+	if err == nil {
+		accountData := *signingParams.AccountInfo.Result.AccountData
+		accountData.OwnerCount += newObjects
+		reserve, err = validateSenderReserve(&accountData, serverInfo.Result.Info.ValidatedLedger, amountDrops, signingParams.Fee.Fee)
+	}
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Validate XRP Account Reserve",
+		Description:  fmt.Sprintf("Validate that address %s keeps its reserve after moving %s drops to a payment channel", wallet.Address, amountDrops),
+		OperationID:  operationId,
+		FireblocksID: "",
+		Payload:      parseStructToJson(serverInfo),
+		Response:     parseStructToJson(reserve),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		return errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to validate xrp account reserve", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// verifyChannelClaim checks the claim against the channel on the ledger, telling why it is not valid
+func verifyChannelClaim(channelId string, ledgerChannel *xrpn.XrpPayChannel, amount, amountDrops, publicKey, claimSignature string) *ClaimVerification {
+	verification := &ClaimVerification{
+		ChannelID:      strings.ToUpper(channelId),
+		Account:        ledgerChannel.Account,
+		Destination:    ledgerChannel.Destination,
+		PublicKey:      ledgerChannel.PublicKey,
+		Amount:         amount,
+		ChannelAmount:  xrpFromDrops(ledgerChannel.Amount),
+		ChannelBalance: xrpFromDrops(ledgerChannel.Balance),
+		Claimable:      "0",
+	}
+
+	drops := decimal.RequireFromString(amountDrops)
+	balance := decimal.RequireFromString(ledgerChannel.Balance)
+	if drops.GreaterThan(balance) {
+		verification.Claimable = xrpFromDrops(drops.Sub(balance).String())
+	}
+
+	switch {
+	case publicKey != "" && !strings.EqualFold(publicKey, ledgerChannel.PublicKey):
+		verification.Reason = fmt.Sprintf("public key %s is not the key %s of the channel", publicKey, ledgerChannel.PublicKey)
+	case drops.GreaterThan(decimal.RequireFromString(ledgerChannel.Amount)):
+		verification.Reason = "claim amount exceeds the XRP funded on the channel"
+	case !drops.GreaterThan(balance):
+		verification.Reason = "claim amount was already delivered by the channel"
+	default:
+		if err := xrpn.VerifyClaim(channelId, amountDrops, ledgerChannel.PublicKey, claimSignature); err != nil {
+			verification.Reason = fmt.Sprintf("signature does not match the claim: %v", err)
+		} else {
+			verification.Valid = true
+		}
+	}
+
+	return verification
+}
+
+// xrpFromDrops converts the drops of a ledger response into XRP
+func xrpFromDrops(drops string) string {
+	xrp, err := xrpn.ConvertDropsToXrp(drops)
+	if err != nil {
+		return drops
+	}
+
+	return xrp
+}
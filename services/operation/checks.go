@@ -0,0 +1,325 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	ow "crypto-braza-tokens-api/workers"
+
+	"go.uber.org/zap"
+)
+
+const (
+	OPERATION_TYPE_CHECK_CREATE = "CHECK_CREATE"
+	OPERATION_TYPE_CHECK_CANCEL = "CHECK_CANCEL"
+)
+
+// ExecuteCheckPayoutOperation sends the payout of the token from the PAYMENT wallet of the domain as a check, which
+// the destination cashes once its trust line is ready. The check expires after the given seconds, never when zero,
+// and is tracked by its ledger object ID, linked to the external id of the originating transaction request.
+func (o *OperationService) ExecuteCheckPayoutOperation(ctx context.Context, opDomain, tokenId, blockchainId, destination string, destinationTag *uint32, amount string, expiresIn int, externalId, operator string, callback func()) (string, error) {
+	// accepts classic addresses and X-addresses, which carry the destination tag
+	destination, destinationTag, err := xrpn.NormalizeAddressAndTag(destination, destinationTag, o.xrpClient.IsTestnet())
+	if err != nil {
+		l.Logger.Error("operation service: invalid destination address", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve blockchain info for the operation
+	blockchain, err := o.repo.FindBlockchainById(ctx, blockchainId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find blockchain", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve token info for the operation
+	token, err := o.repo.FindTokenById(ctx, tokenId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find token", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve the payment wallet of the domain as origin wallet for the operation
+	walletFrom, err := o.repo.FindWalletByBlockchainWalletTypeAndDomain(ctx, blockchain.ID.Hex(), "PAYMENT", opDomain)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	if walletFrom.Address == destination {
+		return "", fmt.Errorf("destination address must be different from the origin wallet address")
+	}
+
+	// retrieve fireblocks account for the origin wallet
+	fbAccountFrom, err := o.repo.FindFireblocksAccountByWalletId(ctx, walletFrom.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_CHECK_CREATE,
+		Domain:           opDomain,
+		Token:            token.Abbr,
+		Amount:           amount,
+		Operator:         operator,
+		Destination:      destination,
+		DestinationTag:   destinationTag,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of a check of %s %s from %s to %s", OPERATION_TYPE_CHECK_CREATE, amount, token.Abbr, walletFrom.Name, destination)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// validates the destination tag requirements of the destination account
+	if err := o.validateDestinationTag(ctx, operationId.Hex(), destination, destinationTag); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), walletFrom, fbAccountFrom)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// the ledger object ID of the check derives from the sequence of its CheckCreate
+	checkId, err := xrpn.CheckID(walletFrom.Address, uint32(signingParams.Sequence))
+	if err != nil {
+		l.Logger.Error("operation service: failed to compute check id", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s %s %s from %s to %s", OPERATION_TYPE_CHECK_CREATE, amount, token.Abbr, walletFrom.Name, destination)
+	l.Logger.Info(note)
+
+	// builds the typed check of the RAW transaction, the token issuer is the token address
+	checkCreate := &xrpn.XrpCheckCreateTx{
+		XrpTxCommon:    buildRippleTxCommon(walletFrom.Address, signingParams, fbAccountFrom.Flags, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_CHECK_CREATE)),
+		Destination:    destination,
+		DestinationTag: destinationTag,
+		SendMax:        xrpn.NewIssuedAmount(token.Abbr, token.Address, amount),
+	}
+
+	var expiration *time.Time
+	if expiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Second).UTC().Truncate(time.Second)
+		rippleExpiration := xrpn.ToRippleTime(expiresAt)
+		checkCreate.Expiration = &rippleExpiration
+		expiration = &expiresAt
+	}
+
+	// the check is tracked before being signed, so its CheckCreate is recognised by the indexer
+	check := &r.Check{
+		CheckID:        checkId,
+		WalletID:       walletFrom.ID.Hex(),
+		Account:        walletFrom.Address,
+		Blockchain:     blockchain.ID.Hex(),
+		Domain:         opDomain,
+		Sequence:       signingParams.Sequence,
+		Destination:    destination,
+		DestinationTag: destinationTag,
+		SendMax:        toOfferAmount(checkCreate.SendMax),
+		Expiration:     expiration,
+		Status:         ow.CHECK_STATUS_PENDING,
+		ExternalID:     externalId,
+		OperationID:    operationId.Hex(),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	checkObjectId, err := o.repo.SaveCheck(ctx, check)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save check", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccountFrom, note, checkCreate, callback); err != nil {
+		if errUpdate := o.repo.UpdateCheckStatus(ctx, checkObjectId, ow.CHECK_STATUS_FAILED); errUpdate != nil {
+			l.Logger.Error("operation service: failed to update check status", zap.Error(errUpdate))
+		}
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+// ListChecks returns the checks sent by the service with the status, or all of them when no status is given. The
+// pending checks whose CheckCreate failed before reaching the ledger are set as failed.
+func (o *OperationService) ListChecks(ctx context.Context, status string) ([]*r.Check, error) {
+	checks, err := o.repo.FindChecks(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*r.Check{}
+	for _, check := range checks {
+		if check.Status == ow.CHECK_STATUS_PENDING {
+			operation, err := o.repo.FindOperationById(ctx, check.OperationID)
+			if err == nil && operation.BlockchainStatus == "FAILED" {
+				if err := o.repo.UpdateCheckStatus(ctx, check.ID, ow.CHECK_STATUS_FAILED); err != nil {
+					return nil, err
+				}
+				check.Status = ow.CHECK_STATUS_FAILED
+			}
+		}
+
+		if status == "" || check.Status == status {
+			result = append(result, check)
+		}
+	}
+
+	return result, nil
+}
+
+// CancelCheck cancels an expired check sent by the service, from the wallet that sent it
+func (o *OperationService) CancelCheck(ctx context.Context, checkId, operator string, callback func()) (string, error) {
+	check, err := o.repo.FindCheckByCheckId(ctx, checkId)
+	if err != nil {
+		return "", err
+	}
+
+	if check == nil {
+		return "", fmt.Errorf("check %s was not sent by this service", checkId)
+	}
+
+	// the indexer may not have set the expired status yet
+	expired := check.Status == ow.CHECK_STATUS_EXPIRED || (check.Status == ow.CHECK_STATUS_OPEN && check.Expiration != nil && !check.Expiration.After(time.Now()))
+	if !expired {
+		return "", fmt.Errorf("check %s is %s and only expired checks can be cancelled", checkId, check.Status)
+	}
+
+	if check.CancelOperationID != "" {
+		cancelOperation, err := o.repo.FindOperationById(ctx, check.CancelOperationID)
+		if err == nil && cancelOperation.BlockchainStatus != "FAILED" {
+			return "", fmt.Errorf("check %s is already being cancelled by operation %s", checkId, check.CancelOperationID)
+		}
+	}
+
+	wallet, err := o.repo.FindWalletById(ctx, check.WalletID)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve fireblocks account for the origin wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_CHECK_CANCEL,
+		Domain:           check.Domain,
+		Token:            check.SendMax.Currency,
+		Amount:           check.SendMax.Value,
+		Operator:         operator,
+		Destination:      check.Destination,
+		DestinationTag:   check.DestinationTag,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of the check %s sent by operation %s from %s", OPERATION_TYPE_CHECK_CANCEL, checkId, check.OperationID, wallet.Name)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(check),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), wallet, fbAccount)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s of the check %s of %s %s to %s from %s", OPERATION_TYPE_CHECK_CANCEL, checkId, check.SendMax.Value, check.SendMax.Currency, check.Destination, wallet.Name)
+	l.Logger.Info(note)
+
+	checkCancel := &xrpn.XrpCheckCancelTx{
+		XrpTxCommon: buildRippleTxCommon(wallet.Address, signingParams, fbAccount.Flags, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_CHECK_CANCEL)),
+		CheckID:     checkId,
+	}
+
+	if err := o.repo.UpdateCheckCancelOperation(ctx, check.ID, operationId.Hex()); err != nil {
+		l.Logger.Error("operation service: failed to update check cancel operation", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccount, note, checkCancel, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
@@ -0,0 +1,317 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// CROSS_CURRENCY_SLIPPAGE is the margin added to the quoted source amount to suggest the SendMax of the payment
+var CROSS_CURRENCY_SLIPPAGE = decimal.NewFromFloat(0.01)
+
+// QuoteCrossCurrencyPayout finds the cheapest path for the PAYMENT wallet of the domain to deliver the amount of the
+// token to the destination, spending the source token or XRP when no source token is given. The quote is shown to be
+// accepted before the payout is signed.
+func (o *OperationService) QuoteCrossCurrencyPayout(ctx context.Context, opDomain, blockchainId, sourceTokenId, tokenId, destination, amount string) (*CrossCurrencyQuote, error) {
+	destination, _, err := xrpn.NormalizeAddressAndTag(destination, nil, o.xrpClient.IsTestnet())
+	if err != nil {
+		l.Logger.Error("operation service: invalid destination address", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := o.retrieveCrossCurrencyParams(ctx, opDomain, blockchainId, sourceTokenId, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, _, err := o.quoteCrossCurrency(ctx, params, destination, amount)
+	return quote, err
+}
+
+// ExecuteCrossCurrencyPayout pays the amount of the token to the destination spending the source token or XRP through
+// the DEX, up to the accepted send max. The paths are found again, so the payout fails when the quote got more
+// expensive than the send max. Partial payouts may deliver less than the amount, down to the deliver min, and the
+// operation keeps the delivered amount of the validated transaction.
+func (o *OperationService) ExecuteCrossCurrencyPayout(ctx context.Context, opDomain, blockchainId, sourceTokenId, tokenId, destination string, destinationTag *uint32, amount, sendMax string, partial bool, deliverMin, operator string, callback func()) (string, error) {
+	if partial && deliverMin == "" {
+		return "", fmt.Errorf("partial payouts require the minimum amount to be delivered")
+	}
+
+	if !partial && deliverMin != "" {
+		return "", fmt.Errorf("the minimum amount to be delivered is only accepted on partial payouts")
+	}
+
+	// accepts classic addresses and X-addresses, which carry the destination tag
+	destination, destinationTag, err := xrpn.NormalizeAddressAndTag(destination, destinationTag, o.xrpClient.IsTestnet())
+	if err != nil {
+		l.Logger.Error("operation service: invalid destination address", zap.Error(err))
+		return "", err
+	}
+
+	params, err := o.retrieveCrossCurrencyParams(ctx, opDomain, blockchainId, sourceTokenId, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	if params.wallet.Address == destination {
+		return "", fmt.Errorf("destination address must be different from the origin wallet address")
+	}
+
+	// the send max is given in the display unit of the source currency, XRP being converted to drops
+	sendMaxAmount := params.sourceAmount(sendMax)
+	if sendMaxAmount.IsXrp() {
+		drops, err := xrpn.ConvertXrpToDrops(sendMax)
+		if err != nil {
+			l.Logger.Error("operation service: invalid send max", zap.Error(err))
+			return "", err
+		}
+		sendMaxAmount = xrpn.NewXrpAmount(drops)
+	}
+
+	// retrieve fireblocks account for the origin wallet
+	fbAccountFrom, err := o.repo.FindFireblocksAccountByWalletId(ctx, params.wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_PAYOUT,
+		Domain:           opDomain,
+		Token:            params.token.Abbr,
+		Amount:           amount,
+		Operator:         operator,
+		Destination:      destination,
+		DestinationTag:   destinationTag,
+		SourceCurrency:   params.sourceCurrencyName(),
+		SendMax:          sendMax,
+		DeliverMin:       deliverMin,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of %s %s paid with up to %s %s from %s to %s", OPERATION_TYPE_PAYOUT, amount, params.token.Abbr, sendMax, params.sourceCurrencyName(), params.wallet.Name, destination)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// finds the paths again, the quote must still fit in the accepted send max
+	quote, alternative, err := o.quoteCrossCurrency(ctx, params, destination, amount)
+	if err == nil && quote.sourceAmount.Decimal().GreaterThan(sendMaxAmount.Decimal()) {
+		err = fmt.Errorf("the quoted source amount %s %s exceeds the send max %s", quote.SourceAmount, quote.SourceCurrency, sendMax)
+	}
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Cross Currency Quote",
+		Description:  fmt.Sprintf("Quote of the paths from %s to %s for the payout of %s %s", params.wallet.Address, destination, amount, params.token.Abbr),
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(map[string]any{"send_max": sendMax, "partial": partial, "deliver_min": deliverMin}),
+		Response:     parseStructToJson(quote),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to quote cross currency payout", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// validates the destination tag requirements of the destination account
+	if err := o.validateDestinationTag(ctx, operationId.Hex(), destination, destinationTag); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), params.wallet, fbAccountFrom)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s %s %s paid with up to %s %s from %s to %s", OPERATION_TYPE_PAYOUT, amount, params.token.Abbr, sendMax, params.sourceCurrencyName(), params.wallet.Name, destination)
+	l.Logger.Info(note)
+
+	// builds the typed payment of the RAW transaction, spending up to the send max through the quoted paths
+	payment := buildRippleTokenPayment(params.wallet.Address, destination, params.token.Abbr, params.token.Address, amount, destinationTag, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_PAYOUT), fbAccountFrom.Flags, signingParams)
+	payment.SendMax = &sendMaxAmount
+	payment.Paths = alternative.PathsComputed
+
+	if partial {
+		deliverMinAmount := xrpn.NewIssuedAmount(params.token.Abbr, params.token.Address, deliverMin)
+		payment.Flags |= xrpn.TF_PARTIAL_PAYMENT
+		payment.DeliverMin = &deliverMinAmount
+	}
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccountFrom, note, payment, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+// crossCurrencyParams are the origin wallet and the tokens of a cross currency payout, a nil source token being XRP
+type crossCurrencyParams struct {
+	wallet      *r.Wallet
+	token       *r.Token
+	sourceToken *r.Token
+}
+
+func (p *crossCurrencyParams) sourceCurrencyName() string {
+	if p.sourceToken == nil {
+		return xrpn.CURRENCY_XRP
+	}
+	return p.sourceToken.Abbr
+}
+
+func (p *crossCurrencyParams) sourceAmount(value string) xrpn.XrpAmount {
+	if p.sourceToken == nil {
+		return xrpn.NewXrpAmount(value)
+	}
+	return xrpn.NewIssuedAmount(p.sourceToken.Abbr, p.sourceToken.Address, value)
+}
+
+func (o *OperationService) retrieveCrossCurrencyParams(ctx context.Context, opDomain, blockchainId, sourceTokenId, tokenId string) (*crossCurrencyParams, error) {
+	if sourceTokenId == tokenId {
+		return nil, fmt.Errorf("source and destination tokens must be different")
+	}
+
+	// retrieve blockchain info for the operation
+	blockchain, err := o.repo.FindBlockchainById(ctx, blockchainId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find blockchain", zap.Error(err))
+		return nil, err
+	}
+
+	// retrieve the token to be delivered to the destination
+	token, err := o.repo.FindTokenById(ctx, tokenId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find token", zap.Error(err))
+		return nil, err
+	}
+
+	// retrieve the token to be spent, XRP when no source token is given
+	var sourceToken *r.Token
+	if sourceTokenId != "" {
+		sourceToken, err = o.repo.FindTokenById(ctx, sourceTokenId)
+		if err != nil {
+			l.Logger.Error("operation service: failed to find source token", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	// retrieve the payment wallet of the domain as origin wallet for the operation
+	wallet, err := o.repo.FindWalletByBlockchainWalletTypeAndDomain(ctx, blockchain.ID.Hex(), "PAYMENT", opDomain)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return nil, err
+	}
+
+	return &crossCurrencyParams{wallet: wallet, token: token, sourceToken: sourceToken}, nil
+}
+
+// quoteCrossCurrency finds the paths of the payout and quotes its best alternative
+func (o *OperationService) quoteCrossCurrency(ctx context.Context, params *crossCurrencyParams, destination, amount string) (*CrossCurrencyQuote, *xrpn.XrpPathAlternative, error) {
+	destinationAmount := xrpn.NewIssuedAmount(params.token.Abbr, params.token.Address, amount)
+
+	sourceCurrency, sourceIssuer := "", ""
+	if params.sourceToken != nil {
+		sourceCurrency = xrpn.ParseStringToHex(params.sourceToken.Abbr)
+		sourceIssuer = params.sourceToken.Address
+	}
+
+	paths, err := o.xrpClient.FindPaths(ctx, params.wallet.Address, destination, destinationAmount, sourceCurrency, sourceIssuer)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find payment paths", zap.Error(err))
+		return nil, nil, err
+	}
+
+	alternative, sourceAmount, err := paths.BestAlternative()
+	if err != nil {
+		l.Logger.Error("operation service: failed to select payment path", zap.Error(err))
+		return nil, nil, err
+	}
+
+	quote, err := buildCrossCurrencyQuote(params, destination, destinationAmount, sourceAmount, len(alternative.PathsComputed))
+	if err != nil {
+		l.Logger.Error("operation service: failed to build cross currency quote", zap.Error(err))
+		return nil, nil, err
+	}
+
+	return quote, alternative, nil
+}
+
+// buildCrossCurrencyQuote builds the quote in the display units, XRP instead of drops, suggesting a send max with
+// the slippage margin over the source amount
+func buildCrossCurrencyQuote(params *crossCurrencyParams, destination string, destinationAmount, sourceAmount xrpn.XrpAmount, pathsCount int) (*CrossCurrencyQuote, error) {
+	if (params.sourceToken == nil) != sourceAmount.IsXrp() {
+		return nil, fmt.Errorf("path source amount is not of the source currency %s", params.sourceCurrencyName())
+	}
+
+	source := sourceAmount.Decimal()
+	sendMax := source.Mul(decimal.NewFromInt(1).Add(CROSS_CURRENCY_SLIPPAGE))
+
+	if sourceAmount.IsXrp() {
+		// drops are integers, the display amounts being in XRP
+		source = source.Shift(-6)
+		sendMax = sendMax.Ceil().Shift(-6)
+	} else {
+		sendMax = sendMax.RoundCeil(6)
+	}
+
+	return &CrossCurrencyQuote{
+		Source:              params.wallet.Address,
+		Destination:         destination,
+		DestinationCurrency: params.token.Abbr,
+		DestinationAmount:   destinationAmount.Value,
+		SourceCurrency:      params.sourceCurrencyName(),
+		SourceAmount:        source.String(),
+		SendMax:             sendMax.String(),
+		Rate:                source.Div(destinationAmount.Decimal()).String(),
+		PathsCount:          pathsCount,
+		sourceAmount:        sourceAmount,
+	}, nil
+}
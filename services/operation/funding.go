@@ -0,0 +1,201 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const OPERATION_TYPE_FUNDING = "FUNDING"
+
+// ExecuteFundingOperation transfers native XRP between two registered wallets to fund their reserves and fees.
+// The transaction is signed via fireblocks RAW and tracked as an operation of type FUNDING.
+func (o *OperationService) ExecuteFundingOperation(ctx context.Context, blockchainId, walletFromId, walletToId, amount, operator string, callback func()) (string, error) {
+	if walletFromId == walletToId {
+		return "", fmt.Errorf("origin and destination wallets must be different")
+	}
+
+	// retrieve blockchain info for the operation
+	blockchain, err := o.repo.FindBlockchainById(ctx, blockchainId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find blockchain", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve origin wallet for the operation
+	walletFrom, err := o.repo.FindWalletById(ctx, walletFromId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve destination wallet for the operation
+	walletTo, err := o.repo.FindWalletById(ctx, walletToId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	for _, wallet := range []*r.Wallet{walletFrom, walletTo} {
+		if wallet.Blockchain != blockchain.ID.Hex() || !wallet.IsActive {
+			return "", fmt.Errorf("wallet %s is not an active wallet of blockchain %s", wallet.Name, blockchain.Name)
+		}
+	}
+
+	amountDrops, err := xrpn.ConvertXrpToDrops(amount)
+	if err != nil {
+		l.Logger.Error("operation service: invalid xrp amount", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve fireblocks account for the origin wallet
+	fbAccountFrom, err := o.repo.FindFireblocksAccountByWalletId(ctx, walletFrom.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_FUNDING,
+		Domain:           walletTo.Domain,
+		Token:            xrpn.CURRENCY_XRP,
+		Amount:           amount,
+		Operator:         operator,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of %s XRP from %s to %s", OPERATION_TYPE_FUNDING, amount, walletFrom.Name, walletTo.Name)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// validates the destination tag requirements of the destination wallet
+	if err := o.validateDestinationTag(ctx, operationId.Hex(), walletTo.Address, walletTo.DestinationTag); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), walletFrom, fbAccountFrom)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// validates that the origin wallet keeps its reserve after the transfer
+	serverInfo, err := o.xrpClient.GetServerInfo(ctx)
+
+	var reserve *xrpn.XrpAccountReserve
+	if err == nil {
+		reserve, err = validateSenderReserve(signingParams.AccountInfo.Result.AccountData, serverInfo.Result.Info.ValidatedLedger, amountDrops, signingParams.Fee.Fee)
+	}
+
+	operationLog = &r.OperationLog{
+		Event:        "Validate XRP Account Reserve",
+		Description:  fmt.Sprintf("Validate that address %s keeps its reserve after transferring %s drops", walletFrom.Address, amountDrops),
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(serverInfo),
+		Response:     parseStructToJson(reserve),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if errLog := o.repo.SaveOperationLog(ctx, operationLog); errLog != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to validate xrp account reserve", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s %s XRP from %s to %s", OPERATION_TYPE_FUNDING, amount, walletFrom.Name, walletTo.Name)
+	l.Logger.Info(note)
+
+	// builds the typed payment of the RAW transaction
+	payment := buildRippleXrpPayment(walletFrom.Address, walletTo.Address, amountDrops, walletTo.DestinationTag, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_FUNDING), fbAccountFrom.Flags, signingParams)
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccountFrom, note, payment, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+// validateSenderReserve checks that the sender balance covers the amount and fee while keeping
+// its reserve (base reserve plus owner reserve for each owned object)
+func validateSenderReserve(accountData *xrpn.XrpAccountData, ledger *xrpn.XrpServerInfoLedger, amountDrops, feeDrops string) (*xrpn.XrpAccountReserve, error) {
+	if accountData == nil {
+		return nil, fmt.Errorf("account data is required to validate the reserve")
+	}
+
+	reserve, err := xrpn.CalculateAccountReserve(ledger, accountData.OwnerCount)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := decimal.NewFromString(accountData.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse account balance %s: %v", accountData.Balance, err)
+	}
+
+	amount, err := decimal.NewFromString(amountDrops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse amount %s: %v", amountDrops, err)
+	}
+
+	fee, err := decimal.NewFromString(feeDrops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fee %s: %v", feeDrops, err)
+	}
+
+	total := decimal.RequireFromString(reserve.Total)
+	remaining := balance.Sub(amount).Sub(fee)
+
+	if remaining.LessThan(total) {
+		return reserve, fmt.Errorf("insufficient balance: account %s would keep %s drops, below its reserve of %s drops", accountData.Account, remaining, total)
+	}
+
+	return reserve, nil
+}
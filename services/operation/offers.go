@@ -0,0 +1,495 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	OPERATION_TYPE_OFFER_CREATE = "OFFER_CREATE"
+	OPERATION_TYPE_OFFER_CANCEL = "OFFER_CANCEL"
+
+	// the offers are placed from the MARKET_MAKER wallet of the domain
+	WALLET_TYPE_MARKET_MAKER = "MARKET_MAKER"
+
+	OFFER_SIDE_BUY  = "BUY"
+	OFFER_SIDE_SELL = "SELL"
+
+	// an offer is PLACED until it is seen on the ledger, then OPEN until it is filled or expires (CLOSED) or is CANCELLED
+	OFFER_STATUS_PLACED    = "PLACED"
+	OFFER_STATUS_OPEN      = "OPEN"
+	OFFER_STATUS_CLOSED    = "CLOSED"
+	OFFER_STATUS_CANCELLED = "CANCELLED"
+	OFFER_STATUS_FAILED    = "FAILED"
+)
+
+// PlaceOffer places an offer of the token against XRP on the DEX from the MARKET_MAKER wallet of the domain. A SELL
+// offer gives the amount of the token for XRP at the price, a BUY offer gives XRP for the amount of the token. The
+// offer is tracked by the sequence of its OfferCreate transaction.
+func (o *OperationService) PlaceOffer(ctx context.Context, opDomain, blockchainId, tokenId, side, amount, price string, passive bool, expiresIn int, operator string, callback func()) (string, error) {
+	// retrieve blockchain info for the operation
+	blockchain, err := o.repo.FindBlockchainById(ctx, blockchainId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find blockchain", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve token info for the operation
+	token, err := o.repo.FindTokenById(ctx, tokenId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find token", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve the market maker wallet of the domain as origin wallet for the operation
+	wallet, err := o.repo.FindWalletByBlockchainWalletTypeAndDomain(ctx, blockchain.ID.Hex(), WALLET_TYPE_MARKET_MAKER, opDomain)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve fireblocks account for the origin wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	tokenAmount := xrpn.NewIssuedAmount(token.Abbr, token.Address, amount)
+	xrpAmount, err := offerXrpAmount(amount, price)
+	if err != nil {
+		l.Logger.Error("operation service: invalid offer price", zap.Error(err))
+		return "", err
+	}
+
+	// the offer creator gives TakerGets and receives TakerPays
+	takerGets, takerPays := tokenAmount, xrpAmount
+	flags := uint32(fbAccount.Flags)
+	if side == OFFER_SIDE_SELL {
+		flags |= xrpn.TF_SELL
+	} else {
+		takerGets, takerPays = xrpAmount, tokenAmount
+	}
+
+	if passive {
+		flags |= xrpn.TF_PASSIVE
+	}
+
+	var expiration *time.Time
+	if expiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Second).UTC().Truncate(time.Second)
+		expiration = &expiresAt
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_OFFER_CREATE,
+		Domain:           opDomain,
+		Token:            token.Abbr,
+		Amount:           amount,
+		Operator:         operator,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation to %s %s %s at %s XRP from %s", OPERATION_TYPE_OFFER_CREATE, side, amount, token.Abbr, price, wallet.Name)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), wallet, fbAccount)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s %s %s %s at %s XRP from %s", OPERATION_TYPE_OFFER_CREATE, side, amount, token.Abbr, price, wallet.Name)
+	l.Logger.Info(note)
+
+	// builds the typed offer of the RAW transaction
+	offerCreate := &xrpn.XrpOfferCreateTx{
+		XrpTxCommon: buildRippleTxCommon(wallet.Address, signingParams, int(flags), buildOperationMemos(operationId.Hex(), OPERATION_TYPE_OFFER_CREATE)),
+		TakerGets:   takerGets,
+		TakerPays:   takerPays,
+	}
+
+	if expiration != nil {
+		rippleExpiration := xrpn.ToRippleTime(*expiration)
+		offerCreate.Expiration = &rippleExpiration
+	}
+
+	// the offer is tracked before being signed, as its sequence is the one of the transaction
+	offer := &r.Offer{
+		WalletID:    wallet.ID.Hex(),
+		Account:     wallet.Address,
+		Blockchain:  blockchain.ID.Hex(),
+		Domain:      opDomain,
+		Sequence:    signingParams.Sequence,
+		Side:        side,
+		TakerGets:   toOfferAmount(takerGets),
+		TakerPays:   toOfferAmount(takerPays),
+		Flags:       int(flags),
+		Expiration:  expiration,
+		Status:      OFFER_STATUS_PLACED,
+		OperationID: operationId.Hex(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	offerId, err := o.repo.SaveOffer(ctx, offer)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save offer", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccount, note, offerCreate, callback); err != nil {
+		if errUpdate := o.repo.UpdateOfferStatus(ctx, offerId, OFFER_STATUS_FAILED); errUpdate != nil {
+			l.Logger.Error("operation service: failed to update offer status", zap.Error(errUpdate))
+		}
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+// CancelOffer cancels an open offer placed by the service from the MARKET_MAKER wallet of the domain
+func (o *OperationService) CancelOffer(ctx context.Context, opDomain, blockchainId string, sequence int, operator string, callback func()) (string, error) {
+	wallet, err := o.findMarketMakerWallet(ctx, opDomain, blockchainId)
+	if err != nil {
+		return "", err
+	}
+
+	offer, err := o.repo.FindOfferByWalletAndSequence(ctx, wallet.ID.Hex(), sequence)
+	if err != nil {
+		return "", err
+	}
+
+	if offer == nil {
+		return "", fmt.Errorf("offer %d of wallet %s was not placed by this service", sequence, wallet.Name)
+	}
+
+	if offer.Status != OFFER_STATUS_PLACED && offer.Status != OFFER_STATUS_OPEN {
+		return "", fmt.Errorf("offer %d is %s and can not be cancelled", sequence, offer.Status)
+	}
+
+	if offer.CancelOperationID != "" {
+		cancelOperation, err := o.repo.FindOperationById(ctx, offer.CancelOperationID)
+		if err == nil && cancelOperation.BlockchainStatus != "FAILED" {
+			return "", fmt.Errorf("offer %d is already being cancelled by operation %s", sequence, offer.CancelOperationID)
+		}
+	}
+
+	// retrieve fireblocks account for the origin wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, wallet.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// the operation amount is the token amount of the offer
+	amount, token := offer.TakerGets.Value, offer.TakerGets.Currency
+	if offer.Side == OFFER_SIDE_BUY {
+		amount, token = offer.TakerPays.Value, offer.TakerPays.Currency
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_OFFER_CANCEL,
+		Domain:           opDomain,
+		Token:            token,
+		Amount:           amount,
+		Operator:         operator,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of the offer %d placed by operation %s from %s", OPERATION_TYPE_OFFER_CANCEL, sequence, offer.OperationID, wallet.Name)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(offer),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), wallet, fbAccount)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s of the offer %d from %s", OPERATION_TYPE_OFFER_CANCEL, sequence, wallet.Name)
+	l.Logger.Info(note)
+
+	offerCancel := &xrpn.XrpOfferCancelTx{
+		XrpTxCommon:   buildRippleTxCommon(wallet.Address, signingParams, fbAccount.Flags, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_OFFER_CANCEL)),
+		OfferSequence: uint32(sequence),
+	}
+
+	if err := o.repo.UpdateOfferCancelOperation(ctx, offer.ID, operationId.Hex()); err != nil {
+		l.Logger.Error("operation service: failed to update offer cancel operation", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccount, note, offerCancel, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
+
+// ListOffers returns the open offers of the MARKET_MAKER wallet of the domain, telling which ones were placed by the
+// service. The status of the tracked offers is updated from the ledger and from their operations.
+func (o *OperationService) ListOffers(ctx context.Context, opDomain, blockchainId string) ([]*MarketOffer, error) {
+	wallet, err := o.findMarketMakerWallet(ctx, opDomain, blockchainId)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerOffers, err := o.xrpClient.GetAccountOffers(ctx, wallet.Address)
+	if err != nil {
+		l.Logger.Error("operation service: failed to get account offers", zap.Error(err))
+		return nil, err
+	}
+
+	tracked, err := o.repo.FindOffersByWallet(ctx, wallet.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	open := map[int]bool{}
+	for _, ledgerOffer := range ledgerOffers {
+		open[ledgerOffer.Seq] = true
+	}
+
+	bySequence := map[int]*r.Offer{}
+	for _, offer := range tracked {
+		status := o.reconcileOfferStatus(ctx, offer, open[offer.Sequence])
+		if status != offer.Status {
+			if err := o.repo.UpdateOfferStatus(ctx, offer.ID, status); err != nil {
+				return nil, err
+			}
+			offer.Status = status
+		}
+		bySequence[offer.Sequence] = offer
+	}
+
+	result := []*MarketOffer{}
+	for _, ledgerOffer := range ledgerOffers {
+		result = append(result, buildMarketOffer(ledgerOffer, bySequence[ledgerOffer.Seq]))
+	}
+
+	return result, nil
+}
+
+// reconcileOfferStatus returns the status of a tracked offer. An offer that is not on the ledger anymore was either
+// cancelled by the service or closed by being filled or expired, once its OfferCreate is validated. An OfferCreate
+// completed on submission is not on the ledger until it is validated, so the offer stays placed meanwhile.
+func (o *OperationService) reconcileOfferStatus(ctx context.Context, offer *r.Offer, open bool) string {
+	if offer.Status != OFFER_STATUS_PLACED && offer.Status != OFFER_STATUS_OPEN {
+		return offer.Status
+	}
+
+	if open {
+		return OFFER_STATUS_OPEN
+	}
+
+	operation, err := o.repo.FindOperationById(ctx, offer.OperationID)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find offer operation", zap.String("operation", offer.OperationID), zap.Error(err))
+		return offer.Status
+	}
+
+	switch operation.BlockchainStatus {
+	case "FAILED":
+		return OFFER_STATUS_FAILED
+	case "COMPLETED":
+		if offer.CancelOperationID != "" {
+			cancelOperation, err := o.repo.FindOperationById(ctx, offer.CancelOperationID)
+			if err == nil && cancelOperation.BlockchainStatus == "COMPLETED" {
+				return OFFER_STATUS_CANCELLED
+			}
+		}
+		validated, success := o.offerCreateValidated(ctx, operation)
+		if !validated {
+			return offer.Status
+		}
+		if !success {
+			return OFFER_STATUS_FAILED
+		}
+		return OFFER_STATUS_CLOSED
+	}
+
+	// the OfferCreate was not submitted yet
+	return offer.Status
+}
+
+// offerCreateValidated tells whether the OfferCreate of an operation is in a validated ledger and whether it
+// succeeded. The balance changes of an operation are recorded once its transaction is validated, otherwise the
+// transaction is looked up on the node.
+func (o *OperationService) offerCreateValidated(ctx context.Context, operation *r.Operation) (bool, bool) {
+	if len(operation.BalanceChanges) > 0 {
+		return true, true
+	}
+
+	if operation.TransactionHash == "" {
+		return false, false
+	}
+
+	tx, err := o.xrpClient.GetTransaction(ctx, operation.TransactionHash)
+	if err != nil {
+		if !errors.Is(err, xrpn.ErrTransactionNotFound) {
+			l.Logger.Error("operation service: failed to get offer transaction", zap.String("hash", operation.TransactionHash), zap.Error(err))
+		}
+		return false, false
+	}
+
+	if !tx.Validated || tx.Metadata == nil {
+		return false, false
+	}
+
+	return true, tx.Metadata.TransactionResult == "tesSUCCESS"
+}
+
+func (o *OperationService) findMarketMakerWallet(ctx context.Context, opDomain, blockchainId string) (*r.Wallet, error) {
+	blockchain, err := o.repo.FindBlockchainById(ctx, blockchainId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find blockchain", zap.Error(err))
+		return nil, err
+	}
+
+	wallet, err := o.repo.FindWalletByBlockchainWalletTypeAndDomain(ctx, blockchain.ID.Hex(), WALLET_TYPE_MARKET_MAKER, opDomain)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+// offerXrpAmount returns the XRP counter amount of an offer of the token amount at the price in XRP, in whole drops
+func offerXrpAmount(amount, price string) (xrpn.XrpAmount, error) {
+	amountValue, err := decimal.NewFromString(amount)
+	if err != nil {
+		return xrpn.XrpAmount{}, fmt.Errorf("invalid amount %s: %v", amount, err)
+	}
+
+	priceValue, err := decimal.NewFromString(price)
+	if err != nil {
+		return xrpn.XrpAmount{}, fmt.Errorf("invalid price %s: %v", price, err)
+	}
+
+	drops := amountValue.Mul(priceValue).Shift(6).Truncate(0)
+	if !drops.IsPositive() {
+		return xrpn.XrpAmount{}, fmt.Errorf("the offer of %s at %s XRP is less than a drop", amount, price)
+	}
+
+	return xrpn.NewXrpAmount(drops.String()), nil
+}
+
+// toOfferAmount converts an amount to its display unit, XRP instead of drops and the currency code as text
+func toOfferAmount(amount xrpn.XrpAmount) *r.OfferAmount {
+	if amount.IsXrp() {
+		return &r.OfferAmount{Currency: xrpn.CURRENCY_XRP, Value: amount.Decimal().Shift(-6).String()}
+	}
+
+	return &r.OfferAmount{Currency: xrpn.DecodeCurrencyCode(amount.Currency), Issuer: amount.Issuer, Value: amount.Value}
+}
+
+func buildMarketOffer(ledgerOffer *xrpn.XrpAccountOffer, tracked *r.Offer) *MarketOffer {
+	offer := &MarketOffer{
+		Sequence: ledgerOffer.Seq,
+		Quality:  ledgerOffer.Quality,
+		Flags:    ledgerOffer.Flags,
+	}
+
+	if takerGets, err := xrpn.ParseXrpAmount(ledgerOffer.TakerGets); err == nil {
+		offer.TakerGets = toOfferAmount(takerGets)
+	}
+
+	if takerPays, err := xrpn.ParseXrpAmount(ledgerOffer.TakerPays); err == nil {
+		offer.TakerPays = toOfferAmount(takerPays)
+	}
+
+	if ledgerOffer.Expiration > 0 {
+		expiration := xrpn.ConvertRippleTime(ledgerOffer.Expiration)
+		offer.Expiration = &expiration
+	}
+
+	if tracked != nil {
+		offer.Managed = true
+		offer.OfferID = tracked.ID.Hex()
+		offer.OperationID = tracked.OperationID
+		offer.Side = tracked.Side
+		offer.Status = tracked.Status
+	}
+
+	return offer
+}
@@ -10,10 +10,12 @@ import (
 	fb "crypto-braza-tokens-api/clients/fireblocks"
 	xrpn "crypto-braza-tokens-api/clients/ripple"
 	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+	"crypto-braza-tokens-api/clients/ripple/utils/signature"
 	r "crypto-braza-tokens-api/repositories"
 	l "crypto-braza-tokens-api/utils/logger"
 	ow "crypto-braza-tokens-api/workers"
 
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
@@ -123,7 +125,7 @@ func (o *OperationService) ValidateParams(ctx context.Context, opType, opDomain,
 	return nil
 }
 
-func (o *OperationService) ExecuteOperation(ctx context.Context, opType, opDomain, tokenId, blockchainId, amount, operator string, callback func()) (string, error) {
+func (o *OperationService) ExecuteOperation(ctx context.Context, opType, opDomain, tokenId, blockchainId, amount string, backing []*r.BackingRef, operator string, callback func()) (string, error) {
 	// retrieve blockchain info for the operation
 	blockchain, err := o.repo.FindBlockchainById(ctx, blockchainId)
 	if err != nil {
@@ -138,6 +140,12 @@ func (o *OperationService) ExecuteOperation(ctx context.Context, opType, opDomai
 		return "", err
 	}
 
+	// a mint must be covered by fiat deposits in the currency of the token that do not back any other operation
+	if err := o.validateBacking(ctx, opType, token.Abbr, amount, backing); err != nil {
+		l.Logger.Error("operation service: invalid operation backing", zap.Error(err))
+		return "", err
+	}
+
 	// builds the wallet params for the operation
 	domainFrom := token.Abbr
 	domainTo := opDomain
@@ -181,8 +189,11 @@ func (o *OperationService) ExecuteOperation(ctx context.Context, opType, opDomai
 	operation := &r.Operation{
 		Type:             opType,
 		Domain:           opDomain,
+		Token:            token.Abbr,
 		Amount:           amount,
 		Operator:         operator,
+		Backing:          backing,
+		BackingHeld:      len(backing) > 0,
 		FireblocksStatus: "",
 		BlockchainStatus: "",
 		FireblocksId:     "",
@@ -195,6 +206,10 @@ func (o *OperationService) ExecuteOperation(ctx context.Context, opType, opDomai
 	operationId, err := o.repo.SaveOperation(ctx, operation)
 	if err != nil {
 		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		// another instance saved an operation backed by one of the deposits since they were validated
+		if mongo.IsDuplicateKeyError(err) {
+			return "", fmt.Errorf("a bank transaction backing the operation already backs another operation")
+		}
 		return "", err
 	}
 
@@ -214,85 +229,136 @@ func (o *OperationService) ExecuteOperation(ctx context.Context, opType, opDomai
 
 	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
 		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
 		return "", err
 	}
 
-	// retrieve fireblocks account pubkey for the origin wallet
-	fbAccResult, err := o.fbClient.GetPublicKeyInfoFromVaultAccount(ctx, fbAccountFrom.VaultID, fbAccountFrom.AssetID, 0, 0)
-
-	operationLog = &r.OperationLog{
-		Event:        "Retrieve Fireblocks Account Public Key",
-		Description:  fmt.Sprintf("Retrieve Fireblocks Acc PubKey for wallet %s and address %s of domain %s", walletFrom.Name, walletFrom.Address, domainFrom),
-		OperationID:  operationId.Hex(),
-		FireblocksID: "",
-		Payload:      fmt.Sprintf("Fireblocks Account ID: %s, Asset ID: %s Change: %d Address Index: %d", fbAccountFrom.VaultID, fbAccountFrom.AssetID, 0, 0),
-		Response:     fbAccResult,
-		Error:        parseStructToJson(err),
-		CreatedAt:    time.Now(),
+	// validates the destination tag requirements of the destination wallet
+	if err := o.validateDestinationTag(ctx, operationId.Hex(), walletTo.Address, walletTo.DestinationTag); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
 	}
 
-	if errLog := o.repo.SaveOperationLog(ctx, operationLog); errLog != nil {
-		return "", errLog
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), walletFrom, fbAccountFrom)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
 	}
 
-	if err != nil {
-		l.Logger.Error("operation service: failed to get public key info from fireblocks", zap.Error(err))
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s %s %s tokens from %s to %s", opType, amount, token.Abbr, walletFrom.Name, walletTo.Name)
+	l.Logger.Info(note)
+
+	// builds the typed payment of the RAW transaction
+	payment := buildRippleTokenPayment(walletFrom.Address, walletTo.Address, token.Abbr, issuerAddress, amount, walletTo.DestinationTag, buildOperationMemos(operationId.Hex(), opType), fbAccountFrom.Flags, signingParams)
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccountFrom, note, payment, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
 		return "", err
 	}
 
-	// replace the public key for the updated one retrieved from fireblocks if it is not empty
-	if fbAccResult != nil && fbAccResult.PublicKey != "" {
-		fbAccountFrom.PublicKey = fbAccResult.PublicKey
+	return operationId.Hex(), nil
+}
+
+// failOperation marks an operation aborted before its worker was started as failed, so it is not left pending, which
+// also releases the fiat deposits backing a mint
+func (o *OperationService) failOperation(ctx context.Context, operationId string) {
+	if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, "FAILED", "", ""); err != nil {
+		l.Logger.Error("operation service: failed to update operation blockchain status", zap.Error(err))
 	}
+}
 
-	// retrieve xrp account info for the origin wallet
-	accNodeInfo, err := o.xrpClient.GetAccountInfo(ctx, walletFrom.Address)
+// submitRawTransaction validates, encodes and hashes the unsigned transaction, submits it to be signed on fireblocks
+// and starts the worker that submits the signed transaction to the ripple network
+func (o *OperationService) submitRawTransaction(ctx context.Context, operationId string, fbAccount *r.FireblocksAccount, note string, transaction xrpn.XrpTxBuilder, callback func()) error {
+	// validates the transaction fields before anything is sent to be signed
+	err := transaction.Validate()
 
-	operationLog = &r.OperationLog{
-		Event:        "Retrieve Account Params XRP Blockchain",
-		Description:  fmt.Sprintf("Retrieve Account Params Info for address %s from XRP Blockchain Node API", walletFrom.Address),
-		OperationID:  operationId.Hex(),
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Validate XRP Raw Transaction",
+		Description:  "Validated the fields of the XRP Raw Transaction",
+		OperationID:  operationId,
 		FireblocksID: "",
-		Payload:      fmt.Sprintf("Wallet %s, Address %s", walletFrom.Name, walletFrom.Address),
-		Response:     parseStructToJson(accNodeInfo),
+		Payload:      parseStructToJson(transaction),
+		Response:     "",
 		Error:        parseStructToJson(err),
 		CreatedAt:    time.Now(),
-	}
-
-	if errLog := o.repo.SaveOperationLog(ctx, operationLog); errLog != nil {
-		return "", errLog
+	})
+	if errLog != nil {
+		return errLog
 	}
 
 	if err != nil {
-		l.Logger.Error("operation service: failed to get account info from xrp node", zap.Error(err))
-		return "", err
+		l.Logger.Error("operation service: invalid xrp raw transaction", zap.Error(err))
+		return err
 	}
 
-	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
-	note := fmt.Sprintf("%s %s %s tokens from %s to %s", opType, amount, token.Abbr, walletFrom.Name, walletTo.Name)
-	l.Logger.Info(note)
-
-	// builds the base payload for the RAW transaction
-	rawTransactionBasePayload := buildRippleRawTransactionPayload(walletFrom.Address, walletTo.Address, token.Abbr, issuerAddress, amount, fbAccountFrom.PublicKey, fbAccountFrom.Flags, accNodeInfo.Result.AccountData.Sequence, accNodeInfo.Result.LedgerCurrentIndex)
+	rawTransaction := transaction.Payload()
 
 	// encode the unsigned RAW transaction into a blob
-	unsignTxBlob, err := binarycodec.Encode(rawTransactionBasePayload)
+	unsignTxBlob, err := binarycodec.Encode(rawTransaction)
 	if err != nil {
 		l.Logger.Error("operation service: failed to encode xrp tx into blob", zap.Error(err))
-		return "", err
+		return err
+	}
+
+	errLog = o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Encode XRP Raw Transaction",
+		Description:  "Encoded XRP Raw Transaction into the unsigned tx blob",
+		OperationID:  operationId,
+		FireblocksID: "",
+		Payload:      parseStructToJson(rawTransaction),
+		Response:     unsignTxBlob,
+		Error:        "",
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		return errLog
 	}
 
-	// hashes the tx blob into 32 bytes message content for fireblocks raw sign
 	contactedPrefixWithUnsignedTxBlob := xrpn.ConcactPrefixWithTxBlob(xrpn.PREFIX_UNSIGNED, unsignTxBlob)
 
-	hasheUnsignedTx, err := xrpn.Sha512Half(xrpn.HASH_SIZE, contactedPrefixWithUnsignedTxBlob)
+	// the signing algorithm is given by the SigningPubKey of the transaction
+	publicKey, _ := rawTransaction["SigningPubKey"].(string)
+	algorithm, err := signature.DetectAlgorithm(publicKey)
 	if err != nil {
-		l.Logger.Error("operation service: failed to computes the SHA-512 hash of the input hex string", zap.Error(err))
-		return "", err
+		l.Logger.Error("operation service: failed to detect the signing algorithm", zap.Error(err))
+		return err
+	}
+
+	// ed25519 signs the full prefixed tx blob, while secp256k1 signs its 32 bytes SHA-512Half hash
+	fbAlgorithm := fb.ALGORITHM_EDDSA_ED25519
+	rawMessageContent := contactedPrefixWithUnsignedTxBlob
+
+	if algorithm == signature.ALGORITHM_SECP256K1 {
+		fbAlgorithm = fb.ALGORITHM_ECDSA_SECP256K1
+
+		rawMessageContent, err = xrpn.Sha512Half(xrpn.HASH_SIZE, contactedPrefixWithUnsignedTxBlob)
+		if err != nil {
+			l.Logger.Error("operation service: failed to computes the SHA-512 hash of the input hex string", zap.Error(err))
+			return err
+		}
+	}
+
+	if err := o.submitRawMessage(ctx, operationId, fbAccount, note, fbAlgorithm, rawMessageContent); err != nil {
+		return err
 	}
 
+	// start a worker to check the signed transaction status and submit it to the ripple network
+	go o.worker.Start(operationId, rawTransaction, callback)
+
+	l.Logger.Info(fmt.Sprintf("operation service: starting worker for operation %s", operationId))
+
+	return nil
+}
+
+// submitRawMessage submits the content to be signed on fireblocks with the algorithm of the account key and stores the
+// fireblocks transaction on the operation, so the worker follows its signature
+func (o *OperationService) submitRawMessage(ctx context.Context, operationId string, fbAccount *r.FireblocksAccount, note, fbAlgorithm, rawMessageContent string) error {
 	// build the raw transaction request to be submitted to fireblocks
-	rawTxRequest := o.fbClient.BuildRawTransactionRequest(ctx, fbAccountFrom.VaultID, fbAccountFrom.AssetID, note, hasheUnsignedTx)
+	rawTxRequest := o.fbClient.BuildRawTransactionRequest(ctx, fbAccount.VaultID, fbAccount.AssetID, note, fbAlgorithm, rawMessageContent)
 
 	// submit the raw transaction to fireblocks to be signed
 	createRawTxResult, err := o.fbClient.SubmitTransaction(ctx, rawTxRequest)
@@ -300,25 +366,25 @@ func (o *OperationService) ExecuteOperation(ctx context.Context, opType, opDomai
 	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
 		Event:        "Fireblocks Raw Transaction Submitted",
 		Description:  "Fireblocks Raw Transaction Submitted to be signed by authorizers",
-		OperationID:  operationId.Hex(),
+		OperationID:  operationId,
 		FireblocksID: "",
 		Payload:      parseStructToJson(rawTxRequest),
 		Response:     "",
 		Error:        parseStructToJson(err),
 	})
 	if errLog != nil {
-		return "", errLog
+		return errLog
 	}
 
 	if err != nil {
 		l.Logger.Error("operation service: failed to submit raw transaction to fireblocks", zap.Error(err))
-		return "", err
+		return err
 	}
 
-	operationToUpdate, err := o.repo.FindOperationById(ctx, operationId.Hex())
+	operationToUpdate, err := o.repo.FindOperationById(ctx, operationId)
 	if err != nil {
 		l.Logger.Error("operation service: failed to find operation", zap.Error(err))
-		return "", err
+		return err
 	}
 
 	operationToUpdate.FireblocksId = createRawTxResult.ID
@@ -328,13 +394,154 @@ func (o *OperationService) ExecuteOperation(ctx context.Context, opType, opDomai
 	err = o.repo.UpdateOperationFireblocksIdAndStatus(ctx, operationToUpdate.ID.Hex(), operationToUpdate.FireblocksId, operationToUpdate.FireblocksStatus)
 	if err != nil {
 		l.Logger.Error("operation service: failed to update operation", zap.Error(err))
-		return "", err
+		return err
 	}
 
-	// start a worker to check the signed transaction status and submit it to the ripple network
-	go o.worker.Start(operationId.Hex(), rawTransactionBasePayload, callback)
+	return nil
+}
 
-	l.Logger.Info(fmt.Sprintf("operation service: starting worker for operation %s", operationId))
+// retrieveSigningParams retrieves the fireblocks public key, the account sequence and the transaction fee needed
+// to build a RAW transaction signed by the given wallet, logging each step on the operation
+func (o *OperationService) retrieveSigningParams(ctx context.Context, operationId string, wallet *r.Wallet, fbAccount *r.FireblocksAccount) (*SigningParams, error) {
+	// retrieve fireblocks account pubkey for the origin wallet
+	fbAccResult, err := o.fbClient.GetPublicKeyInfoFromVaultAccount(ctx, fbAccount.VaultID, fbAccount.AssetID, 0, 0)
 
-	return operationId.Hex(), nil
+	operationLog := &r.OperationLog{
+		Event:        "Retrieve Fireblocks Account Public Key",
+		Description:  fmt.Sprintf("Retrieve Fireblocks Acc PubKey for wallet %s and address %s of domain %s", wallet.Name, wallet.Address, wallet.Domain),
+		OperationID:  operationId,
+		FireblocksID: "",
+		Payload:      fmt.Sprintf("Fireblocks Account ID: %s, Asset ID: %s Change: %d Address Index: %d", fbAccount.VaultID, fbAccount.AssetID, 0, 0),
+		Response:     fbAccResult,
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if errLog := o.repo.SaveOperationLog(ctx, operationLog); errLog != nil {
+		return nil, errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to get public key info from fireblocks", zap.Error(err))
+		return nil, err
+	}
+
+	// replace the public key for the updated one retrieved from fireblocks if it is not empty
+	if fbAccResult != nil && fbAccResult.PublicKey != "" {
+		fbAccount.PublicKey = fbAccResult.PublicKey
+	}
+
+	// validates the public key algorithm against the one declared on the wallet (secp256k1 when not declared)
+	algorithm, err := signature.DetectAlgorithm(fbAccount.PublicKey)
+	if err != nil {
+		l.Logger.Error("operation service: failed to detect the public key algorithm", zap.Error(err))
+		return nil, err
+	}
+
+	declaredAlgorithm := wallet.Algorithm
+	if declaredAlgorithm == "" {
+		declaredAlgorithm = signature.ALGORITHM_SECP256K1
+	}
+
+	if !strings.EqualFold(algorithm, declaredAlgorithm) {
+		return nil, fmt.Errorf("wallet %s declares algorithm %s but its fireblocks public key is %s", wallet.Name, declaredAlgorithm, algorithm)
+	}
+
+	signingPubKey, err := signature.FormatSigningPubKey(fbAccount.PublicKey)
+	if err != nil {
+		l.Logger.Error("operation service: failed to format the signing public key", zap.Error(err))
+		return nil, err
+	}
+
+	// retrieve xrp account info for the origin wallet
+	accNodeInfo, err := o.xrpClient.GetAccountInfo(ctx, wallet.Address)
+
+	operationLog = &r.OperationLog{
+		Event:        "Retrieve Account Params XRP Blockchain",
+		Description:  fmt.Sprintf("Retrieve Account Params Info for address %s from XRP Blockchain Node API", wallet.Address),
+		OperationID:  operationId,
+		FireblocksID: "",
+		Payload:      fmt.Sprintf("Wallet %s, Address %s", wallet.Name, wallet.Address),
+		Response:     parseStructToJson(accNodeInfo),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if errLog := o.repo.SaveOperationLog(ctx, operationLog); errLog != nil {
+		return nil, errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to get account info from xrp node", zap.Error(err))
+		return nil, err
+	}
+
+	// retrieve the current transaction cost from the xrp node
+	feeResult, err := o.xrpClient.GetFee(ctx)
+
+	var feeCalculation *xrpn.XrpFeeCalculation
+	if err == nil {
+		// operations are signed by a single fireblocks key, so no multisig scaling applies
+		feeCalculation, err = o.xrpClient.CalculateFee(feeResult.Result, 0)
+	}
+
+	operationLog = &r.OperationLog{
+		Event:        "Calculate XRP Transaction Fee",
+		Description:  "Calculate the transaction fee from the XRP Blockchain Node API open ledger fee",
+		OperationID:  operationId,
+		FireblocksID: "",
+		Payload:      parseStructToJson(feeResult),
+		Response:     parseStructToJson(feeCalculation),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if errLog := o.repo.SaveOperationLog(ctx, operationLog); errLog != nil {
+		return nil, errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to calculate xrp transaction fee", zap.Error(err))
+		return nil, err
+	}
+
+	return &SigningParams{
+		PublicKey:          signingPubKey,
+		Algorithm:          algorithm,
+		Sequence:           accNodeInfo.Result.AccountData.Sequence,
+		LedgerCurrentIndex: accNodeInfo.Result.LedgerCurrentIndex,
+		AccountInfo:        accNodeInfo,
+		Fee:                feeCalculation,
+	}, nil
+}
+
+// validateDestinationTag checks if the destination account requires a destination tag (RequireDestinationTag flag)
+// and fails when none was provided for the payment
+func (o *OperationService) validateDestinationTag(ctx context.Context, operationId, address string, destinationTag *uint32) error {
+	accNodeInfo, err := o.xrpClient.GetAccountInfo(ctx, address)
+
+	if err == nil && destinationTag == nil && accNodeInfo.Result != nil && accNodeInfo.Result.AccountFlags != nil && accNodeInfo.Result.AccountFlags.RequireDestinationTag {
+		err = fmt.Errorf("destination address %s requires a destination tag", address)
+	}
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Validate Destination Tag",
+		Description:  fmt.Sprintf("Validate destination tag requirements for address %s on XRP Blockchain", address),
+		OperationID:  operationId,
+		FireblocksID: "",
+		Payload:      parseStructToJson(map[string]any{"destination": address, "destination_tag": destinationTag}),
+		Response:     parseStructToJson(accNodeInfo),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		return errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to validate destination tag", zap.Error(err))
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,130 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+const OPERATION_TYPE_PAYOUT = "PAYOUT"
+
+// ExecutePayoutOperation transfers tokens from the PAYMENT wallet of a domain to an external XRPL address.
+// The destination tag is optional, but required when the destination account has the RequireDestinationTag flag.
+func (o *OperationService) ExecutePayoutOperation(ctx context.Context, opDomain, tokenId, blockchainId, destination string, destinationTag *uint32, amount, operator string, callback func()) (string, error) {
+	// accepts classic addresses and X-addresses, which carry the destination tag
+	destination, destinationTag, err := xrpn.NormalizeAddressAndTag(destination, destinationTag, o.xrpClient.IsTestnet())
+	if err != nil {
+		l.Logger.Error("operation service: invalid destination address", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve blockchain info for the operation
+	blockchain, err := o.repo.FindBlockchainById(ctx, blockchainId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find blockchain", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve token info for the operation
+	token, err := o.repo.FindTokenById(ctx, tokenId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find token", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve the payment wallet of the domain as origin wallet for the operation
+	walletFrom, err := o.repo.FindWalletByBlockchainWalletTypeAndDomain(ctx, blockchain.ID.Hex(), "PAYMENT", opDomain)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	if walletFrom.Address == destination {
+		return "", fmt.Errorf("destination address must be different from the origin wallet address")
+	}
+
+	// retrieve fireblocks account for the origin wallet
+	fbAccountFrom, err := o.repo.FindFireblocksAccountByWalletId(ctx, walletFrom.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_PAYOUT,
+		Domain:           opDomain,
+		Token:            token.Abbr,
+		Amount:           amount,
+		Operator:         operator,
+		Destination:      destination,
+		DestinationTag:   destinationTag,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of %s %s from %s to %s", OPERATION_TYPE_PAYOUT, amount, token.Abbr, walletFrom.Name, destination)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     "",
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// validates the destination tag requirements of the destination account
+	if err := o.validateDestinationTag(ctx, operationId.Hex(), destination, destinationTag); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the public key, account sequence and transaction fee to sign with the origin wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), walletFrom, fbAccountFrom)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW transaction
+	note := fmt.Sprintf("%s %s %s from %s to %s", OPERATION_TYPE_PAYOUT, amount, token.Abbr, walletFrom.Name, destination)
+	l.Logger.Info(note)
+
+	// builds the typed payment of the RAW transaction, the token issuer is the token address
+	payment := buildRippleTokenPayment(walletFrom.Address, destination, token.Abbr, token.Address, amount, destinationTag, buildOperationMemos(operationId.Hex(), OPERATION_TYPE_PAYOUT), fbAccountFrom.Flags, signingParams)
+
+	// sign the RAW transaction with fireblocks and start the worker to submit it to the ripple network
+	if err := o.submitRawTransaction(ctx, operationId.Hex(), fbAccountFrom, note, payment, callback); err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	return operationId.Hex(), nil
+}
@@ -0,0 +1,311 @@
+package operation
+
+import (
+	"bufio"
+	"context"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+var (
+	// fields the rows of each report can be grouped by and the metrics of their rows, in the order of the CSV columns
+	supplyReportGroups      = []string{"token", "domain", "operator"}
+	supplyReportMetrics     = []string{"minted", "burned", "net", "mint_count", "burn_count"}
+	operationsReportGroups  = []string{"type", "token", "domain", "operator"}
+	operationsReportMetrics = []string{"count", "completed", "failed", "pending", "amount", "failed_steps", "avg_duration_seconds"}
+	mintsReportColumns      = []string{"operation_id", "created_at", "token", "domain", "operator", "amount", "blockchain_status", "transaction_hash", "bank_transaction_id", "backing_amount", "backing_currency", "received_at"}
+)
+
+// reportRow is a row of a report, whose values are written to the columns of the same name
+type reportRow interface {
+	values() map[string]string
+}
+
+// Report is an open report whose rows are read from the database while they are written, so reports of large ranges
+// are streamed instead of held in memory
+type Report struct {
+	Columns []string
+	cursor  *mongo.Cursor
+	decode  func(cursor *mongo.Cursor) (reportRow, error)
+}
+
+// OpenSupplyReport opens the report of the tokens minted and burned by the completed operations created between the
+// dates, grouped by the fields and by the period in the timezone when a period is given. The rows are always grouped
+// by token, as amounts of different tokens do not add up.
+func (o *OperationService) OpenSupplyReport(ctx context.Context, from, to time.Time, groupBy []string, period, timezone string) (*Report, error) {
+	if !slices.Contains(groupBy, "token") {
+		groupBy = append([]string{"token"}, groupBy...)
+	}
+
+	query, err := buildReportQuery(from, to, groupBy, supplyReportGroups, period, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := o.repo.AggregateSupplyReport(ctx, query)
+	if err != nil {
+		l.Logger.Error("operation service: failed to aggregate supply report", zap.Error(err))
+		return nil, err
+	}
+
+	return &Report{
+		Columns: reportColumns(query, supplyReportMetrics),
+		cursor:  cursor,
+		decode: func(cursor *mongo.Cursor) (reportRow, error) {
+			row := &SupplyReportRow{}
+			if err := cursor.Decode(row); err != nil {
+				return nil, err
+			}
+			row.Minted, row.Burned, row.Net = reportAmount(row.Minted), reportAmount(row.Burned), reportAmount(row.Net)
+			return row, nil
+		},
+	}, nil
+}
+
+// OpenOperationsReport opens the report of the activity of the operations created between the dates, grouped by the
+// fields and by the period in the timezone when a period is given. The amount is only reported when the rows are
+// grouped by token, as amounts of different tokens do not add up.
+func (o *OperationService) OpenOperationsReport(ctx context.Context, from, to time.Time, groupBy []string, period, timezone string) (*Report, error) {
+	if len(groupBy) == 0 {
+		groupBy = []string{"type", "token"}
+	}
+
+	query, err := buildReportQuery(from, to, groupBy, operationsReportGroups, period, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := o.repo.AggregateOperationsReport(ctx, query)
+	if err != nil {
+		l.Logger.Error("operation service: failed to aggregate operations report", zap.Error(err))
+		return nil, err
+	}
+
+	metrics := operationsReportMetrics
+	if !slices.Contains(groupBy, "token") {
+		metrics = slices.DeleteFunc(slices.Clone(metrics), func(metric string) bool { return metric == "amount" })
+	}
+
+	return &Report{
+		Columns: reportColumns(query, metrics),
+		cursor:  cursor,
+		decode: func(cursor *mongo.Cursor) (reportRow, error) {
+			row := &OperationsReportRow{}
+			if err := cursor.Decode(row); err != nil {
+				return nil, err
+			}
+			row.Amount = reportAmount(row.Amount)
+			return row, nil
+		},
+	}, nil
+}
+
+// OpenMintsReport opens the audit report of the mints created between the dates with the fiat deposits backing them,
+// one row per deposit and the dates in the timezone. Its rows are not grouped.
+func (o *OperationService) OpenMintsReport(ctx context.Context, from, to time.Time, groupBy []string, period, timezone string) (*Report, error) {
+	if len(groupBy) > 0 || period != "" {
+		return nil, fmt.Errorf("mints report lists each backing of the mints, so it can not be grouped")
+	}
+
+	query, err := buildReportQuery(from, to, nil, nil, "", timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := o.repo.AggregateMintsReport(ctx, query)
+	if err != nil {
+		l.Logger.Error("operation service: failed to aggregate mints report", zap.Error(err))
+		return nil, err
+	}
+
+	return &Report{
+		Columns: mintsReportColumns,
+		cursor:  cursor,
+		decode: func(cursor *mongo.Cursor) (reportRow, error) {
+			row := &MintsReportRow{}
+			if err := cursor.Decode(row); err != nil {
+				return nil, err
+			}
+			return row, nil
+		},
+	}, nil
+}
+
+// WriteJSON writes the rows of the report as a JSON array, one row at a time, and closes the report
+func (rp *Report) WriteJSON(ctx context.Context, w io.Writer) error {
+	defer rp.cursor.Close(ctx)
+
+	buffer := bufio.NewWriter(w)
+	if _, err := buffer.WriteString("["); err != nil {
+		return err
+	}
+
+	first := true
+	err := rp.each(ctx, func(row reportRow) error {
+		if !first {
+			if _, err := buffer.WriteString(","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		data, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		_, err = buffer.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := buffer.WriteString("]"); err != nil {
+		return err
+	}
+
+	return buffer.Flush()
+}
+
+// WriteCSV writes the header and the rows of the report as CSV, one row at a time, and closes the report
+func (rp *Report) WriteCSV(ctx context.Context, w io.Writer) error {
+	defer rp.cursor.Close(ctx)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(rp.Columns); err != nil {
+		return err
+	}
+
+	err := rp.each(ctx, func(row reportRow) error {
+		values := row.values()
+
+		record := make([]string, 0, len(rp.Columns))
+		for _, column := range rp.Columns {
+			record = append(record, values[column])
+		}
+		return writer.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (rp *Report) each(ctx context.Context, write func(row reportRow) error) error {
+	for rp.cursor.Next(ctx) {
+		row, err := rp.decode(rp.cursor)
+		if err != nil {
+			l.Logger.Error("operation service: failed to decode report row", zap.Error(err))
+			return err
+		}
+
+		if err := write(row); err != nil {
+			return err
+		}
+	}
+
+	return rp.cursor.Err()
+}
+
+// buildReportQuery validates the fields the rows are grouped by and the timezone of the report
+func buildReportQuery(from, to time.Time, groupBy, allowed []string, period, timezone string) (*r.ReportQuery, error) {
+	seen := map[string]bool{}
+	for _, field := range groupBy {
+		if !slices.Contains(allowed, field) {
+			return nil, fmt.Errorf("report can not be grouped by %s, only by %v", field, allowed)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("report is grouped by %s more than once", field)
+		}
+		seen[field] = true
+	}
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone %s: %w", timezone, err)
+	}
+
+	return &r.ReportQuery{From: from, To: to, GroupBy: groupBy, Period: period, Timezone: timezone}, nil
+}
+
+// reportColumns are the period, the fields the rows are grouped by and the metrics of the report
+func reportColumns(query *r.ReportQuery, metrics []string) []string {
+	columns := []string{}
+	if query.Period != "" {
+		columns = append(columns, "period")
+	}
+	columns = append(columns, query.GroupBy...)
+	return append(columns, metrics...)
+}
+
+// reportAmount formats a sum of amounts of the database without its trailing zeros or exponent
+func reportAmount(amount string) string {
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return amount
+	}
+	return value.String()
+}
+
+func (s *SupplyReportRow) values() map[string]string {
+	return map[string]string{
+		"period":     s.Period,
+		"token":      s.Token,
+		"domain":     s.Domain,
+		"operator":   s.Operator,
+		"minted":     s.Minted,
+		"burned":     s.Burned,
+		"net":        s.Net,
+		"mint_count": strconv.Itoa(s.MintCount),
+		"burn_count": strconv.Itoa(s.BurnCount),
+	}
+}
+
+func (o *OperationsReportRow) values() map[string]string {
+	return map[string]string{
+		"period":               o.Period,
+		"type":                 o.Type,
+		"token":                o.Token,
+		"domain":               o.Domain,
+		"operator":             o.Operator,
+		"count":                strconv.Itoa(o.Count),
+		"completed":            strconv.Itoa(o.Completed),
+		"failed":               strconv.Itoa(o.Failed),
+		"pending":              strconv.Itoa(o.Pending),
+		"amount":               o.Amount,
+		"failed_steps":         strconv.Itoa(o.FailedSteps),
+		"avg_duration_seconds": strconv.FormatFloat(o.AvgDurationSeconds, 'f', -1, 64),
+	}
+}
+
+func (m *MintsReportRow) values() map[string]string {
+	return map[string]string{
+		"operation_id":        m.OperationID,
+		"created_at":          m.CreatedAt,
+		"token":               m.Token,
+		"domain":              m.Domain,
+		"operator":            m.Operator,
+		"amount":              m.Amount,
+		"blockchain_status":   m.BlockchainStatus,
+		"transaction_hash":    m.TransactionHash,
+		"bank_transaction_id": m.BankTransactionID,
+		"backing_amount":      m.BackingAmount,
+		"backing_currency":    m.BackingCurrency,
+		"received_at":         m.ReceivedAt,
+	}
+}
@@ -0,0 +1,389 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	fb "crypto-braza-tokens-api/clients/fireblocks"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	"crypto-braza-tokens-api/clients/ripple/utils/signature"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	ow "crypto-braza-tokens-api/workers"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const OPERATION_TYPE_RESERVE_ATTESTATION = "RESERVE_ATTESTATION"
+
+// RESERVE_ATTESTATION_VERSION is the version of the attestation document, to be raised when its fields change
+const RESERVE_ATTESTATION_VERSION = 1
+
+// RecordReserveBalance records the fiat balance backing the token held on the custodian account on the date, with the
+// references of its evidences
+func (o *OperationService) RecordReserveBalance(ctx context.Context, tokenId, custodian, account, currency, amount string, asOf time.Time, evidences []string, operator string) (*r.ReserveBalance, error) {
+	token, err := o.repo.FindTokenById(ctx, tokenId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find token", zap.Error(err))
+		return nil, err
+	}
+
+	if !strings.EqualFold(token.Type, "ISSUED_CURRENCY") {
+		return nil, fmt.Errorf("token %s is not issued by the service, so it has no reserves", token.Abbr)
+	}
+
+	value, err := decimal.NewFromString(amount)
+	if err != nil || value.IsNegative() {
+		return nil, fmt.Errorf("invalid reserve amount %s", amount)
+	}
+
+	balance := &r.ReserveBalance{
+		TokenID:   token.ID.Hex(),
+		Abbr:      token.Abbr,
+		Custodian: custodian,
+		Account:   account,
+		Currency:  strings.ToUpper(currency),
+		Amount:    value.String(),
+		AsOf:      asOf,
+		Evidences: evidences,
+		Operator:  operator,
+		CreatedAt: time.Now(),
+	}
+
+	if _, err := o.repo.SaveReserveBalance(ctx, balance); err != nil {
+		l.Logger.Error("operation service: failed to save reserve balance", zap.Error(err))
+		return nil, err
+	}
+
+	l.Logger.Info(fmt.Sprintf("operation service: reserve balance of %s %s of %s recorded on %s %s", balance.Amount, balance.Currency, token.Abbr, custodian, account))
+
+	return balance, nil
+}
+
+// ListReserveBalances returns the reserve balances recorded for the token, or for all the tokens when no token is given
+func (o *OperationService) ListReserveBalances(ctx context.Context, tokenId string) ([]*r.ReserveBalance, error) {
+	return o.repo.FindReserveBalances(ctx, tokenId)
+}
+
+// GetReserves returns the current state of the reserves backing the token, as it would be attested now
+func (o *OperationService) GetReserves(ctx context.Context, tokenId string) (*ReserveAttestationDocument, error) {
+	token, err := o.repo.FindTokenById(ctx, tokenId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find token", zap.Error(err))
+		return nil, err
+	}
+
+	return o.buildReserveDocument(ctx, token)
+}
+
+// PublishReserveAttestation attests the reserves backing the circulating supply of the token. The SHA-256 digest of
+// the attestation document is signed on fireblocks by the issuer key, and the attestation is published once signed.
+func (o *OperationService) PublishReserveAttestation(ctx context.Context, tokenId, operator string, callback func()) (string, error) {
+	token, err := o.repo.FindTokenById(ctx, tokenId)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find token", zap.Error(err))
+		return "", err
+	}
+
+	// the document is built before anything is stored, so a token without reserves is rejected right away
+	document, err := o.buildReserveDocument(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	// retrieve the issuer wallet that signs the attestation
+	issuer, err := o.repo.FindWalletByBlockchainWalletTypeAndDomain(ctx, token.Blockchain, "ISSUER", token.Abbr)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallet", zap.Error(err))
+		return "", err
+	}
+
+	// retrieve fireblocks account for the issuer wallet
+	fbAccount, err := o.repo.FindFireblocksAccountByWalletId(ctx, issuer.ID.Hex())
+	if err != nil {
+		l.Logger.Error("operation service: failed to find fireblocks account", zap.Error(err))
+		return "", err
+	}
+
+	// create the operation object and store it to futher update and trackings
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_RESERVE_ATTESTATION,
+		Domain:           token.Abbr,
+		Token:            token.Abbr,
+		Amount:           document.CirculatingSupply,
+		Operator:         operator,
+		FireblocksStatus: "",
+		BlockchainStatus: "",
+		FireblocksId:     "",
+		TransactionHash:  "",
+		TransactionLink:  "",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := o.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("operation service: failed to save operation", zap.Error(err))
+		return "", err
+	}
+
+	msg := fmt.Sprintf("New %s Operation of %s %s in circulation backed by %s %s on ledger %d", OPERATION_TYPE_RESERVE_ATTESTATION, document.CirculatingSupply, token.Abbr, document.TotalReserves, document.ReserveCurrency, document.LedgerIndex)
+	l.Logger.Info(msg)
+
+	operationLog := &r.OperationLog{
+		Event:        "Operation Started",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(operation),
+		Response:     parseStructToJson(document),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := o.repo.SaveOperationLog(ctx, operationLog); err != nil {
+		l.Logger.Error("operation service: failed to save operation log", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// retrieve the issuer public key and its algorithm, validated against the one declared on the wallet
+	signingParams, err := o.retrieveSigningParams(ctx, operationId.Hex(), issuer, fbAccount)
+	if err != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	content, err := json.Marshal(document)
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Encode Reserves Attestation",
+		Description:  fmt.Sprintf("Encoded the reserves attestation of %s to have its SHA-256 digest signed by the issuer key", token.Abbr),
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      parseStructToJson(document),
+		Response:     string(content),
+		Error:        parseStructToJson(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		o.failOperation(ctx, operationId.Hex())
+		return "", errLog
+	}
+
+	if err != nil {
+		l.Logger.Error("operation service: failed to encode reserves attestation", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	attestation := &r.ReserveAttestation{
+		TokenID:                token.ID.Hex(),
+		Abbr:                   token.Abbr,
+		LedgerIndex:            document.LedgerIndex,
+		CirculatingSupply:      document.CirculatingSupply,
+		TotalReserves:          document.TotalReserves,
+		CollateralizationRatio: document.CollateralizationRatio,
+		Document:               string(content),
+		Digest:                 ow.AttestationDigest(string(content)),
+		Algorithm:              signingParams.Algorithm,
+		PublicKey:              signingParams.PublicKey,
+		Status:                 ow.ATTESTATION_STATUS_PENDING,
+		OperationID:            operationId.Hex(),
+		AttestedAt:             document.AttestedAt,
+		CreatedAt:              time.Now(),
+		UpdatedAt:              time.Now(),
+	}
+
+	if _, err := o.repo.SaveReserveAttestation(ctx, attestation); err != nil {
+		l.Logger.Error("operation service: failed to save reserve attestation", zap.Error(err))
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// the 32 bytes digest is signed as it is by both ed25519 and secp256k1 keys
+	fbAlgorithm := fb.ALGORITHM_EDDSA_ED25519
+	if signingParams.Algorithm == signature.ALGORITHM_SECP256K1 {
+		fbAlgorithm = fb.ALGORITHM_ECDSA_SECP256K1
+	}
+
+	// builds the note message to be sent to fireblocks authorizers who will sign the RAW digest
+	note := fmt.Sprintf("%s of %s %s in circulation backed by %s %s", OPERATION_TYPE_RESERVE_ATTESTATION, document.CirculatingSupply, token.Abbr, document.TotalReserves, document.ReserveCurrency)
+	l.Logger.Info(note)
+
+	if err := o.submitRawMessage(ctx, operationId.Hex(), fbAccount, note, fbAlgorithm, attestation.Digest); err != nil {
+		if errUpdate := o.repo.UpdateReserveAttestationSignature(ctx, operationId.Hex(), ow.ATTESTATION_STATUS_FAILED, ""); errUpdate != nil {
+			l.Logger.Error("operation service: failed to update reserve attestation", zap.Error(errUpdate))
+		}
+		o.failOperation(ctx, operationId.Hex())
+		return "", err
+	}
+
+	// start a worker to publish the attestation once signed
+	go o.worker.SignAttestation(operationId.Hex(), attestation, callback)
+
+	l.Logger.Info(fmt.Sprintf("operation service: starting attestation worker for operation %s", operationId.Hex()))
+
+	return operationId.Hex(), nil
+}
+
+// ListReserveAttestations returns the attestations of the token with the status, or of all the tokens or with any
+// status when they are not given
+func (o *OperationService) ListReserveAttestations(ctx context.Context, tokenId, status string) ([]*r.ReserveAttestation, error) {
+	return o.repo.FindReserveAttestations(ctx, tokenId, status)
+}
+
+// ListPublicReserveAttestations returns the published attestations of the token, or of all the tokens when no token
+// is given, with their detached signatures
+func (o *OperationService) ListPublicReserveAttestations(ctx context.Context, tokenId string) ([]*PublicReserveAttestation, error) {
+	attestations, err := o.repo.FindReserveAttestations(ctx, tokenId, ow.ATTESTATION_STATUS_PUBLISHED)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*PublicReserveAttestation{}
+	for _, attestation := range attestations {
+		result = append(result, publicReserveAttestation(attestation))
+	}
+
+	return result, nil
+}
+
+// GetPublicReserveAttestation returns the published attestation with its detached signature, or nil when it does not
+// exist or was not published
+func (o *OperationService) GetPublicReserveAttestation(ctx context.Context, attestationId string) (*PublicReserveAttestation, error) {
+	attestation, err := o.repo.FindReserveAttestationById(ctx, attestationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if attestation == nil || attestation.Status != ow.ATTESTATION_STATUS_PUBLISHED {
+		return nil, nil
+	}
+
+	return publicReserveAttestation(attestation), nil
+}
+
+func publicReserveAttestation(attestation *r.ReserveAttestation) *PublicReserveAttestation {
+	return &PublicReserveAttestation{
+		ID:       attestation.ID.Hex(),
+		Token:    attestation.Abbr,
+		Document: json.RawMessage(attestation.Document),
+		Signature: AttestationSignature{
+			Algorithm:       attestation.Algorithm,
+			PublicKey:       attestation.PublicKey,
+			DigestAlgorithm: "SHA-256",
+			Digest:          attestation.Digest,
+			Signature:       attestation.Signature,
+		},
+		AttestedAt: attestation.AttestedAt,
+	}
+}
+
+// buildReserveDocument reads the supply of the token on the last validated ledger and totals the last balance of each
+// custodian account backing it
+func (o *OperationService) buildReserveDocument(ctx context.Context, token *r.Token) (*ReserveAttestationDocument, error) {
+	if !strings.EqualFold(token.Type, "ISSUED_CURRENCY") || token.Address == "" {
+		return nil, fmt.Errorf("token %s is not issued by the service, so it has no reserves", token.Abbr)
+	}
+
+	balances, err := o.repo.FindLatestReserveBalances(ctx, token.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(balances) == 0 {
+		return nil, fmt.Errorf("no reserve balances recorded for token %s", token.Abbr)
+	}
+
+	document := &ReserveAttestationDocument{
+		Version:         RESERVE_ATTESTATION_VERSION,
+		Token:           token.Abbr,
+		Currency:        token.Contract,
+		Issuer:          token.Address,
+		ReserveCurrency: balances[0].Currency,
+		Treasury:        []AttestedHolding{},
+		Reserves:        []AttestedReserve{},
+		AttestedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+
+	totalReserves := decimal.Zero
+	for _, balance := range balances {
+		if balance.Currency != document.ReserveCurrency {
+			return nil, fmt.Errorf("reserves of token %s are recorded in %s and %s, attest them in a single currency", token.Abbr, document.ReserveCurrency, balance.Currency)
+		}
+
+		totalReserves = totalReserves.Add(decimal.RequireFromString(balance.Amount))
+		document.Reserves = append(document.Reserves, AttestedReserve{
+			Custodian: balance.Custodian,
+			Account:   balance.Account,
+			Amount:    balance.Amount,
+			AsOf:      balance.AsOf.UTC(),
+			Evidences: balance.Evidences,
+		})
+	}
+	document.TotalReserves = totalReserves.String()
+
+	wallets, err := o.repo.FindWalletsByBlockchainId(ctx, token.Blockchain)
+	if err != nil {
+		l.Logger.Error("operation service: failed to find wallets", zap.Error(err))
+		return nil, err
+	}
+
+	// the tokens held by the supply wallets were issued but are not in circulation
+	supplyWallets := map[string]*r.Wallet{}
+	hotWallets := []string{}
+	for _, wallet := range wallets {
+		if wallet.IsActive && strings.EqualFold(wallet.Type, "SUPPLY") {
+			supplyWallets[wallet.Address] = wallet
+			hotWallets = append(hotWallets, wallet.Address)
+		}
+	}
+
+	gateway, err := o.xrpClient.GetGatewayBalances(ctx, token.Address, hotWallets)
+	if err != nil {
+		return nil, err
+	}
+
+	document.LedgerIndex = gateway.LedgerIndex
+	document.LedgerHash = gateway.LedgerHash
+
+	circulating := decimal.Zero
+	for currency, value := range gateway.Obligations {
+		if isTokenCurrency(token, currency) {
+			circulating = circulating.Add(decimal.RequireFromString(value))
+		}
+	}
+
+	treasury := decimal.Zero
+	for _, address := range hotWallets {
+		balance := decimal.Zero
+		for _, held := range gateway.Balances[address] {
+			if isTokenCurrency(token, held.Currency) {
+				balance = balance.Add(decimal.RequireFromString(held.Value))
+			}
+		}
+
+		treasury = treasury.Add(balance)
+		document.Treasury = append(document.Treasury, AttestedHolding{Wallet: supplyWallets[address].Name, Address: address, Balance: balance.String()})
+	}
+
+	document.CirculatingSupply = circulating.String()
+	document.TreasuryHoldings = treasury.String()
+	document.IssuedSupply = circulating.Add(treasury).String()
+
+	if circulating.IsPositive() {
+		document.CollateralizationRatio = totalReserves.Div(circulating).Round(6).String()
+	}
+
+	return document, nil
+}
+
+// isTokenCurrency tells whether the currency code returned by the node, in its hex or readable form, is the token's
+func isTokenCurrency(token *r.Token, currency string) bool {
+	return strings.EqualFold(currency, token.Contract) || strings.EqualFold(xrpn.DecodeCurrencyCode(currency), token.Abbr)
+}
@@ -1,7 +1,9 @@
 package operation
 
 import (
+	xrpn "crypto-braza-tokens-api/clients/ripple"
 	r "crypto-braza-tokens-api/repositories"
+	"encoding/json"
 	"time"
 )
 
@@ -26,3 +28,184 @@ type OperationDomain struct {
 	Base
 	Name string `json:"name"`
 }
+
+type SigningParams struct {
+	PublicKey          string
+	Algorithm          string
+	Sequence           int
+	LedgerCurrentIndex int
+	AccountInfo        *xrpn.XrpAccountInfo
+	Fee                *xrpn.XrpFeeCalculation
+}
+
+// CrossCurrencyQuote is the quote of a cross currency payout, its amounts in the display unit of their currencies
+type CrossCurrencyQuote struct {
+	Source              string `json:"source" example:"rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"`
+	Destination         string `json:"destination" example:"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"`
+	DestinationCurrency string `json:"destination_currency" example:"USDB"`
+	DestinationAmount   string `json:"destination_amount" example:"100"`
+	SourceCurrency      string `json:"source_currency" example:"BBRL"`
+	SourceAmount        string `json:"source_amount" example:"512.35"`
+	SendMax             string `json:"send_max" example:"517.4735"`
+	Rate                string `json:"rate" example:"5.1235"`
+	PathsCount          int    `json:"paths_count" example:"2"`
+
+	// sourceAmount is the quoted source amount as on the ledger, in drops for XRP
+	sourceAmount xrpn.XrpAmount
+}
+
+// MarketOffer is an open offer of the MARKET_MAKER wallet on the DEX, Managed when it was placed by the service
+type MarketOffer struct {
+	Sequence    int            `json:"sequence" example:"4218"`
+	Side        string         `json:"side,omitempty" example:"SELL"`
+	TakerGets   *r.OfferAmount `json:"taker_gets"`
+	TakerPays   *r.OfferAmount `json:"taker_pays"`
+	Quality     string         `json:"quality" example:"0.19"`
+	Flags       int            `json:"flags" example:"524288"`
+	Expiration  *time.Time     `json:"expiration,omitempty"`
+	Managed     bool           `json:"managed" example:"true"`
+	OfferID     string         `json:"offer_id,omitempty" example:"6731c302c2c5a4d1f1d4aac9"`
+	OperationID string         `json:"operation_id,omitempty" example:"66f79f17ba6b56108cb3e81d"`
+	Status      string         `json:"status,omitempty" example:"OPEN"`
+}
+
+// AmmPool is the state of the AMM pool of a token against XRP, LPTokenBalance being the LP tokens held by the
+// MARKET_MAKER wallet of the domain
+type AmmPool struct {
+	Account         string                 `json:"account" example:"rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM"`
+	Wallet          string                 `json:"wallet" example:"rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"`
+	TokenAmount     *r.OfferAmount         `json:"token_amount"`
+	XrpAmount       *r.OfferAmount         `json:"xrp_amount"`
+	LPTokenCurrency string                 `json:"lp_token_currency" example:"03930D02208264E2E40EC1B0C09E4DB96EE197B1"`
+	LPTokenSupply   string                 `json:"lp_token_supply" example:"71150.53584131501"`
+	LPTokenBalance  string                 `json:"lp_token_balance" example:"1000"`
+	TradingFee      int                    `json:"trading_fee" example:"500"`
+	VoteSlots       []*xrpn.XrpAmmVoteSlot `json:"vote_slots,omitempty"`
+}
+
+// PaymentChannelState is a payment channel opened by the service with its state on the ledger, in XRP, Claimable
+// being the XRP funded on the channel not delivered to the destination yet
+type PaymentChannelState struct {
+	*r.PaymentChannel
+	LedgerAmount  string     `json:"ledger_amount,omitempty" example:"250"`
+	LedgerBalance string     `json:"ledger_balance,omitempty" example:"12.5"`
+	Claimable     string     `json:"claimable,omitempty" example:"237.5"`
+	Expiration    *time.Time `json:"expiration,omitempty"`
+}
+
+// ClaimVerification is the result of the verification of a claim of a payment channel, in XRP, Claimable being what
+// the claim delivers over the balance already delivered by the channel
+type ClaimVerification struct {
+	Valid          bool   `json:"valid" example:"true"`
+	Reason         string `json:"reason,omitempty" example:"claim amount exceeds the XRP funded on the channel"`
+	ChannelID      string `json:"channel_id" example:"E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366"`
+	Account        string `json:"account" example:"rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"`
+	Destination    string `json:"destination" example:"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"`
+	PublicKey      string `json:"public_key" example:"0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020"`
+	Amount         string `json:"amount" example:"12.5"`
+	ChannelAmount  string `json:"channel_amount" example:"250"`
+	ChannelBalance string `json:"channel_balance" example:"10"`
+	Claimable      string `json:"claimable" example:"2.5"`
+}
+
+// ReserveAttestationDocument is the attested state of the reserves backing a token, its JSON being the document whose
+// SHA-256 digest is signed by the issuer key. The supply is read on a validated ledger: the circulating supply is what
+// the issuer owes outside of its supply wallets, whose holdings are listed apart as treasury.
+type ReserveAttestationDocument struct {
+	Version                int               `json:"version" example:"1"`
+	Token                  string            `json:"token" example:"BBRL"`
+	Currency               string            `json:"currency" example:"4242524C00000000000000000000000000000000"`
+	Issuer                 string            `json:"issuer" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"`
+	LedgerIndex            int               `json:"ledger_index" example:"90000123"`
+	LedgerHash             string            `json:"ledger_hash" example:"7D9B8F37A2A37DCBEA08F4A1E94A53A4F5C4E3B0BDF0E8A2C1F32E9A0E3A1B8C"`
+	IssuedSupply           string            `json:"issued_supply" example:"1500000"`
+	TreasuryHoldings       string            `json:"treasury_holdings" example:"500000"`
+	CirculatingSupply      string            `json:"circulating_supply" example:"1000000"`
+	Treasury               []AttestedHolding `json:"treasury"`
+	ReserveCurrency        string            `json:"reserve_currency" example:"BRL"`
+	Reserves               []AttestedReserve `json:"reserves"`
+	TotalReserves          string            `json:"total_reserves" example:"1000500"`
+	CollateralizationRatio string            `json:"collateralization_ratio" example:"1.0005"`
+	AttestedAt             time.Time         `json:"attested_at"`
+}
+
+type AttestedHolding struct {
+	Wallet  string `json:"wallet" example:"Estoque Braza On"`
+	Address string `json:"address" example:"rwSLnXVuEPNkMw4sR7p1EsMTivsEo1LbsT"`
+	Balance string `json:"balance" example:"500000"`
+}
+
+type AttestedReserve struct {
+	Custodian string    `json:"custodian" example:"Banco do Brasil"`
+	Account   string    `json:"account" example:"0001-12345-6"`
+	Amount    string    `json:"amount" example:"1000500"`
+	AsOf      time.Time `json:"as_of"`
+	Evidences []string  `json:"evidences"`
+}
+
+// PublicReserveAttestation is a published attestation of the reserves of a token, Document being the exact JSON whose
+// digest was signed, so it can be verified against the detached signature
+type PublicReserveAttestation struct {
+	ID         string               `json:"id" example:"6737b2d50404579f10316ae0"`
+	Token      string               `json:"token" example:"BBRL"`
+	Document   json.RawMessage      `json:"document" swaggertype:"object"`
+	Signature  AttestationSignature `json:"signature"`
+	AttestedAt time.Time            `json:"attested_at"`
+}
+
+// AttestationSignature is the detached signature of the SHA-256 digest of an attestation document by the issuer key,
+// DER-encoded for secp256k1 keys
+type AttestationSignature struct {
+	Algorithm       string `json:"algorithm" example:"SECP256K1"`
+	PublicKey       string `json:"public_key" example:"031EBB60A3036A67B6AA2055D5BD79A5ADFEC608057407F1EE3720E01489D59E82"`
+	DigestAlgorithm string `json:"digest_algorithm" example:"SHA-256"`
+	Digest          string `json:"digest" example:"2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824"`
+	Signature       string `json:"signature" example:"3045022100..."`
+}
+
+// SupplyReportRow is the amount of tokens minted and burned on a period by the group of the row, the fields the rows
+// were not grouped by being empty
+type SupplyReportRow struct {
+	Period    string `bson:"period,omitempty" json:"period,omitempty" example:"2024-11"`
+	Token     string `bson:"token,omitempty" json:"token,omitempty" example:"BBRL"`
+	Domain    string `bson:"domain,omitempty" json:"domain,omitempty" example:"BRAZA-ON"`
+	Operator  string `bson:"operator,omitempty" json:"operator,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Minted    string `bson:"minted" json:"minted" example:"150000"`
+	Burned    string `bson:"burned" json:"burned" example:"25000"`
+	Net       string `bson:"net" json:"net" example:"125000"`
+	MintCount int    `bson:"mint_count" json:"mint_count" example:"12"`
+	BurnCount int    `bson:"burn_count" json:"burn_count" example:"3"`
+}
+
+// OperationsReportRow is the activity of the operations created on a period by the group of the row, the fields the
+// rows were not grouped by being empty
+type OperationsReportRow struct {
+	Period             string  `bson:"period,omitempty" json:"period,omitempty" example:"2024-11-15"`
+	Type               string  `bson:"type,omitempty" json:"type,omitempty" example:"MINT"`
+	Token              string  `bson:"token,omitempty" json:"token,omitempty" example:"BBRL"`
+	Domain             string  `bson:"domain,omitempty" json:"domain,omitempty" example:"BRAZA-ON"`
+	Operator           string  `bson:"operator,omitempty" json:"operator,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Count              int     `bson:"count" json:"count" example:"10"`
+	Completed          int     `bson:"completed" json:"completed" example:"8"`
+	Failed             int     `bson:"failed" json:"failed" example:"1"`
+	Pending            int     `bson:"pending" json:"pending" example:"1"`
+	Amount             string  `bson:"amount,omitempty" json:"amount,omitempty" example:"80000"` // sum of the completed operations, when grouped by token
+	FailedSteps        int     `bson:"failed_steps" json:"failed_steps" example:"2"`
+	AvgDurationSeconds float64 `bson:"avg_duration_seconds" json:"avg_duration_seconds" example:"7.5"` // of the completed operations
+}
+
+// MintsReportRow is a mint with one of the fiat deposits backing it, the deposit being empty when the mint has none
+type MintsReportRow struct {
+	OperationID       string `bson:"operation_id" json:"operation_id" example:"6737b2d50404579f10316ae1"`
+	CreatedAt         string `bson:"created_at" json:"created_at" example:"2024-11-15T15:45:10-0300"`
+	Token             string `bson:"token" json:"token" example:"BBRL"`
+	Domain            string `bson:"domain" json:"domain" example:"BRAZA-ON"`
+	Operator          string `bson:"operator" json:"operator" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Amount            string `bson:"amount" json:"amount" example:"1000"`
+	BlockchainStatus  string `bson:"blockchain_status" json:"blockchain_status" example:"COMPLETED"`
+	TransactionHash   string `bson:"transaction_hash" json:"transaction_hash"`
+	BankTransactionID string `bson:"bank_transaction_id" json:"bank_transaction_id" example:"E18236120202411151830s0123456789"`
+	BackingAmount     string `bson:"backing_amount" json:"backing_amount" example:"1000.00"`
+	BackingCurrency   string `bson:"backing_currency" json:"backing_currency" example:"BRL"`
+	ReceivedAt        string `bson:"received_at" json:"received_at" example:"2024-11-15T15:30:00-0300"`
+}
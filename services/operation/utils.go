@@ -5,29 +5,53 @@ import (
 	"encoding/json"
 )
 
-func buildRippleRawTransactionPayload(
+// buildRippleTxCommon builds the common fields of a transaction signed by the given address with the retrieved signing params
+func buildRippleTxCommon(address string, signingParams *SigningParams, flags int, memos []xrpn.XrpMemo) xrpn.XrpTxCommon {
+	return xrpn.XrpTxCommon{
+		Account:            address,
+		Fee:                signingParams.Fee.Fee,
+		Sequence:           uint32(signingParams.Sequence),
+		Flags:              uint32(flags),
+		LastLedgerSequence: uint32(signingParams.LedgerCurrentIndex + xrpn.LEDGER_INCREMENT),
+		SigningPubKey:      signingParams.PublicKey,
+		Memos:              memos,
+	}
+}
+
+// buildRippleTokenPayment builds the payment of an amount of the token issued by the issuer address
+func buildRippleTokenPayment(
 	walletFromAddress, walletToAddress,
-	tokenAbbr, issuerAddress,
-	amount, publicKey string,
-	flags, sequence, ledgerCurrentIndex int,
-) map[string]any {
+	tokenAbbr, issuerAddress, amount string,
+	destinationTag *uint32, memos []xrpn.XrpMemo,
+	flags int, signingParams *SigningParams,
+) *xrpn.XrpPaymentTx {
+	return &xrpn.XrpPaymentTx{
+		XrpTxCommon:    buildRippleTxCommon(walletFromAddress, signingParams, flags, memos),
+		Destination:    walletToAddress,
+		DestinationTag: destinationTag,
+		Amount:         xrpn.NewIssuedAmount(tokenAbbr, issuerAddress, amount),
+	}
+}
+
+// buildRippleXrpPayment builds the payment of a native XRP amount, expressed in drops
+func buildRippleXrpPayment(
+	walletFromAddress, walletToAddress, amountDrops string,
+	destinationTag *uint32, memos []xrpn.XrpMemo,
+	flags int, signingParams *SigningParams,
+) *xrpn.XrpPaymentTx {
+	return &xrpn.XrpPaymentTx{
+		XrpTxCommon:    buildRippleTxCommon(walletFromAddress, signingParams, flags, memos),
+		Destination:    walletToAddress,
+		DestinationTag: destinationTag,
+		Amount:         xrpn.NewXrpAmount(amountDrops),
+	}
+}
 
-	// builds the base payload for the RAW transaction
-	return map[string]any{
-		"TransactionType": "Payment",
-		"Account":         walletFromAddress,
-		"Destination":     walletToAddress,
-		"DestinationTag":  1,
-		"Amount": map[string]any{
-			"currency": xrpn.ParseStringToHex(tokenAbbr),
-			"issuer":   issuerAddress,
-			"value":    amount,
-		},
-		"Flags":              flags,
-		"Sequence":           sequence,
-		"Fee":                xrpn.BASE_FEE,
-		"LastLedgerSequence": ledgerCurrentIndex + xrpn.LEDGER_INCREMENT,
-		"SigningPubKey":      publicKey,
+// buildOperationMemos builds the memos that link an on-chain transaction to the operation that originated it
+func buildOperationMemos(operationId, operationType string) []xrpn.XrpMemo {
+	return []xrpn.XrpMemo{
+		{Type: "operation_id", Data: operationId},
+		{Type: "operation_type", Data: operationType},
 	}
 }
 
@@ -0,0 +1,130 @@
+package tokens
+
+import (
+	"context"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// GetHolderDistribution returns the holders count, the concentration metrics and the top holders of the token on the
+// snapshot, or on the last snapshot when no snapshot is given. It returns nil when the snapshot does not exist.
+func (ts *TokenService) GetHolderDistribution(ctx context.Context, tokenId, snapshotId string, top int) (*HolderDistribution, error) {
+	var snapshot *r.HolderSnapshot
+	var err error
+	if snapshotId != "" {
+		snapshot, err = ts.repo.FindHolderSnapshotById(ctx, tokenId, snapshotId)
+	} else {
+		snapshot, err = ts.repo.FindLatestHolderSnapshot(ctx, tokenId)
+	}
+	if err != nil {
+		l.Logger.Error("service: error finding holder snapshot", zap.String("token_id", tokenId), zap.Error(err))
+		return nil, err
+	}
+
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	balances, err := ts.repo.FindHolderBalances(ctx, snapshot.ID.Hex())
+	if err != nil {
+		l.Logger.Error("service: error finding holder balances", zap.String("snapshot_id", snapshot.ID.Hex()), zap.Error(err))
+		return nil, err
+	}
+
+	total, _ := decimal.NewFromString(snapshot.TotalBalance)
+
+	holders := []*TopHolder{}
+	for _, balance := range balances {
+		value, err := decimal.NewFromString(balance.Balance)
+		if err != nil || !value.IsPositive() {
+			continue
+		}
+
+		share := 0.0
+		if total.IsPositive() {
+			share = value.Div(total).Round(6).InexactFloat64()
+		}
+		holders = append(holders, &TopHolder{HolderBalance: balance, Share: share})
+	}
+
+	sort.SliceStable(holders, func(i, j int) bool {
+		a, _ := decimal.NewFromString(holders[i].Balance)
+		b, _ := decimal.NewFromString(holders[j].Balance)
+		return a.GreaterThan(b)
+	})
+
+	if len(holders) > top {
+		holders = holders[:top]
+	}
+
+	return &HolderDistribution{HolderSnapshot: snapshot, TopHolders: holders}, nil
+}
+
+// GetHolderSnapshots returns the snapshots of the holders of the token taken between the dates, with the holders
+// count and concentration metrics of each of them
+func (ts *TokenService) GetHolderSnapshots(ctx context.Context, tokenId string, from, to time.Time) ([]*r.HolderSnapshot, error) {
+	snapshots, err := ts.repo.FindHolderSnapshots(ctx, tokenId, from, to)
+	if err != nil {
+		l.Logger.Error("service: error finding holder snapshots", zap.String("token_id", tokenId), zap.Error(err))
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// GetHolderHistory returns the balance of the holder on each snapshot of the token taken between the dates and how
+// much it changed since the previous snapshot
+func (ts *TokenService) GetHolderHistory(ctx context.Context, tokenId, account string, from, to time.Time) (*HolderHistory, error) {
+	snapshots, err := ts.repo.FindHolderSnapshots(ctx, tokenId, from, to)
+	if err != nil {
+		l.Logger.Error("service: error finding holder snapshots", zap.String("token_id", tokenId), zap.Error(err))
+		return nil, err
+	}
+
+	balances, err := ts.repo.FindHolderBalancesByAccount(ctx, tokenId, account, from, to)
+	if err != nil {
+		l.Logger.Error("service: error finding holder balances", zap.String("token_id", tokenId), zap.String("account", account), zap.Error(err))
+		return nil, err
+	}
+
+	bySnapshot := map[string]*r.HolderBalance{}
+	for _, balance := range balances {
+		bySnapshot[balance.SnapshotID] = balance
+	}
+
+	history := &HolderHistory{TokenID: tokenId, Account: account, Points: []*HolderHistoryPoint{}}
+
+	previous := decimal.Zero
+	for i, snapshot := range snapshots {
+		point := &HolderHistoryPoint{
+			SnapshotID:  snapshot.ID.Hex(),
+			LedgerIndex: snapshot.LedgerIndex,
+			TakenAt:     snapshot.CreatedAt,
+		}
+
+		current := decimal.Zero
+		if balance, ok := bySnapshot[point.SnapshotID]; ok {
+			point.HasTrustLine = true
+			current, _ = decimal.NewFromString(balance.Balance)
+		}
+
+		// the first snapshot of the range has no previous balance to be compared to
+		change := decimal.Zero
+		if i > 0 {
+			change = current.Sub(previous)
+		}
+
+		point.Balance = current.String()
+		point.Change = change.String()
+		history.Points = append(history.Points, point)
+
+		previous = current
+	}
+
+	return history, nil
+}
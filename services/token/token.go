@@ -2,8 +2,10 @@ package tokens
 
 import (
 	"context"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
 	r "crypto-braza-tokens-api/repositories"
 	l "crypto-braza-tokens-api/utils/logger"
+	ow "crypto-braza-tokens-api/workers"
 	"errors"
 	"time"
 
@@ -12,11 +14,27 @@ import (
 )
 
 type TokenService struct {
-	repo *r.Repository
+	repo     *r.Repository
+	registry *ow.HolderRegistry
 }
 
 func NewTokenService(repo *r.Repository) *TokenService {
-	return &TokenService{repo}
+	xrpCli, err := xrpn.NewRippleNodeClient()
+	if err != nil {
+		l.Logger.Fatal("token service: failed to create a new xrp node client", zap.Error(err))
+	}
+
+	registry, err := ow.NewHolderRegistry(xrpCli, repo)
+	if err != nil {
+		l.Logger.Fatal("token service: failed to create a new holder registry", zap.Error(err))
+	}
+
+	return &TokenService{repo, registry}
+}
+
+// StartHolderRegistry starts snapshotting the holders of the issued tokens in background
+func (ts *TokenService) StartHolderRegistry(ctx context.Context) {
+	ts.registry.Start(ctx)
 }
 
 func (ts *TokenService) FindAll(ctx context.Context) ([]*Token, error) {
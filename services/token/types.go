@@ -1,6 +1,7 @@
 package tokens
 
 import (
+	r "crypto-braza-tokens-api/repositories"
 	"time"
 )
 
@@ -28,3 +29,33 @@ type Token struct {
 	BlockchainID string      `json:"blockchain_id"`
 	Blockchain   *Blockchain `json:"blockchain"`
 }
+
+// HolderDistribution is the distribution of a token among its holders on a snapshot, with its largest holders
+type HolderDistribution struct {
+	*r.HolderSnapshot
+	TopHolders []*TopHolder `json:"top_holders"`
+}
+
+// TopHolder is the balance of a holder with the share of the total held by all the holders
+type TopHolder struct {
+	*r.HolderBalance
+	Share float64 `json:"share"`
+}
+
+// HolderHistory is the balance of a holder on each snapshot of the token
+type HolderHistory struct {
+	TokenID string                `json:"token_id"`
+	Account string                `json:"account"`
+	Points  []*HolderHistoryPoint `json:"points"`
+}
+
+// HolderHistoryPoint is the balance of the holder on a snapshot and its change since the previous one. A holder
+// without a trust line on the snapshot has a zero balance.
+type HolderHistoryPoint struct {
+	SnapshotID   string    `json:"snapshot_id"`
+	LedgerIndex  int       `json:"ledger_index"`
+	HasTrustLine bool      `json:"has_trust_line"`
+	Balance      string    `json:"balance"`
+	Change       string    `json:"change"`
+	TakenAt      time.Time `json:"taken_at"`
+}
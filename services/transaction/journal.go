@@ -0,0 +1,382 @@
+package transaction
+
+import (
+	"context"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	ow "crypto-braza-tokens-api/workers"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// PostManualJournalEntry posts an entry made by an operator, such as the opening balances of the wallets or an
+// adjustment, rejecting it when its debits and credits are not balanced for every currency
+func (t *TransactionService) PostManualJournalEntry(ctx context.Context, entryType, description, domain string, postedAt time.Time, lines []*r.JournalLine, operator string) (*r.JournalEntry, error) {
+	now := time.Now()
+	if postedAt.IsZero() {
+		postedAt = now
+	}
+
+	for _, line := range lines {
+		line.Account = strings.ToUpper(line.Account)
+		line.Currency = strings.ToUpper(line.Currency)
+		if line.Debit == "" {
+			line.Debit = "0"
+		}
+		if line.Credit == "" {
+			line.Credit = "0"
+		}
+	}
+
+	entry := &r.JournalEntry{
+		Type:        strings.ToUpper(entryType),
+		Source:      ow.JOURNAL_SOURCE_MANUAL,
+		Description: description,
+		Domain:      domain,
+		Operator:    operator,
+		Lines:       lines,
+		PostedAt:    postedAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := ow.ValidateJournalEntry(entry); err != nil {
+		l.Logger.Error("transaction service: journal entry rejected", zap.Error(err))
+		return nil, err
+	}
+
+	if _, err := t.repo.SaveJournalEntry(ctx, entry); err != nil {
+		l.Logger.Error("transaction service: error saving journal entry", zap.Error(err))
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ListJournalEntries returns the entries with a line of the account in the currency posted between the dates
+func (t *TransactionService) ListJournalEntries(ctx context.Context, account, currency string, from, to time.Time) ([]*r.JournalEntry, error) {
+	entries, err := t.repo.FindJournalEntries(ctx, strings.ToUpper(account), strings.ToUpper(currency), from, to)
+	if err != nil {
+		l.Logger.Error("transaction service: error finding journal entries", zap.Error(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetTrialBalance returns the balance of every account with the entries posted before the date. When reconciled, the
+// balances of our wallets and of the tokens issued are compared with their balances on the ledger.
+func (t *TransactionService) GetTrialBalance(ctx context.Context, asOf time.Time, reconcile bool) (*TrialBalance, error) {
+	totals, err := t.repo.FindJournalAccountTotals(ctx, "", asOf)
+	if err != nil {
+		l.Logger.Error("transaction service: error finding journal account totals", zap.Error(err))
+		return nil, err
+	}
+
+	var ledger *ledgerBalances
+	if reconcile {
+		ledger, err = t.newLedgerBalances(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	trialBalance := &TrialBalance{AsOf: asOf, Balanced: true, Reconciled: reconcile, Accounts: []*TrialBalanceAccount{}, Totals: []*TrialBalanceTotal{}}
+
+	type currencyKey struct{ currency, issuer string }
+	debits, credits := map[currencyKey]decimal.Decimal{}, map[currencyKey]decimal.Decimal{}
+	currencies := []currencyKey{}
+
+	for _, total := range totals {
+		debit, _ := decimal.NewFromString(total.Debits)
+		credit, _ := decimal.NewFromString(total.Credits)
+		balance := normalBalance(total.Account, debit, credit)
+
+		account := &TrialBalanceAccount{
+			Account:  total.Account,
+			Currency: total.Currency,
+			Issuer:   total.Issuer,
+			Debits:   debit.String(),
+			Credits:  credit.String(),
+			Balance:  balance.String(),
+		}
+
+		if ledger != nil {
+			onChain, ok, err := ledger.balance(ctx, total.Account, total.Currency, total.Issuer)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				account.OnChainBalance, account.Difference, account.Matches = compareBalances(balance, onChain)
+			}
+		}
+
+		trialBalance.Accounts = append(trialBalance.Accounts, account)
+
+		key := currencyKey{total.Currency, total.Issuer}
+		if _, ok := debits[key]; !ok {
+			currencies = append(currencies, key)
+		}
+		debits[key] = debits[key].Add(debit)
+		credits[key] = credits[key].Add(credit)
+	}
+
+	for _, key := range currencies {
+		balanced := debits[key].Equal(credits[key])
+		trialBalance.Balanced = trialBalance.Balanced && balanced
+		trialBalance.Totals = append(trialBalance.Totals, &TrialBalanceTotal{
+			Currency: key.currency,
+			Issuer:   key.issuer,
+			Debits:   debits[key].String(),
+			Credits:  credits[key].String(),
+			Balanced: balanced,
+		})
+	}
+
+	return trialBalance, nil
+}
+
+// GetAccountStatement returns the lines posted to the account in the currency between the dates, from the balance of
+// the entries posted before the start. When reconciled, the closing balance is compared with the ledger.
+func (t *TransactionService) GetAccountStatement(ctx context.Context, account, currency string, from, to time.Time, reconcile bool) (*AccountStatement, error) {
+	account, currency = strings.ToUpper(account), strings.ToUpper(currency)
+
+	totals, err := t.repo.FindJournalAccountTotals(ctx, account, from)
+	if err != nil {
+		l.Logger.Error("transaction service: error finding journal account totals", zap.String("account", account), zap.Error(err))
+		return nil, err
+	}
+
+	opening := decimal.Zero
+	for _, total := range totals {
+		if total.Currency != currency {
+			continue
+		}
+		debit, _ := decimal.NewFromString(total.Debits)
+		credit, _ := decimal.NewFromString(total.Credits)
+		opening = opening.Add(normalBalance(account, debit, credit))
+	}
+
+	entries, err := t.repo.FindJournalEntries(ctx, account, currency, from, to)
+	if err != nil {
+		l.Logger.Error("transaction service: error finding journal entries", zap.String("account", account), zap.Error(err))
+		return nil, err
+	}
+
+	statement := &AccountStatement{
+		Account:        account,
+		Currency:       currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening.String(),
+		Lines:          []*StatementLine{},
+	}
+
+	balance := opening
+	issuer := ""
+	for _, entry := range entries {
+		debit, credit := decimal.Zero, decimal.Zero
+		for _, line := range entry.Lines {
+			if line.Account != account || line.Currency != currency {
+				continue
+			}
+			value, _ := decimal.NewFromString(line.Debit)
+			debit = debit.Add(value)
+			value, _ = decimal.NewFromString(line.Credit)
+			credit = credit.Add(value)
+			issuer = line.Issuer
+		}
+
+		balance = balance.Add(normalBalance(account, debit, credit))
+		statement.Lines = append(statement.Lines, &StatementLine{
+			EntryID:         entry.ID.Hex(),
+			Type:            entry.Type,
+			Description:     entry.Description,
+			TransactionHash: entry.TransactionHash,
+			OperationID:     entry.OperationID,
+			PostedAt:        entry.PostedAt,
+			Debit:           debit.String(),
+			Credit:          credit.String(),
+			Balance:         balance.String(),
+		})
+	}
+	statement.ClosingBalance = balance.String()
+
+	if reconcile {
+		ledger, err := t.newLedgerBalances(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		onChain, ok, err := ledger.balance(ctx, account, currency, issuer)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			statement.OnChainBalance, statement.Difference, statement.Matches = compareBalances(balance, onChain)
+		}
+	}
+
+	return statement, nil
+}
+
+// normalBalance is the balance of the account on its normal side, the debits less the credits for the assets and the
+// expenses, and the credits less the debits for the liabilities and the equity
+func normalBalance(account string, debit, credit decimal.Decimal) decimal.Decimal {
+	if ow.IsDebitNormal(account) {
+		return debit.Sub(credit)
+	}
+	return credit.Sub(debit)
+}
+
+func compareBalances(balance, onChain decimal.Decimal) (string, string, *bool) {
+	matches := balance.Equal(onChain)
+	return onChain.String(), balance.Sub(onChain).String(), &matches
+}
+
+// ledgerBalances reads the current balances of our wallets and the obligations of our issuers on the ledger, each
+// account being read once for all the accounts of the journal
+type ledgerBalances struct {
+	xrpClient   *xrpn.RippleNodeClient
+	wallets     []*r.Wallet
+	xrp         map[string]decimal.Decimal
+	lines       map[string][]xrpn.Line
+	obligations map[string]map[string]string
+}
+
+func (t *TransactionService) newLedgerBalances(ctx context.Context) (*ledgerBalances, error) {
+	blockchain, err := t.repo.FindBlockchainByAbbr(ctx, "XRP")
+	if err != nil {
+		l.Logger.Error("transaction service: error finding blockchain", zap.Error(err))
+		return nil, err
+	}
+
+	wallets, err := t.repo.FindWalletsByBlockchainId(ctx, blockchain.ID.Hex())
+	if err != nil {
+		l.Logger.Error("transaction service: error finding wallets", zap.Error(err))
+		return nil, err
+	}
+
+	return &ledgerBalances{
+		xrpClient:   t.xrpClient,
+		wallets:     wallets,
+		xrp:         map[string]decimal.Decimal{},
+		lines:       map[string][]xrpn.Line{},
+		obligations: map[string]map[string]string{},
+	}, nil
+}
+
+// balance returns the balance on the ledger of an account of the journal: the tokens issued by the issuer for its
+// liability, or the balances of the wallets of an asset account. The other accounts have no balance on the ledger.
+func (b *ledgerBalances) balance(ctx context.Context, account, currency, issuer string) (decimal.Decimal, bool, error) {
+	if account == ow.JournalIssuedAccount(currency) {
+		if issuer == "" {
+			for _, wallet := range b.wallets {
+				if strings.EqualFold(wallet.Type, "ISSUER") && strings.EqualFold(wallet.Domain, currency) {
+					issuer = wallet.Address
+				}
+			}
+		}
+		if issuer == "" {
+			return decimal.Zero, false, nil
+		}
+
+		issued, err := b.issued(ctx, issuer, currency)
+		return issued, err == nil, err
+	}
+
+	found := false
+	total := decimal.Zero
+	for _, wallet := range b.wallets {
+		if ow.JournalWalletAccount(wallet) != account {
+			continue
+		}
+		found = true
+
+		var held decimal.Decimal
+		var err error
+		if currency == xrpn.CURRENCY_XRP {
+			held, err = b.xrpBalance(ctx, wallet.Address)
+		} else {
+			held, err = b.tokenBalance(ctx, wallet.Address, currency, issuer)
+		}
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		total = total.Add(held)
+	}
+
+	return total, found, nil
+}
+
+func (b *ledgerBalances) xrpBalance(ctx context.Context, address string) (decimal.Decimal, error) {
+	if balance, ok := b.xrp[address]; ok {
+		return balance, nil
+	}
+
+	info, err := b.xrpClient.GetAccountInfo(ctx, address)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	balance := decimal.Zero
+	if info.Result != nil && info.Result.AccountData != nil {
+		xrp, err := xrpn.ConvertDropsToXrp(info.Result.AccountData.Balance)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		balance, _ = decimal.NewFromString(xrp)
+	}
+
+	b.xrp[address] = balance
+	return balance, nil
+}
+
+// tokenBalance sums the balances of the trust lines of the wallet in the currency, of the issuer when it is given
+func (b *ledgerBalances) tokenBalance(ctx context.Context, address, currency, issuer string) (decimal.Decimal, error) {
+	lines, ok := b.lines[address]
+	if !ok {
+		var err error
+		lines, _, err = b.xrpClient.GetAllAccountLines(ctx, address)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		b.lines[address] = lines
+	}
+
+	balance := decimal.Zero
+	for _, line := range lines {
+		if !strings.EqualFold(xrpn.DecodeCurrencyCode(line.Currency), currency) || (issuer != "" && line.Account != issuer) {
+			continue
+		}
+		value, _ := decimal.NewFromString(line.Balance)
+		balance = balance.Add(value)
+	}
+
+	return balance, nil
+}
+
+// issued is the amount of the currency the issuer owes to its holders, including our own supply and payment wallets
+func (b *ledgerBalances) issued(ctx context.Context, issuer, currency string) (decimal.Decimal, error) {
+	obligations, ok := b.obligations[issuer]
+	if !ok {
+		result, err := b.xrpClient.GetGatewayBalances(ctx, issuer, nil)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		obligations = result.Obligations
+		b.obligations[issuer] = obligations
+	}
+
+	for code, amount := range obligations {
+		if strings.EqualFold(xrpn.DecodeCurrencyCode(code), currency) {
+			return decimal.NewFromString(amount)
+		}
+	}
+
+	return decimal.Zero, nil
+}
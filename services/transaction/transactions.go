@@ -6,6 +6,7 @@ import (
 	xrpn "crypto-braza-tokens-api/clients/ripple"
 	r "crypto-braza-tokens-api/repositories"
 	l "crypto-braza-tokens-api/utils/logger"
+	ow "crypto-braza-tokens-api/workers"
 	"fmt"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ type TransactionService struct {
 	repo      *r.Repository
 	fbClient  *fb.FireblocksClient
 	xrpClient *xrpn.RippleNodeClient
+	indexer   *ow.LedgerIndexer
 }
 
 func NewTransactionService(repo *r.Repository) *TransactionService {
@@ -31,7 +33,24 @@ func NewTransactionService(repo *r.Repository) *TransactionService {
 		l.Logger.Fatal("transaction service: failed to create a new xrp node client", zap.Error(err))
 	}
 
-	return &TransactionService{repo, fbCli, xrpCli}
+	// the indexer reacts to the stream when it is configured, otherwise it only indexes on every interval
+	stream, err := xrpn.NewRippleStreamClient()
+	if err != nil {
+		l.Logger.Warn("transaction service: ledger indexer without xrp node stream", zap.Error(err))
+		stream = nil
+	}
+
+	indexer, err := ow.NewLedgerIndexer(xrpCli, stream, repo)
+	if err != nil {
+		l.Logger.Fatal("transaction service: failed to create a new ledger indexer", zap.Error(err))
+	}
+
+	return &TransactionService{repo, fbCli, xrpCli, indexer}
+}
+
+// StartIndexer starts indexing the on-chain transactions of the wallets in background
+func (t *TransactionService) StartIndexer(ctx context.Context) {
+	t.indexer.Start(ctx)
 }
 
 func (t *TransactionService) ExecuteInternalTransaction(ctx context.Context, domain, txType, blockchainId, assetId, amount, externalTxId string) (*fb.SubmittedTransactionResponse, error) {
@@ -29,3 +29,62 @@ type InternalTransactionParams struct {
 
 type Transaction struct {
 }
+
+// TrialBalance is the balance of every account of the journal on a date and the totals of the debits and credits of
+// each currency, that are balanced when the same
+type TrialBalance struct {
+	AsOf       time.Time              `json:"as_of"`
+	Balanced   bool                   `json:"balanced"`
+	Reconciled bool                   `json:"reconciled"` // whether the balances were compared with the ledger
+	Accounts   []*TrialBalanceAccount `json:"accounts"`
+	Totals     []*TrialBalanceTotal   `json:"totals"`
+}
+
+// TrialBalanceAccount is the balance of an account in a currency, positive on its normal side. The balances of our
+// wallets and of the tokens issued are compared with the ledger when the trial balance is reconciled.
+type TrialBalanceAccount struct {
+	Account        string `json:"account" example:"ASSET:BRAZA-ON:SUPPLY"`
+	Currency       string `json:"currency" example:"BBRL"`
+	Issuer         string `json:"issuer,omitempty" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"`
+	Debits         string `json:"debits" example:"1500"`
+	Credits        string `json:"credits" example:"500"`
+	Balance        string `json:"balance" example:"1000"`
+	OnChainBalance string `json:"on_chain_balance,omitempty" example:"1000"`
+	Difference     string `json:"difference,omitempty" example:"0"` // balance minus the on-chain balance
+	Matches        *bool  `json:"matches,omitempty"`
+}
+
+type TrialBalanceTotal struct {
+	Currency string `json:"currency" example:"BBRL"`
+	Issuer   string `json:"issuer,omitempty" example:"rfWmf1YZLfcaHVZioBBSUuRLHgMMSfBkBd"`
+	Debits   string `json:"debits" example:"1500"`
+	Credits  string `json:"credits" example:"1500"`
+	Balanced bool   `json:"balanced"`
+}
+
+// AccountStatement is the movement of an account in a currency between two dates, with the balance after each line.
+// The closing balance is compared with the ledger when the statement runs up to now.
+type AccountStatement struct {
+	Account        string           `json:"account" example:"ASSET:BRAZA-ON:SUPPLY"`
+	Currency       string           `json:"currency" example:"BBRL"`
+	From           time.Time        `json:"from"`
+	To             time.Time        `json:"to"`
+	OpeningBalance string           `json:"opening_balance" example:"1000"`
+	ClosingBalance string           `json:"closing_balance" example:"900"`
+	OnChainBalance string           `json:"on_chain_balance,omitempty" example:"900"`
+	Difference     string           `json:"difference,omitempty" example:"0"`
+	Matches        *bool            `json:"matches,omitempty"`
+	Lines          []*StatementLine `json:"lines"`
+}
+
+type StatementLine struct {
+	EntryID         string    `json:"entry_id"`
+	Type            string    `json:"type" example:"OFF-RAMP"`
+	Description     string    `json:"description"`
+	TransactionHash string    `json:"transaction_hash,omitempty"`
+	OperationID     string    `json:"operation_id,omitempty"`
+	PostedAt        time.Time `json:"posted_at"`
+	Debit           string    `json:"debit" example:"0"`
+	Credit          string    `json:"credit" example:"100"`
+	Balance         string    `json:"balance" example:"900"`
+}
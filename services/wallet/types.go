@@ -20,12 +20,14 @@ type Blockchain struct {
 
 type Wallet struct {
 	Base
-	Name         string      `json:"name"`
-	Address      string      `json:"address"`
-	Type         string      `json:"type"`
-	BlockchainID string      `json:"blockchain_id"`
-	Domain       string      `json:"domain"`
-	Blockchain   *Blockchain `json:"blockchain"`
+	Name           string      `json:"name"`
+	Address        string      `json:"address"`
+	Type           string      `json:"type"`
+	BlockchainID   string      `json:"blockchain_id"`
+	Domain         string      `json:"domain"`
+	DestinationTag *uint32     `json:"destination_tag,omitempty"`
+	Algorithm      string      `json:"algorithm,omitempty"`
+	Blockchain     *Blockchain `json:"blockchain"`
 }
 
 type TokenBalance struct {
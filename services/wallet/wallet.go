@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"context"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
 	xsc "crypto-braza-tokens-api/clients/xrp-scan"
 	r "crypto-braza-tokens-api/repositories"
 	kvs "crypto-braza-tokens-api/utils/keys-values"
@@ -19,6 +20,7 @@ import (
 type WalletService struct {
 	repo    *r.Repository
 	xscCli  *xsc.XrpScanClient
+	xrpCli  *xrpn.RippleNodeClient
 	network string
 }
 
@@ -28,12 +30,17 @@ func NewWalletService(repo *r.Repository) *WalletService {
 		panic(err)
 	}
 
+	xrpCli, err := xrpn.NewRippleNodeClient()
+	if err != nil {
+		panic(err)
+	}
+
 	network, err := kvs.Get("XRP_NETWORK")
 	if err != nil {
 		panic(err)
 	}
 
-	return &WalletService{repo, xrpScanCli, network}
+	return &WalletService{repo, xrpScanCli, xrpCli, network}
 }
 
 func (ws *WalletService) FindAll(ctx context.Context) ([]*Wallet, error) {
@@ -74,11 +81,14 @@ func (ws *WalletService) FindAll(ctx context.Context) ([]*Wallet, error) {
 				CreatedAt: wallet.CreatedAt,
 				UpdatedAt: wallet.UpdatedAt,
 			},
-			Name:         wallet.Name,
-			Address:      wallet.Address,
-			Type:         wallet.Type,
-			BlockchainID: wallet.Blockchain,
-			Blockchain:   blockchainsMap[wallet.Blockchain],
+			Name:           wallet.Name,
+			Address:        wallet.Address,
+			Type:           wallet.Type,
+			Domain:         wallet.Domain,
+			DestinationTag: wallet.DestinationTag,
+			Algorithm:      wallet.Algorithm,
+			BlockchainID:   wallet.Blockchain,
+			Blockchain:     blockchainsMap[wallet.Blockchain],
 		})
 	}
 
@@ -99,10 +109,13 @@ func (ws *WalletService) FindWalletById(ctx context.Context, walletId string) (*
 			CreatedAt: walletResult.CreatedAt,
 			UpdatedAt: walletResult.UpdatedAt,
 		},
-		Name:         walletResult.Name,
-		Address:      walletResult.Address,
-		Type:         walletResult.Type,
-		BlockchainID: walletResult.Blockchain,
+		Name:           walletResult.Name,
+		Address:        walletResult.Address,
+		Type:           walletResult.Type,
+		Domain:         walletResult.Domain,
+		DestinationTag: walletResult.DestinationTag,
+		Algorithm:      walletResult.Algorithm,
+		BlockchainID:   walletResult.Blockchain,
 	}
 
 	return result, nil
@@ -122,10 +135,13 @@ func (ws *WalletService) FindWalletByAddressAndBlockchain(ctx context.Context, a
 			CreatedAt: walletResult.CreatedAt,
 			UpdatedAt: walletResult.UpdatedAt,
 		},
-		Name:         walletResult.Name,
-		Address:      walletResult.Address,
-		Type:         walletResult.Type,
-		BlockchainID: walletResult.Blockchain,
+		Name:           walletResult.Name,
+		Address:        walletResult.Address,
+		Type:           walletResult.Type,
+		Domain:         walletResult.Domain,
+		DestinationTag: walletResult.DestinationTag,
+		Algorithm:      walletResult.Algorithm,
+		BlockchainID:   walletResult.Blockchain,
 	}
 
 	return result, nil
@@ -145,10 +161,13 @@ func (ws *WalletService) FindWalletByBlockchainWalletTypeAndDomain(ctx context.C
 			CreatedAt: walletResult.CreatedAt,
 			UpdatedAt: walletResult.UpdatedAt,
 		},
-		Name:         walletResult.Name,
-		Address:      walletResult.Address,
-		Type:         walletResult.Type,
-		BlockchainID: walletResult.Blockchain,
+		Name:           walletResult.Name,
+		Address:        walletResult.Address,
+		Type:           walletResult.Type,
+		Domain:         walletResult.Domain,
+		DestinationTag: walletResult.DestinationTag,
+		Algorithm:      walletResult.Algorithm,
+		BlockchainID:   walletResult.Blockchain,
 	}
 
 	return result, nil
@@ -189,11 +208,14 @@ func (ws *WalletService) FindAllByBlockchainId(ctx context.Context, blockchainId
 				CreatedAt: wallet.CreatedAt,
 				UpdatedAt: wallet.UpdatedAt,
 			},
-			Name:         wallet.Name,
-			Address:      wallet.Address,
-			Type:         wallet.Type,
-			BlockchainID: wallet.Blockchain,
-			Blockchain:   blockchain,
+			Name:           wallet.Name,
+			Address:        wallet.Address,
+			Type:           wallet.Type,
+			Domain:         wallet.Domain,
+			DestinationTag: wallet.DestinationTag,
+			Algorithm:      wallet.Algorithm,
+			BlockchainID:   wallet.Blockchain,
+			Blockchain:     blockchain,
 		})
 	}
 
@@ -235,11 +257,14 @@ func (ws *WalletService) FindAllByBlockchainAndDomain(ctx context.Context, block
 				CreatedAt: wallet.CreatedAt,
 				UpdatedAt: wallet.UpdatedAt,
 			},
-			Name:         wallet.Name,
-			Address:      wallet.Address,
-			Type:         wallet.Type,
-			BlockchainID: wallet.Blockchain,
-			Blockchain:   blockchain,
+			Name:           wallet.Name,
+			Address:        wallet.Address,
+			Type:           wallet.Type,
+			Domain:         wallet.Domain,
+			DestinationTag: wallet.DestinationTag,
+			Algorithm:      wallet.Algorithm,
+			BlockchainID:   wallet.Blockchain,
+			Blockchain:     blockchain,
 		})
 	}
 
@@ -272,9 +297,14 @@ func (ws *WalletService) GetTokensBalances(ctx context.Context, address string)
 	result := []*TokenBalance{}
 
 	for _, balance := range balances {
+		contract := balance.Currency
+		if xrpn.IsLPTokenCurrency(balance.Currency) {
+			contract = ws.lpTokenContract(ctx, balance.Currency, balance.Counterparty)
+		}
+
 		result = append(result, &TokenBalance{
 			Address:  address,
-			Contract: balance.Currency,
+			Contract: contract,
 			Amount:   balance.Value,
 		})
 	}
@@ -282,6 +312,31 @@ func (ws *WalletService) GetTokensBalances(ctx context.Context, address string)
 	return result, nil
 }
 
+// lpTokenContract names the LP tokens after the assets of their AMM pool, the issuer of LP tokens being the AMM account
+func (ws *WalletService) lpTokenContract(ctx context.Context, currency, ammAccount string) string {
+	amm, err := ws.xrpCli.GetAmmInfoByAccount(ctx, ammAccount)
+	if err != nil {
+		l.Logger.Error("service: error get amm info of lp token", zap.String("amm_account", ammAccount), zap.Error(err))
+		return currency
+	}
+
+	assets := []string{}
+	for _, amount := range []any{amm.Amount, amm.Amount2} {
+		asset, err := xrpn.ParseXrpAmount(amount)
+		if err != nil {
+			return currency
+		}
+
+		if asset.IsXrp() {
+			assets = append(assets, xrpn.CURRENCY_XRP)
+		} else {
+			assets = append(assets, xrpn.DecodeCurrencyCode(asset.Currency))
+		}
+	}
+
+	return fmt.Sprintf("LP %s", strings.Join(assets, "/"))
+}
+
 func (ws *WalletService) GetTokenObligations(ctx context.Context, issuerAddress string) (*TokenBalance, error) {
 	obligations, err := ws.xscCli.GetTokenObligations(ctx, issuerAddress)
 	if err != nil {
@@ -360,14 +415,21 @@ func (ws *WalletService) GetAllBalances(ctx context.Context) ([]*TokenBalance, e
 	return result, nil
 }
 
-func (ws *WalletService) SaveWallet(ctx context.Context, blockchain, name, adress, walletType, domain string, isActive bool) (primitive.ObjectID, error) {
+func (ws *WalletService) SaveWallet(ctx context.Context, blockchain, name, adress, walletType, domain string, destinationTag *uint32, algorithm string, isActive bool) (primitive.ObjectID, error) {
 	if isValid := ws.repo.BlockchainExists(ctx, blockchain); !isValid {
 		l.Logger.Error("service: blockchain already exists", zap.String("blockhain_id", blockchain))
 		return primitive.ObjectID{}, errors.New("blockchain already exists")
 	}
 
+	// X-addresses are stored as the classic address and its embedded destination tag
+	adress, destinationTag, err := xrpn.NormalizeAddressAndTag(adress, destinationTag, ws.xrpCli.IsTestnet())
+	if err != nil {
+		l.Logger.Error("service: invalid wallet address", zap.Error(err))
+		return primitive.ObjectID{}, err
+	}
+
 	wallet := &r.Wallet{
-		Blockchain: blockchain, Name: name, Address: adress, Type: walletType, Domain: domain, IsActive: isActive, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		Blockchain: blockchain, Name: name, Address: adress, Type: walletType, Domain: domain, DestinationTag: destinationTag, Algorithm: algorithm, IsActive: isActive, CreatedAt: time.Now(), UpdatedAt: time.Now(),
 	}
 
 	if isValid := ws.repo.WalletExistsSave(ctx, name, blockchain, adress, walletType, domain, isActive); isValid {
@@ -394,9 +456,19 @@ func (ws *WalletService) DeleteWallet(ctx context.Context, walletId string) (str
 	return walletId, nil
 }
 
-func (ws *WalletService) EditWallet(ctx context.Context, blockchain, name, adress, walletType, domain string, isActive bool) (primitive.ObjectID, error) {
+func (ws *WalletService) EditWallet(ctx context.Context, blockchain, name, adress, walletType, domain string, destinationTag *uint32, algorithm string, isActive bool) (primitive.ObjectID, error) {
+	// X-addresses are stored as the classic address and its embedded destination tag
+	if adress != "" {
+		var err error
+		adress, destinationTag, err = xrpn.NormalizeAddressAndTag(adress, destinationTag, ws.xrpCli.IsTestnet())
+		if err != nil {
+			l.Logger.Error("service: invalid wallet address", zap.Error(err))
+			return primitive.ObjectID{}, err
+		}
+	}
+
 	wallet := &r.Wallet{
-		Blockchain: blockchain, Name: name, Address: adress, Type: walletType, Domain: domain, IsActive: isActive, UpdatedAt: time.Now(),
+		Blockchain: blockchain, Name: name, Address: adress, Type: walletType, Domain: domain, DestinationTag: destinationTag, Algorithm: algorithm, IsActive: isActive, UpdatedAt: time.Now(),
 	}
 
 	id, err := ws.repo.EditWallet(ctx, wallet)
@@ -0,0 +1,116 @@
+package xrpl
+
+import (
+	"context"
+	"fmt"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+const (
+	// operation log events that carry the blobs of an operation transaction
+	EVENT_ENCODE_RAW_TRANSACTION    = "Encode XRP Raw Transaction"
+	EVENT_SUBMIT_SIGNED_TRANSACTION = "Submit XRP Signed Transaction"
+)
+
+type XrplService struct {
+	repo      *r.Repository
+	xrpClient *xrpn.RippleNodeClient
+}
+
+func NewXrplService(repo *r.Repository) *XrplService {
+	xrpCli, err := xrpn.NewRippleNodeClient()
+	if err != nil {
+		l.Logger.Fatal("xrpl service: failed to create a new xrp node client", zap.Error(err))
+	}
+
+	return &XrplService{repo, xrpCli}
+}
+
+// DecodeTransactionBlob decodes a transaction blob into JSON with its signing hash and transaction ID
+func (x *XrplService) DecodeTransactionBlob(txBlob string) (*xrpn.XrpDecodedTransaction, error) {
+	result, err := xrpn.DecodeTransactionBlob(txBlob)
+	if err != nil {
+		l.Logger.Error("xrpl service: failed to decode tx blob", zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DecodeOperationTransaction decodes the transaction of an operation, using the signed blob submitted to the
+// ripple node when available, and the unsigned blob sent to be signed on fireblocks otherwise
+func (x *XrplService) DecodeOperationTransaction(ctx context.Context, operationId string) (*xrpn.XrpDecodedTransaction, error) {
+	logs, err := x.repo.FindOperationLogsByOperationId(ctx, operationId)
+	if err != nil {
+		l.Logger.Error("xrpl service: failed to find operation logs", zap.Error(err))
+		return nil, err
+	}
+
+	signedTxBlob, unsignedTxBlob := findOperationTxBlobs(logs)
+
+	txBlob := signedTxBlob
+	if txBlob == "" {
+		txBlob = unsignedTxBlob
+	}
+
+	if txBlob == "" {
+		return nil, fmt.Errorf("no tx blob found for operation %s", operationId)
+	}
+
+	return x.DecodeTransactionBlob(txBlob)
+}
+
+// findOperationTxBlobs returns the latest signed and unsigned tx blobs found on the operation logs
+func findOperationTxBlobs(logs []*r.OperationLog) (string, string) {
+	signedTxBlob, unsignedTxBlob := "", ""
+
+	// logs are sorted by creation date in descending order, so the first match is the latest one
+	for _, log := range logs {
+		switch log.Event {
+		case EVENT_SUBMIT_SIGNED_TRANSACTION:
+			if signedTxBlob == "" {
+				signedTxBlob = extractSubmittedTxBlob(log.Payload)
+			}
+		case EVENT_ENCODE_RAW_TRANSACTION:
+			if blob, ok := log.Response.(string); ok && unsignedTxBlob == "" {
+				unsignedTxBlob = blob
+			}
+		}
+	}
+
+	return signedTxBlob, unsignedTxBlob
+}
+
+// extractSubmittedTxBlob reads the tx_blob param of the submit request stored on the operation log payload
+func extractSubmittedTxBlob(payload any) string {
+	raw, err := bson.Marshal(bson.M{"payload": payload})
+	if err != nil {
+		return ""
+	}
+
+	var doc struct {
+		Payload struct {
+			Params []struct {
+				TxBlob string `bson:"tx_blob"`
+			} `bson:"params"`
+		} `bson:"payload"`
+	}
+
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+
+	for _, param := range doc.Payload.Params {
+		if param.TxBlob != "" {
+			return param.TxBlob
+		}
+	}
+
+	return ""
+}
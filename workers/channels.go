@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"context"
+	fb "crypto-braza-tokens-api/clients/fireblocks"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	"crypto-braza-tokens-api/clients/ripple/utils/signature"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// the status of the off-ledger claims of the payment channels, signed on fireblocks
+const (
+	CLAIM_STATUS_PENDING = "PENDING"
+	CLAIM_STATUS_SIGNED  = "SIGNED"
+	CLAIM_STATUS_FAILED  = "FAILED"
+)
+
+// SignClaim waits for fireblocks to sign the claim of the operation and stores its signature, the claim never being
+// submitted to the ledger by the service
+func (o *OperationsWorker) SignClaim(operationId string, claim *r.ChannelClaim, callback func()) {
+	o.wg.Add(1)
+
+	go func() {
+		defer func() {
+			o.wg.Done()
+		}()
+
+		ctx := context.Background()
+		signedTx, err := o.waitForSignature(ctx, operationId)
+		if err != nil {
+			return
+		}
+
+		o.processClaim(ctx, operationId, signedTx, claim, callback)
+	}()
+}
+
+func (o *OperationsWorker) processClaim(ctx context.Context, operationId string, signedTx *fb.TransactionByIdResponse, claim *r.ChannelClaim, callback func()) {
+	defer callback()
+
+	verification, err := verifyClaimSignature(signedTx, claim)
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Verify Fireblocks Claim Signature",
+		Description:  fmt.Sprintf("Verify Fireblocks %s signature of the claim of %s XRP of channel %s against the channel public key", verification.Algorithm, claim.Amount, claim.ChannelID),
+		OperationID:  operationId,
+		FireblocksID: signedTx.ID,
+		Payload:      map[string]any{"content": verification.Content, "public_key": claim.PublicKey},
+		Response:     verification,
+		Error:        errorMessage(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		l.Logger.Error("operation worker: failed to save operation log", zap.Error(errLog))
+		return
+	}
+
+	status, claimStatus := "COMPLETED", CLAIM_STATUS_SIGNED
+	if err != nil {
+		l.Logger.Error("operation worker: failed to verify fireblocks claim signature", zap.Error(err))
+		status, claimStatus = "FAILED", CLAIM_STATUS_FAILED
+	}
+
+	if err := o.repo.UpdateChannelClaimSignature(ctx, operationId, claimStatus, strings.ToUpper(verification.TxnSignature)); err != nil {
+		l.Logger.Error("operation worker: failed to update channel claim", zap.Error(err))
+		return
+	}
+
+	// the claim is off-ledger, so the operation completes without a transaction hash
+	if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, status, "", ""); err != nil {
+		l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+		return
+	}
+
+	l.Logger.Info(fmt.Sprintf("operation worker: claim of operation %s %s", operationId, strings.ToLower(claimStatus)))
+}
+
+// verifyClaimSignature rebuilds the claim content that had to be signed and verifies the fireblocks signature of it
+func verifyClaimSignature(signedTx *fb.TransactionByIdResponse, claim *r.ChannelClaim) (*SignatureVerification, error) {
+	algorithm, err := signature.DetectAlgorithm(claim.PublicKey)
+	if err != nil {
+		return &SignatureVerification{}, err
+	}
+
+	content, err := xrpn.ClaimSigningContent(claim.ChannelID, claim.Drops, claim.PublicKey)
+	if err != nil {
+		return &SignatureVerification{Algorithm: algorithm}, err
+	}
+
+	return verifySignedContent(signedTx, claim.PublicKey, algorithm, content)
+}
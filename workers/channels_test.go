@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	fb "crypto-braza-tokens-api/clients/fireblocks"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyClaimSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	claim := &r.ChannelClaim{
+		ChannelID: "E35708503B3C3143FB522D749AAFCC296E8060F0FB371A9A56FAE0B1ED127366",
+		Amount:    "12.5",
+		Drops:     "12500000",
+		PublicKey: "ED" + strings.ToUpper(hex.EncodeToString(publicKey)),
+	}
+
+	content, err := xrpn.ClaimSigningContent(claim.ChannelID, claim.Drops, claim.PublicKey)
+	require.NoError(t, err)
+
+	message, _ := hex.DecodeString(content)
+	fullSig := hex.EncodeToString(ed25519.Sign(privateKey, message))
+
+	buildSignedTx := func(signedContent, signature string) *fb.TransactionByIdResponse {
+		return &fb.TransactionByIdResponse{
+			ID:             "fb-claim",
+			SignedMessages: []*fb.TxByIdSignedMessages{{Content: signedContent, Signature: &fb.TxByIdSignature{FullSig: signature}}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		signedTx *fb.TransactionByIdResponse
+		wantErr  string
+	}{
+		{name: "claim signed by the channel key", signedTx: buildSignedTx(content, fullSig)},
+		{name: "another content signed", signedTx: buildSignedTx(strings.Replace(content, "434C4D00", "53545800", 1), fullSig), wantErr: "signed content does not match"},
+		{name: "signature of another claim", signedTx: buildSignedTx(content, strings.Repeat("0", 128)), wantErr: "signature"},
+		{name: "no signed messages", signedTx: &fb.TransactionByIdResponse{ID: "fb-claim"}, wantErr: "without signed messages"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			verification, err := verifyClaimSignature(tc.signedTx, claim)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, strings.ToUpper(fullSig), verification.TxnSignature)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"context"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// a check is PENDING until its CheckCreate is validated, then OPEN until it is CASHED, or CANCELLED by its sender,
+// its receiver or anyone once EXPIRED
+const (
+	CHECK_STATUS_PENDING   = "PENDING"
+	CHECK_STATUS_OPEN      = "OPEN"
+	CHECK_STATUS_CASHED    = "CASHED"
+	CHECK_STATUS_EXPIRED   = "EXPIRED"
+	CHECK_STATUS_CANCELLED = "CANCELLED"
+	CHECK_STATUS_FAILED    = "FAILED"
+)
+
+// trackCheck updates the checks sent by the service from the check transactions of the wallets. The checks are
+// cashed and may be cancelled by their receivers, the transactions being found on the sender account_tx.
+func (i *LedgerIndexer) trackCheck(ctx context.Context, tx *xrpn.XrpTransaction, transaction *r.Transaction) error {
+	switch transaction.Type {
+	case "CheckCreate":
+		if transaction.OperationId == "" {
+			return nil
+		}
+
+		check, err := i.repo.FindCheckByOperationId(ctx, transaction.OperationId)
+		if err != nil || check == nil || check.Status != CHECK_STATUS_PENDING {
+			return err
+		}
+
+		status := CHECK_STATUS_OPEN
+		if transaction.Status != "tesSUCCESS" {
+			status = CHECK_STATUS_FAILED
+		}
+
+		return i.repo.UpdateCheckStatus(ctx, check.ID, status)
+
+	case "CheckCash", "CheckCancel":
+		if transaction.Status != "tesSUCCESS" {
+			return nil
+		}
+
+		checkId, _ := tx.Transaction["CheckID"].(string)
+		check, err := i.repo.FindCheckByCheckId(ctx, checkId)
+		if err != nil || check == nil {
+			return err
+		}
+
+		// the last indexed ledger is read again, so the check may be closed already
+		if check.Status == CHECK_STATUS_CASHED || check.Status == CHECK_STATUS_CANCELLED {
+			return nil
+		}
+
+		if transaction.Type == "CheckCash" {
+			l.Logger.Info("ledger indexer: check cashed", zap.String("check_id", check.CheckID), zap.String("hash", transaction.TransactionHash))
+			return i.repo.CloseCheck(ctx, check.ID, CHECK_STATUS_CASHED, cashedAmount(check, transaction), transaction.TransactionHash)
+		}
+
+		l.Logger.Info("ledger indexer: check cancelled", zap.String("check_id", check.CheckID), zap.String("hash", transaction.TransactionHash))
+		return i.repo.CloseCheck(ctx, check.ID, CHECK_STATUS_CANCELLED, "", transaction.TransactionHash)
+	}
+
+	return nil
+}
+
+// expireChecks sets the EXPIRED status on the open checks whose expiration is past, so they can be cancelled
+func (i *LedgerIndexer) expireChecks(ctx context.Context) {
+	expired, err := i.repo.ExpireChecks(ctx, CHECK_STATUS_OPEN, CHECK_STATUS_EXPIRED, time.Now())
+	if err != nil {
+		l.Logger.Error("ledger indexer: failed to expire checks", zap.Error(err))
+		return
+	}
+
+	if expired > 0 {
+		l.Logger.Info("ledger indexer: checks expired", zap.Int64("count", expired))
+	}
+}
+
+// cashedAmount returns the amount debited from the check sender by the CheckCash, in the currency of the check
+func cashedAmount(check *r.Check, transaction *r.Transaction) string {
+	total := decimal.Zero
+	for _, change := range transaction.BalanceChanges {
+		if change.Account != check.Account || change.Currency != check.SendMax.Currency {
+			continue
+		}
+
+		value, err := decimal.NewFromString(change.Value)
+		if err != nil {
+			continue
+		}
+		total = total.Sub(value)
+	}
+
+	return total.String()
+}
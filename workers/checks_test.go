@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"testing"
+
+	r "crypto-braza-tokens-api/repositories"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCashedAmount(t *testing.T) {
+	check := &r.Check{Account: indexerIssuer, SendMax: &r.OfferAmount{Currency: "BRZA", Issuer: indexerIssuer, Value: "10"}}
+
+	tests := []struct {
+		name     string
+		changes  []*r.BalanceChange
+		expected string
+	}{
+		{
+			name: "cashed for less than the send max",
+			changes: []*r.BalanceChange{
+				{Account: indexerIssuer, Currency: "BRZA", Issuer: indexerHolder, Value: "-7.5"},
+				{Account: indexerHolder, Currency: "BRZA", Issuer: indexerIssuer, Value: "7.5"},
+			},
+			expected: "7.5",
+		},
+		{
+			name: "fee and other currencies are ignored",
+			changes: []*r.BalanceChange{
+				{Account: indexerIssuer, Currency: "XRP", Value: "-0.000012"},
+				{Account: indexerIssuer, Currency: "BRZA", Issuer: indexerHolder, Value: "-10"},
+			},
+			expected: "10",
+		},
+		{name: "no balance changes", expected: "0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, cashedAmount(check, &r.Transaction{BalanceChanges: tc.changes}))
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package worker
+
+import (
+	"context"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// interval between the snapshots of the holders of the issued tokens
+	HOLDER_REGISTRY_INTERVAL = 1 * time.Hour
+
+	// amount of largest holders whose share of the supply is kept on the snapshot
+	HOLDER_TOP_SHARE_SIZE = 10
+)
+
+type HolderRegistry struct {
+	XrpCli *xrpn.RippleNodeClient
+	repo   *r.Repository
+}
+
+func NewHolderRegistry(xrpClient *xrpn.RippleNodeClient, repository *r.Repository) (*HolderRegistry, error) {
+	return &HolderRegistry{
+		XrpCli: xrpClient,
+		repo:   repository,
+	}, nil
+}
+
+// Start snapshots the holders of the tokens right away and then on every interval, until the context is done
+func (h *HolderRegistry) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(HOLDER_REGISTRY_INTERVAL)
+		defer ticker.Stop()
+
+		for {
+			if err := h.SnapshotHolders(ctx); err != nil {
+				l.Logger.Error("holder registry: failed to snapshot holders", zap.Error(err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// SnapshotHolders snapshots the holders of every active issued token of the XRP blockchain. A token that fails is
+// snapshotted again on the next run without stopping the others.
+func (h *HolderRegistry) SnapshotHolders(ctx context.Context) error {
+	blockchain, err := h.repo.FindBlockchainByAbbr(ctx, "XRP")
+	if err != nil {
+		l.Logger.Error("holder registry: failed to find blockchain", zap.Error(err))
+		return err
+	}
+
+	tokens, err := h.repo.FindTokensByBlockchainAndMintables(ctx, blockchain.ID.Hex())
+	if err != nil {
+		l.Logger.Error("holder registry: failed to find tokens", zap.Error(err))
+		return err
+	}
+
+	for _, token := range tokens {
+		if token.Address == "" {
+			continue
+		}
+
+		if err := h.snapshotToken(ctx, token); err != nil {
+			l.Logger.Error("holder registry: failed to snapshot token holders", zap.String("token", token.Abbr), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// snapshotToken reads every trust line of the token issuer on the last validated ledger and saves the balances of
+// the holders before the snapshot, so a snapshot is only listed once all of its balances were saved
+func (h *HolderRegistry) snapshotToken(ctx context.Context, token *r.Token) error {
+	lines, ledgerIndex, err := h.XrpCli.GetAllAccountLines(ctx, token.Address)
+	if err != nil {
+		return err
+	}
+
+	snapshot, balances := buildHolderSnapshot(token, lines, ledgerIndex, time.Now())
+
+	if err := h.repo.SaveHolderBalances(ctx, balances); err != nil {
+		return err
+	}
+
+	if _, err := h.repo.SaveHolderSnapshot(ctx, snapshot); err != nil {
+		return err
+	}
+
+	l.Logger.Info("holder registry: token holders snapshotted", zap.String("token", token.Abbr), zap.Int("ledger_index", ledgerIndex), zap.Int("holders", snapshot.HoldersCount))
+
+	return nil
+}
+
+// buildHolderSnapshot builds the snapshot of the trust lines of the token issuer. The issuer sees the tokens it issued
+// as a negative balance, so the balance of each holder is the opposite of the balance of the line.
+func buildHolderSnapshot(token *r.Token, lines []xrpn.Line, ledgerIndex int, now time.Time) (*r.HolderSnapshot, []*r.HolderBalance) {
+	snapshot := &r.HolderSnapshot{
+		ID:          primitive.NewObjectID(),
+		TokenID:     token.ID.Hex(),
+		Abbr:        token.Abbr,
+		Issuer:      token.Address,
+		Currency:    token.Contract,
+		LedgerIndex: ledgerIndex,
+		CreatedAt:   now,
+	}
+
+	balances := []*r.HolderBalance{}
+	held := []decimal.Decimal{}
+	for _, line := range lines {
+		if !strings.EqualFold(line.Currency, token.Contract) {
+			continue
+		}
+
+		balance, err := decimal.NewFromString(line.Balance)
+		if err != nil {
+			l.Logger.Warn("holder registry: skipping trust line with invalid balance", zap.String("account", line.Account), zap.String("balance", line.Balance))
+			continue
+		}
+		balance = balance.Neg()
+
+		balances = append(balances, &r.HolderBalance{
+			SnapshotID: snapshot.ID.Hex(),
+			TokenID:    snapshot.TokenID,
+			Account:    line.Account,
+			Balance:    balance.String(),
+			Limit:      line.LimitPeer,
+			NoRipple:   line.NoRipplePeer,
+			Frozen:     line.Freeze,
+			Authorized: line.Authorized,
+			CreatedAt:  now,
+		})
+
+		if balance.IsPositive() {
+			held = append(held, balance)
+		}
+	}
+
+	total := decimal.Zero
+	for _, balance := range held {
+		total = total.Add(balance)
+	}
+
+	snapshot.TrustLinesCount = len(balances)
+	snapshot.HoldersCount = len(held)
+	snapshot.TotalBalance = total.String()
+	snapshot.Top10Share, snapshot.HHI, snapshot.Gini = concentration(held, total)
+
+	return snapshot, balances
+}
+
+// concentration returns the share of the supply held by the largest holders, the Herfindahl-Hirschman index (sum of
+// the squared shares, from 1/n when evenly held to 1 when a single holder) and the Gini coefficient of the balances
+func concentration(balances []decimal.Decimal, total decimal.Decimal) (float64, float64, float64) {
+	if len(balances) == 0 || !total.IsPositive() {
+		return 0, 0, 0
+	}
+
+	sorted := make([]decimal.Decimal, len(balances))
+	copy(sorted, balances)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+
+	n := decimal.NewFromInt(int64(len(sorted)))
+	top, hhi, weighted := decimal.Zero, decimal.Zero, decimal.Zero
+	for i, balance := range sorted {
+		share := balance.Div(total)
+		hhi = hhi.Add(share.Mul(share))
+
+		if i >= len(sorted)-HOLDER_TOP_SHARE_SIZE {
+			top = top.Add(share)
+		}
+
+		weighted = weighted.Add(decimal.NewFromInt(int64(i + 1)).Mul(balance))
+	}
+
+	// G = 2 * sum(i * x_i) / (n * sum(x)) - (n + 1) / n, with the balances in ascending order
+	gini := weighted.Mul(decimal.NewFromInt(2)).Div(n.Mul(total)).Sub(n.Add(decimal.NewFromInt(1)).Div(n))
+
+	return roundShare(top), roundShare(hhi), roundShare(gini)
+}
+
+func roundShare(value decimal.Decimal) float64 {
+	return value.Round(6).InexactFloat64()
+}
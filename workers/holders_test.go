@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildHolderSnapshot(t *testing.T) {
+	token := &r.Token{ID: primitive.NewObjectID(), Abbr: "BBRL", Contract: "4242524C00000000000000000000000000000000", Address: indexerIssuer}
+	lines := []xrpn.Line{
+		{Account: indexerHolder, Balance: "-75", Currency: "4242524C00000000000000000000000000000000", LimitPeer: "1000", NoRipplePeer: true},
+		{Account: "rPEPPER7kfTD9w2To4CQk6UCfuHM9c6GDY", Balance: "-25", Currency: "4242524c00000000000000000000000000000000", LimitPeer: "500", Freeze: true},
+		{Account: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", Balance: "0", Currency: "4242524C00000000000000000000000000000000", LimitPeer: "100"},
+		{Account: indexerHolder, Balance: "-10", Currency: "5553444200000000000000000000000000000000", LimitPeer: "100"},
+	}
+
+	snapshot, balances := buildHolderSnapshot(token, lines, 90000123, time.Now())
+
+	require.Equal(t, token.ID.Hex(), snapshot.TokenID)
+	require.Equal(t, 90000123, snapshot.LedgerIndex)
+	require.Equal(t, 3, snapshot.TrustLinesCount)
+	require.Equal(t, 2, snapshot.HoldersCount)
+	require.Equal(t, "100", snapshot.TotalBalance)
+	require.Equal(t, 1.0, snapshot.Top10Share)
+	require.Equal(t, 0.625, snapshot.HHI)
+	require.Equal(t, 0.25, snapshot.Gini)
+
+	require.Len(t, balances, 3)
+	require.Equal(t, snapshot.ID.Hex(), balances[0].SnapshotID)
+	require.Equal(t, "75", balances[0].Balance)
+	require.Equal(t, "1000", balances[0].Limit)
+	require.True(t, balances[0].NoRipple)
+	require.True(t, balances[1].Frozen)
+	require.Equal(t, "0", balances[2].Balance)
+}
+
+func TestConcentration(t *testing.T) {
+	amounts := func(values ...int64) []decimal.Decimal {
+		result := []decimal.Decimal{}
+		for _, value := range values {
+			result = append(result, decimal.NewFromInt(value))
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		balances []decimal.Decimal
+		top      float64
+		hhi      float64
+		gini     float64
+	}{
+		{name: "no holders"},
+		{name: "single holder", balances: amounts(50), top: 1, hhi: 1, gini: 0},
+		{name: "evenly held", balances: amounts(10, 10, 10, 10), top: 1, hhi: 0.25, gini: 0},
+		{name: "top holders of many", balances: amounts(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 90), top: 0.99, hhi: 0.811, gini: 0.809091},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			total := decimal.Zero
+			for _, balance := range tc.balances {
+				total = total.Add(balance)
+			}
+
+			top, hhi, gini := concentration(tc.balances, total)
+			require.Equal(t, tc.top, top)
+			require.Equal(t, tc.hhi, hhi)
+			require.Equal(t, tc.gini, gini)
+		})
+	}
+}
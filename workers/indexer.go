@@ -0,0 +1,380 @@
+package worker
+
+import (
+	"context"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// interval between the indexing runs and amount of transactions read on each account_tx page
+	INDEXER_INTERVAL  = 30 * time.Second
+	INDEXER_PAGE_SIZE = 200
+
+	// memo set on the payments submitted by this service with the id of the originating operation
+	OPERATION_ID_MEMO = "operation_id"
+
+	// operations recorded from on-chain activity that was not initiated by this service
+	OPERATION_TYPE_BURN       = "BURN"
+	OPERATION_ORIGIN_EXTERNAL = "EXTERNAL"
+)
+
+type LedgerIndexer struct {
+	XrpCli *xrpn.RippleNodeClient
+	stream *xrpn.RippleStreamClient
+	repo   *r.Repository
+
+	// addresses whose transactions are subscribed on the stream
+	watched map[string]bool
+}
+
+// NewLedgerIndexer builds the indexer of the wallets transactions. The stream client is optional, without it the
+// wallets are only indexed on every interval.
+func NewLedgerIndexer(xrpClient *xrpn.RippleNodeClient, stream *xrpn.RippleStreamClient, repository *r.Repository) (*LedgerIndexer, error) {
+	return &LedgerIndexer{
+		XrpCli:  xrpClient,
+		stream:  stream,
+		repo:    repository,
+		watched: map[string]bool{},
+	}, nil
+}
+
+// Start indexes the transactions of the wallets right away and then on every interval, until the context is done.
+// When the stream is connected, the wallets are also indexed as soon as one of their transactions is validated, the
+// interval filling the gaps of the stream while it reconnects.
+func (i *LedgerIndexer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(INDEXER_INTERVAL)
+		defer ticker.Stop()
+
+		transactions := i.connectStream(ctx)
+
+		for {
+			if err := i.IndexWallets(ctx); err != nil {
+				l.Logger.Error("ledger indexer: failed to index wallets", zap.Error(err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case _, ok := <-transactions:
+				if !ok {
+					transactions = nil
+				}
+				// the run reads every transaction up to the last validated ledger, the ones already notified included
+				drainTransactions(transactions)
+			}
+		}
+	}()
+}
+
+// connectStream connects the stream client and returns the channel of the validated transactions of the watched
+// wallets, nil when there is no stream client or it failed to connect.
+func (i *LedgerIndexer) connectStream(ctx context.Context) <-chan *xrpn.XrpTransaction {
+	if i.stream == nil {
+		return nil
+	}
+
+	if err := i.stream.Connect(ctx); err != nil {
+		l.Logger.Error("ledger indexer: failed to connect the stream, indexing on every interval only", zap.Error(err))
+		i.stream = nil
+		return nil
+	}
+
+	go func() {
+		<-ctx.Done()
+		i.stream.Close()
+	}()
+
+	return i.stream.Transactions()
+}
+
+// watchWallets subscribes to the transactions of the active wallets that are not watched yet
+func (i *LedgerIndexer) watchWallets(ctx context.Context, wallets []*r.Wallet) {
+	if i.stream == nil {
+		return
+	}
+
+	accounts := unwatchedAccounts(wallets, i.watched)
+	if len(accounts) == 0 {
+		return
+	}
+
+	if err := i.stream.SubscribeAccounts(ctx, accounts...); err != nil {
+		l.Logger.Error("ledger indexer: failed to subscribe to the wallets transactions", zap.Strings("addresses", accounts), zap.Error(err))
+		return
+	}
+
+	for _, account := range accounts {
+		i.watched[account] = true
+	}
+}
+
+// unwatchedAccounts returns the addresses of the active wallets that are not watched
+func unwatchedAccounts(wallets []*r.Wallet, watched map[string]bool) []string {
+	accounts := []string{}
+	for _, wallet := range wallets {
+		if wallet.IsActive && !watched[wallet.Address] {
+			accounts = append(accounts, wallet.Address)
+		}
+	}
+	return accounts
+}
+
+// drainTransactions discards the transactions already notified by the stream without waiting for more
+func drainTransactions(transactions <-chan *xrpn.XrpTransaction) {
+	for {
+		select {
+		case _, ok := <-transactions:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// IndexWallets reads the new transactions of every active XRP wallet. A wallet that fails is retried
+// from its checkpoint on the next run without stopping the others.
+func (i *LedgerIndexer) IndexWallets(ctx context.Context) error {
+	blockchain, err := i.repo.FindBlockchainByAbbr(ctx, "XRP")
+	if err != nil {
+		l.Logger.Error("ledger indexer: failed to find blockchain", zap.Error(err))
+		return err
+	}
+
+	wallets, err := i.repo.FindWalletsByBlockchainId(ctx, blockchain.ID.Hex())
+	if err != nil {
+		l.Logger.Error("ledger indexer: failed to find wallets", zap.Error(err))
+		return err
+	}
+
+	managed := map[string]*r.Wallet{}
+	for _, wallet := range wallets {
+		managed[wallet.Address] = wallet
+	}
+
+	i.watchWallets(ctx, wallets)
+
+	for _, wallet := range wallets {
+		if !wallet.IsActive {
+			continue
+		}
+
+		if err := i.indexWallet(ctx, wallet, managed); err != nil {
+			l.Logger.Error("ledger indexer: failed to index wallet", zap.String("address", wallet.Address), zap.Error(err))
+		}
+	}
+
+	// the checks cashed or cancelled before their expiration were closed by the transactions just indexed
+	i.expireChecks(ctx)
+
+	return nil
+}
+
+// indexWallet pages through account_tx from the wallet checkpoint up to the last validated ledger. The checkpoint
+// is saved after each page, keeping the marker while the range is being read. The last indexed ledger is read again
+// on the next run so the range is never empty, the transactions being upserted by hash.
+func (i *LedgerIndexer) indexWallet(ctx context.Context, wallet *r.Wallet, managed map[string]*r.Wallet) error {
+	checkpoint, err := i.repo.FindWalletCheckpoint(ctx, wallet.ID.Hex())
+	if err != nil {
+		return err
+	}
+
+	// a wallet whose address was edited is indexed from the beginning
+	if checkpoint == nil || checkpoint.Address != wallet.Address {
+		checkpoint = &r.WalletCheckpoint{
+			WalletID:   wallet.ID.Hex(),
+			Address:    wallet.Address,
+			Blockchain: wallet.Blockchain,
+		}
+	}
+
+	for {
+		ledgerIndexMin := -1
+		if checkpoint.LedgerIndex > 0 {
+			ledgerIndexMin = checkpoint.LedgerIndex
+		}
+
+		var marker *xrpn.XrpAccountTxMarker
+		if checkpoint.Marker != nil {
+			marker = &xrpn.XrpAccountTxMarker{Ledger: checkpoint.Marker.Ledger, Seq: checkpoint.Marker.Seq}
+		}
+
+		page, err := i.XrpCli.GetAccountTransactions(ctx, wallet.Address, ledgerIndexMin, marker, INDEXER_PAGE_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range page.Transactions {
+			if !tx.Validated {
+				continue
+			}
+
+			if err := i.indexTransaction(ctx, wallet, tx, managed); err != nil {
+				return err
+			}
+		}
+
+		if page.Marker != nil {
+			checkpoint.Marker = &r.LedgerMarker{Ledger: page.Marker.Ledger, Seq: page.Marker.Seq}
+		} else {
+			checkpoint.Marker = nil
+			if page.LedgerIndexMax > checkpoint.LedgerIndex {
+				checkpoint.LedgerIndex = page.LedgerIndexMax
+			}
+		}
+
+		if err := i.repo.SaveWalletCheckpoint(ctx, checkpoint); err != nil {
+			return err
+		}
+
+		if checkpoint.Marker == nil {
+			return nil
+		}
+	}
+}
+
+// indexTransaction stores the normalised transaction, linking it to the operation that submitted it, and posts its
+// journal entry. Transactions without an operation, such as customer redemptions or manual transfers, are flagged as
+// external.
+func (i *LedgerIndexer) indexTransaction(ctx context.Context, wallet *r.Wallet, tx *xrpn.XrpTransaction, managed map[string]*r.Wallet) error {
+	transaction := buildIndexedTransaction(tx)
+	transaction.Blockchain = wallet.Blockchain
+	transaction.Domain = wallet.Domain
+	transaction.TransactionLink = i.XrpCli.GetTransactionLink(tx.Hash)
+
+	operation, err := i.findOperation(ctx, transaction, managed)
+	if err != nil {
+		return err
+	}
+
+	if operation == nil && isRedemption(wallet, transaction) {
+		operation, err = i.recordRedemption(ctx, wallet, transaction, managed)
+		if err != nil {
+			return err
+		}
+	}
+
+	transaction.IsExternal = operation == nil || operation.Origin == OPERATION_ORIGIN_EXTERNAL
+	if operation != nil {
+		transaction.OperationId = operation.ID.Hex()
+		transaction.FireblocksId = operation.FireblocksId
+		transaction.Operator = operation.Operator
+		transaction.Domain = operation.Domain
+	}
+
+	if err := i.trackCheck(ctx, tx, transaction); err != nil {
+		return err
+	}
+
+	if err := i.repo.SaveIndexedTransaction(ctx, transaction, wallet.ID.Hex()); err != nil {
+		return err
+	}
+
+	return i.postJournalEntry(ctx, transaction, operation, managed)
+}
+
+// findOperation matches the transaction with an operation by its hash or, while the operation hash is not
+// updated yet, by the operation id memo of the transactions sent from our wallets
+func (i *LedgerIndexer) findOperation(ctx context.Context, transaction *r.Transaction, managed map[string]*r.Wallet) (*r.Operation, error) {
+	operation, err := i.repo.FindOperationByTransactionHash(ctx, transaction.TransactionHash)
+	if err != nil || operation != nil {
+		return operation, err
+	}
+
+	operationId := operationIdFromMemos(transaction, managed)
+	if operationId == "" {
+		return nil, nil
+	}
+
+	operation, err = i.repo.FindOperationById(ctx, operationId)
+	if err != nil {
+		// a memo with an unknown operation does not link the transaction to any operation
+		l.Logger.Warn("ledger indexer: operation of transaction memo not found", zap.String("hash", transaction.TransactionHash), zap.String("operation_id", operationId))
+		return nil, nil
+	}
+
+	if operation.TransactionHash != "" && operation.TransactionHash != transaction.TransactionHash {
+		return nil, nil
+	}
+
+	return operation, nil
+}
+
+// operationIdFromMemos returns the operation id memo of a transaction, only trusted when sent from one of our wallets
+func operationIdFromMemos(transaction *r.Transaction, managed map[string]*r.Wallet) string {
+	if managed[transaction.Account] == nil {
+		return ""
+	}
+	return transaction.Memos[OPERATION_ID_MEMO]
+}
+
+// buildIndexedTransaction normalises a decoded transaction. The amount of payments is the delivered amount,
+// amounts and fees in XRP are given in XRP instead of drops.
+func buildIndexedTransaction(tx *xrpn.XrpTransaction) *r.Transaction {
+	transaction := &r.Transaction{
+		TransactionHash: tx.Hash,
+		LedgerIndex:     tx.LedgerIndex,
+		BalanceChanges:  toBalanceChanges(tx.BalanceChanges),
+		Memos:           binarycodec.DecodeMemos(tx.Transaction["Memos"]),
+	}
+
+	transaction.Type, _ = tx.Transaction["TransactionType"].(string)
+	transaction.Account, _ = tx.Transaction["Account"].(string)
+	transaction.Destination, _ = tx.Transaction["Destination"].(string)
+
+	if tag, ok := tx.Transaction["DestinationTag"].(int); ok {
+		destinationTag := uint32(tag)
+		transaction.DestinationTag = &destinationTag
+	}
+
+	if fee, ok := tx.Transaction["Fee"].(string); ok {
+		transaction.Fee, _ = xrpn.ConvertDropsToXrp(fee)
+	}
+
+	if tx.Date > 0 {
+		transaction.LedgerDate = xrpn.ConvertRippleTime(tx.Date)
+	}
+
+	if tx.Metadata != nil {
+		transaction.Status = tx.Metadata.TransactionResult
+	}
+
+	switch amount := tx.Transaction["Amount"].(type) {
+	case string:
+		transaction.Currency = xrpn.CURRENCY_XRP
+		transaction.Amount, _ = xrpn.ConvertDropsToXrp(amount)
+	case map[string]any:
+		currency, _ := amount["currency"].(string)
+		transaction.Currency = xrpn.DecodeCurrencyCode(currency)
+		transaction.Issuer, _ = amount["issuer"].(string)
+		transaction.Amount, _ = amount["value"].(string)
+	}
+
+	if tx.DeliveredAmount != "" {
+		transaction.Amount = tx.DeliveredAmount
+	}
+
+	return transaction
+}
+
+func toBalanceChanges(changes []*xrpn.XrpBalanceChange) []*r.BalanceChange {
+	balanceChanges := make([]*r.BalanceChange, 0, len(changes))
+	for _, change := range changes {
+		balanceChanges = append(balanceChanges, &r.BalanceChange{
+			Account:  change.Account,
+			Currency: change.Currency,
+			Issuer:   change.Issuer,
+			Value:    change.Value,
+		})
+	}
+	return balanceChanges
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+	r "crypto-braza-tokens-api/repositories"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	indexerIssuer = "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"
+	indexerHolder = "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"
+)
+
+func TestBuildIndexedTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		tx       *xrpn.XrpTransaction
+		expected *r.Transaction
+	}{
+		{
+			name: "issued currency payment",
+			tx: &xrpn.XrpTransaction{
+				Hash:        "A1B2",
+				LedgerIndex: 100,
+				Validated:   true,
+				Date:        1,
+				Transaction: map[string]any{
+					"TransactionType": "Payment",
+					"Account":         indexerHolder,
+					"Destination":     indexerIssuer,
+					"DestinationTag":  7,
+					"Amount":          map[string]any{"currency": xrpn.ParseStringToHex("BRZA"), "issuer": indexerIssuer, "value": "12"},
+					"Fee":             "12",
+					"Memos":           []any{binarycodec.NewMemo("operation_id", "abc")},
+				},
+				Metadata:        &xrpn.XrpTransactionMetadata{TransactionResult: "tesSUCCESS"},
+				BalanceChanges:  []*xrpn.XrpBalanceChange{{Account: indexerHolder, Currency: "BRZA", Issuer: indexerIssuer, Value: "-10"}},
+				DeliveredAmount: "10",
+			},
+			expected: &r.Transaction{
+				Type:            "Payment",
+				Amount:          "10",
+				Status:          "tesSUCCESS",
+				TransactionHash: "A1B2",
+				Account:         indexerHolder,
+				Destination:     indexerIssuer,
+				DestinationTag:  func() *uint32 { tag := uint32(7); return &tag }(),
+				Currency:        "BRZA",
+				Issuer:          indexerIssuer,
+				Fee:             "0.000012",
+				LedgerIndex:     100,
+				LedgerDate:      time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC),
+				BalanceChanges:  []*r.BalanceChange{{Account: indexerHolder, Currency: "BRZA", Issuer: indexerIssuer, Value: "-10"}},
+				Memos:           map[string]string{"operation_id": "abc"},
+			},
+		},
+		{
+			name: "trust set",
+			tx: &xrpn.XrpTransaction{
+				Hash:        "C3D4",
+				LedgerIndex: 101,
+				Transaction: map[string]any{
+					"TransactionType": "TrustSet",
+					"Account":         indexerHolder,
+					"Fee":             "15",
+				},
+				Metadata: &xrpn.XrpTransactionMetadata{TransactionResult: "tesSUCCESS"},
+			},
+			expected: &r.Transaction{
+				Type:            "TrustSet",
+				Status:          "tesSUCCESS",
+				TransactionHash: "C3D4",
+				Account:         indexerHolder,
+				Fee:             "0.000015",
+				LedgerIndex:     101,
+				BalanceChanges:  []*r.BalanceChange{},
+				Memos:           map[string]string{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, buildIndexedTransaction(tc.tx))
+		})
+	}
+}
+
+func TestOperationIdFromMemos(t *testing.T) {
+	managed := map[string]*r.Wallet{indexerIssuer: {Address: indexerIssuer}}
+	memos := map[string]string{OPERATION_ID_MEMO: "abc"}
+
+	// the memo is only trusted on transactions sent from our wallets
+	require.Equal(t, "abc", operationIdFromMemos(&r.Transaction{Account: indexerIssuer, Memos: memos}, managed))
+	require.Empty(t, operationIdFromMemos(&r.Transaction{Account: indexerHolder, Memos: memos}, managed))
+	require.Empty(t, operationIdFromMemos(&r.Transaction{Account: indexerIssuer}, managed))
+}
+
+func TestUnwatchedAccounts(t *testing.T) {
+	wallets := []*r.Wallet{
+		{Address: indexerIssuer, IsActive: true},
+		{Address: indexerHolder, IsActive: true},
+		{Address: "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe", IsActive: false},
+	}
+
+	require.Equal(t, []string{indexerIssuer, indexerHolder}, unwatchedAccounts(wallets, map[string]bool{}))
+	require.Equal(t, []string{indexerHolder}, unwatchedAccounts(wallets, map[string]bool{indexerIssuer: true}))
+	require.Empty(t, unwatchedAccounts(wallets, map[string]bool{indexerIssuer: true, indexerHolder: true}))
+}
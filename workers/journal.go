@@ -0,0 +1,270 @@
+package worker
+
+import (
+	"context"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// origin of the journal entries, posted from an indexed transaction or by an operator
+	JOURNAL_SOURCE_LEDGER = "LEDGER"
+	JOURNAL_SOURCE_MANUAL = "MANUAL"
+
+	// accounts of the journal that are not held by one of our wallets
+	JOURNAL_ACCOUNT_COUNTERPARTIES = "EXTERNAL:COUNTERPARTIES"
+	JOURNAL_ACCOUNT_OPENING        = "EQUITY:OPENING_BALANCES"
+)
+
+var (
+	ErrJournalEntryTooFewLines = errors.New("journal entry must have at least two lines")
+	ErrJournalEntryInvalidLine = errors.New("journal entry line must have an account, a currency and either a positive debit or a positive credit")
+	ErrJournalEntryNotBalanced = errors.New("journal entry debits and credits are not balanced")
+)
+
+// JournalWalletAccount is the asset account of the balances held by a wallet, named by its domain and type
+func JournalWalletAccount(wallet *r.Wallet) string {
+	return fmt.Sprintf("ASSET:%s:%s", strings.ToUpper(wallet.Domain), strings.ToUpper(wallet.Type))
+}
+
+// JournalIssuedAccount is the liability account of the tokens issued in the currency, that are owed to their holders
+func JournalIssuedAccount(currency string) string {
+	return fmt.Sprintf("LIABILITY:%s:ISSUED", strings.ToUpper(currency))
+}
+
+// JournalFeesAccount is the expense account of the network fees paid by the wallets of the domain
+func JournalFeesAccount(domain string) string {
+	return fmt.Sprintf("EXPENSE:%s:NETWORK_FEES", strings.ToUpper(domain))
+}
+
+// IsDebitNormal tells whether the balance of the account grows with its debits, as the assets, expenses and the
+// balances of the counterparties do, or with its credits, as the liabilities and the equity do
+func IsDebitNormal(account string) bool {
+	return !strings.HasPrefix(account, "LIABILITY:") && !strings.HasPrefix(account, "EQUITY:")
+}
+
+// isIssuerOf tells whether the wallet is the issuer of the currency, whose balance changes are the tokens it issued
+func isIssuerOf(wallet *r.Wallet, currency string) bool {
+	return strings.EqualFold(wallet.Type, "ISSUER") && strings.EqualFold(wallet.Domain, currency)
+}
+
+type journalKey struct {
+	account  string
+	currency string
+	issuer   string
+}
+
+// BuildLedgerJournalEntry builds the entry of the balance changes of our wallets on an indexed transaction. A change
+// is debited to the account of the wallet when the balance grows and credited when it shrinks, the fee paid by one of
+// our wallets is debited to the network fees of its domain, and whatever is left to balance each currency was sent
+// to or received from the counterparties. It returns nil when the transaction did not move any of our balances.
+func BuildLedgerJournalEntry(transaction *r.Transaction, operation *r.Operation, managed map[string]*r.Wallet) *r.JournalEntry {
+	amounts := map[journalKey]decimal.Decimal{}
+	keys := []journalKey{}
+	post := func(key journalKey, value decimal.Decimal) {
+		if _, ok := amounts[key]; !ok {
+			keys = append(keys, key)
+		}
+		amounts[key] = amounts[key].Add(value)
+	}
+
+	sender := managed[transaction.Account]
+
+	fee := decimal.Zero
+	if sender != nil && transaction.Fee != "" {
+		fee, _ = decimal.NewFromString(transaction.Fee)
+	}
+
+	for _, change := range transaction.BalanceChanges {
+		wallet := managed[change.Account]
+		if wallet == nil {
+			continue
+		}
+
+		value, err := decimal.NewFromString(change.Value)
+		if err != nil {
+			continue
+		}
+
+		if change.Currency == xrpn.CURRENCY_XRP {
+			post(journalKey{account: JournalWalletAccount(wallet), currency: xrpn.CURRENCY_XRP}, value)
+			continue
+		}
+
+		// the issuer sees the tokens it issued to a holder as a negative balance of its trust line, which is credited to
+		// the liability of the issued tokens, the tokens being issued by the account itself
+		if isIssuerOf(wallet, change.Currency) {
+			post(journalKey{account: JournalIssuedAccount(change.Currency), currency: change.Currency, issuer: change.Account}, value)
+			continue
+		}
+
+		post(journalKey{account: JournalWalletAccount(wallet), currency: change.Currency, issuer: change.Issuer}, value)
+	}
+
+	// the XRP change of the sender already includes the fee, that was burnt instead of sent to a counterparty
+	if fee.IsPositive() {
+		post(journalKey{account: JournalFeesAccount(sender.Domain), currency: xrpn.CURRENCY_XRP}, fee)
+	}
+
+	// whatever our accounts gained of a currency was received from the counterparties, and whatever they lost was sent
+	owned := append([]journalKey{}, keys...)
+	for _, key := range owned {
+		post(journalKey{account: JOURNAL_ACCOUNT_COUNTERPARTIES, currency: key.currency, issuer: key.issuer}, amounts[key].Neg())
+	}
+
+	lines := []*r.JournalLine{}
+	for _, key := range keys {
+		if line := journalLine(key, amounts[key]); line != nil {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	entry := &r.JournalEntry{
+		Type:            journalEntryType(transaction, operation, managed),
+		Source:          JOURNAL_SOURCE_LEDGER,
+		Description:     fmt.Sprintf("%s %s", transaction.Type, transaction.TransactionHash),
+		Domain:          transaction.Domain,
+		TransactionHash: transaction.TransactionHash,
+		OperationID:     transaction.OperationId,
+		Operator:        transaction.Operator,
+		Lines:           lines,
+		PostedAt:        transaction.LedgerDate,
+	}
+
+	return entry
+}
+
+// journalLine is the debit of a positive amount or the credit of a negative one, nil when the amount is zero
+func journalLine(key journalKey, amount decimal.Decimal) *r.JournalLine {
+	if amount.IsZero() {
+		return nil
+	}
+
+	line := &r.JournalLine{
+		Account:  key.account,
+		Currency: key.currency,
+		Issuer:   key.issuer,
+		Debit:    "0",
+		Credit:   "0",
+	}
+	if amount.IsPositive() {
+		line.Debit = amount.String()
+	} else {
+		line.Credit = amount.Neg().String()
+	}
+
+	return line
+}
+
+// journalEntryType is the type of the operation of the transaction or, for the transfers between our wallets that
+// were not made by an operation, the movement implied by the types of the wallets
+func journalEntryType(transaction *r.Transaction, operation *r.Operation, managed map[string]*r.Wallet) string {
+	if operation != nil && operation.Type != "" {
+		return operation.Type
+	}
+
+	sender, destination := managed[transaction.Account], managed[transaction.Destination]
+	if sender == nil || destination == nil {
+		return transaction.Type
+	}
+
+	switch strings.ToUpper(sender.Type) + ">" + strings.ToUpper(destination.Type) {
+	case "ISSUER>SUPPLY":
+		return "MINT"
+	case "SUPPLY>ISSUER":
+		return OPERATION_TYPE_BURN
+	case "SUPPLY>PAYMENT":
+		return "ON-RAMP"
+	case "PAYMENT>SUPPLY":
+		return "OFF-RAMP"
+	}
+
+	return transaction.Type
+}
+
+// ValidateJournalEntry rejects an entry with less than two lines, a line that is not either a positive debit or a
+// positive credit, or whose debits and credits are not the same for every currency
+func ValidateJournalEntry(entry *r.JournalEntry) error {
+	if len(entry.Lines) < 2 {
+		return ErrJournalEntryTooFewLines
+	}
+
+	balances := map[string]decimal.Decimal{}
+	for _, line := range entry.Lines {
+		if line == nil || line.Account == "" || line.Currency == "" {
+			return ErrJournalEntryInvalidLine
+		}
+
+		debit, err := journalAmount(line.Debit)
+		if err != nil {
+			return ErrJournalEntryInvalidLine
+		}
+		credit, err := journalAmount(line.Credit)
+		if err != nil {
+			return ErrJournalEntryInvalidLine
+		}
+
+		if debit.IsPositive() == credit.IsPositive() {
+			return ErrJournalEntryInvalidLine
+		}
+
+		currency := strings.ToUpper(line.Currency) + ":" + line.Issuer
+		balances[currency] = balances[currency].Add(debit).Sub(credit)
+	}
+
+	currencies := make([]string, 0, len(balances))
+	for currency := range balances {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		if !balances[currency].IsZero() {
+			return fmt.Errorf("%w: %s is off by %s", ErrJournalEntryNotBalanced, strings.TrimSuffix(currency, ":"), balances[currency].String())
+		}
+	}
+
+	return nil
+}
+
+// journalAmount parses the debit or the credit of a line, an empty amount being zero
+func journalAmount(amount string) (decimal.Decimal, error) {
+	if amount == "" {
+		return decimal.Zero, nil
+	}
+
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if value.IsNegative() {
+		return decimal.Zero, ErrJournalEntryInvalidLine
+	}
+
+	return value, nil
+}
+
+// postJournalEntry posts the entry of an indexed transaction, skipping the transactions that did not move any of our
+// balances. An entry that does not balance is not posted, as it means the balance changes were not read correctly.
+func (i *LedgerIndexer) postJournalEntry(ctx context.Context, transaction *r.Transaction, operation *r.Operation, managed map[string]*r.Wallet) error {
+	entry := BuildLedgerJournalEntry(transaction, operation, managed)
+	if entry == nil {
+		return nil
+	}
+
+	if err := ValidateJournalEntry(entry); err != nil {
+		return fmt.Errorf("journal entry of transaction %s: %w", transaction.TransactionHash, err)
+	}
+
+	return i.repo.SaveLedgerJournalEntry(ctx, entry)
+}
@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"testing"
+
+	r "crypto-braza-tokens-api/repositories"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	journalSupply  = "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+	journalPayment = "rJb5KsHsDHF1YS5B5DU6QCkH5NsPaKQTcy"
+)
+
+func TestBuildLedgerJournalEntry(t *testing.T) {
+	managed := map[string]*r.Wallet{
+		indexerIssuer:  {Address: indexerIssuer, Type: "ISSUER", Domain: "BBRL"},
+		journalSupply:  {Address: journalSupply, Type: "SUPPLY", Domain: "BRAZA-ON"},
+		journalPayment: {Address: journalPayment, Type: "PAYMENT", Domain: "BRAZA-ON"},
+	}
+
+	tests := []struct {
+		name         string
+		tx           *r.Transaction
+		operation    *r.Operation
+		expectedType string
+		expected     []*r.JournalLine
+	}{
+		{
+			name: "mint from the issuer to the supply wallet",
+			tx: &r.Transaction{
+				Type: "Payment", Account: indexerIssuer, Destination: journalSupply, Fee: "0.000012",
+				BalanceChanges: []*r.BalanceChange{
+					{Account: journalSupply, Currency: "BBRL", Issuer: indexerIssuer, Value: "100"},
+					{Account: indexerIssuer, Currency: "BBRL", Issuer: journalSupply, Value: "-100"},
+					{Account: indexerIssuer, Currency: "XRP", Value: "-0.000012"},
+				},
+			},
+			expectedType: "MINT",
+			expected: []*r.JournalLine{
+				{Account: "ASSET:BRAZA-ON:SUPPLY", Currency: "BBRL", Issuer: indexerIssuer, Debit: "100", Credit: "0"},
+				{Account: "LIABILITY:BBRL:ISSUED", Currency: "BBRL", Issuer: indexerIssuer, Debit: "0", Credit: "100"},
+				{Account: "ASSET:BBRL:ISSUER", Currency: "XRP", Debit: "0", Credit: "0.000012"},
+				{Account: "EXPENSE:BBRL:NETWORK_FEES", Currency: "XRP", Debit: "0.000012", Credit: "0"},
+			},
+		},
+		{
+			name: "on-ramp linked to an operation",
+			tx: &r.Transaction{
+				Type: "Payment", Account: journalSupply, Destination: journalPayment, Fee: "0.00001",
+				BalanceChanges: []*r.BalanceChange{
+					{Account: journalSupply, Currency: "BBRL", Issuer: indexerIssuer, Value: "-25"},
+					{Account: indexerIssuer, Currency: "BBRL", Issuer: journalSupply, Value: "25"},
+					{Account: indexerIssuer, Currency: "BBRL", Issuer: journalPayment, Value: "-25"},
+					{Account: journalPayment, Currency: "BBRL", Issuer: indexerIssuer, Value: "25"},
+					{Account: journalSupply, Currency: "XRP", Value: "-0.00001"},
+				},
+			},
+			operation:    &r.Operation{Type: "TRANSFER"},
+			expectedType: "TRANSFER",
+			expected: []*r.JournalLine{
+				{Account: "ASSET:BRAZA-ON:SUPPLY", Currency: "BBRL", Issuer: indexerIssuer, Debit: "0", Credit: "25"},
+				{Account: "ASSET:BRAZA-ON:PAYMENT", Currency: "BBRL", Issuer: indexerIssuer, Debit: "25", Credit: "0"},
+				{Account: "ASSET:BRAZA-ON:SUPPLY", Currency: "XRP", Debit: "0", Credit: "0.00001"},
+				{Account: "EXPENSE:BRAZA-ON:NETWORK_FEES", Currency: "XRP", Debit: "0.00001", Credit: "0"},
+			},
+		},
+		{
+			name: "redemption from a customer",
+			tx: &r.Transaction{
+				Type: "Payment", Account: indexerHolder, Destination: indexerIssuer, Fee: "0.00001",
+				BalanceChanges: []*r.BalanceChange{
+					{Account: indexerHolder, Currency: "BBRL", Issuer: indexerIssuer, Value: "-10"},
+					{Account: indexerIssuer, Currency: "BBRL", Issuer: indexerHolder, Value: "10"},
+					{Account: indexerHolder, Currency: "XRP", Value: "-0.00001"},
+				},
+			},
+			expectedType: "Payment",
+			expected: []*r.JournalLine{
+				{Account: "LIABILITY:BBRL:ISSUED", Currency: "BBRL", Issuer: indexerIssuer, Debit: "10", Credit: "0"},
+				{Account: "EXTERNAL:COUNTERPARTIES", Currency: "BBRL", Issuer: indexerIssuer, Debit: "0", Credit: "10"},
+			},
+		},
+		{
+			name: "xrp sent to a customer",
+			tx: &r.Transaction{
+				Type: "Payment", Account: journalPayment, Destination: indexerHolder, Fee: "0.00001",
+				BalanceChanges: []*r.BalanceChange{
+					{Account: journalPayment, Currency: "XRP", Value: "-5.00001"},
+					{Account: indexerHolder, Currency: "XRP", Value: "5"},
+				},
+			},
+			expectedType: "Payment",
+			expected: []*r.JournalLine{
+				{Account: "ASSET:BRAZA-ON:PAYMENT", Currency: "XRP", Debit: "0", Credit: "5.00001"},
+				{Account: "EXPENSE:BRAZA-ON:NETWORK_FEES", Currency: "XRP", Debit: "0.00001", Credit: "0"},
+				{Account: "EXTERNAL:COUNTERPARTIES", Currency: "XRP", Debit: "5", Credit: "0"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := BuildLedgerJournalEntry(tc.tx, tc.operation, managed)
+			require.NotNil(t, entry)
+			require.Equal(t, JOURNAL_SOURCE_LEDGER, entry.Source)
+			require.Equal(t, tc.expectedType, entry.Type)
+			require.Equal(t, tc.expected, entry.Lines)
+			require.NoError(t, ValidateJournalEntry(entry))
+		})
+	}
+
+	t.Run("transaction that did not move our balances", func(t *testing.T) {
+		tx := &r.Transaction{
+			Type: "Payment", Account: indexerHolder, Destination: "rDsbeomae4FXwgQTJp9Rs64Qg9vDiTCdBv", Fee: "0.00001",
+			BalanceChanges: []*r.BalanceChange{{Account: indexerHolder, Currency: "XRP", Value: "-1.00001"}},
+		}
+		require.Nil(t, BuildLedgerJournalEntry(tx, nil, managed))
+	})
+}
+
+func TestValidateJournalEntry(t *testing.T) {
+	line := func(account, currency, debit, credit string) *r.JournalLine {
+		return &r.JournalLine{Account: account, Currency: currency, Debit: debit, Credit: credit}
+	}
+
+	tests := []struct {
+		name     string
+		lines    []*r.JournalLine
+		expected error
+	}{
+		{name: "balanced entry", lines: []*r.JournalLine{
+			line("ASSET:BRAZA-ON:SUPPLY", "BBRL", "100", "0"),
+			line(JOURNAL_ACCOUNT_OPENING, "BBRL", "", "100"),
+		}},
+		{name: "balanced per currency", lines: []*r.JournalLine{
+			line("ASSET:BRAZA-ON:SUPPLY", "BBRL", "100", "0"),
+			line("ASSET:BRAZA-ON:SUPPLY", "XRP", "20", "0"),
+			line(JOURNAL_ACCOUNT_OPENING, "BBRL", "0", "100"),
+			line(JOURNAL_ACCOUNT_OPENING, "XRP", "0", "20"),
+		}},
+		{name: "single line", lines: []*r.JournalLine{line("ASSET:BRAZA-ON:SUPPLY", "BBRL", "100", "0")}, expected: ErrJournalEntryTooFewLines},
+		{name: "unbalanced entry", lines: []*r.JournalLine{
+			line("ASSET:BRAZA-ON:SUPPLY", "BBRL", "100", "0"),
+			line(JOURNAL_ACCOUNT_OPENING, "BBRL", "0", "99.99"),
+		}, expected: ErrJournalEntryNotBalanced},
+		{name: "balanced across different currencies", lines: []*r.JournalLine{
+			line("ASSET:BRAZA-ON:SUPPLY", "BBRL", "100", "0"),
+			line(JOURNAL_ACCOUNT_OPENING, "XRP", "0", "100"),
+		}, expected: ErrJournalEntryNotBalanced},
+		{name: "debit and credit on the same line", lines: []*r.JournalLine{
+			line("ASSET:BRAZA-ON:SUPPLY", "BBRL", "100", "100"),
+			line(JOURNAL_ACCOUNT_OPENING, "BBRL", "0", "0"),
+		}, expected: ErrJournalEntryInvalidLine},
+		{name: "negative amount", lines: []*r.JournalLine{
+			line("ASSET:BRAZA-ON:SUPPLY", "BBRL", "-100", "0"),
+			line(JOURNAL_ACCOUNT_OPENING, "BBRL", "0", "-100"),
+		}, expected: ErrJournalEntryInvalidLine},
+		{name: "line without account", lines: []*r.JournalLine{
+			line("", "BBRL", "100", "0"),
+			line(JOURNAL_ACCOUNT_OPENING, "BBRL", "0", "100"),
+		}, expected: ErrJournalEntryInvalidLine},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateJournalEntry(&r.JournalEntry{Lines: tc.lines})
+			if tc.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
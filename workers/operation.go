@@ -5,8 +5,10 @@ import (
 	fb "crypto-braza-tokens-api/clients/fireblocks"
 	xrpn "crypto-braza-tokens-api/clients/ripple"
 	binarycodec "crypto-braza-tokens-api/clients/ripple/utils/binary-codec"
+	"crypto-braza-tokens-api/clients/ripple/utils/signature"
 	r "crypto-braza-tokens-api/repositories"
 	l "crypto-braza-tokens-api/utils/logger"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -15,6 +17,17 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// blockchain status of an operation whose transaction was submitted and is waiting to be validated
+	OPERATION_STATUS_SUBMITTED = "SUBMITTED"
+	OPERATION_STATUS_COMPLETED = "COMPLETED"
+	OPERATION_STATUS_FAILED    = "FAILED"
+
+	// attempts and interval to wait for a submitted transaction to be validated, longer than the LastLedgerSequence window
+	VALIDATION_ATTEMPTS = 15
+	VALIDATION_INTERVAL = 4 * time.Second
+)
+
 type OperationsWorker struct {
 	fbCli  *fb.FireblocksClient
 	XrpCli *xrpn.RippleNodeClient
@@ -39,77 +52,100 @@ func (o *OperationsWorker) Start(operationId string, rawTransaction map[string]a
 		}()
 
 		ctx := context.Background()
-		operation, err := o.repo.FindOperationById(ctx, operationId)
+		signedTx, err := o.waitForSignature(ctx, operationId)
 		if err != nil {
-			l.Logger.Error("operation worker: failed to find operation", zap.Error(err))
+			// the transaction was never signed, so the operation fails and releases the running flag
+			if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, "FAILED", "", ""); err != nil {
+				l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+			}
+			callback()
 			return
 		}
 
-		var signedTx *fb.TransactionByIdResponse
-		for {
-			// retrieve the signed transaction status from fireblocks
-			signedTx, err = o.fbCli.GetTransactionByID(ctx, operation.FireblocksId)
-			if err != nil {
-				l.Logger.Error("operation worker: failed to get transaction status from fireblocks", zap.Error(err))
-				return
-			}
+		o.processOperation(ctx, operationId, signedTx, rawTransaction, callback)
+	}()
+}
 
-			errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
-				Event:        "Fireblocks Raw Transaction Status Update",
-				Description:  "Fireblocks Raw Transaction Status Response",
-				OperationID:  operationId,
-				FireblocksID: signedTx.ID,
-				Payload:      "",
-				Response:     signedTx,
-				Error:        err,
-			})
-			if errLog != nil {
-				l.Logger.Error("operation worker: failed to save operation log", zap.Error(errLog))
-				return
-			}
+// waitForSignature polls fireblocks until the RAW transaction of the operation is signed, updating the fireblocks
+// status of the operation on each poll
+func (o *OperationsWorker) waitForSignature(ctx context.Context, operationId string) (*fb.TransactionByIdResponse, error) {
+	operation, err := o.repo.FindOperationById(ctx, operationId)
+	if err != nil {
+		l.Logger.Error("operation worker: failed to find operation", zap.Error(err))
+		return nil, err
+	}
 
-			// updates the operation status
-			err = o.repo.UpdateOperationFireblocksStatus(ctx, operationId, signedTx.Status)
-			if err != nil {
-				l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
-				return
-			}
+	for {
+		// retrieve the signed transaction status from fireblocks
+		signedTx, err := o.fbCli.GetTransactionByID(ctx, operation.FireblocksId)
+		if err != nil {
+			l.Logger.Error("operation worker: failed to get transaction status from fireblocks", zap.Error(err))
+			return nil, err
+		}
 
-			if strings.EqualFold(signedTx.Status, "COMPLETED") {
-				break
-			}
+		errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+			Event:        "Fireblocks Raw Transaction Status Update",
+			Description:  "Fireblocks Raw Transaction Status Response",
+			OperationID:  operationId,
+			FireblocksID: signedTx.ID,
+			Payload:      "",
+			Response:     signedTx,
+			Error:        err,
+		})
+		if errLog != nil {
+			l.Logger.Error("operation worker: failed to save operation log", zap.Error(errLog))
+			return nil, errLog
+		}
 
-			if strings.EqualFold(signedTx.Status, "FAILED") {
-				l.Logger.Error("operation worker: transaction failed", zap.String("status", signedTx.Status))
-				return
-			}
+		// updates the operation status
+		err = o.repo.UpdateOperationFireblocksStatus(ctx, operationId, signedTx.Status)
+		if err != nil {
+			l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+			return nil, err
+		}
 
-			// Optionally, add a sleep to avoid hammering the API
-			time.Sleep(5 * time.Second)
+		if strings.EqualFold(signedTx.Status, "COMPLETED") {
+			return signedTx, nil
 		}
 
-		o.processOperation(ctx, operationId, signedTx, rawTransaction, callback)
-	}()
+		if strings.EqualFold(signedTx.Status, "FAILED") {
+			l.Logger.Error("operation worker: transaction failed", zap.String("status", signedTx.Status))
+			return nil, fmt.Errorf("fireblocks transaction %s failed", signedTx.ID)
+		}
+
+		// Optionally, add a sleep to avoid hammering the API
+		time.Sleep(5 * time.Second)
+	}
 }
 
+// processOperation submits the signed transaction and releases the running flag once its submission is recorded, so
+// the operation endpoints are not locked while the validation of the transaction is confirmed
 func (o *OperationsWorker) processOperation(ctx context.Context, operationId string, signedTx *fb.TransactionByIdResponse, rawTransaction map[string]any, callback func()) {
-	defer callback()
+	release := sync.OnceFunc(callback)
+	defer release()
 
-	rHex := signedTx.SignedMessages[0].Signature.R
-	sHex := signedTx.SignedMessages[0].Signature.S
-	derEncoded, err := xrpn.EncodeDER(rHex, sHex)
+	// verifies the fireblocks signature locally, so a bad signature fails the operation before reaching the network
+	txnSignature, err := o.verifySignature(ctx, operationId, signedTx, rawTransaction)
 	if err != nil {
-		l.Logger.Error("operation worker: failed to create a DER-encoded hexadecimal", zap.Error(err))
+		l.Logger.Error("operation worker: failed to verify fireblocks signature", zap.Error(err))
+
+		if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, "FAILED", "", ""); err != nil {
+			l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+		}
 		return
 	}
 
-	// add the der encoded signature to the RAW tx payload
-	rawTransaction["TxnSignature"] = derEncoded
+	// add the encoded signature to the RAW tx payload
+	rawTransaction["TxnSignature"] = txnSignature
 
 	// encode the signed RAW transaction into a blob
 	signedTxBlob, err := binarycodec.Encode(rawTransaction)
 	if err != nil {
 		l.Logger.Error("operation worker: failed to encode xrp tx into blob", zap.Error(err))
+
+		if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, "FAILED", "", ""); err != nil {
+			l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+		}
 		return
 	}
 
@@ -117,6 +153,10 @@ func (o *OperationsWorker) processOperation(ctx context.Context, operationId str
 	hashedSignedTx, err := xrpn.Sha512Half(xrpn.HASH_SIZE, contactedPrefixWithSignedTxBlob)
 	if err != nil {
 		l.Logger.Error("operation worker: failed to computes the SHA-512 hash of the input hex string", zap.Error(err))
+
+		if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, "FAILED", "", ""); err != nil {
+			l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+		}
 		return
 	}
 
@@ -144,10 +184,9 @@ func (o *OperationsWorker) processOperation(ctx context.Context, operationId str
 
 	hash := hashedSignedTx
 	link := ""
-	status := "FAILED"
-	if strings.EqualFold(submitedTx.Result.EngineResult, "tesSUCCESS") {
+	status := submissionStatus(submitedTx.Result.EngineResult)
+	if status != OPERATION_STATUS_FAILED {
 		link = o.XrpCli.GetTransactionLink(hash)
-		status = "COMPLETED"
 	}
 
 	err = o.repo.UpdateOperationBlockchainStatus(ctx, operationId, status, hash, link)
@@ -156,5 +195,227 @@ func (o *OperationsWorker) processOperation(ctx context.Context, operationId str
 		return
 	}
 
-	l.Logger.Info(fmt.Sprintf("operation worker: operation %s completed with hash %s", operationId, hash), zap.String("details at:", link))
+	l.Logger.Info(fmt.Sprintf("operation worker: operation %s submitted with hash %s and result %s", operationId, hash, submitedTx.Result.EngineResult), zap.String("details at:", link))
+
+	release()
+
+	if status != OPERATION_STATUS_FAILED {
+		lastLedgerSequence, _ := rawTransaction["LastLedgerSequence"].(int)
+		o.confirmTransaction(ctx, operationId, signedTx.ID, hash, link, lastLedgerSequence)
+	}
+}
+
+// submissionStatus is the blockchain status of an operation after its transaction was submitted. Only the results
+// of a transaction that can never be applied fail the operation: tem, a malformed transaction, and tef, such as a
+// sequence already used. The other results are provisional, as a queued, retried or locally rejected transaction can
+// still be validated until its LastLedgerSequence, so the operation stays submitted until its validation is confirmed.
+func submissionStatus(engineResult string) string {
+	switch {
+	case strings.EqualFold(engineResult, "tesSUCCESS"):
+		return OPERATION_STATUS_COMPLETED
+	case strings.HasPrefix(engineResult, "tem"), strings.HasPrefix(engineResult, "tef"):
+		return OPERATION_STATUS_FAILED
+	}
+
+	return OPERATION_STATUS_SUBMITTED
+}
+
+// confirmTransaction waits for the submitted transaction to be validated and records the balance changes of its
+// metadata, so the operation keeps the amount that moved on the ledger instead of the requested amount. The operation
+// fails when the transaction is validated with another result than tesSUCCESS, or when it was not validated once the
+// validated ledger passed its LastLedgerSequence. Otherwise its status is kept until the indexer sees the transaction.
+func (o *OperationsWorker) confirmTransaction(ctx context.Context, operationId, fireblocksId, hash, link string, lastLedgerSequence int) {
+	var tx *xrpn.XrpTransaction
+	var err error
+
+	for attempt := 0; attempt < VALIDATION_ATTEMPTS; attempt++ {
+		time.Sleep(VALIDATION_INTERVAL)
+
+		tx, err = o.XrpCli.GetTransaction(ctx, hash)
+		if err == nil && tx.Validated && tx.Metadata != nil {
+			break
+		}
+		if err != nil && !errors.Is(err, xrpn.ErrTransactionNotFound) {
+			l.Logger.Error("operation worker: failed to get transaction from ripple node", zap.Error(err))
+		}
+		tx = nil
+	}
+
+	// once the validated ledger passed its LastLedgerSequence, the transaction is either validated or never will be
+	expired := false
+	if tx == nil {
+		var passed bool
+		passed, err = o.ledgerPassed(ctx, lastLedgerSequence)
+		if err == nil && passed {
+			tx, err = o.XrpCli.GetTransaction(ctx, hash)
+			if errors.Is(err, xrpn.ErrTransactionNotFound) || (err == nil && (!tx.Validated || tx.Metadata == nil)) {
+				tx, err, expired = nil, nil, true
+			}
+		} else if err == nil {
+			err = fmt.Errorf("transaction %s not validated after %d attempts", hash, VALIDATION_ATTEMPTS)
+		}
+	}
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Validate XRP Transaction",
+		Description:  fmt.Sprintf("Validated XRP Transaction with Hash %s and its balance changes for Operation ID %s", hash, operationId),
+		OperationID:  operationId,
+		FireblocksID: fireblocksId,
+		Payload:      hash,
+		Response:     tx,
+		Error:        errorMessage(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		l.Logger.Error("operation worker: failed to save operation log", zap.Error(errLog))
+		return
+	}
+
+	if expired {
+		l.Logger.Error("operation worker: transaction expired without being validated", zap.String("hash", hash), zap.Int("last_ledger_sequence", lastLedgerSequence))
+		if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, OPERATION_STATUS_FAILED, hash, link); err != nil {
+			l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+		}
+		return
+	}
+
+	if tx == nil {
+		l.Logger.Error("operation worker: failed to validate transaction", zap.String("hash", hash), zap.Error(err))
+		return
+	}
+
+	// a transaction can be validated with another result than the one of its submission, e.g. with a tec code
+	status := OPERATION_STATUS_COMPLETED
+	if !strings.EqualFold(tx.Metadata.TransactionResult, "tesSUCCESS") {
+		l.Logger.Error("operation worker: transaction failed on validation", zap.String("hash", hash), zap.String("result", tx.Metadata.TransactionResult))
+		status = OPERATION_STATUS_FAILED
+	}
+
+	if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, status, hash, link); err != nil {
+		l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+	}
+
+	if err := o.repo.UpdateOperationBalanceChanges(ctx, operationId, tx.DeliveredAmount, toBalanceChanges(tx.BalanceChanges)); err != nil {
+		l.Logger.Error("operation worker: failed to update operation balance changes", zap.Error(err))
+	}
+}
+
+// ledgerPassed tells whether the validated ledger passed the LastLedgerSequence of a transaction, after which the
+// transaction can no longer be validated. A transaction without LastLedgerSequence never expires.
+func (o *OperationsWorker) ledgerPassed(ctx context.Context, lastLedgerSequence int) (bool, error) {
+	if lastLedgerSequence == 0 {
+		return false, nil
+	}
+
+	serverInfo, err := o.XrpCli.GetServerInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return serverInfo.Result.Info.ValidatedLedger.Seq > lastLedgerSequence, nil
+}
+
+// verifySignature checks that fireblocks signed the expected content of the unsigned transaction and that the signature
+// matches the SigningPubKey of the transaction, returning the encoded TxnSignature
+func (o *OperationsWorker) verifySignature(ctx context.Context, operationId string, signedTx *fb.TransactionByIdResponse, rawTransaction map[string]any) (string, error) {
+	verification, err := verifySignedMessage(signedTx, rawTransaction)
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Verify Fireblocks Signature",
+		Description:  fmt.Sprintf("Verify Fireblocks %s signature of content %s against the transaction signing public key", verification.Algorithm, verification.Content),
+		OperationID:  operationId,
+		FireblocksID: signedTx.ID,
+		Payload:      map[string]any{"content": verification.Content, "signing_pub_key": rawTransaction["SigningPubKey"]},
+		Response:     verification,
+		Error:        errorMessage(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		return "", errLog
+	}
+
+	return verification.TxnSignature, err
+}
+
+// verifySignedMessage rebuilds the content that had to be signed from the unsigned transaction, checks it against the
+// content signed on fireblocks and verifies the signature according to the algorithm of the SigningPubKey.
+// Secp256k1 signs the SHA-512Half hash and is DER-encoded with a canonical S, while ed25519 signs the full
+// prefixed blob and is encoded as the raw 64 bytes signature.
+func verifySignedMessage(signedTx *fb.TransactionByIdResponse, rawTransaction map[string]any) (*SignatureVerification, error) {
+	publicKey, _ := rawTransaction["SigningPubKey"].(string)
+	algorithm, err := signature.DetectAlgorithm(publicKey)
+	if err != nil {
+		return &SignatureVerification{}, err
+	}
+
+	unsignedTxBlob, err := binarycodec.Encode(rawTransaction)
+	if err != nil {
+		return &SignatureVerification{Algorithm: algorithm}, fmt.Errorf("failed to encode xrp tx into blob: %v", err)
+	}
+
+	content := xrpn.ConcactPrefixWithTxBlob(xrpn.PREFIX_UNSIGNED, unsignedTxBlob)
+	if algorithm == signature.ALGORITHM_SECP256K1 {
+		content, err = xrpn.Sha512Half(xrpn.HASH_SIZE, content)
+		if err != nil {
+			return &SignatureVerification{Algorithm: algorithm, Content: content}, err
+		}
+	}
+
+	return verifySignedContent(signedTx, publicKey, algorithm, content)
+}
+
+// verifySignedContent checks the content signed on fireblocks against the expected one and verifies its signature
+// with the public key, returning the signature encoded as the ledger expects it for the algorithm
+func verifySignedContent(signedTx *fb.TransactionByIdResponse, publicKey, algorithm, content string) (*SignatureVerification, error) {
+	verification := &SignatureVerification{Algorithm: algorithm, Content: content}
+
+	if len(signedTx.SignedMessages) == 0 || signedTx.SignedMessages[0].Signature == nil {
+		return verification, fmt.Errorf("fireblocks transaction %s without signed messages", signedTx.ID)
+	}
+
+	signedMessage := signedTx.SignedMessages[0]
+
+	if err := signature.VerifySignedContent(verification.Content, signedMessage.Content); err != nil {
+		return verification, err
+	}
+
+	if algorithm == signature.ALGORITHM_ED25519 {
+		fullSig := signedMessage.Signature.FullSig
+		if fullSig == "" {
+			fullSig = signedMessage.Signature.R + signedMessage.Signature.S
+		}
+
+		if err := signature.VerifyEd25519(publicKey, verification.Content, fullSig); err != nil {
+			return verification, err
+		}
+
+		verification.TxnSignature = strings.ToUpper(fullSig)
+		return verification, nil
+	}
+
+	sHex, canonicalized, err := signature.CanonicalizeS(signedMessage.Signature.S)
+	if err != nil {
+		return verification, err
+	}
+	verification.SCanonicalized = canonicalized
+
+	if err := signature.VerifySecp256k1(publicKey, verification.Content, signedMessage.Signature.R, sHex); err != nil {
+		return verification, err
+	}
+
+	verification.TxnSignature, err = xrpn.EncodeDER(signedMessage.Signature.R, sHex)
+	if err != nil {
+		return verification, fmt.Errorf("failed to create a DER-encoded hexadecimal: %v", err)
+	}
+
+	return verification, nil
+}
+
+// errorMessage returns the message of an error to be stored on the operation logs, or an empty string
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
 }
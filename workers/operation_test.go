@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		engineResult string
+		expected     string
+	}{
+		{name: "applied to the open ledger", engineResult: "tesSUCCESS", expected: OPERATION_STATUS_COMPLETED},
+		{name: "queued keeps the backing held", engineResult: "terQUEUED", expected: OPERATION_STATUS_SUBMITTED},
+		{name: "sequence ahead of the account", engineResult: "terPRE_SEQ", expected: OPERATION_STATUS_SUBMITTED},
+		{name: "claimed fee on submission", engineResult: "tecUNFUNDED_PAYMENT", expected: OPERATION_STATUS_SUBMITTED},
+		{name: "fee below the local load", engineResult: "telINSUF_FEE_P", expected: OPERATION_STATUS_SUBMITTED},
+		{name: "malformed transaction", engineResult: "temBAD_AMOUNT", expected: OPERATION_STATUS_FAILED},
+		{name: "sequence already used", engineResult: "tefPAST_SEQ", expected: OPERATION_STATUS_FAILED},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, submissionStatus(tc.engineResult))
+		})
+	}
+}
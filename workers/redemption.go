@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"context"
+	xrpn "crypto-braza-tokens-api/clients/ripple"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// isRedemption tells if the transaction is a validated payment of the issuer token received by an issuer wallet,
+// the holder sending the token back to be burned. The domain of an issuer wallet is the token abbreviation.
+func isRedemption(wallet *r.Wallet, transaction *r.Transaction) bool {
+	if !strings.EqualFold(wallet.Type, "ISSUER") {
+		return false
+	}
+
+	if transaction.Type != "Payment" || transaction.Status != "tesSUCCESS" {
+		return false
+	}
+
+	if transaction.Destination != wallet.Address || transaction.Account == wallet.Address {
+		return false
+	}
+
+	if transaction.Currency == xrpn.CURRENCY_XRP || !strings.EqualFold(transaction.Currency, wallet.Domain) {
+		return false
+	}
+
+	amount, err := decimal.NewFromString(transaction.Amount)
+	return err == nil && amount.IsPositive()
+}
+
+// recordRedemption creates the BURN operation of a redemption, with the domain of the sender when it is one of our wallets
+func (i *LedgerIndexer) recordRedemption(ctx context.Context, issuer *r.Wallet, transaction *r.Transaction, managed map[string]*r.Wallet) (*r.Operation, error) {
+	domain := ""
+	if sender := managed[transaction.Account]; sender != nil {
+		domain = sender.Domain
+	}
+
+	operation := &r.Operation{
+		Type:             OPERATION_TYPE_BURN,
+		Domain:           domain,
+		Token:            transaction.Currency,
+		Amount:           transaction.Amount,
+		Operator:         transaction.Account,
+		Origin:           OPERATION_ORIGIN_EXTERNAL,
+		Destination:      issuer.Address,
+		FireblocksStatus: "",
+		BlockchainStatus: "COMPLETED",
+		FireblocksId:     "",
+		TransactionHash:  transaction.TransactionHash,
+		TransactionLink:  transaction.TransactionLink,
+		DeliveredAmount:  transaction.Amount,
+		BalanceChanges:   transaction.BalanceChanges,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	operationId, err := i.repo.SaveOperation(ctx, operation)
+	if err != nil {
+		l.Logger.Error("ledger indexer: failed to save redemption operation", zap.Error(err))
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("New %s %s Operation of %s %s from %s to %s", OPERATION_ORIGIN_EXTERNAL, OPERATION_TYPE_BURN, transaction.Amount, transaction.Currency, transaction.Account, issuer.Name)
+	l.Logger.Info(msg)
+
+	errLog := i.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "External Redemption Detected",
+		Description:  msg,
+		OperationID:  operationId.Hex(),
+		FireblocksID: "",
+		Payload:      transaction,
+		Response:     "",
+		Error:        nil,
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		l.Logger.Error("ledger indexer: failed to save operation log", zap.Error(errLog))
+		return nil, errLog
+	}
+
+	return operation, nil
+}
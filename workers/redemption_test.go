@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"testing"
+
+	r "crypto-braza-tokens-api/repositories"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRedemption(t *testing.T) {
+	issuer := &r.Wallet{Address: indexerIssuer, Type: "ISSUER", Domain: "BRZA"}
+
+	buildPayment := func(update func(tx *r.Transaction)) *r.Transaction {
+		tx := &r.Transaction{
+			Type:        "Payment",
+			Status:      "tesSUCCESS",
+			Account:     indexerHolder,
+			Destination: indexerIssuer,
+			Currency:    "BRZA",
+			Issuer:      indexerIssuer,
+			Amount:      "10",
+		}
+		if update != nil {
+			update(tx)
+		}
+		return tx
+	}
+
+	tests := []struct {
+		name     string
+		wallet   *r.Wallet
+		tx       *r.Transaction
+		expected bool
+	}{
+		{name: "token sent back to the issuer", wallet: issuer, tx: buildPayment(nil), expected: true},
+		{name: "wallet is not an issuer", wallet: &r.Wallet{Address: indexerIssuer, Type: "SUPPLY", Domain: "BRZA"}, tx: buildPayment(nil)},
+		{name: "payment sent by the issuer", wallet: issuer, tx: buildPayment(func(tx *r.Transaction) {
+			tx.Account, tx.Destination = indexerIssuer, indexerHolder
+		})},
+		{name: "failed payment", wallet: issuer, tx: buildPayment(func(tx *r.Transaction) { tx.Status = "tecPATH_PARTIAL" })},
+		{name: "xrp payment", wallet: issuer, tx: buildPayment(func(tx *r.Transaction) { tx.Currency, tx.Issuer = "XRP", "" })},
+		{name: "token of another issuer", wallet: issuer, tx: buildPayment(func(tx *r.Transaction) { tx.Currency = "USDB" })},
+		{name: "trust set", wallet: issuer, tx: buildPayment(func(tx *r.Transaction) { tx.Type = "TrustSet" })},
+		{name: "nothing delivered", wallet: issuer, tx: buildPayment(func(tx *r.Transaction) { tx.Amount = "0" })},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isRedemption(tc.wallet, tc.tx))
+		})
+	}
+}
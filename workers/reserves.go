@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	fb "crypto-braza-tokens-api/clients/fireblocks"
+	r "crypto-braza-tokens-api/repositories"
+	l "crypto-braza-tokens-api/utils/logger"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// the status of the reserve attestations, published once their digest is signed on fireblocks
+const (
+	ATTESTATION_STATUS_PENDING   = "PENDING"
+	ATTESTATION_STATUS_PUBLISHED = "PUBLISHED"
+	ATTESTATION_STATUS_FAILED    = "FAILED"
+)
+
+// AttestationDigest returns the SHA-256 digest of the attestation document that is signed by the issuer key
+func AttestationDigest(document string) string {
+	digest := sha256.Sum256([]byte(document))
+	return strings.ToUpper(hex.EncodeToString(digest[:]))
+}
+
+// SignAttestation waits for fireblocks to sign the digest of the attestation of the operation and publishes the
+// attestation with its signature
+func (o *OperationsWorker) SignAttestation(operationId string, attestation *r.ReserveAttestation, callback func()) {
+	o.wg.Add(1)
+
+	go func() {
+		defer func() {
+			o.wg.Done()
+		}()
+
+		ctx := context.Background()
+		signedTx, err := o.waitForSignature(ctx, operationId)
+		if err != nil {
+			return
+		}
+
+		o.processAttestation(ctx, operationId, signedTx, attestation, callback)
+	}()
+}
+
+func (o *OperationsWorker) processAttestation(ctx context.Context, operationId string, signedTx *fb.TransactionByIdResponse, attestation *r.ReserveAttestation, callback func()) {
+	defer callback()
+
+	verification, err := verifyAttestationSignature(signedTx, attestation)
+
+	errLog := o.repo.SaveOperationLog(ctx, &r.OperationLog{
+		Event:        "Verify Fireblocks Attestation Signature",
+		Description:  fmt.Sprintf("Verify Fireblocks %s signature of the reserves attestation of %s against the issuer public key", verification.Algorithm, attestation.Abbr),
+		OperationID:  operationId,
+		FireblocksID: signedTx.ID,
+		Payload:      map[string]any{"digest": attestation.Digest, "public_key": attestation.PublicKey},
+		Response:     verification,
+		Error:        errorMessage(err),
+		CreatedAt:    time.Now(),
+	})
+	if errLog != nil {
+		l.Logger.Error("operation worker: failed to save operation log", zap.Error(errLog))
+		return
+	}
+
+	status, attestationStatus := "COMPLETED", ATTESTATION_STATUS_PUBLISHED
+	if err != nil {
+		l.Logger.Error("operation worker: failed to verify fireblocks attestation signature", zap.Error(err))
+		status, attestationStatus = "FAILED", ATTESTATION_STATUS_FAILED
+	}
+
+	if err := o.repo.UpdateReserveAttestationSignature(ctx, operationId, attestationStatus, strings.ToUpper(verification.TxnSignature)); err != nil {
+		l.Logger.Error("operation worker: failed to update reserve attestation", zap.Error(err))
+		return
+	}
+
+	// the attestation is off-ledger, so the operation completes without a transaction hash
+	if err := o.repo.UpdateOperationBlockchainStatus(ctx, operationId, status, "", ""); err != nil {
+		l.Logger.Error("operation worker: failed to update operation status", zap.Error(err))
+		return
+	}
+
+	l.Logger.Info(fmt.Sprintf("operation worker: reserves attestation of operation %s %s", operationId, strings.ToLower(attestationStatus)))
+}
+
+// verifyAttestationSignature checks that the digest sent to be signed is the one of the attestation document and
+// verifies the fireblocks signature of it
+func verifyAttestationSignature(signedTx *fb.TransactionByIdResponse, attestation *r.ReserveAttestation) (*SignatureVerification, error) {
+	if digest := AttestationDigest(attestation.Document); !strings.EqualFold(digest, attestation.Digest) {
+		return &SignatureVerification{Algorithm: attestation.Algorithm}, fmt.Errorf("attestation digest %s does not match the digest %s of its document", attestation.Digest, digest)
+	}
+
+	return verifySignedContent(signedTx, attestation.PublicKey, attestation.Algorithm, attestation.Digest)
+}
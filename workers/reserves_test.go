@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	fb "crypto-braza-tokens-api/clients/fireblocks"
+	"crypto-braza-tokens-api/clients/ripple/utils/signature"
+	r "crypto-braza-tokens-api/repositories"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationDigest(t *testing.T) {
+	require.Equal(t, "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855", AttestationDigest(""))
+	require.Equal(t, "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824", AttestationDigest("hello"))
+}
+
+func TestVerifyAttestationSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	document := `{"token":"BBRL","circulating_supply":"1000","total_reserves":"1000"}`
+	attestation := &r.ReserveAttestation{
+		Abbr:      "BBRL",
+		Document:  document,
+		Digest:    AttestationDigest(document),
+		Algorithm: signature.ALGORITHM_ED25519,
+		PublicKey: "ED" + strings.ToUpper(hex.EncodeToString(publicKey)),
+	}
+
+	message, _ := hex.DecodeString(attestation.Digest)
+	fullSig := hex.EncodeToString(ed25519.Sign(privateKey, message))
+
+	signedTx := &fb.TransactionByIdResponse{
+		ID:             "fb-attestation",
+		SignedMessages: []*fb.TxByIdSignedMessages{{Content: attestation.Digest, Signature: &fb.TxByIdSignature{FullSig: fullSig}}},
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		wantErr  string
+	}{
+		{name: "digest of the document signed by the issuer key", document: document},
+		{name: "document changed after it was signed", document: strings.Replace(document, "1000", "2000", 1), wantErr: "does not match the digest"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			signed := *attestation
+			signed.Document = tc.document
+
+			verification, err := verifyAttestationSignature(signedTx, &signed)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, strings.ToUpper(fullSig), verification.TxnSignature)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package worker
+
+type SignatureVerification struct {
+	Algorithm      string `json:"algorithm"`
+	Content        string `json:"content"`
+	SCanonicalized bool   `json:"s_canonicalized"`
+	TxnSignature   string `json:"txn_signature"`
+}